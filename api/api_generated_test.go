@@ -0,0 +1,94 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	scfs "github.com/nats-io/jsm.go/schemas"
+)
+
+// jsonFields lists the json names of the fields of t including those of embedded structs
+func jsonFields(t reflect.Type) []string {
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			fields = append(fields, jsonFields(f.Type)...)
+			continue
+		}
+
+		fields = append(fields, strings.Split(f.Tag.Get("json"), ",")[0])
+	}
+
+	return fields
+}
+
+func TestGeneratedApiMatchesSchemas(t *testing.T) {
+	for _, v := range []SchemaManagedType{JSApiConsumerPauseRequest{}, JSApiConsumerPauseResponse{}, JSApiConsumerUnpinRequest{}, JSApiConsumerUnpinResponse{}} {
+		f, err := SchemaFileForType(v.SchemaType())
+		checkErr(t, err, "schema file failed")
+		data, err := scfs.Load(f)
+		checkErr(t, err, "schema load failed")
+
+		var schema struct {
+			Properties map[string]any `json:"properties"`
+			OneOf      []struct {
+				Properties map[string]any `json:"properties"`
+			} `json:"oneOf"`
+		}
+		checkErr(t, json.Unmarshal(data, &schema), "schema parse failed")
+
+		var expected []string
+		for k := range schema.Properties {
+			expected = append(expected, k)
+		}
+		for _, branch := range schema.OneOf {
+			for k := range branch.Properties {
+				expected = append(expected, k)
+			}
+		}
+		slices.Sort(expected)
+		expected = slices.Compact(expected)
+
+		fields := jsonFields(reflect.TypeOf(v))
+		slices.Sort(fields)
+
+		if !slices.Equal(fields, expected) {
+			t.Fatalf("%T does not match %s, run go generate: fields %v schema %v", v, v.SchemaType(), fields, expected)
+		}
+	}
+}
+
+func TestGeneratedApiResponseTags(t *testing.T) {
+	data, err := json.Marshal(JSApiConsumerPauseResponse{})
+	checkErr(t, err, "marshal failed")
+
+	var res map[string]any
+	checkErr(t, json.Unmarshal(data, &res), "unmarshal failed")
+
+	for _, k := range []string{"paused", "pause_until"} {
+		if _, ok := res[k]; !ok {
+			t.Fatalf("expected %s to be rendered when empty: %s", k, data)
+		}
+	}
+
+	if _, ok := res["pause_remaining"]; ok {
+		t.Fatalf("expected pause_remaining to be omitted when empty: %s", data)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// LinearBackoff creates a list of steps backoff periods increasing linearly from min to max, both inclusive
+func LinearBackoff(min time.Duration, max time.Duration, steps uint) ([]time.Duration, error) {
+	if steps == 0 {
+		return nil, fmt.Errorf("steps must be more than 0")
+	}
+	if min <= 0 || max <= 0 {
+		return nil, fmt.Errorf("backoff periods must be positive")
+	}
+	if max < min {
+		return nil, fmt.Errorf("maximum backoff can not be less than the minimum")
+	}
+
+	if steps == 1 {
+		return []time.Duration{min}, nil
+	}
+
+	res := make([]time.Duration, steps)
+	stepSize := float64(max-min) / float64(steps-1)
+	for i := range res {
+		res[i] = (min + time.Duration(float64(i)*stepSize)).Round(time.Millisecond)
+	}
+
+	return res, nil
+}
+
+// ExponentialBackoff creates a list of steps backoff periods starting at base with every following period
+// multiplied by factor
+func ExponentialBackoff(base time.Duration, factor float64, steps uint) ([]time.Duration, error) {
+	if steps == 0 {
+		return nil, fmt.Errorf("steps must be more than 0")
+	}
+	if base <= 0 {
+		return nil, fmt.Errorf("base backoff period must be positive")
+	}
+	if factor < 1 {
+		return nil, fmt.Errorf("factor must be at least 1")
+	}
+
+	res := make([]time.Duration, steps)
+	current := float64(base)
+	for i := range res {
+		if current > math.MaxInt64 {
+			return nil, fmt.Errorf("backoff period %d exceeds the maximum duration", i+1)
+		}
+
+		res[i] = time.Duration(current).Round(time.Millisecond)
+		current *= factor
+	}
+
+	return res, nil
+}
+
+// ValidateBackoff checks that a consumer backoff policy is compatible with the max deliver and ack wait settings
+// using the same rules the server applies when creating a consumer, an ackWait of 0 means it is not set
+func ValidateBackoff(backoff []time.Duration, maxDeliver int, ackWait time.Duration) error {
+	if len(backoff) == 0 {
+		return nil
+	}
+
+	for i, p := range backoff {
+		if p <= 0 {
+			return fmt.Errorf("backoff period %d must be positive", i+1)
+		}
+	}
+
+	if maxDeliver > 0 && len(backoff) > maxDeliver {
+		return fmt.Errorf("max deliver %d is less than the %d backoff periods", maxDeliver, len(backoff))
+	}
+
+	if ackWait > 0 && ackWait != backoff[0] {
+		return fmt.Errorf("ack wait %v will be replaced by the first backoff period %v", ackWait, backoff[0])
+	}
+
+	return nil
+}
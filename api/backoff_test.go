@@ -0,0 +1,82 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLinearBackoff(t *testing.T) {
+	_, err := LinearBackoff(time.Second, time.Minute, 0)
+	if err == nil {
+		t.Fatalf("expected error for 0 steps")
+	}
+
+	_, err = LinearBackoff(time.Minute, time.Second, 2)
+	if err == nil {
+		t.Fatalf("expected error for max below min")
+	}
+
+	p, err := LinearBackoff(time.Second, 5*time.Second, 5)
+	checkErr(t, err, "backoff failed")
+	expect := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second, 5 * time.Second}
+	if !reflect.DeepEqual(p, expect) {
+		t.Fatalf("expected %v got %v", expect, p)
+	}
+
+	p, err = LinearBackoff(time.Second, 5*time.Second, 1)
+	checkErr(t, err, "backoff failed")
+	if !reflect.DeepEqual(p, []time.Duration{time.Second}) {
+		t.Fatalf("expected [1s] got %v", p)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	_, err := ExponentialBackoff(time.Second, 0.5, 2)
+	if err == nil {
+		t.Fatalf("expected error for factor below 1")
+	}
+
+	_, err = ExponentialBackoff(time.Hour, 100, 10)
+	if err == nil {
+		t.Fatalf("expected error for overflow")
+	}
+
+	p, err := ExponentialBackoff(time.Second, 2, 4)
+	checkErr(t, err, "backoff failed")
+	expect := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}
+	if !reflect.DeepEqual(p, expect) {
+		t.Fatalf("expected %v got %v", expect, p)
+	}
+}
+
+func TestValidateBackoff(t *testing.T) {
+	policy := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+
+	checkErr(t, ValidateBackoff(nil, 1, time.Second), "empty policy failed")
+	checkErr(t, ValidateBackoff(policy, -1, 0), "unlimited deliveries failed")
+	checkErr(t, ValidateBackoff(policy, 3, time.Second), "valid policy failed")
+
+	if ValidateBackoff(policy, 2, 0) == nil {
+		t.Fatalf("expected max deliver error")
+	}
+	if ValidateBackoff(policy, 5, 30*time.Second) == nil {
+		t.Fatalf("expected ack wait error")
+	}
+	if ValidateBackoff([]time.Duration{time.Second, 0}, 5, 0) == nil {
+		t.Fatalf("expected non positive period error")
+	}
+}
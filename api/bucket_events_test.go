@@ -0,0 +1,135 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go/api/event"
+	jsadvisory "github.com/nats-io/jsm.go/api/jetstream/advisory"
+	srvadvisory "github.com/nats-io/jsm.go/api/server/advisory"
+)
+
+func TestBucketEvent(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	nev := func(kind string) event.NATSEvent {
+		return event.NATSEvent{Type: kind, ID: "x", Time: ts}
+	}
+	client := srvadvisory.ClientInfoV1{User: "bob", Account: "USERS"}
+
+	streamAction := func(stream string, action jsadvisory.ActionAdvisoryTypeV1) *jsadvisory.JSStreamActionAdvisoryV1 {
+		return &jsadvisory.JSStreamActionAdvisoryV1{NATSEvent: nev("io.nats.jetstream.advisory.v1.stream_action"), Stream: stream, Action: action, Domain: "hub"}
+	}
+	purgeAudit := func(stream string, request string, response string) *jsadvisory.JetStreamAPIAuditV1 {
+		return &jsadvisory.JetStreamAPIAuditV1{
+			NATSEvent: nev("io.nats.jetstream.advisory.v1.api_audit"),
+			Server:    "n1",
+			Client:    client,
+			Subject:   "$JS.API.STREAM.PURGE." + stream,
+			Request:   request,
+			Response:  response,
+		}
+	}
+
+	cases := []struct {
+		name   string
+		event  any
+		expect any
+	}{
+		{
+			name:  "kv bucket create",
+			event: streamAction("KV_CONFIG", jsadvisory.CreateEvent),
+			expect: &jsadvisory.JSKVBucketActionAdvisoryV1{
+				NATSEvent: nev("io.nats.jetstream.advisory.v1.kv_bucket_action"),
+				Bucket:    "CONFIG", Stream: "KV_CONFIG", Action: jsadvisory.CreateEvent, Domain: "hub",
+			},
+		},
+		{
+			name:  "kv bucket modify",
+			event: streamAction("KV_CONFIG", jsadvisory.ModifyEvent),
+			expect: &jsadvisory.JSKVBucketActionAdvisoryV1{
+				NATSEvent: nev("io.nats.jetstream.advisory.v1.kv_bucket_action"),
+				Bucket:    "CONFIG", Stream: "KV_CONFIG", Action: jsadvisory.ModifyEvent, Domain: "hub",
+			},
+		},
+		{
+			name:  "kv bucket delete",
+			event: streamAction("KV_CONFIG", jsadvisory.DeleteEvent),
+			expect: &jsadvisory.JSKVBucketActionAdvisoryV1{
+				NATSEvent: nev("io.nats.jetstream.advisory.v1.kv_bucket_action"),
+				Bucket:    "CONFIG", Stream: "KV_CONFIG", Action: jsadvisory.DeleteEvent, Domain: "hub",
+			},
+		},
+		{
+			name:  "object store bucket create",
+			event: streamAction("OBJ_FILES", jsadvisory.CreateEvent),
+			expect: &jsadvisory.JSObjectStoreBucketActionAdvisoryV1{
+				NATSEvent: nev("io.nats.jetstream.advisory.v1.objectstore_bucket_action"),
+				Bucket:    "FILES", Stream: "OBJ_FILES", Action: jsadvisory.CreateEvent, Domain: "hub",
+			},
+		},
+		{
+			name:  "object store bucket delete",
+			event: streamAction("OBJ_FILES", jsadvisory.DeleteEvent),
+			expect: &jsadvisory.JSObjectStoreBucketActionAdvisoryV1{
+				NATSEvent: nev("io.nats.jetstream.advisory.v1.objectstore_bucket_action"),
+				Bucket:    "FILES", Stream: "OBJ_FILES", Action: jsadvisory.DeleteEvent, Domain: "hub",
+			},
+		},
+		{
+			name:  "kv key purge",
+			event: purgeAudit("KV_CONFIG", `{"filter":"$KV.CONFIG.host"}`, `{"success":true,"purged":3}`),
+			expect: &jsadvisory.JSKVKeyPurgeAdvisoryV1{
+				NATSEvent: nev("io.nats.jetstream.advisory.v1.kv_key_purge"),
+				Server:    "n1", Bucket: "CONFIG", Stream: "KV_CONFIG", Key: "host", Purged: 3, Client: client,
+			},
+		},
+		{
+			name:  "kv bucket purge",
+			event: purgeAudit("KV_CONFIG", "", `{"success":true,"purged":10}`),
+			expect: &jsadvisory.JSKVKeyPurgeAdvisoryV1{
+				NATSEvent: nev("io.nats.jetstream.advisory.v1.kv_key_purge"),
+				Server:    "n1", Bucket: "CONFIG", Stream: "KV_CONFIG", Purged: 10, Client: client,
+			},
+		},
+		{name: "regular stream action", event: streamAction("ORDERS", jsadvisory.CreateEvent)},
+		{name: "regular stream purge", event: purgeAudit("ORDERS", "", `{"success":true,"purged":1}`)},
+		{name: "object store purge", event: purgeAudit("OBJ_FILES", "", `{"success":true,"purged":1}`)},
+		{name: "failed kv purge", event: purgeAudit("KV_CONFIG", "", `{"error":{"code":500,"description":"failed"}}`)},
+		{name: "invalid kv purge request", event: purgeAudit("KV_CONFIG", "{", `{"success":true,"purged":1}`)},
+		{name: "other api audit", event: &jsadvisory.JetStreamAPIAuditV1{Subject: "$JS.API.STREAM.INFO.KV_CONFIG", Response: `{}`}},
+		{name: "other advisory", event: &jsadvisory.JSConsumerActionAdvisoryV1{Stream: "KV_CONFIG"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			bev, ok := jsadvisory.BucketEvent(c.event)
+			if c.expect == nil {
+				if ok || bev != nil {
+					t.Fatalf("expected the event to be ignored, got %+v", bev)
+				}
+				return
+			}
+
+			if !ok {
+				t.Fatalf("expected a bucket event")
+			}
+			if !reflect.DeepEqual(bev, c.expect) {
+				t.Fatalf("expected %+v, got %+v", c.expect, bev)
+			}
+		})
+	}
+}
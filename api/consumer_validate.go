@@ -0,0 +1,271 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/jsm.go/subject"
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+// ConfigViolation is a single problem found while validating a stream or consumer configuration
+type ConfigViolation struct {
+	// Field is the JSON name of the offending setting like max_deliver
+	Field string `json:"field"`
+	// Message describes the problem
+	Message string `json:"message"`
+}
+
+func (v ConfigViolation) Error() string {
+	return fmt.Sprintf("%s: %s", v.Field, v.Message)
+}
+
+// validPriorityGroupName matches the priority group names accepted by the server
+var validPriorityGroupName = regexp.MustCompile(`^[a-zA-Z0-9/_=-]{1,16}$`)
+
+// defaultAckWait is the ack wait the server sets when none is configured on an acknowledged consumer
+const defaultAckWait = 30 * time.Second
+
+// ValidatePedantic performs the checks the server does when creating a consumer in pedantic mode, that would otherwise
+// either fail or result in the server silently adjusting the configuration. Settings not supported by a server with
+// features are also reported. All violations are returned, an empty result means the configuration is valid.
+//
+// Checks that require the stream configuration or server limits are not performed
+func (c ConsumerConfig) ValidatePedantic(features Features) []ConfigViolation {
+	var violations []ConfigViolation
+	fail := func(field string, format string, a ...any) {
+		violations = append(violations, ConfigViolation{Field: field, Message: fmt.Sprintf(format, a...)})
+	}
+
+	for _, v := range []struct {
+		field string
+		value int64
+	}{
+		{"max_waiting", int64(c.MaxWaiting)},
+		{"max_batch", int64(c.MaxRequestBatch)},
+		{"max_expires", int64(c.MaxRequestExpires)},
+		{"max_bytes", int64(c.MaxRequestMaxBytes)},
+		{"idle_heartbeat", int64(c.Heartbeat)},
+		{"inactive_threshold", int64(c.InactiveThreshold)},
+		{"priority_timeout", int64(c.PinnedTTL)},
+		{"ack_wait", int64(c.AckWait)},
+		{"num_replicas", int64(c.Replicas)},
+	} {
+		if v.value < 0 {
+			fail(v.field, "must not be negative")
+		}
+	}
+
+	if c.MaxDeliver < -1 {
+		fail("max_deliver", "must be -1 for unlimited deliveries")
+	}
+	if c.MaxAckPending < -1 {
+		fail("max_ack_pending", "must be -1 for unlimited pending acknowledgements")
+	}
+
+	if len(c.BackOff) > 0 {
+		for i, b := range c.BackOff {
+			if b < 0 {
+				fail("backoff", "value %d must not be negative", i)
+			}
+		}
+
+		ackWait := c.AckWait
+		if ackWait == 0 && (c.AckPolicy == AckExplicit || c.AckPolicy == AckAll) {
+			ackWait = defaultAckWait
+		}
+		if ackWait != c.BackOff[0] {
+			fail("backoff", "first value %v must equal ack_wait %v", c.BackOff[0], ackWait)
+		}
+
+		if c.MaxDeliver > 0 && len(c.BackOff) > c.MaxDeliver {
+			fail("backoff", "has %d values which exceeds max_deliver %d", len(c.BackOff), c.MaxDeliver)
+		}
+	}
+
+	if len(c.Description) > server.JSMaxDescriptionLen {
+		fail("description", "must be at most %d bytes", server.JSMaxDescriptionLen)
+	}
+
+	if c.DeliverSubject != "" {
+		if !subject.IsValidLiteral(c.DeliverSubject) {
+			fail("deliver_subject", "must be a valid literal subject")
+		}
+		if c.MaxWaiting != 0 {
+			fail("max_waiting", "is only supported on pull consumers")
+		}
+		if c.MaxAckPending > 0 && c.AckPolicy == AckNone {
+			fail("max_ack_pending", "requires an ack policy other than none")
+		}
+		if c.Heartbeat > 0 && c.Heartbeat < 100*time.Millisecond {
+			fail("idle_heartbeat", "must be at least 100ms")
+		}
+		for _, v := range []struct {
+			field string
+			set   bool
+		}{
+			{"max_batch", c.MaxRequestBatch != 0},
+			{"max_expires", c.MaxRequestExpires != 0},
+			{"max_bytes", c.MaxRequestMaxBytes != 0},
+		} {
+			if v.set {
+				fail(v.field, "is only supported on pull consumers")
+			}
+		}
+	} else {
+		if c.DeliverGroup != "" {
+			fail("deliver_group", "is only supported on push consumers")
+		}
+		if c.RateLimit > 0 {
+			fail("rate_limit_bps", "is only supported on push consumers")
+		}
+		if c.Heartbeat > 0 {
+			fail("idle_heartbeat", "is only supported on push consumers")
+		}
+		if c.FlowControl {
+			fail("flow_control", "is only supported on push consumers")
+		}
+		if c.MaxRequestExpires > 0 && c.MaxRequestExpires < time.Millisecond {
+			fail("max_expires", "must be at least 1ms")
+		}
+	}
+
+	if c.FlowControl && c.Heartbeat <= 0 {
+		fail("flow_control", "requires idle_heartbeat to be set")
+	}
+
+	if c.Direct {
+		if c.DeliverSubject == "" {
+			fail("direct", "requires a push consumer")
+		}
+		if c.Durable != "" {
+			fail("direct", "requires an ephemeral consumer")
+		}
+	}
+
+	if c.Durable != "" && c.Name != "" && c.Durable != c.Name {
+		fail("durable_name", "must match name %q", c.Name)
+	}
+
+	c.validateFilters(fail)
+	c.validateStartPosition(fail)
+
+	if c.SampleFrequency != "" {
+		freq, err := strconv.Atoi(strings.TrimSuffix(c.SampleFrequency, "%"))
+		if err != nil || freq < 0 || freq > 100 {
+			fail("sample_freq", "must be a percentage between 0 and 100")
+		}
+	}
+
+	metadataLen := 0
+	for k, v := range c.Metadata {
+		metadataLen += len(k) + len(v)
+	}
+	if metadataLen > server.JSMaxMetadataLen {
+		fail("metadata", "must be at most %d bytes", server.JSMaxMetadataLen)
+	}
+
+	if c.PriorityPolicy != PriorityNone {
+		if c.DeliverSubject != "" {
+			fail("priority_policy", "is only supported on pull consumers")
+		}
+		if len(c.PriorityGroups) == 0 {
+			fail("priority_groups", "are required when a priority policy is set")
+		}
+		for _, group := range c.PriorityGroups {
+			if !validPriorityGroupName.MatchString(group) {
+				fail("priority_groups", "%q is not a valid group name", group)
+			}
+		}
+	} else {
+		if len(c.PriorityGroups) > 0 {
+			fail("priority_groups", "requires a priority policy")
+		}
+		if c.PinnedTTL > 0 {
+			fail("priority_timeout", "requires a priority policy")
+		}
+	}
+
+	if !c.PauseUntil.IsZero() && !features.Supports(FeatureConsumerPause) {
+		fail("pause_until", "is not supported by the server")
+	}
+	if (len(c.PriorityGroups) > 0 || c.PriorityPolicy != PriorityNone) && !features.Supports(FeaturePriorityGroups) {
+		fail("priority_groups", "are not supported by the server")
+	}
+	if c.PriorityPolicy == PriorityPrioritized && !features.Supports(FeaturePrioritizedPolicy) {
+		fail("priority_policy", "prioritized is not supported by the server")
+	}
+
+	return violations
+}
+
+func (c ConsumerConfig) validateFilters(fail func(field string, format string, a ...any)) {
+	if c.FilterSubject != "" && len(c.FilterSubjects) > 0 {
+		fail("filter_subjects", "can not be combined with filter_subject")
+	}
+
+	filters := c.FilterSubjects
+	if c.FilterSubject != "" {
+		filters = append([]string{c.FilterSubject}, filters...)
+	}
+
+	for i, filter := range filters {
+		if !subject.IsValid(filter) {
+			fail("filter_subjects", "%q is not a valid subject", filter)
+			continue
+		}
+
+		for _, other := range filters[i+1:] {
+			if subject.IsValid(other) && subject.Overlaps(filter, other) {
+				fail("filter_subjects", "%q overlaps %q", filter, other)
+			}
+		}
+	}
+}
+
+func (c ConsumerConfig) validateStartPosition(fail func(field string, format string, a ...any)) {
+	switch c.DeliverPolicy {
+	case DeliverAll, DeliverLast, DeliverNew, DeliverLastPerSubject:
+		if c.OptStartSeq > 0 {
+			fail("opt_start_seq", "can not be set with deliver policy %s", c.DeliverPolicy)
+		}
+		if c.OptStartTime != nil {
+			fail("opt_start_time", "can not be set with deliver policy %s", c.DeliverPolicy)
+		}
+		if c.DeliverPolicy == DeliverLastPerSubject && c.FilterSubject == "" && len(c.FilterSubjects) == 0 {
+			fail("filter_subject", "is required with deliver policy %s", c.DeliverPolicy)
+		}
+
+	case DeliverByStartSequence:
+		if c.OptStartSeq == 0 {
+			fail("opt_start_seq", "is required with deliver policy %s", c.DeliverPolicy)
+		}
+		if c.OptStartTime != nil {
+			fail("opt_start_time", "can not be set with deliver policy %s", c.DeliverPolicy)
+		}
+
+	case DeliverByStartTime:
+		if c.OptStartTime == nil {
+			fail("opt_start_time", "is required with deliver policy %s", c.DeliverPolicy)
+		}
+		if c.OptStartSeq != 0 {
+			fail("opt_start_seq", "can not be set with deliver policy %s", c.DeliverPolicy)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConsumerConfigValidatePedantic(t *testing.T) {
+	features := FeaturesForApiLevel(2)
+
+	valid := ConsumerConfig{
+		Durable:        "ORDERS",
+		AckPolicy:      AckExplicit,
+		AckWait:        time.Second,
+		BackOff:        []time.Duration{time.Second, 2 * time.Second},
+		MaxDeliver:     5,
+		DeliverPolicy:  DeliverAll,
+		FilterSubjects: []string{"orders.new", "orders.processed"},
+		PriorityPolicy: PriorityPinnedClient,
+		PriorityGroups: []string{"jobs"},
+	}
+	if v := valid.ValidatePedantic(features); len(v) != 0 {
+		t.Fatalf("expected no violations got %v", v)
+	}
+
+	cfg := ConsumerConfig{
+		AckPolicy:      AckExplicit,
+		BackOff:        []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+		MaxDeliver:     2,
+		MaxWaiting:     -1,
+		DeliverPolicy:  DeliverByStartSequence,
+		FilterSubject:  "orders.>",
+		FilterSubjects: []string{"orders.new"},
+		Heartbeat:      time.Second,
+		PauseUntil:     time.Now(),
+	}
+
+	expected := []ConfigViolation{
+		{"max_waiting", "must not be negative"},
+		{"backoff", "first value 1s must equal ack_wait 30s"},
+		{"backoff", "has 3 values which exceeds max_deliver 2"},
+		{"idle_heartbeat", "is only supported on push consumers"},
+		{"filter_subjects", "can not be combined with filter_subject"},
+		{"filter_subjects", `"orders.>" overlaps "orders.new"`},
+		{"opt_start_seq", "is required with deliver policy By Start Sequence"},
+		{"pause_until", "is not supported by the server"},
+	}
+
+	violations := cfg.ValidatePedantic(FeaturesForApiLevel(0))
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("expected %v got %v", expected, violations)
+	}
+
+	push := ConsumerConfig{
+		AckPolicy:       AckNone,
+		DeliverSubject:  "deliver.*",
+		MaxAckPending:   10,
+		MaxRequestBatch: 10,
+		FlowControl:     true,
+		PriorityPolicy:  PriorityPrioritized,
+	}
+	expected = []ConfigViolation{
+		{"deliver_subject", "must be a valid literal subject"},
+		{"max_ack_pending", "requires an ack policy other than none"},
+		{"max_batch", "is only supported on pull consumers"},
+		{"flow_control", "requires idle_heartbeat to be set"},
+		{"priority_policy", "is only supported on pull consumers"},
+		{"priority_groups", "are required when a priority policy is set"},
+		{"priority_groups", "are not supported by the server"},
+		{"priority_policy", "prioritized is not supported by the server"},
+	}
+
+	violations = push.ValidatePedantic(FeaturesForApiLevel(0))
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("expected %v got %v", expected, violations)
+	}
+}
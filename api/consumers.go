@@ -45,12 +45,6 @@ const (
 	JSApiConsumerNames                = "$JS.API.CONSUMER.NAMES.*"
 	JSApiConsumerNamesPrefix          = "$JS.API.CONSUMER.NAMES"
 	JSApiConsumerNamesT               = "$JS.API.CONSUMER.NAMES.%s"
-	JSApiConsumerPause                = "$JS.API.CONSUMER.PAUSE.*.*"
-	JSApiConsumerPausePrefix          = "$JS.API.CONSUMER.PAUSE"
-	JSApiConsumerPauseT               = "$JS.API.CONSUMER.PAUSE.%s.%s"
-	JSApiConsumerUnpin                = "$JS.API.CONSUMER.UNPIN.*.*"
-	JSApiConsumerUnpinPrefix          = "$JS.API.CONSUMER.UNPIN"
-	JSApiConsumerUnpinT               = "$JS.API.CONSUMER.UNPIN.%s.%s"
 	JSApiDurableCreate                = "$JS.API.CONSUMER.DURABLE.CREATE.*.*"
 	JSApiDurableCreatePrefix          = "$JS.API.CONSUMER.DURABLE.CREATE"
 	JSApiDurableCreateT               = "$JS.API.CONSUMER.DURABLE.CREATE.%s.%s"
@@ -59,6 +53,8 @@ const (
 	JSApiRequestNextT                 = "$JS.API.CONSUMER.MSG.NEXT.%s.%s"
 
 	JSAdvisoryConsumerMaxDeliveryExceedPre = JSAdvisoryPrefix + ".CONSUMER.MAX_DELIVERIES"
+	JSAdvisoryConsumerMsgNakPre            = JSAdvisoryPrefix + ".CONSUMER.MSG_NAKED"
+	JSAdvisoryConsumerMsgTerminatedPre     = JSAdvisoryPrefix + ".CONSUMER.MSG_TERMINATED"
 	JSMetricConsumerAckPre                 = JSMetricPrefix + ".CONSUMER.ACK"
 )
 
@@ -114,16 +110,6 @@ func (a *ConsumerAction) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// io.nats.jetstream.api.v1.consumer_unpin_request
-type JSApiConsumerUnpinRequest struct {
-	Group string `json:"group"`
-}
-
-// io.nats.jetstream.api.v1.consumer_unpin_response
-type JSApiConsumerUnpinResponse struct {
-	JSApiResponse
-}
-
 // io.nats.jetstream.api.v1.consumer_delete_response
 type JSApiConsumerDeleteResponse struct {
 	JSApiResponse
@@ -188,19 +174,6 @@ type JSApiConsumerLeaderStepDownResponse struct {
 	Success bool `json:"success,omitempty"`
 }
 
-// io.nats.jetstream.api.v1.consumer_pause_request
-type JSApiConsumerPauseRequest struct {
-	PauseUntil time.Time `json:"pause_until,omitempty" api_level:"1"`
-}
-
-// io.nats.jetstream.api.v1.consumer_pause_response
-type JSApiConsumerPauseResponse struct {
-	JSApiResponse
-	Paused         bool          `json:"paused"`
-	PauseUntil     time.Time     `json:"pause_until"`
-	PauseRemaining time.Duration `json:"pause_remaining,omitempty"`
-}
-
 type AckPolicy int
 
 const (
@@ -0,0 +1,102 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+)
+
+// JSError is a typed JetStream API error identified by its unique NATS error code, use with errors.Is and errors.As
+//
+//	if errors.Is(err, api.ErrStreamNotFound) {
+//	   // handle missing stream
+//	}
+//
+//	var jserr *api.JSError
+//	if errors.As(err, &jserr) {
+//	   fmt.Println(jserr.ErrCode)
+//	}
+type JSError struct {
+	// Code is the HTTP like status code of the error
+	Code int
+	// ErrCode is the unique NATS error code, see `nats errors` command
+	ErrCode uint16
+	// Description is a human friendly description of the error
+	Description string
+}
+
+// Sentinel errors for common JetStream API failures
+var (
+	ErrAccountResourcesExceeded = &JSError{Code: 400, ErrCode: 10002, Description: "resource limits exceeded for account"}
+	ErrBadRequest               = &JSError{Code: 400, ErrCode: 10003, Description: "bad request"}
+	ErrClusterNotAvailable      = &JSError{Code: 503, ErrCode: 10008, Description: "JetStream system temporarily unavailable"}
+	ErrConsumerNameExists       = &JSError{Code: 400, ErrCode: 10013, Description: "consumer name already in use"}
+	ErrConsumerNotFound         = &JSError{Code: 404, ErrCode: 10014, Description: "consumer not found"}
+	ErrInsufficientResources    = &JSError{Code: 503, ErrCode: 10023, Description: "insufficient resources"}
+	ErrMaximumConsumersLimit    = &JSError{Code: 400, ErrCode: 10026, Description: "maximum consumers limit reached"}
+	ErrMaximumStreamsLimit      = &JSError{Code: 400, ErrCode: 10027, Description: "maximum number of streams reached"}
+	ErrMemoryResourcesExceeded  = &JSError{Code: 500, ErrCode: 10028, Description: "insufficient memory resources available"}
+	ErrNoMessageFound           = &JSError{Code: 404, ErrCode: 10037, Description: "no message found"}
+	ErrNotEnabledForAccount     = &JSError{Code: 503, ErrCode: 10039, Description: "JetStream not enabled for account"}
+	ErrStorageResourcesExceeded = &JSError{Code: 500, ErrCode: 10047, Description: "insufficient storage resources available"}
+	ErrStreamNameExists         = &JSError{Code: 400, ErrCode: 10058, Description: "stream name already in use with a different configuration"}
+	ErrStreamNotFound           = &JSError{Code: 404, ErrCode: 10059, Description: "stream not found"}
+	ErrStreamSubjectOverlap     = &JSError{Code: 400, ErrCode: 10065, Description: "subjects overlap with an existing stream"}
+	ErrNotEnabled               = &JSError{Code: 503, ErrCode: 10076, Description: "JetStream not enabled"}
+	ErrConsumerExistingActive   = &JSError{Code: 400, ErrCode: 10105, Description: "consumer already exists and is still active"}
+	ErrStreamSealed             = &JSError{Code: 400, ErrCode: 10109, Description: "invalid operation on sealed stream"}
+	ErrConsumerAlreadyExists    = &JSError{Code: 400, ErrCode: 10148, Description: "consumer already exists"}
+	ErrConsumerDoesNotExist     = &JSError{Code: 400, ErrCode: 10149, Description: "consumer does not exist"}
+)
+
+// Error implements error
+func (e *JSError) Error() string {
+	return fmt.Sprintf("%s (%d)", e.Description, e.ErrCode)
+}
+
+// Is supports errors.Is by comparing NATS error codes
+func (e *JSError) Is(target error) bool {
+	switch t := target.(type) {
+	case *JSError:
+		return t != nil && e.ErrCode == t.ErrCode
+	case ApiError:
+		return e.ErrCode == t.ErrCode
+	case *ApiError:
+		return t != nil && e.ErrCode == t.ErrCode
+	default:
+		return false
+	}
+}
+
+// Is supports errors.Is against the JSError sentinels by comparing NATS error codes
+func (e ApiError) Is(target error) bool {
+	t, ok := target.(*JSError)
+	if !ok || t == nil || e.ErrCode == 0 {
+		return false
+	}
+
+	return e.ErrCode == t.ErrCode
+}
+
+// As supports errors.As into a *JSError
+func (e ApiError) As(target any) bool {
+	t, ok := target.(**JSError)
+	if !ok {
+		return false
+	}
+
+	*t = &JSError{Code: e.Code, ErrCode: e.ErrCode, Description: e.Description}
+
+	return true
+}
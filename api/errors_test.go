@@ -0,0 +1,49 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestJSError(t *testing.T) {
+	resp := JSApiResponse{Error: &ApiError{Code: 404, ErrCode: 10059, Description: "stream not found"}}
+	err := fmt.Errorf("load failed: %w", resp.ToError())
+
+	if !errors.Is(err, ErrStreamNotFound) {
+		t.Fatalf("expected stream not found error")
+	}
+
+	if errors.Is(err, ErrConsumerNotFound) {
+		t.Fatalf("did not expect consumer not found error")
+	}
+
+	var jserr *JSError
+	if !errors.As(err, &jserr) {
+		t.Fatalf("expected errors.As to succeed")
+	}
+	if jserr.ErrCode != 10059 || jserr.Code != 404 {
+		t.Fatalf("invalid error extracted: %#v", jserr)
+	}
+
+	if !errors.Is(jserr, ErrStreamNotFound) {
+		t.Fatalf("expected extracted error to match sentinel")
+	}
+
+	if errors.Is(ApiError{Code: 500}, &JSError{}) {
+		t.Fatalf("did not expect errors without codes to match")
+	}
+}
@@ -1,7 +1,10 @@
 package event
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
 	"net"
 	"strconv"
 	"strings"
@@ -21,6 +24,28 @@ var (
 	lTemplates      = map[string]*template.Template{}
 )
 
+// templateFuncs are the functions available to templates, see RegisterTemplateFuncs
+var templateFuncs = template.FuncMap{
+	"ShortTime":    func(v time.Time) string { return v.Format("15:04:05") },
+	"NanoTime":     func(v time.Time) string { return v.Format("15:04:05.000") },
+	"IBytes":       func(v int64) string { return humanize.IBytes(uint64(v)) },
+	"UIBytes":      func(v uint64) string { return humanize.IBytes(v) },
+	"IntCommas":    func(v int) string { return humanize.Comma(int64(v)) },
+	"Int64Commas":  func(v int64) string { return humanize.Comma(v) },
+	"Uint64Commas": func(v uint64) string { return humanize.Comma(int64(v)) },
+	"HostPort":     func(h string, p int) string { return net.JoinHostPort(h, strconv.Itoa(p)) },
+	"LeftPad":      func(indent int, v string) string { return leftPad(v, indent) },
+	"ToString":     func(v stringer) string { return v.String() },
+	"TitleString":  func(v string) string { return cases.Title(language.AmericanEnglish).String(v) },
+	"JoinStrings":  func(v []string) string { return strings.Join(v, ",") },
+}
+
+// registeredFuncs are the names of functions added using RegisterTemplateFuncs
+var registeredFuncs = map[string]bool{}
+
+// typeFactory creates instances of schema types used to validate templates, see SetTypeFactory
+var typeFactory func(schema string) (any, bool)
+
 var mu sync.Mutex
 
 const (
@@ -56,6 +81,55 @@ func (e NATSEvent) EventSource() string {
 	return fmt.Sprintf("urn:nats:%s", parts[2])
 }
 
+// JSONEnvelope is the normalized form of any event rendered by RenderJSON, Payload holds all fields of the event
+// other than its type, id and timestamp
+type JSONEnvelope struct {
+	Type    string          `json:"type"`
+	ID      string          `json:"id"`
+	Time    time.Time       `json:"time"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+type eventHeader interface {
+	EventType() string
+	EventID() string
+	EventTime() time.Time
+}
+
+// RenderJSON writes e, any type embedding NATSEvent, to wr as a single line JSONEnvelope
+func RenderJSON(wr io.Writer, e any) error {
+	hdr, ok := e.(eventHeader)
+	if !ok {
+		return fmt.Errorf("%T is not an event", e)
+	}
+
+	j, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(j, &fields)
+	if err != nil {
+		return err
+	}
+	delete(fields, "type")
+	delete(fields, "id")
+	delete(fields, "timestamp")
+
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(wr).Encode(JSONEnvelope{
+		Type:    hdr.EventType(),
+		ID:      hdr.EventID(),
+		Time:    hdr.EventTime(),
+		Payload: payload,
+	})
+}
+
 func (e NATSEvent) EventTemplate(kind string) (*template.Template, error) {
 	switch kind {
 	case textCompact:
@@ -93,11 +167,6 @@ func (e NATSEvent) textCompactTemplate() (*template.Template, error) {
 }
 
 func RegisterTextCompactTemplate(schema string, body string) error {
-	parsed, err := compileTemplate(schema, body)
-	if err != nil {
-		return fmt.Errorf("invalid short text template for schema %q: %s", schema, err)
-	}
-
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -106,6 +175,11 @@ func RegisterTextCompactTemplate(schema string, body string) error {
 		return fmt.Errorf("text/compact template for %q already registered", schema)
 	}
 
+	parsed, err := compileTemplate(schema, body, templateFuncs)
+	if err != nil {
+		return fmt.Errorf("invalid short text template for schema %q: %s", schema, err)
+	}
+
 	sTemplateBodies[schema] = body
 	sTemplates[schema] = parsed
 
@@ -113,11 +187,6 @@ func RegisterTextCompactTemplate(schema string, body string) error {
 }
 
 func RegisterTextExtendedTemplate(schema string, body string) error {
-	parsed, err := compileTemplate(schema, body)
-	if err != nil {
-		return fmt.Errorf("invalid long text template for schema %q: %s", schema, err)
-	}
-
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -126,6 +195,11 @@ func RegisterTextExtendedTemplate(schema string, body string) error {
 		return fmt.Errorf("text/extended template for %q already registered", schema)
 	}
 
+	parsed, err := compileTemplate(schema, body, templateFuncs)
+	if err != nil {
+		return fmt.Errorf("invalid long text template for schema %q: %s", schema, err)
+	}
+
 	lTemplateBodies[schema] = body
 	lTemplates[schema] = parsed
 
@@ -136,19 +210,137 @@ type stringer interface {
 	String() string
 }
 
-func compileTemplate(schema string, body string) (*template.Template, error) {
-	return template.New(schema).Funcs(map[string]any{
-		"ShortTime":   func(v time.Time) string { return v.Format("15:04:05") },
-		"NanoTime":    func(v time.Time) string { return v.Format("15:04:05.000") },
-		"IBytes":      func(v int64) string { return humanize.IBytes(uint64(v)) },
-		"IntCommas":   func(v int) string { return humanize.Comma(int64(v)) },
-		"Int64Commas": func(v int64) string { return humanize.Comma(v) },
-		"HostPort":    func(h string, p int) string { return net.JoinHostPort(h, strconv.Itoa(p)) },
-		"LeftPad":     func(indent int, v string) string { return leftPad(v, indent) },
-		"ToString":    func(v stringer) string { return v.String() },
-		"TitleString": func(v string) string { return cases.Title(language.AmericanEnglish).String(v) },
-		"JoinStrings": func(v []string) string { return strings.Join(v, ",") },
-	}).Parse(body)
+// OverrideTemplate replaces the text/compact or text/extended template for schemaType, the template has to parse and
+// when the schema type is known to the type factory it may only reference fields of that type
+func OverrideTemplate(schemaType string, format string, body string) error {
+	if format != textCompact && format != textExtended {
+		return fmt.Errorf("unsupported template format %q", format)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	parsed, err := compileTemplate(schemaType, body, templateFuncs)
+	if err != nil {
+		return fmt.Errorf("invalid %s template for schema %q: %s", format, schemaType, err)
+	}
+
+	err = validateTemplateFields(schemaType, parsed)
+	if err != nil {
+		return fmt.Errorf("invalid %s template for schema %q: %s", format, schemaType, err)
+	}
+
+	switch format {
+	case textCompact:
+		sTemplateBodies[schemaType] = body
+		sTemplates[schemaType] = parsed
+	case textExtended:
+		lTemplateBodies[schemaType] = body
+		lTemplates[schemaType] = parsed
+	}
+
+	return nil
+}
+
+// SetTypeFactory sets the function OverrideTemplate uses to create instances of schema types, ok is false for
+// unknown types. The api package sets this to api.NewMessage
+func SetTypeFactory(factory func(schema string) (instance any, ok bool)) {
+	mu.Lock()
+	typeFactory = factory
+	mu.Unlock()
+}
+
+// validateTemplateFields renders t using an empty instance of schemaType to detect references to unknown fields,
+// other errors are ignored since empty instances can not satisfy all templates
+func validateTemplateFields(schemaType string, t *template.Template) error {
+	if typeFactory == nil {
+		return nil
+	}
+
+	instance, ok := typeFactory(schemaType)
+	if !ok {
+		return nil
+	}
+
+	err := t.Execute(io.Discard, instance)
+	if err != nil && strings.Contains(err.Error(), "can't evaluate field") {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterTemplateFuncs makes funcs available to templates, a function named like a built-in one like ShortTime
+// replaces it while a name registered before is rejected. Templates that are already registered are compiled again
+// using funcs
+func RegisterTemplateFuncs(funcs template.FuncMap) error {
+	err := validateTemplateFuncs(funcs)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for name := range funcs {
+		if registeredFuncs[name] {
+			return fmt.Errorf("template function %q already registered", name)
+		}
+	}
+
+	merged := maps.Clone(templateFuncs)
+	maps.Copy(merged, funcs)
+
+	compact, err := compileTemplates(sTemplateBodies, merged)
+	if err != nil {
+		return err
+	}
+
+	extended, err := compileTemplates(lTemplateBodies, merged)
+	if err != nil {
+		return err
+	}
+
+	templateFuncs = merged
+	sTemplates = compact
+	lTemplates = extended
+
+	for name := range funcs {
+		registeredFuncs[name] = true
+	}
+
+	return nil
+}
+
+// validateTemplateFuncs checks that funcs are valid template functions, text/template panics on invalid ones
+func validateTemplateFuncs(funcs template.FuncMap) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid template function: %v", r)
+		}
+	}()
+
+	template.New("validate").Funcs(funcs)
+
+	return nil
+}
+
+func compileTemplates(bodies map[string]string, funcs template.FuncMap) (map[string]*template.Template, error) {
+	compiled := make(map[string]*template.Template, len(bodies))
+	for schema, body := range bodies {
+		parsed, err := compileTemplate(schema, body, funcs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template for schema %q: %s", schema, err)
+		}
+
+		compiled[schema] = parsed
+	}
+
+	return compiled, nil
+}
+
+func compileTemplate(schema string, body string, funcs template.FuncMap) (*template.Template, error) {
+	return template.New(schema).Funcs(funcs).Parse(body)
 }
 
 func leftPad(s string, indent int) string {
@@ -0,0 +1,282 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/jsm.go/api/event"
+	jsadvisory "github.com/nats-io/jsm.go/api/jetstream/advisory"
+	srvadvisory "github.com/nats-io/jsm.go/api/server/advisory"
+	"github.com/nats-io/nuid"
+)
+
+// CorrelatedEventSchemaType is the type of events produced by the EventCorrelator
+const CorrelatedEventSchemaType = "io.nats.jsm.correlation.v1.correlated_event"
+
+const (
+	// CorrelationShortLivedConnection is a client connection followed by its disconnection
+	CorrelationShortLivedConnection = "short_lived_connection"
+	// CorrelationQuorumRecovered is a stream or consumer that lost quorum followed by a leader election
+	CorrelationQuorumRecovered = "quorum_recovered"
+)
+
+// CorrelatedEvent summarizes a group of related events that happened within the window of an EventCorrelator
+type CorrelatedEvent struct {
+	event.NATSEvent
+
+	Kind     string        `json:"kind"`
+	Key      string        `json:"key"`
+	Summary  string        `json:"summary"`
+	Duration time.Duration `json:"duration"`
+	EventIDs []string      `json:"events"`
+
+	// Events are the correlated events in the order they were received
+	Events []Event `json:"-"`
+}
+
+// heldEvent is an event waiting for a related event to arrive
+type heldEvent struct {
+	kind     string
+	key      string
+	event    Event
+	received time.Time
+}
+
+// EventCorrelator groups related events that arrive within a time window into a single CorrelatedEvent. Connections
+// that disconnect within the window and streams or consumers that elect a leader within the window of losing quorum
+// are summarized, events seen before with the same ID are dropped and all other events pass through unchanged.
+//
+// Events that could start a correlation are held back until the related event arrives or the window passes
+type EventCorrelator struct {
+	window time.Duration
+	held   []*heldEvent
+	seen   map[string]time.Time
+	mu     sync.Mutex
+}
+
+// NewEventCorrelator creates a new correlator that groups events arriving within window of each other
+func NewEventCorrelator(window time.Duration) (*EventCorrelator, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("correlation window is required")
+	}
+
+	return &EventCorrelator{
+		window: window,
+		seen:   map[string]time.Time{},
+	}, nil
+}
+
+// Add processes an event and returns the events that are ready for delivery, empty when e is held back or is
+// a duplicate
+func (c *EventCorrelator) Add(e Event) []Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	if id := e.EventID(); id != "" {
+		if _, ok := c.seen[id]; ok {
+			return nil
+		}
+		c.seen[id] = now
+	}
+
+	kind, key, opens := correlationKey(e)
+	if kind == "" {
+		return []Event{e}
+	}
+
+	if opens {
+		var ready []Event
+		if prev := c.take(kind, key); prev != nil {
+			ready = append(ready, prev.event)
+		}
+		c.held = append(c.held, &heldEvent{kind: kind, key: key, event: e, received: now})
+
+		return ready
+	}
+
+	prev := c.take(kind, key)
+	if prev == nil {
+		return []Event{e}
+	}
+
+	if e.EventTime().Sub(prev.event.EventTime()) > c.window {
+		return []Event{prev.event, e}
+	}
+
+	return []Event{newCorrelatedEvent(kind, key, prev.event, e)}
+}
+
+// Expire returns the events held back for longer than the window as of now and forgets duplicates older than the window
+func (c *EventCorrelator) Expire(now time.Time) []Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, t := range c.seen {
+		if now.Sub(t) > c.window {
+			delete(c.seen, id)
+		}
+	}
+
+	var ready []Event
+	var held []*heldEvent
+	for _, h := range c.held {
+		if now.Sub(h.received) > c.window {
+			ready = append(ready, h.event)
+		} else {
+			held = append(held, h)
+		}
+	}
+	c.held = held
+
+	return ready
+}
+
+// Flush returns all events being held back
+func (c *EventCorrelator) Flush() []Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var ready []Event
+	for _, h := range c.held {
+		ready = append(ready, h.event)
+	}
+	c.held = nil
+
+	return ready
+}
+
+// Run correlates events read from events and publishes the results to out until ctx is canceled or events is
+// closed, events still being held back are published before returning
+func (c *EventCorrelator) Run(ctx context.Context, events <-chan Event, out chan<- Event) error {
+	ticker := time.NewTicker(max(c.window/2, time.Millisecond))
+	defer ticker.Stop()
+
+	publish := func(ready []Event) error {
+		for _, e := range ready {
+			select {
+			case out <- e:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		return nil
+	}
+
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return publish(c.Flush())
+			}
+
+			err := publish(c.Add(e))
+			if err != nil {
+				return err
+			}
+
+		case now := <-ticker.C:
+			err := publish(c.Expire(now))
+			if err != nil {
+				return err
+			}
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *EventCorrelator) take(kind string, key string) *heldEvent {
+	for i, h := range c.held {
+		if h.kind == kind && h.key == key {
+			c.held = append(c.held[:i], c.held[i+1:]...)
+			return h
+		}
+	}
+
+	return nil
+}
+
+// correlationKey determines the kind of correlation e takes part in and the key identifying related events, opens
+// indicates that e starts the correlation. Kind is empty for events that are not correlated
+func correlationKey(e Event) (kind string, key string, opens bool) {
+	switch ev := e.(type) {
+	case *srvadvisory.ConnectEventMsgV1:
+		return CorrelationShortLivedConnection, fmt.Sprintf("%s.%d", ev.Server.ID, ev.Client.ID), true
+	case *srvadvisory.DisconnectEventMsgV1:
+		return CorrelationShortLivedConnection, fmt.Sprintf("%s.%d", ev.Server.ID, ev.Client.ID), false
+	case *jsadvisory.JSStreamQuorumLostV1:
+		return CorrelationQuorumRecovered, fmt.Sprintf("%s.%s.%s", ev.Domain, ev.Account, ev.Stream), true
+	case *jsadvisory.JSStreamLeaderElectedV1:
+		return CorrelationQuorumRecovered, fmt.Sprintf("%s.%s.%s", ev.Domain, ev.Account, ev.Stream), false
+	case *jsadvisory.JSConsumerQuorumLostV1:
+		return CorrelationQuorumRecovered, fmt.Sprintf("%s.%s.%s.%s", ev.Domain, ev.Account, ev.Stream, ev.Consumer), true
+	case *jsadvisory.JSConsumerLeaderElectedV1:
+		return CorrelationQuorumRecovered, fmt.Sprintf("%s.%s.%s.%s", ev.Domain, ev.Account, ev.Stream, ev.Consumer), false
+	default:
+		return "", "", false
+	}
+}
+
+func newCorrelatedEvent(kind string, key string, first Event, last Event) *CorrelatedEvent {
+	ce := &CorrelatedEvent{
+		NATSEvent: event.NATSEvent{
+			Type: CorrelatedEventSchemaType,
+			ID:   nuid.Next(),
+			Time: last.EventTime(),
+		},
+		Kind:     kind,
+		Key:      key,
+		Duration: last.EventTime().Sub(first.EventTime()),
+		EventIDs: []string{first.EventID(), last.EventID()},
+		Events:   []Event{first, last},
+	}
+
+	switch ev := last.(type) {
+	case *srvadvisory.DisconnectEventMsgV1:
+		ce.Summary = fmt.Sprintf("Client %d in account %s on server %s disconnected after %v: %s", ev.Client.ID, ev.Client.Account, ev.Server.Name, ce.Duration.Round(time.Millisecond), ev.Reason)
+	case *jsadvisory.JSStreamLeaderElectedV1:
+		ce.Summary = fmt.Sprintf("Stream %s in account %s elected leader %s %v after losing quorum", ev.Stream, ev.Account, ev.Leader, ce.Duration.Round(time.Millisecond))
+	case *jsadvisory.JSConsumerLeaderElectedV1:
+		ce.Summary = fmt.Sprintf("Consumer %s > %s in account %s elected leader %s %v after losing quorum", ev.Stream, ev.Consumer, ev.Account, ev.Leader, ce.Duration.Round(time.Millisecond))
+	}
+
+	return ce
+}
+
+func init() {
+	err := event.RegisterTextCompactTemplate(CorrelatedEventSchemaType, `{{ .Time | ShortTime }} [Correlated {{ .Kind }}] {{ .Summary }}`)
+	if err != nil {
+		panic(err)
+	}
+
+	err = event.RegisterTextExtendedTemplate(CorrelatedEventSchemaType, `
+[{{ .Time | ShortTime }}] [{{ .ID }}] Correlated Events
+
+        Kind: {{ .Kind }}
+         Key: {{ .Key }}
+     Summary: {{ .Summary }}
+    Duration: {{ .Duration }}
+      Events: {{ .EventIDs | JoinStrings }}`)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	jsadvisory "github.com/nats-io/jsm.go/api/jetstream/advisory"
+	srvadvisory "github.com/nats-io/jsm.go/api/server/advisory"
+)
+
+func TestEventCorrelator(t *testing.T) {
+	_, err := NewEventCorrelator(0)
+	if err == nil {
+		t.Fatalf("expected an error for an empty window")
+	}
+
+	c, err := NewEventCorrelator(time.Minute)
+	checkErr(t, err, "correlator failed")
+
+	start := time.Now()
+
+	conn := &srvadvisory.ConnectEventMsgV1{}
+	conn.Type = "io.nats.server.advisory.v1.client_connect"
+	conn.ID = "c1"
+	conn.Time = start
+	conn.Server.ID = "S1"
+	conn.Client.ID = 10
+
+	dc := &srvadvisory.DisconnectEventMsgV1{Reason: "Client Closed"}
+	dc.Type = "io.nats.server.advisory.v1.client_disconnect"
+	dc.ID = "d1"
+	dc.Time = start.Add(time.Second)
+	dc.Server.ID = "S1"
+	dc.Server.Name = "n1"
+	dc.Client.ID = 10
+	dc.Client.Account = "A"
+
+	if res := c.Add(conn); len(res) != 0 {
+		t.Fatalf("expected the connect event to be held: %v", res)
+	}
+	if res := c.Add(conn); len(res) != 0 {
+		t.Fatalf("expected the duplicate to be dropped: %v", res)
+	}
+
+	res := c.Add(dc)
+	if len(res) != 1 {
+		t.Fatalf("expected 1 event got %d", len(res))
+	}
+	ce, ok := res[0].(*CorrelatedEvent)
+	if !ok {
+		t.Fatalf("expected a correlated event got %T", res[0])
+	}
+	if ce.Kind != CorrelationShortLivedConnection || ce.Duration != time.Second || len(ce.Events) != 2 {
+		t.Fatalf("invalid correlated event: %+v", ce)
+	}
+	if ce.Summary != "Client 10 in account A on server n1 disconnected after 1s: Client Closed" {
+		t.Fatalf("invalid summary: %q", ce.Summary)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	checkErr(t, RenderEvent(buf, ce, TextCompactFormat), "render failed")
+	if !strings.Contains(buf.String(), "[Correlated short_lived_connection] Client 10") {
+		t.Fatalf("invalid rendering: %q", buf.String())
+	}
+
+	ql := &jsadvisory.JSStreamQuorumLostV1{Stream: "ORDERS"}
+	ql.Type = "io.nats.jetstream.advisory.v1.stream_quorum_lost"
+	ql.ID = "q1"
+	ql.Time = start
+
+	other := &jsadvisory.JSStreamLeaderElectedV1{Stream: "OTHER", Leader: "n2"}
+	other.Type = "io.nats.jetstream.advisory.v1.stream_leader_elected"
+	other.ID = "l1"
+	other.Time = start
+
+	c.Add(ql)
+	if res := c.Add(other); len(res) != 1 || res[0] != other {
+		t.Fatalf("expected unrelated leader election to pass through: %v", res)
+	}
+	if res := c.Expire(time.Now()); len(res) != 0 {
+		t.Fatalf("expected nothing to expire: %v", res)
+	}
+	if res := c.Expire(time.Now().Add(2 * time.Minute)); len(res) != 1 || res[0] != ql {
+		t.Fatalf("expected the quorum lost event to expire: %v", res)
+	}
+}
+
+func TestEventCorrelatorRun(t *testing.T) {
+	c, err := NewEventCorrelator(time.Minute)
+	checkErr(t, err, "correlator failed")
+
+	ql := &jsadvisory.JSConsumerQuorumLostV1{Stream: "ORDERS", Consumer: "NEW"}
+	ql.Type = "io.nats.jetstream.advisory.v1.consumer_quorum_lost"
+	ql.ID = "q1"
+
+	le := &jsadvisory.JSConsumerLeaderElectedV1{Stream: "ORDERS", Consumer: "NEW", Leader: "n2"}
+	le.Type = "io.nats.jetstream.advisory.v1.consumer_leader_elected"
+	le.ID = "l1"
+
+	conn := &srvadvisory.ConnectEventMsgV1{}
+	conn.Type = "io.nats.server.advisory.v1.client_connect"
+	conn.ID = "c1"
+
+	in := make(chan Event, 3)
+	out := make(chan Event, 3)
+	in <- ql
+	in <- le
+	in <- conn
+	close(in)
+
+	checkErr(t, c.Run(context.Background(), in, out), "run failed")
+	close(out)
+
+	var received []Event
+	for e := range out {
+		received = append(received, e)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 events got %d", len(received))
+	}
+	if ce, ok := received[0].(*CorrelatedEvent); !ok || ce.Kind != CorrelationQuorumRecovered {
+		t.Fatalf("expected a quorum recovered event got %+v", received[0])
+	}
+	if received[1] != conn {
+		t.Fatalf("expected the held connect event to be flushed got %+v", received[1])
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// EventStreamRenderer continuously renders events received on a channel to a writer, one event per line for
+// ApplicationJSONFormat and EventFormatJSON producing newline delimited JSON
+type EventStreamRenderer struct {
+	wr            *bufio.Writer
+	format        RenderFormat
+	flushInterval time.Duration
+	errHandler    func(Event, error)
+}
+
+// EventStreamOption configures an EventStreamRenderer
+type EventStreamOption func(r *EventStreamRenderer) error
+
+// EventStreamFlushInterval sets how often buffered output is written to the writer, defaults to 1 second
+func EventStreamFlushInterval(d time.Duration) EventStreamOption {
+	return func(r *EventStreamRenderer) error {
+		if d <= 0 {
+			return fmt.Errorf("flush interval must be positive")
+		}
+
+		r.flushInterval = d
+		return nil
+	}
+}
+
+// EventStreamErrorHandler calls cb for events that could not be rendered, by default they are skipped
+func EventStreamErrorHandler(cb func(Event, error)) EventStreamOption {
+	return func(r *EventStreamRenderer) error {
+		r.errHandler = cb
+		return nil
+	}
+}
+
+// NewEventStreamRenderer creates a renderer writing events in format to wr
+func NewEventStreamRenderer(wr io.Writer, format RenderFormat, opts ...EventStreamOption) (*EventStreamRenderer, error) {
+	switch format {
+	case TextCompactFormat, TextExtendedFormat, TextRichFormat, ApplicationJSONFormat, ApplicationCloudEventV1Format, EventFormatJSON, SyslogFormat, CEFFormat:
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+
+	r := &EventStreamRenderer{
+		wr:            bufio.NewWriter(wr),
+		format:        format,
+		flushInterval: time.Second,
+	}
+
+	for _, opt := range opts {
+		err := opt(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Run renders events until events is closed or ctx is canceled, buffered output is flushed periodically and
+// before returning. Only errors writing to the writer are returned
+func (r *EventStreamRenderer) Run(ctx context.Context, events <-chan Event) error {
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return r.wr.Flush()
+			}
+
+			err := r.render(e)
+			if err != nil {
+				return err
+			}
+
+		case <-ticker.C:
+			err := r.wr.Flush()
+			if err != nil {
+				return err
+			}
+
+		case <-ctx.Done():
+			return r.wr.Flush()
+		}
+	}
+}
+
+// render writes a single event followed by a newline, events that fail to render are passed to the error handler
+func (r *EventStreamRenderer) render(e Event) error {
+	buf := bytes.NewBuffer(nil)
+
+	var err error
+	switch r.format {
+	case ApplicationJSONFormat:
+		err = json.NewEncoder(buf).Encode(e)
+	case ApplicationCloudEventV1Format:
+		var ce []byte
+		ce, err = ToCloudEventV1(e)
+		if err == nil {
+			err = json.Compact(buf, ce)
+		}
+	default:
+		err = RenderEvent(buf, e, r.format)
+	}
+	if err != nil {
+		if r.errHandler != nil {
+			r.errHandler(e, err)
+		}
+		return nil
+	}
+
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+
+	_, err = r.wr.Write(buf.Bytes())
+	return err
+}
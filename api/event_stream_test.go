@@ -0,0 +1,84 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	jsadvisory "github.com/nats-io/jsm.go/api/jetstream/advisory"
+)
+
+func TestEventStreamRenderer(t *testing.T) {
+	_, err := NewEventStreamRenderer(nil, "text/other")
+	if err == nil {
+		t.Fatalf("expected an error for an unsupported format")
+	}
+
+	ja := &jsadvisory.JetStreamAPIAuditV1{}
+	err = json.Unmarshal([]byte(jetStreamAPIAuditEvent), ja)
+	checkErr(t, err, "could not unmarshal event")
+
+	unknown := &jsadvisory.JetStreamAPIAuditV1{}
+	unknown.Type = "io.nats.unknown.v1.event"
+
+	buf := bytes.NewBuffer(nil)
+	var failed int
+	r, err := NewEventStreamRenderer(buf, ApplicationJSONFormat, EventStreamErrorHandler(func(Event, error) { failed++ }))
+	checkErr(t, err, "renderer failed")
+
+	events := make(chan Event, 3)
+	events <- ja
+	events <- ja
+	close(events)
+
+	err = r.Run(context.Background(), events)
+	checkErr(t, err, "run failed")
+
+	lines := 0
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		lines++
+		parsed := jsadvisory.JetStreamAPIAuditV1{}
+		checkErr(t, json.Unmarshal(scanner.Bytes(), &parsed), "invalid line")
+		if parsed.ID != ja.ID {
+			t.Fatalf("invalid event: %#v", parsed)
+		}
+	}
+	if lines != 2 {
+		t.Fatalf("expected 2 lines got %d", lines)
+	}
+
+	buf.Reset()
+	r, err = NewEventStreamRenderer(buf, TextCompactFormat, EventStreamErrorHandler(func(Event, error) { failed++ }))
+	checkErr(t, err, "renderer failed")
+
+	events = make(chan Event, 2)
+	events <- unknown
+	events <- ja
+	close(events)
+
+	err = r.Run(context.Background(), events)
+	checkErr(t, err, "run failed")
+
+	if failed != 1 {
+		t.Fatalf("expected 1 failure got %d", failed)
+	}
+	if bytes.Count(buf.Bytes(), []byte("\n")) != 1 || !bytes.Contains(buf.Bytes(), []byte("[JS API] $JS.STREAM.LIST")) {
+		t.Fatalf("invalid output: %q", buf.String())
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Feature is a JetStream capability only available from a certain API level
+type Feature string
+
+const (
+	// FeatureConsumerPause is the ability to pause consumers until a certain time
+	FeatureConsumerPause Feature = "consumer_pause"
+	// FeaturePriorityGroups is support for priority groups with overflow and pinned client policies on pull consumers
+	FeaturePriorityGroups Feature = "priority_groups"
+	// FeaturePrioritizedPolicy is support for the prioritized priority policy on pull consumers
+	FeaturePrioritizedPolicy Feature = "prioritized_policy"
+	// FeatureMessageTTL is support for per message TTLs and subject delete markers
+	FeatureMessageTTL Feature = "message_ttl"
+	// FeatureBatchPublish is support for atomic batch publishing
+	FeatureBatchPublish Feature = "batch_publish"
+	// FeatureMessageCounters is support for streams holding distributed counters
+	FeatureMessageCounters Feature = "message_counters"
+	// FeatureMessageSchedules is support for scheduling messages
+	FeatureMessageSchedules Feature = "message_schedules"
+	// FeatureAsyncPersist is support for the asynchronous stream persist mode
+	FeatureAsyncPersist Feature = "async_persist"
+)
+
+// featureApiLevels is the API level each feature was introduced in
+var featureApiLevels = map[Feature]int{
+	FeatureConsumerPause:     1,
+	FeaturePriorityGroups:    1,
+	FeatureMessageTTL:        1,
+	FeaturePrioritizedPolicy: 2,
+	FeatureBatchPublish:      2,
+	FeatureMessageCounters:   2,
+	FeatureMessageSchedules:  2,
+	FeatureAsyncPersist:      2,
+}
+
+// serverApiLevels maps server major.minor versions to the JetStream API level they introduced
+var serverApiLevels = []struct {
+	major int
+	minor int
+	level int
+}{
+	{2, 12, 2},
+	{2, 11, 1},
+}
+
+// Features describes the JetStream capabilities of a server, create it using FeaturesForApiLevel
+// or FeaturesForVersion
+type Features struct {
+	// ApiLevel is the JetStream API level supported by the server
+	ApiLevel int `json:"api_level"`
+}
+
+// FeaturesForApiLevel describes the capabilities of a server supporting level, typically as found in
+// JetStreamAccountStats or the JetStream section of server varz information
+func FeaturesForApiLevel(level int) Features {
+	return Features{ApiLevel: level}
+}
+
+// FeaturesForVersion describes the capabilities of a server based on its version like 2.11.3 or v2.12.0-RC.1
+func FeaturesForVersion(version string) (Features, error) {
+	parts := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".", 3)
+	if len(parts) < 2 {
+		return Features{}, fmt.Errorf("invalid server version %q", version)
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return Features{}, fmt.Errorf("invalid server version %q", version)
+	}
+
+	minor, err := strconv.Atoi(strings.SplitN(parts[1], "-", 2)[0])
+	if err != nil {
+		return Features{}, fmt.Errorf("invalid server version %q", version)
+	}
+
+	for _, v := range serverApiLevels {
+		if major > v.major || (major == v.major && minor >= v.minor) {
+			return FeaturesForApiLevel(v.level), nil
+		}
+	}
+
+	return FeaturesForApiLevel(0), nil
+}
+
+// FeatureApiLevel is the API level that introduced feature
+func FeatureApiLevel(feature Feature) (int, error) {
+	lvl, ok := featureApiLevels[feature]
+	if !ok {
+		return 0, fmt.Errorf("unknown feature %q", feature)
+	}
+
+	return lvl, nil
+}
+
+// Supports determines if feature is available, unknown features are never supported
+func (f Features) Supports(feature Feature) bool {
+	lvl, err := FeatureApiLevel(feature)
+	if err != nil {
+		return false
+	}
+
+	return f.ApiLevel >= lvl
+}
+
+// Supported lists all the features that are available, sorted by name
+func (f Features) Supported() []Feature {
+	var res []Feature
+	for feature := range featureApiLevels {
+		if f.Supports(feature) {
+			res = append(res, feature)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+
+	return res
+}
+
+// Check returns an error naming the first of features that is not available
+func (f Features) Check(features ...Feature) error {
+	for _, feature := range features {
+		lvl, err := FeatureApiLevel(feature)
+		if err != nil {
+			return err
+		}
+
+		if f.ApiLevel < lvl {
+			return fmt.Errorf("%s requires JetStream API level %d but the server supports level %d", feature, lvl, f.ApiLevel)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFeaturesForVersion(t *testing.T) {
+	for version, level := range map[string]int{"2.10.22": 0, "2.11.0": 1, "v2.11.4": 1, "2.12.0-RC.1": 2, "2.13.0": 2, "3.0.0": 2} {
+		f, err := FeaturesForVersion(version)
+		checkErr(t, err, "features failed")
+		if f.ApiLevel != level {
+			t.Fatalf("expected level %d for %s got %d", level, version, f.ApiLevel)
+		}
+	}
+
+	for _, version := range []string{"", "2", "x.11.0", "2.x"} {
+		_, err := FeaturesForVersion(version)
+		if err == nil {
+			t.Fatalf("expected an error for %q", version)
+		}
+	}
+}
+
+func TestFeatures(t *testing.T) {
+	f := FeaturesForApiLevel(1)
+
+	if !f.Supports(FeatureConsumerPause) || !f.Supports(FeaturePriorityGroups) || !f.Supports(FeatureMessageTTL) {
+		t.Fatalf("expected level 1 features to be supported")
+	}
+	if f.Supports(FeatureBatchPublish) || f.Supports(Feature("unknown")) {
+		t.Fatalf("expected level 2 and unknown features to be unsupported")
+	}
+
+	expected := []Feature{FeatureConsumerPause, FeatureMessageTTL, FeaturePriorityGroups}
+	if !reflect.DeepEqual(f.Supported(), expected) {
+		t.Fatalf("expected %v got %v", expected, f.Supported())
+	}
+
+	checkErr(t, f.Check(FeatureConsumerPause, FeatureMessageTTL), "check failed")
+
+	err := f.Check(FeatureConsumerPause, FeatureBatchPublish)
+	if err == nil || err.Error() != "batch_publish requires JetStream API level 2 but the server supports level 1" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if FeaturesForApiLevel(0).Supported() != nil {
+		t.Fatalf("expected no features at level 0")
+	}
+}
@@ -231,6 +231,9 @@ func getSchema(u string) (title string, id string, body string, err error) {
 func main() {
 	s := schemas{
 		&schema{P: "jetstream/advisory/v1/api_audit.json", St: "jsadvisory.JetStreamAPIAuditV1"},
+		&schema{P: "jetstream/advisory/v1/kv_bucket_action.json", St: "jsadvisory.JSKVBucketActionAdvisoryV1"},
+		&schema{P: "jetstream/advisory/v1/kv_key_purge.json", St: "jsadvisory.JSKVKeyPurgeAdvisoryV1"},
+		&schema{P: "jetstream/advisory/v1/objectstore_bucket_action.json", St: "jsadvisory.JSObjectStoreBucketActionAdvisoryV1"},
 		&schema{P: "jetstream/advisory/v1/stream_batch_abandoned.json", St: "jsadvisory.JSStreamBatchAbandonedAdvisoryV1"},
 		&schema{P: "jetstream/advisory/v1/consumer_action.json", St: "jsadvisory.JSConsumerActionAdvisoryV1"},
 		&schema{P: "jetstream/advisory/v1/consumer_group_pinned.json", St: "jsadvisory.JSConsumerGroupPinnedAdvisoryV1"},
@@ -305,8 +308,14 @@ func main() {
 		&schema{P: "micro/v1/ping_response.json", St: "micro.Ping"},
 		&schema{P: "micro/v1/stats_response.json", St: "micro.Stats"},
 		&schema{P: "server/advisory/v1/account_connections.json", St: "srvadvisory.AccountConnectionsV1"},
+		&schema{P: "server/advisory/v1/auth_error.json", St: "srvadvisory.AuthErrorEventMsgV1"},
 		&schema{P: "server/advisory/v1/client_connect.json", St: "srvadvisory.ConnectEventMsgV1"},
 		&schema{P: "server/advisory/v1/client_disconnect.json", St: "srvadvisory.DisconnectEventMsgV1"},
+		&schema{P: "server/advisory/v1/leafnode_connect.json", St: "srvadvisory.LeafNodeConnectEventMsgV1"},
+		&schema{P: "server/advisory/v1/leafnode_disconnect.json", St: "srvadvisory.LeafNodeDisconnectEventMsgV1"},
+		&schema{P: "server/advisory/v1/server_lame_duck.json", St: "srvadvisory.ServerLameDuckEventMsgV1"},
+		&schema{P: "server/advisory/v1/server_shutdown.json", St: "srvadvisory.ServerShutdownEventMsgV1"},
+		&schema{P: "server/advisory/v1/slow_consumer.json", St: "srvadvisory.SlowConsumerEventMsgV1"},
 		&schema{P: "server/metric/v1/service_latency.json", St: "srvmetric.ServiceLatencyV1"},
 		&schema{P: "server/monitor/v1/varz.json", St: "zmonitor.VarzV1"},
 	}
@@ -0,0 +1,414 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ignore
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"log"
+	"math"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	scfs "github.com/nats-io/jsm.go/schemas"
+)
+
+// endpoint describes a JetStream API endpoint the subject constants, request and response structures are generated for
+type endpoint struct {
+	// Name is the base name of the subject constants, the Prefix and T suffixed variants are also generated
+	Name string
+	// Tokens is the number of asset names, like stream and consumer, following the subject prefix
+	Tokens int
+	// Request is the schema describing the request, may be empty for APIs without a request body
+	Request string
+	// RequestType is the name of the struct generated for the request
+	RequestType string
+	// Response is the schema describing the response
+	Response string
+	// ResponseType is the name of the struct generated for the response
+	ResponseType string
+	// RequestLevels are the API levels of request properties that were added after API level 0, the schemas do not
+	// express these
+	RequestLevels map[string]int
+	// ResponseOmitEmpty are the response properties tagged omitempty, other response properties are always
+	// rendered so that clients can tell zero values from missing values
+	ResponseOmitEmpty []string
+}
+
+// endpoints are the APIs generated from the schemas, the remaining APIs are maintained by hand and move here once
+// their generated types match the existing ones
+var endpoints = []endpoint{
+	{
+		Name:              "JSApiConsumerPause",
+		Tokens:            2,
+		Request:           "jetstream/api/v1/consumer_pause_request.json",
+		RequestType:       "JSApiConsumerPauseRequest",
+		Response:          "jetstream/api/v1/consumer_pause_response.json",
+		ResponseType:      "JSApiConsumerPauseResponse",
+		RequestLevels:     map[string]int{"pause_until": 1},
+		ResponseOmitEmpty: []string{"pause_remaining"},
+	},
+	{
+		Name:         "JSApiConsumerUnpin",
+		Tokens:       2,
+		Request:      "jetstream/api/v1/consumer_unpin_request.json",
+		RequestType:  "JSApiConsumerUnpinRequest",
+		Response:     "jetstream/api/v1/consumer_unpin_response.json",
+		ResponseType: "JSApiConsumerUnpinResponse",
+	},
+}
+
+// initialisms are json name tokens that are upper cased in Go field names
+var initialisms = []string{"id", "ttl", "url", "cpu", "ip"}
+
+var subjectPattern = regexp.MustCompile(`\$JS\.API(\.[A-Z]+)+`)
+
+type property struct {
+	Name        string
+	Type        string
+	Tag         string
+	Description string
+}
+
+type structure struct {
+	Name          string
+	SchemaType    string
+	Description   string
+	EmbedResponse bool
+	Properties    []property
+}
+
+type subjects struct {
+	Name     string
+	Wildcard string
+	Prefix   string
+	Format   string
+}
+
+type output struct {
+	Subjects   []subjects
+	Structures []structure
+	Time       bool
+}
+
+var apiTemplate = `// Code generated by gen_api.go; DO NOT EDIT.
+
+package api
+
+{{ if .Time }}import "time"{{ end }}
+
+const (
+{{- range .Subjects }}
+	{{ .Name }} = "{{ .Wildcard }}"
+	{{ .Name }}Prefix = "{{ .Prefix }}"
+	{{ .Name }}T = "{{ .Format }}"
+{{- end }}
+)
+
+{{- range .Structures }}
+
+// {{ .Name }} is {{ .Description }}
+//
+// NATS Schema Type {{ .SchemaType }}
+type {{ .Name }} struct {
+{{- if .EmbedResponse }}
+	JSApiResponse
+{{- end }}
+{{- range .Properties }}
+{{- if .Description }}
+	// {{ .Description }}
+{{- end }}
+	{{ .Name }} {{ .Type }} ` + "`{{ .Tag }}`" + `
+{{- end }}
+}
+{{- end }}
+`
+
+func loadSchema(path string) (map[string]any, error) {
+	data, err := scfs.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s map[string]any
+	err = json.Unmarshal(data, &s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// fieldName converts a json property name like pause_until to a go field name like PauseUntil
+func fieldName(name string) string {
+	var res strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if slices.Contains(initialisms, part) {
+			res.WriteString(strings.ToUpper(part))
+			continue
+		}
+		if part != "" {
+			res.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+
+	return res.String()
+}
+
+// goType determines the go type used to represent a property schema
+func goType(s map[string]any) (string, error) {
+	t, _ := s["type"].(string)
+	switch t {
+	case "boolean":
+		return "bool", nil
+	case "number":
+		return "float64", nil
+	case "string":
+		if s["format"] == "date-time" {
+			return "time.Time", nil
+		}
+		return "string", nil
+	case "integer":
+		comment, _ := s["$comment"].(string)
+		if strings.Contains(comment, "duration") {
+			return "time.Duration", nil
+		}
+
+		minimum, _ := s["minimum"].(float64)
+		maximum, hasMax := s["maximum"].(float64)
+		switch {
+		case !hasMax:
+			return "int", nil
+		case minimum >= 0 && maximum > math.MaxInt64:
+			return "uint64", nil
+		case maximum > math.MaxInt32:
+			return "int64", nil
+		default:
+			return "int", nil
+		}
+	case "array":
+		items, ok := s["items"].(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("arrays without items are not supported")
+		}
+		it, err := goType(items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + it, nil
+	case "object":
+		ap, ok := s["additionalProperties"].(map[string]any)
+		if !ok || s["properties"] != nil {
+			return "", fmt.Errorf("only objects with additionalProperties are supported")
+		}
+		vt, err := goType(ap)
+		if err != nil {
+			return "", err
+		}
+		return "map[string]" + vt, nil
+	default:
+		return "", fmt.Errorf("unsupported type %q", t)
+	}
+}
+
+// objectProperties finds the properties of the object a schema describes, for responses the properties are found in
+// the oneOf branch that is not the error response
+func objectProperties(s map[string]any) (props map[string]any, required []string, isResponse bool) {
+	props = map[string]any{}
+
+	merge := func(o map[string]any) {
+		if p, ok := o["properties"].(map[string]any); ok {
+			for k, v := range p {
+				if k == "error" {
+					isResponse = true
+					continue
+				}
+				props[k] = v
+			}
+		}
+		if r, ok := o["required"].([]any); ok {
+			for _, v := range r {
+				if name, ok := v.(string); ok && name != "error" {
+					required = append(required, name)
+				}
+			}
+		}
+	}
+
+	merge(s)
+	if branches, ok := s["oneOf"].([]any); ok {
+		for _, b := range branches {
+			if o, ok := b.(map[string]any); ok {
+				merge(o)
+			}
+		}
+	}
+
+	if isResponse {
+		// the type is handled by the embedded JSApiResponse
+		delete(props, "type")
+		required = slices.DeleteFunc(required, func(s string) bool { return s == "type" })
+	}
+
+	return props, required, isResponse
+}
+
+// generateStructure renders the structure described by the schema at path, properties of requests are omitted when
+// empty unless required while those of responses are only omitted when listed in omitEmpty
+func generateStructure(path string, name string, levels map[string]int, omitEmpty []string) (*structure, error) {
+	s, err := loadSchema(path)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &structure{Name: name}
+	res.SchemaType, _ = s["title"].(string)
+	res.Description, _ = s["description"].(string)
+	if res.Description != "" {
+		res.Description = strings.ToLower(res.Description[:1]) + res.Description[1:]
+	}
+
+	props, required, isResponse := objectProperties(s)
+	res.EmbedResponse = isResponse
+
+	var names []string
+	for k := range props {
+		names = append(names, k)
+	}
+	// json objects are unordered, sort for stable output
+	slices.Sort(names)
+
+	for _, k := range names {
+		ps, ok := props[k].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: invalid property %s", path, k)
+		}
+
+		t, err := goType(ps)
+		if err != nil {
+			return nil, fmt.Errorf("%s: property %s: %w", path, k, err)
+		}
+
+		omit := !slices.Contains(required, k)
+		if isResponse {
+			omit = slices.Contains(omitEmpty, k)
+		}
+
+		tag := fmt.Sprintf(`json:"%s"`, k)
+		if omit {
+			tag = fmt.Sprintf(`json:"%s,omitempty"`, k)
+		}
+		if level := levels[k]; level > 0 {
+			tag = fmt.Sprintf(`%s api_level:"%d"`, tag, level)
+		}
+
+		desc, _ := ps["description"].(string)
+		res.Properties = append(res.Properties, property{
+			Name:        fieldName(k),
+			Type:        t,
+			Tag:         tag,
+			Description: desc,
+		})
+	}
+
+	return res, nil
+}
+
+// endpointSubject extracts the API subject from the schema description of an endpoint
+func endpointSubject(e endpoint) (string, error) {
+	for _, path := range []string{e.Request, e.Response} {
+		if path == "" {
+			continue
+		}
+
+		s, err := loadSchema(path)
+		if err != nil {
+			return "", err
+		}
+
+		desc, _ := s["description"].(string)
+		if subj := subjectPattern.FindString(desc); subj != "" {
+			return subj, nil
+		}
+	}
+
+	return "", fmt.Errorf("no api subject found in the schemas of %s", e.Name)
+}
+
+func main() {
+	var out output
+
+	for _, e := range endpoints {
+		prefix, err := endpointSubject(e)
+		if err != nil {
+			log.Fatalf("could not determine subject: %s", err)
+		}
+
+		out.Subjects = append(out.Subjects, subjects{
+			Name:     e.Name,
+			Prefix:   prefix,
+			Wildcard: prefix + strings.Repeat(".*", e.Tokens),
+			Format:   prefix + strings.Repeat(".%s", e.Tokens),
+		})
+
+		for _, st := range []struct {
+			path      string
+			name      string
+			levels    map[string]int
+			omitEmpty []string
+		}{{e.Request, e.RequestType, e.RequestLevels, nil}, {e.Response, e.ResponseType, nil, e.ResponseOmitEmpty}} {
+			if st.path == "" {
+				continue
+			}
+
+			s, err := generateStructure(st.path, st.name, st.levels, st.omitEmpty)
+			if err != nil {
+				log.Fatalf("could not generate %s: %s", st.name, err)
+			}
+
+			for _, p := range s.Properties {
+				if strings.Contains(p.Type, "time.") {
+					out.Time = true
+				}
+			}
+
+			out.Structures = append(out.Structures, *s)
+		}
+	}
+
+	tmpl := template.Must(template.New("api").Parse(apiTemplate))
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, out)
+	if err != nil {
+		log.Fatalf("could not render api: %s", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("could not format api: %s", err)
+	}
+
+	err = os.WriteFile("api_generated.go", src, 0644)
+	if err != nil {
+		log.Fatalf("could not write api: %s", err)
+	}
+}
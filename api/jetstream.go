@@ -13,6 +13,8 @@
 
 package api
 
+//go:generate go run gen_api.go
+
 import (
 	"errors"
 	"fmt"
@@ -30,7 +32,8 @@ const (
 	JSApiAccountInfo       = "$JS.API.INFO"
 	JSApiAccountInfoPrefix = "$JS.API.INFO"
 
-	// also update FilterServerMetadata when this changes
+	// JSMetaReservedPrefix is the prefix of metadata keys reserved for use by the server
+	JSMetaReservedPrefix = "_nats."
 
 	JSMetaCurrentServerLevel   = "_nats.level"
 	JSMetaCurrentServerVersion = "_nats.ver"
@@ -75,6 +78,21 @@ const (
 	// JSMessageTTL sets a TTL per message
 	JSMessageTTL = "Nats-TTL"
 
+	// JSMessageTTLNever is the value for JSMessageTTL header to store a message that never expires
+	JSMessageTTLNever = "never"
+
+	// JSMarkerReason is a header set on subject delete markers placed by the server
+	JSMarkerReason = "Nats-Marker-Reason"
+
+	// JSMarkerReasonMaxAge is the value for JSMarkerReason header when a message was removed by the MaxAge limit
+	JSMarkerReasonMaxAge = "MaxAge"
+
+	// JSMarkerReasonPurge is the value for JSMarkerReason header when a subject was purged
+	JSMarkerReasonPurge = "Purge"
+
+	// JSMarkerReasonRemove is the value for JSMarkerReason header when a message was removed
+	JSMarkerReasonRemove = "Remove"
+
 	// JSSchedulePattern holds a message schedule pattern
 	JSSchedulePattern = "Nats-Schedule"
 
@@ -0,0 +1,101 @@
+package advisory
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const (
+	kvStreamPrefix          = "KV_"
+	objectStoreStreamPrefix = "OBJ_"
+	kvSubjectPrefix         = "$KV."
+	streamPurgeSubjectPre   = "$JS.API.STREAM.PURGE."
+)
+
+// BucketEvent translates an advisory about a stream backing a Key-Value or Object Store bucket into
+// a bucket level event, ok is false when e is not about a bucket.
+//
+// Supported are *JSStreamActionAdvisoryV1, translated to *JSKVBucketActionAdvisoryV1 or
+// *JSObjectStoreBucketActionAdvisoryV1, and *JetStreamAPIAuditV1 of successful purge requests,
+// translated to *JSKVKeyPurgeAdvisoryV1. The bucket event has the ID and Time of e.
+func BucketEvent(e any) (bucketEvent any, ok bool) {
+	switch adv := e.(type) {
+	case *JSStreamActionAdvisoryV1:
+		return bucketActionEvent(adv)
+	case *JetStreamAPIAuditV1:
+		return kvPurgeEvent(adv)
+	default:
+		return nil, false
+	}
+}
+
+func bucketActionEvent(adv *JSStreamActionAdvisoryV1) (any, bool) {
+	switch {
+	case strings.HasPrefix(adv.Stream, kvStreamPrefix):
+		e := &JSKVBucketActionAdvisoryV1{
+			NATSEvent: adv.NATSEvent,
+			Bucket:    strings.TrimPrefix(adv.Stream, kvStreamPrefix),
+			Stream:    adv.Stream,
+			Action:    adv.Action,
+			Domain:    adv.Domain,
+		}
+		e.Type = "io.nats.jetstream.advisory.v1.kv_bucket_action"
+
+		return e, true
+
+	case strings.HasPrefix(adv.Stream, objectStoreStreamPrefix):
+		e := &JSObjectStoreBucketActionAdvisoryV1{
+			NATSEvent: adv.NATSEvent,
+			Bucket:    strings.TrimPrefix(adv.Stream, objectStoreStreamPrefix),
+			Stream:    adv.Stream,
+			Action:    adv.Action,
+			Domain:    adv.Domain,
+		}
+		e.Type = "io.nats.jetstream.advisory.v1.objectstore_bucket_action"
+
+		return e, true
+
+	default:
+		return nil, false
+	}
+}
+
+func kvPurgeEvent(adv *JetStreamAPIAuditV1) (any, bool) {
+	stream, ok := strings.CutPrefix(adv.Subject, streamPurgeSubjectPre)
+	if !ok || !strings.HasPrefix(stream, kvStreamPrefix) {
+		return nil, false
+	}
+
+	var res struct {
+		Success bool   `json:"success"`
+		Purged  uint64 `json:"purged"`
+	}
+	err := json.Unmarshal([]byte(adv.Response), &res)
+	if err != nil || !res.Success {
+		return nil, false
+	}
+
+	var req struct {
+		Filter string `json:"filter"`
+	}
+	if adv.Request != "" {
+		err = json.Unmarshal([]byte(adv.Request), &req)
+		if err != nil {
+			return nil, false
+		}
+	}
+
+	bucket := strings.TrimPrefix(stream, kvStreamPrefix)
+	e := &JSKVKeyPurgeAdvisoryV1{
+		NATSEvent: adv.NATSEvent,
+		Server:    adv.Server,
+		Bucket:    bucket,
+		Stream:    stream,
+		Key:       strings.TrimPrefix(req.Filter, kvSubjectPrefix+bucket+"."),
+		Purged:    res.Purged,
+		Client:    adv.Client,
+	}
+	e.Type = "io.nats.jetstream.advisory.v1.kv_key_purge"
+
+	return e, true
+}
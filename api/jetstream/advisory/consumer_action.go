@@ -4,7 +4,8 @@ import (
 	"github.com/nats-io/jsm.go/api/event"
 )
 
-// JSConsumerActionAdvisoryV1 is a advisory published on create or delete of a Consumer
+// JSConsumerActionAdvisoryV1 is a advisory published on create or delete of a Consumer,
+// the client making the change is reported in the matching JetStreamAPIAuditV1 advisory
 //
 // NATS Schema Type io.nats.jetstream.advisory.v1.consumer_action
 type JSConsumerActionAdvisoryV1 struct {
@@ -13,10 +14,11 @@ type JSConsumerActionAdvisoryV1 struct {
 	Stream   string               `json:"stream"`
 	Consumer string               `json:"consumer"`
 	Action   ActionAdvisoryTypeV1 `json:"action"`
+	Domain   string               `json:"domain,omitempty"`
 }
 
 func init() {
-	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.consumer_action", `{{ .Time | ShortTime }} [Consumer {{ .Action | ToString | TitleString }}] {{ .Stream }} > {{ .Consumer }}`)
+	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.consumer_action", `{{ .Time | ShortTime }} [Consumer {{ .Action | ToString | TitleString }}] {{ .Stream }} > {{ .Consumer }}{{ if .Domain }} in domain {{ .Domain }}{{ end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -25,7 +27,10 @@ func init() {
 [{{ .Time | ShortTime }}] [{{ .ID }}] Consumer {{ .Action | ToString | TitleString }} Action
 
         Stream: {{ .Stream }}
-      Consumer: {{ .Consumer }}`)
+      Consumer: {{ .Consumer }}
+{{- if .Domain }}
+        Domain: {{ .Domain }}
+{{- end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -4,7 +4,7 @@ import (
 	"github.com/nats-io/jsm.go/api/event"
 )
 
-// JSConsumerLeaderElectedV1 is a advisory published when a stream elects a new leader
+// JSConsumerLeaderElectedV1 is a advisory published when a consumer elects a new leader
 //
 // NATS Schema Type io.nats.jetstream.advisory.v1.consumer_leader_elected
 type JSConsumerLeaderElectedV1 struct {
@@ -14,10 +14,12 @@ type JSConsumerLeaderElectedV1 struct {
 	Consumer string        `json:"consumer"`
 	Leader   string        `json:"leader"`
 	Replicas []*PeerInfoV1 `json:"replicas"`
+	Account  string        `json:"account,omitempty"`
+	Domain   string        `json:"domain,omitempty"`
 }
 
 func init() {
-	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.consumer_leader_elected", `{{ .Time | ShortTime }} [RAFT] Consumer {{ .Stream }} > {{ .Consumer }} elected {{ .Leader }} of {{ .Replicas | len }} peers`)
+	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.consumer_leader_elected", `{{ .Time | ShortTime }} [RAFT] Consumer {{ .Stream }} > {{ .Consumer }}{{ if .Account }} in account {{ .Account }}{{ end }}{{ if .Domain }} in domain {{ .Domain }}{{ end }} elected {{ .Leader }} of {{ .Replicas | len }} peers`)
 	if err != nil {
 		panic(err)
 	}
@@ -28,13 +30,25 @@ func init() {
         Stream: {{ .Stream }}
       Consumer: {{ .Consumer }}
         Leader: {{ .Leader }}
+{{- if .Account }}
+       Account: {{ .Account }}
+{{- end }}
+{{- if .Domain }}
+        Domain: {{ .Domain }}
+{{- end }}
       Replicas:
-{{ range .Replicas }}
+{{- range .Replicas }}
+
              Name: {{ .Name }}
           Current: {{ .Current }}
+{{- if .Offline }}
+          Offline: {{ .Offline }}
+{{- end }}
            Active: {{ .Active }}
-{{ end }}
-`)
+{{- if .Lag }}
+              Lag: {{ .Lag | Uint64Commas }}
+{{- end }}
+{{- end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -7,6 +7,8 @@ import (
 )
 
 // JSConsumerPauseAdvisoryV1 indicates that a consumer was paused or unpaused
+//
+// NATS Schema Type io.nats.jetstream.advisory.v1.consumer_pause
 type JSConsumerPauseAdvisoryV1 struct {
 	event.NATSEvent
 
@@ -18,7 +20,7 @@ type JSConsumerPauseAdvisoryV1 struct {
 }
 
 func init() {
-	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.consumer_pause", `{{ .Time | ShortTime }} [Consumer Pause] Consumer: {{ .Stream }} > {{ .Consumer }} Paused: {{ .Paused }}{{ if .Paused }} until {{ .PauseUntil }}{{ end }}`)
+	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.consumer_pause", `{{ .Time | ShortTime }} [Consumer Pause] Consumer: {{ .Stream }} > {{ .Consumer }} Paused: {{ .Paused }}{{ if .Paused }} until {{ .PauseUntil }}{{ end }}{{ if .Domain }} in domain {{ .Domain }}{{ end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -34,8 +36,7 @@ func init() {
 {{- end }}
 {{- if .Domain }}
         Domain: {{ .Domain }}
-{{- end }}
-`)
+{{- end }}`)
 	if err != nil {
 		panic(err)
 	}
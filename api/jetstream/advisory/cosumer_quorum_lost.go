@@ -4,7 +4,8 @@ import (
 	"github.com/nats-io/jsm.go/api/event"
 )
 
-// JSConsumerQuorumLostV1 is a advisory published when a stream elects a new leader
+// JSConsumerQuorumLostV1 is a advisory published when a clustered consumer lost quorum and is
+// unable to make progress, Replicas shows which peers are offline or lagging
 //
 // NATS Schema Type io.nats.jetstream.advisory.v1.consumer_quorum_lost
 type JSConsumerQuorumLostV1 struct {
@@ -13,10 +14,12 @@ type JSConsumerQuorumLostV1 struct {
 	Stream   string        `json:"stream"`
 	Consumer string        `json:"consumer"`
 	Replicas []*PeerInfoV1 `json:"replicas"`
+	Account  string        `json:"account,omitempty"`
+	Domain   string        `json:"domain,omitempty"`
 }
 
 func init() {
-	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.consumer_quorum_lost", `{{ .Time | ShortTime }} [RAFT] Consumer {{ .Stream }} > {{ .Consumer }} lost quorum of {{ .Replicas | len }} peers`)
+	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.consumer_quorum_lost", `{{ .Time | ShortTime }} [RAFT] Consumer {{ .Stream }} > {{ .Consumer }}{{ if .Account }} in account {{ .Account }}{{ end }}{{ if .Domain }} in domain {{ .Domain }}{{ end }} lost quorum of {{ .Replicas | len }} peers`)
 	if err != nil {
 		panic(err)
 	}
@@ -26,13 +29,25 @@ func init() {
 
         Stream: {{ .Stream }}
       Consumer: {{ .Consumer }}
+{{- if .Account }}
+       Account: {{ .Account }}
+{{- end }}
+{{- if .Domain }}
+        Domain: {{ .Domain }}
+{{- end }}
       Replicas:
-{{ range .Replicas }}
+{{- range .Replicas }}
+
              Name: {{ .Name }}
           Current: {{ .Current }}
+{{- if .Offline }}
+          Offline: {{ .Offline }}
+{{- end }}
            Active: {{ .Active }}
-{{ end }}
-`)
+{{- if .Lag }}
+              Lag: {{ .Lag | Uint64Commas }}
+{{- end }}
+{{- end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,53 @@
+package advisory
+
+import (
+	"github.com/nats-io/jsm.go/api/event"
+	"github.com/nats-io/jsm.go/api/server/advisory"
+)
+
+// JSKVKeyPurgeAdvisoryV1 is a advisory published when data is purged from a Key-Value bucket, Key is empty
+// when the entire bucket was purged. It is derived from the JetStreamAPIAuditV1 of the purge request
+// against the backing stream using BucketEvent()
+//
+// NATS Schema Type io.nats.jetstream.advisory.v1.kv_key_purge
+type JSKVKeyPurgeAdvisoryV1 struct {
+	event.NATSEvent
+
+	Server string                `json:"server,omitempty"`
+	Bucket string                `json:"bucket"`
+	Stream string                `json:"stream"`
+	Key    string                `json:"key,omitempty"`
+	Purged uint64                `json:"purged"`
+	Client advisory.ClientInfoV1 `json:"client"`
+}
+
+func init() {
+	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.kv_key_purge", `{{ .Time | ShortTime }} [KV Purge] {{ .Bucket }} > {{ with .Key }}{{ . }}{{ else }}all keys{{ end }} removed {{ .Purged | Uint64Commas }} messages{{ if .Client.User }} by {{ .Client.User }}{{ end }}`)
+	if err != nil {
+		panic(err)
+	}
+
+	err = event.RegisterTextExtendedTemplate("io.nats.jetstream.advisory.v1.kv_key_purge", `
+[{{ .Time | ShortTime }}] [{{ .ID }}] KV Bucket Purge
+
+        Bucket: {{ .Bucket }}
+        Stream: {{ .Stream }}
+           Key: {{ with .Key }}{{ . }}{{ else }}All Keys{{ end }}
+        Purged: {{ .Purged | Uint64Commas }} messages
+{{- if .Server }}
+        Server: {{ .Server }}
+{{- end }}
+        Client:
+{{- if .Client.User }}
+                      User: {{ .Client.User }} Account: {{ .Client.Account }}
+{{- end }}
+                      Host: {{ .Client.Host }}
+                        ID: {{ .Client.ID }}
+{{- if .Client.Name }}
+                      Name: {{ .Client.Name }}
+{{- end }}
+           Library Version: {{ .Client.Version }}  Language: {{ with .Client.Lang }}{{ . }}{{ else }}Unknown{{ end }}`)
+	if err != nil {
+		panic(err)
+	}
+}
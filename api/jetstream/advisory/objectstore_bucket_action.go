@@ -0,0 +1,37 @@
+package advisory
+
+import (
+	"github.com/nats-io/jsm.go/api/event"
+)
+
+// JSObjectStoreBucketActionAdvisoryV1 is a advisory published on create, modify or delete of an Object Store bucket,
+// it is derived from the JSStreamActionAdvisoryV1 of the backing stream using BucketEvent()
+//
+// NATS Schema Type io.nats.jetstream.advisory.v1.objectstore_bucket_action
+type JSObjectStoreBucketActionAdvisoryV1 struct {
+	event.NATSEvent
+
+	Bucket string               `json:"bucket"`
+	Stream string               `json:"stream"`
+	Action ActionAdvisoryTypeV1 `json:"action"`
+	Domain string               `json:"domain,omitempty"`
+}
+
+func init() {
+	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.objectstore_bucket_action", `{{ .Time | ShortTime }} [Object Store Bucket {{ .Action | ToString | TitleString }}] {{ .Bucket }}{{ if .Domain }} in domain {{ .Domain }}{{ end }}`)
+	if err != nil {
+		panic(err)
+	}
+
+	err = event.RegisterTextExtendedTemplate("io.nats.jetstream.advisory.v1.objectstore_bucket_action", `
+[{{ .Time | ShortTime }}] [{{ .ID }}] Object Store Bucket {{ .Action | ToString | TitleString }} Action
+
+        Bucket: {{ .Bucket }}
+        Stream: {{ .Stream }}
+{{- if .Domain }}
+        Domain: {{ .Domain }}
+{{- end }}`)
+	if err != nil {
+		panic(err)
+	}
+}
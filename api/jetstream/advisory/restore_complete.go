@@ -7,9 +7,10 @@ import (
 	"github.com/nats-io/jsm.go/api/server/advisory"
 )
 
-// JSRestoreCompleteAdvisoryV1 is an advisory sent after a snapshot is successfully started
+// JSRestoreCompleteAdvisoryV1 is an advisory sent after a restore completed, the duration
+// of the restore is the time between Start and End
 //
-// NATS Schema type io.nats.jetstream.advisory.v1.restore_complete
+// NATS Schema Type io.nats.jetstream.advisory.v1.restore_complete
 type JSRestoreCompleteAdvisoryV1 struct {
 	event.NATSEvent
 
@@ -18,10 +19,11 @@ type JSRestoreCompleteAdvisoryV1 struct {
 	End    time.Time             `json:"end"`
 	Bytes  int64                 `json:"bytes"`
 	Client advisory.ClientInfoV1 `json:"client"`
+	Domain string                `json:"domain,omitempty"`
 }
 
 func init() {
-	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.restore_complete", `{{ .Time | ShortTime }} [Restore Complete] {{ .Stream }} restored {{ .Bytes | IBytes }} bytes started {{ .Start | ShortTime }} ended {{ .End | ShortTime }}`)
+	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.restore_complete", `{{ .Time | ShortTime }} [Restore Complete] {{ .Stream }}{{ if .Domain }} in domain {{ .Domain }}{{ end }} restored {{ .Bytes | IBytes }} in {{ .End.Sub .Start }} by {{ with .Client.User }}{{ . }}{{ else }}{{ .Client.Host }}{{ end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -30,8 +32,12 @@ func init() {
 [{{ .Time | ShortTime }}] [{{ .ID }}] Stream Restore Completed
 
         Stream: {{ .Stream }}
+{{- if .Domain }}
+        Domain: {{ .Domain }}
+{{- end }}
          Start: {{ .Start | NanoTime }}
-           End: {{ .Start | NanoTime }}
+           End: {{ .End | NanoTime }}
+      Duration: {{ .End.Sub .Start }}
          Bytes: {{ .Bytes | IBytes }}
         Client:
 {{- if .Client.User }}
@@ -42,8 +48,7 @@ func init() {
 {{- if .Client.Name }}
                       Name: {{ .Client.Name }}
 {{- end }}
-           Library Version: {{ .Client.Version }}  Language: {{ with .Client.Lang }}{{ . }}{{ else }}Unknown{{ end }}
-`)
+           Library Version: {{ .Client.Version }}  Language: {{ with .Client.Lang }}{{ . }}{{ else }}Unknown{{ end }}`)
 	if err != nil {
 		panic(err)
 	}
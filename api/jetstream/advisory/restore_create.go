@@ -5,18 +5,19 @@ import (
 	"github.com/nats-io/jsm.go/api/server/advisory"
 )
 
-// JSRestoreCreateAdvisoryV1 is an advisory sent after a snapshot is successfully started
+// JSRestoreCreateAdvisoryV1 is an advisory sent after a restore is successfully started
 //
-// NATS Schema io.nats.jetstream.advisory.v1.restore_create
+// NATS Schema Type io.nats.jetstream.advisory.v1.restore_create
 type JSRestoreCreateAdvisoryV1 struct {
 	event.NATSEvent
 
 	Stream string                `json:"stream"`
 	Client advisory.ClientInfoV1 `json:"client"`
+	Domain string                `json:"domain,omitempty"`
 }
 
 func init() {
-	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.restore_create", `{{ .Time | ShortTime }} [Restore Create] {{ .Stream }}`)
+	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.restore_create", `{{ .Time | ShortTime }} [Restore Create] {{ .Stream }}{{ if .Domain }} in domain {{ .Domain }}{{ end }} by {{ with .Client.User }}{{ . }}{{ else }}{{ .Client.Host }}{{ end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -25,6 +26,9 @@ func init() {
 [{{ .Time | ShortTime }}] [{{ .ID }}] Stream Restore Created
 
         Stream: {{ .Stream }}
+{{- if .Domain }}
+        Domain: {{ .Domain }}
+{{- end }}
         Client:
 {{- if .Client.User }}
                       User: {{ .Client.User }} Account: {{ .Client.Account }}
@@ -34,8 +38,7 @@ func init() {
 {{- if .Client.Name }}
                       Name: {{ .Client.Name }}
 {{- end }}
-           Library Version: {{ .Client.Version }}  Language: {{ with .Client.Lang }}{{ . }}{{ else }}Unknown{{ end }}
-`)
+           Library Version: {{ .Client.Version }}  Language: {{ with .Client.Lang }}{{ . }}{{ else }}Unknown{{ end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -7,7 +7,8 @@ import (
 	"github.com/nats-io/jsm.go/api/server/advisory"
 )
 
-// JSSnapshotCompleteAdvisoryV1 is an advisory sent after a snapshot is successfully started
+// JSSnapshotCompleteAdvisoryV1 is an advisory sent after a snapshot completed, the duration
+// of the snapshot is the time between Start and End
 //
 // NATS Schema Type io.nats.jetstream.advisory.v1.snapshot_complete
 type JSSnapshotCompleteAdvisoryV1 struct {
@@ -17,10 +18,11 @@ type JSSnapshotCompleteAdvisoryV1 struct {
 	Start  time.Time             `json:"start"`
 	End    time.Time             `json:"end"`
 	Client advisory.ClientInfoV1 `json:"client"`
+	Domain string                `json:"domain,omitempty"`
 }
 
 func init() {
-	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.snapshot_complete", `{{ .Time | ShortTime }} [Snapshot Complete] {{ .Stream }} started {{ .Start | ShortTime }} ended {{ .End | ShortTime }}`)
+	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.snapshot_complete", `{{ .Time | ShortTime }} [Snapshot Complete] {{ .Stream }}{{ if .Domain }} in domain {{ .Domain }}{{ end }} completed in {{ .End.Sub .Start }} by {{ with .Client.User }}{{ . }}{{ else }}{{ .Client.Host }}{{ end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -29,19 +31,22 @@ func init() {
 [{{ .Time | ShortTime }}] [{{ .ID }}] Stream Snapshot Completed
 
         Stream: {{ .Stream }}
-		Start: {{ .Start | NanoTime }}
-          End: {{ .End | NanoTime }}
+{{- if .Domain }}
+        Domain: {{ .Domain }}
+{{- end }}
+         Start: {{ .Start | NanoTime }}
+           End: {{ .End | NanoTime }}
+      Duration: {{ .End.Sub .Start }}
         Client:
 {{- if .Client.User }}
-                     User: {{ .Client.User }} Account: {{ .Client.Account }}
+                      User: {{ .Client.User }} Account: {{ .Client.Account }}
 {{- end }}
-                     Host: {{ .Client.Host }}
-                      ID: {{ .Client.ID }}
+                      Host: {{ .Client.Host }}
+                        ID: {{ .Client.ID }}
 {{- if .Client.Name }}
-                     Name: {{ .Client.Name }}
+                      Name: {{ .Client.Name }}
 {{- end }}
-          Library Version: {{ .Client.Version }}  Language: {{ with .Client.Lang }}{{ . }}{{ else }}Unknown{{ end }}
-`)
+           Library Version: {{ .Client.Version }}  Language: {{ with .Client.Lang }}{{ . }}{{ else }}Unknown{{ end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -1,23 +1,41 @@
 package advisory
 
 import (
+	"time"
+
 	"github.com/nats-io/jsm.go/api/event"
 	"github.com/nats-io/jsm.go/api/server/advisory"
 )
 
-// JSSnapshotCreateAdvisoryV1 is an advisory sent after a snapshot is successfully started
+// StreamStateV1 is the state of a Stream at the time an advisory was published
+type StreamStateV1 struct {
+	Msgs        uint64    `json:"messages"`
+	Bytes       uint64    `json:"bytes"`
+	FirstSeq    uint64    `json:"first_seq"`
+	FirstTime   time.Time `json:"first_ts"`
+	LastSeq     uint64    `json:"last_seq"`
+	LastTime    time.Time `json:"last_ts"`
+	NumSubjects int       `json:"num_subjects,omitempty"`
+	Consumers   int       `json:"consumer_count"`
+}
+
+// JSSnapshotCreateAdvisoryV1 is an advisory sent after a snapshot is successfully started,
+// recent servers report the Stream State while older ones report the blocks being sent
 //
-// NATS Schema io.nats.jetstream.advisory.v1.snapshot_create
+// NATS Schema Type io.nats.jetstream.advisory.v1.snapshot_create
 type JSSnapshotCreateAdvisoryV1 struct {
 	event.NATSEvent
+
 	Stream  string                `json:"stream"`
-	NumBlks int64                 `json:"blocks"`
-	BlkSize int64                 `json:"block_size"`
+	State   *StreamStateV1        `json:"state,omitempty"`
+	NumBlks int64                 `json:"blocks,omitempty"`
+	BlkSize int64                 `json:"block_size,omitempty"`
 	Client  advisory.ClientInfoV1 `json:"client"`
+	Domain  string                `json:"domain,omitempty"`
 }
 
 func init() {
-	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.snapshot_create", `{{ .Time | ShortTime }} [Snapshot Create] {{ .Stream }} {{ .NumBlks | Int64Commas }} blocks of {{ .BlkSize | IBytes }}`)
+	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.snapshot_create", `{{ .Time | ShortTime }} [Snapshot Create] {{ .Stream }}{{ if .Domain }} in domain {{ .Domain }}{{ end }}{{ with .State }} {{ .Msgs | Uint64Commas }} messages ({{ .Bytes | UIBytes }}){{ else }} {{ .NumBlks | Int64Commas }} blocks of {{ .BlkSize | IBytes }}{{ end }} by {{ with .Client.User }}{{ . }}{{ else }}{{ .Client.Host }}{{ end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -26,19 +44,28 @@ func init() {
 [{{ .Time | ShortTime }}] [{{ .ID }}] Stream Snapshot Created
 
         Stream: {{ .Stream }}
+{{- if .Domain }}
+        Domain: {{ .Domain }}
+{{- end }}
+{{- with .State }}
+      Messages: {{ .Msgs | Uint64Commas }}
+         Bytes: {{ .Bytes | UIBytes }}
+      Sequence: {{ .FirstSeq | Uint64Commas }} to {{ .LastSeq | Uint64Commas }}
+     Consumers: {{ .Consumers }}
+{{- else }}
         Blocks: {{ .NumBlks | Int64Commas }}
     Block Size: {{ .BlkSize | IBytes }}
+{{- end }}
         Client:
 {{- if .Client.User }}
                       User: {{ .Client.User }} Account: {{ .Client.Account }}
 {{- end }}
                       Host: {{ .Client.Host }}
-                       ID: {{ .Client.ID }}
+                        ID: {{ .Client.ID }}
 {{- if .Client.Name }}
                       Name: {{ .Client.Name }}
 {{- end }}
-           Library Version: {{ .Client.Version }}  Language: {{ with .Client.Lang }}{{ . }}{{ else }}Unknown{{ end }}
-`)
+           Library Version: {{ .Client.Version }}  Language: {{ with .Client.Lang }}{{ . }}{{ else }}Unknown{{ end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -17,7 +17,8 @@ const (
 	ModifyEvent ActionAdvisoryTypeV1 = "modify"
 )
 
-// JetStreamAPIAuditV1 is a advisory published on create, modify or delete of a Stream
+// JSStreamActionAdvisoryV1 is a advisory published on create, modify or delete of a Stream,
+// the client making the change is reported in the matching JetStreamAPIAuditV1 advisory
 //
 // NATS Schema Type io.nats.jetstream.advisory.v1.stream_action
 type JSStreamActionAdvisoryV1 struct {
@@ -26,10 +27,11 @@ type JSStreamActionAdvisoryV1 struct {
 	Stream   string               `json:"stream"`
 	Action   ActionAdvisoryTypeV1 `json:"action"`
 	Template string               `json:"template,omitempty"`
+	Domain   string               `json:"domain,omitempty"`
 }
 
 func init() {
-	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.stream_action", `{{ .Time | ShortTime }} [Stream {{ .Action | ToString | TitleString }}] {{ .Stream }}`)
+	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.stream_action", `{{ .Time | ShortTime }} [Stream {{ .Action | ToString | TitleString }}] {{ .Stream }}{{ if .Domain }} in domain {{ .Domain }}{{ end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -40,6 +42,9 @@ func init() {
         Stream: {{ .Stream }}
 {{- if .Template }}
       Template: {{ .Template }}
+{{- end }}
+{{- if .Domain }}
+        Domain: {{ .Domain }}
 {{- end }}`)
 	if err != nil {
 		panic(err)
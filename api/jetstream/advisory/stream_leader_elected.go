@@ -10,7 +10,9 @@ import (
 type PeerInfoV1 struct {
 	Name    string        `json:"name"`
 	Current bool          `json:"current"`
+	Offline bool          `json:"offline,omitempty"`
 	Active  time.Duration `json:"active"`
+	Lag     uint64        `json:"lag,omitempty"`
 }
 
 // JSStreamLeaderElectedV1 is a advisory published when a stream elects a new leader
@@ -22,10 +24,12 @@ type JSStreamLeaderElectedV1 struct {
 	Stream   string        `json:"stream"`
 	Leader   string        `json:"leader"`
 	Replicas []*PeerInfoV1 `json:"replicas"`
+	Account  string        `json:"account,omitempty"`
+	Domain   string        `json:"domain,omitempty"`
 }
 
 func init() {
-	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.stream_leader_elected", `{{ .Time | ShortTime }} [RAFT] Stream {{ .Stream }} elected {{ .Leader }} of {{ .Replicas | len }} peers`)
+	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.stream_leader_elected", `{{ .Time | ShortTime }} [RAFT] Stream {{ .Stream }}{{ if .Account }} in account {{ .Account }}{{ end }}{{ if .Domain }} in domain {{ .Domain }}{{ end }} elected {{ .Leader }} of {{ .Replicas | len }} peers`)
 	if err != nil {
 		panic(err)
 	}
@@ -35,13 +39,25 @@ func init() {
 
         Stream: {{ .Stream }}
         Leader: {{ .Leader }}
+{{- if .Account }}
+       Account: {{ .Account }}
+{{- end }}
+{{- if .Domain }}
+        Domain: {{ .Domain }}
+{{- end }}
       Replicas:
-{{ range .Replicas }}
+{{- range .Replicas }}
+
              Name: {{ .Name }}
           Current: {{ .Current }}
+{{- if .Offline }}
+          Offline: {{ .Offline }}
+{{- end }}
            Active: {{ .Active }}
-{{ end }}
-`)
+{{- if .Lag }}
+              Lag: {{ .Lag | Uint64Commas }}
+{{- end }}
+{{- end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -4,7 +4,8 @@ import (
 	"github.com/nats-io/jsm.go/api/event"
 )
 
-// JSStreamQuorumLostV1 is a advisory published when a stream elects a new leader
+// JSStreamQuorumLostV1 is a advisory published when a clustered stream lost quorum and is
+// unable to make progress, Replicas shows which peers are offline or lagging
 //
 // NATS Schema Type io.nats.jetstream.advisory.v1.stream_quorum_lost
 type JSStreamQuorumLostV1 struct {
@@ -12,10 +13,12 @@ type JSStreamQuorumLostV1 struct {
 
 	Stream   string        `json:"stream"`
 	Replicas []*PeerInfoV1 `json:"replicas"`
+	Account  string        `json:"account,omitempty"`
+	Domain   string        `json:"domain,omitempty"`
 }
 
 func init() {
-	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.stream_quorum_lost", `{{ .Time | ShortTime }} [RAFT] Stream {{ .Stream }} lost quorum of {{ .Replicas | len }} peers`)
+	err := event.RegisterTextCompactTemplate("io.nats.jetstream.advisory.v1.stream_quorum_lost", `{{ .Time | ShortTime }} [RAFT] Stream {{ .Stream }}{{ if .Account }} in account {{ .Account }}{{ end }}{{ if .Domain }} in domain {{ .Domain }}{{ end }} lost quorum of {{ .Replicas | len }} peers`)
 	if err != nil {
 		panic(err)
 	}
@@ -24,13 +27,25 @@ func init() {
 [{{ .Time | ShortTime }}] [{{ .ID }}] Stream Quorum Lost
 
         Stream: {{ .Stream }}
+{{- if .Account }}
+       Account: {{ .Account }}
+{{- end }}
+{{- if .Domain }}
+        Domain: {{ .Domain }}
+{{- end }}
       Replicas:
-{{ range .Replicas }}
+{{- range .Replicas }}
+
              Name: {{ .Name }}
           Current: {{ .Current }}
+{{- if .Offline }}
+          Offline: {{ .Offline }}
+{{- end }}
            Active: {{ .Active }}
-{{ end }}
-`)
+{{- if .Lag }}
+              Lag: {{ .Lag | Uint64Commas }}
+{{- end }}
+{{- end }}`)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,112 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ServerMetadata is the metadata the server maintains on streams and consumers, it is stored in the
+// reserved _nats. keys of the asset metadata
+type ServerMetadata struct {
+	// Version is the version of the server that last created or updated the asset
+	Version string `json:"version,omitempty" yaml:"version"`
+	// ApiLevel is the API level supported by the server that last created or updated the asset
+	ApiLevel int `json:"api_level,omitempty" yaml:"api_level"`
+	// RequiredApiLevel is the API level a server needs to support all features used by the asset
+	RequiredApiLevel int `json:"required_api_level,omitempty" yaml:"required_api_level"`
+}
+
+// IsServerMetadataKey determines if k is a metadata key reserved for use by the server
+func IsServerMetadataKey(k string) bool {
+	return strings.HasPrefix(k, JSMetaReservedPrefix)
+}
+
+// FilterServerMetadata copies metadata with all keys reserved for use by the server removed
+func FilterServerMetadata(metadata map[string]string) map[string]string {
+	if metadata == nil {
+		return nil
+	}
+
+	nm := map[string]string{}
+	for k, v := range metadata {
+		if !IsServerMetadataKey(k) {
+			nm[k] = v
+		}
+	}
+
+	return nm
+}
+
+// ServerMetadataOnly copies metadata retaining only the keys reserved for use by the server
+func ServerMetadataOnly(metadata map[string]string) map[string]string {
+	if metadata == nil {
+		return nil
+	}
+
+	nm := map[string]string{}
+	for k, v := range metadata {
+		if IsServerMetadataKey(k) {
+			nm[k] = v
+		}
+	}
+
+	return nm
+}
+
+// ParseServerMetadata extracts the server maintained values from metadata, unknown or invalid values are ignored
+func ParseServerMetadata(metadata map[string]string) ServerMetadata {
+	var sm ServerMetadata
+
+	sm.Version = metadata[JSMetaCurrentServerVersion]
+	if v, err := strconv.Atoi(metadata[JSMetaCurrentServerLevel]); err == nil {
+		sm.ApiLevel = v
+	}
+	if v, err := strconv.Atoi(metadata[JsMetaRequiredServerLevel]); err == nil {
+		sm.RequiredApiLevel = v
+	}
+
+	return sm
+}
+
+// UserMetadata is the metadata of the stream without keys reserved for use by the server
+func (c StreamConfig) UserMetadata() map[string]string {
+	return FilterServerMetadata(c.Metadata)
+}
+
+// ServerMetadata is the metadata maintained by the server for the stream
+func (c StreamConfig) ServerMetadata() ServerMetadata {
+	return ParseServerMetadata(c.Metadata)
+}
+
+// UserMetadata is the metadata of the consumer without keys reserved for use by the server
+func (c ConsumerConfig) UserMetadata() map[string]string {
+	return FilterServerMetadata(c.Metadata)
+}
+
+// ServerMetadata is the metadata maintained by the server for the consumer
+func (c ConsumerConfig) ServerMetadata() ServerMetadata {
+	return ParseServerMetadata(c.Metadata)
+}
+
+// ServerMetadata is the metadata maintained by the server for the stream
+func (i StreamInfo) ServerMetadata() ServerMetadata {
+	return i.Config.ServerMetadata()
+}
+
+// ServerMetadata is the metadata maintained by the server for the consumer
+func (i ConsumerInfo) ServerMetadata() ServerMetadata {
+	return i.Config.ServerMetadata()
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestServerMetadata(t *testing.T) {
+	metadata := map[string]string{
+		JSMetaCurrentServerVersion: "2.12.0",
+		JSMetaCurrentServerLevel:   "2",
+		JsMetaRequiredServerLevel:  "1",
+		"_nats.future":             "x",
+		"owner":                    "a",
+	}
+
+	if !IsServerMetadataKey(JsMetaRequiredServerLevel) || IsServerMetadataKey("owner") || IsServerMetadataKey("nats.ver") {
+		t.Fatalf("invalid reserved key detection")
+	}
+
+	if FilterServerMetadata(nil) != nil || ServerMetadataOnly(nil) != nil {
+		t.Fatalf("expected nil metadata to remain nil")
+	}
+
+	if got := FilterServerMetadata(metadata); !reflect.DeepEqual(got, map[string]string{"owner": "a"}) {
+		t.Fatalf("invalid filtered metadata: %v", got)
+	}
+
+	got := ServerMetadataOnly(metadata)
+	if len(got) != 4 || got["owner"] != "" || got["_nats.future"] != "x" {
+		t.Fatalf("invalid server metadata: %v", got)
+	}
+
+	expected := ServerMetadata{Version: "2.12.0", ApiLevel: 2, RequiredApiLevel: 1}
+	si := StreamInfo{Config: StreamConfig{Metadata: metadata}}
+	if sm := si.ServerMetadata(); sm != expected {
+		t.Fatalf("invalid stream server metadata: %+v", sm)
+	}
+	if um := si.Config.UserMetadata(); !reflect.DeepEqual(um, map[string]string{"owner": "a"}) {
+		t.Fatalf("invalid stream user metadata: %v", um)
+	}
+
+	ci := ConsumerInfo{Config: ConsumerConfig{Metadata: metadata}}
+	if sm := ci.ServerMetadata(); sm != expected {
+		t.Fatalf("invalid consumer server metadata: %+v", sm)
+	}
+	if um := ci.Config.UserMetadata(); !reflect.DeepEqual(um, map[string]string{"owner": "a"}) {
+		t.Fatalf("invalid consumer user metadata: %v", um)
+	}
+
+	if sm := ParseServerMetadata(map[string]string{JSMetaCurrentServerLevel: "x"}); sm != (ServerMetadata{}) {
+		t.Fatalf("expected invalid levels to be ignored: %+v", sm)
+	}
+}
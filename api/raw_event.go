@@ -0,0 +1,113 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/nats-io/jsm.go/api/event"
+)
+
+// RawEventSchemaType is the schema type the generic templates used to render a RawEvent are registered for,
+// use event.OverrideTemplate() to change them
+const RawEventSchemaType = "io.nats.unknown_event"
+
+// RawEvent is an event with a type that is not known to this package, for example one added in a newer
+// server version. It holds the type, id and time of the event and the full event as Payload
+type RawEvent struct {
+	event.NATSEvent
+
+	Payload json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON stores data as the Payload and extracts the type, id and time of the event
+func (e *RawEvent) UnmarshalJSON(data []byte) error {
+	var hdr event.NATSEvent
+	err := json.Unmarshal(data, &hdr)
+	if err != nil {
+		return err
+	}
+
+	e.NATSEvent = hdr
+	e.Payload = append(json.RawMessage{}, data...)
+
+	return nil
+}
+
+// MarshalJSON returns the Payload unchanged
+func (e RawEvent) MarshalJSON() ([]byte, error) {
+	if len(e.Payload) == 0 {
+		return json.Marshal(e.NATSEvent)
+	}
+
+	return e.Payload, nil
+}
+
+// EventSubject is the 4th part of the event type like advisory for io.nats.jetstream.advisory.v1.api_audit
+func (e RawEvent) EventSubject() string {
+	parts := strings.Split(e.Type, ".")
+	if len(parts) < 4 {
+		return "unknown"
+	}
+
+	return parts[3]
+}
+
+// EventSource is the source of the event based on the 3rd part of its type like urn:nats:jetstream
+func (e RawEvent) EventSource() string {
+	parts := strings.Split(e.Type, ".")
+	if len(parts) < 3 {
+		return "urn:nats:unknown"
+	}
+
+	return fmt.Sprintf("urn:nats:%s", parts[2])
+}
+
+// EventTemplate is the generic template for kind registered for RawEventSchemaType
+func (e RawEvent) EventTemplate(kind string) (*template.Template, error) {
+	return event.NATSEvent{Type: RawEventSchemaType}.EventTemplate(kind)
+}
+
+// IndentedPayload is the Payload formatted as indented JSON
+func (e RawEvent) IndentedPayload() string {
+	var buf bytes.Buffer
+	err := json.Indent(&buf, e.Payload, "", "  ")
+	if err != nil {
+		return string(e.Payload)
+	}
+
+	return buf.String()
+}
+
+func init() {
+	err := event.RegisterTextCompactTemplate(RawEventSchemaType, `{{ .Time | ShortTime }} [{{ with .Type }}{{ . }}{{ else }}Unknown Event{{ end }}] {{ .ID }}`)
+	if err != nil {
+		panic(err)
+	}
+
+	err = event.RegisterTextExtendedTemplate(RawEventSchemaType, `
+[{{ .Time | ShortTime }}] [{{ .ID }}] Unknown Event
+
+    Type: {{ .Type }}
+ Payload:
+
+{{ .IndentedPayload | LeftPad 6 }}`)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const futureEvent = `{"type":"io.nats.server.advisory.v9.future_event","id":"abc","timestamp":"2025-01-02T03:04:05Z","detail":{"count":10}}`
+
+func TestParseMessage_RawEvent(t *testing.T) {
+	schema, msg, err := ParseMessage([]byte(futureEvent))
+	checkErr(t, err, "parse failed")
+
+	if schema != "io.nats.server.advisory.v9.future_event" {
+		t.Fatalf("invalid schema %q", schema)
+	}
+
+	raw, ok := msg.(*RawEvent)
+	if !ok {
+		t.Fatalf("expected *RawEvent got %T", msg)
+	}
+	if raw.Type != schema || raw.ID != "abc" || raw.Time.Year() != 2025 {
+		t.Fatalf("invalid header: %#v", raw.NATSEvent)
+	}
+	if string(raw.Payload) != futureEvent {
+		t.Fatalf("invalid payload: %s", raw.Payload)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	checkErr(t, RenderEvent(buf, raw, TextCompactFormat), "compact render failed")
+	if buf.String() != "03:04:05 [io.nats.server.advisory.v9.future_event] abc" {
+		t.Fatalf("invalid compact render: %q", buf.String())
+	}
+
+	buf.Reset()
+	checkErr(t, RenderEvent(buf, raw, TextExtendedFormat), "extended render failed")
+	if !strings.Contains(buf.String(), `"count": 10`) {
+		t.Fatalf("invalid extended render: %q", buf.String())
+	}
+
+	j, err := json.Marshal(raw)
+	checkErr(t, err, "marshal failed")
+	if string(j) != futureEvent {
+		t.Fatalf("invalid json: %s", j)
+	}
+
+	ce, err := ToCloudEventV1(raw)
+	checkErr(t, err, "cloud event failed")
+	_, msg, err = FromCloudEventV1(ce)
+	checkErr(t, err, "cloud event parse failed")
+	if msg.(*RawEvent).ID != "abc" {
+		t.Fatalf("invalid cloud event round trip: %#v", msg)
+	}
+
+	_, msg, err = ParseMessage([]byte(`{"name":"n1"}`))
+	checkErr(t, err, "parse failed")
+	if _, ok := msg.(*UnknownMessage); !ok {
+		t.Fatalf("expected *UnknownMessage for untyped messages got %T", msg)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// RichRenderOptions controls how RenderRich adjusts events to the output
+type RichRenderOptions struct {
+	// Width is the width values are wrapped to, 0 disables wrapping
+	Width int
+	// Color enables highlighting the title and severity-bearing fields of events
+	Color bool
+}
+
+var (
+	// richFieldPattern matches the "Label: value" lines of extended templates
+	richFieldPattern = regexp.MustCompile(`^(\s*)([A-Z][A-Za-z ]*): (.*)$`)
+
+	// richErrorFields are highlighted as errors
+	richErrorFields = map[string]bool{
+		"Error":  true,
+		"Reason": true,
+	}
+
+	// richWarningFields are highlighted as warnings
+	richWarningFields = map[string]bool{
+		"Offline":        true,
+		"Lag":            true,
+		"Dropped":        true,
+		"Slow Consumers": true,
+		"Pending Bytes":  true,
+		"Deliveries":     true,
+	}
+)
+
+// minRichWrapWidth is the narrowest a value is wrapped to, narrower values are left as is
+const minRichWrapWidth = 20
+
+// RichRenderOptionsFor detects the width and color support of wr, colors are only enabled when wr is a terminal
+// and NO_COLOR is not set
+func RichRenderOptionsFor(wr io.Writer) RichRenderOptions {
+	f, ok := wr.(*os.File)
+	if !ok {
+		return RichRenderOptions{}
+	}
+
+	width, ok := terminalWidth(f)
+	if !ok {
+		return RichRenderOptions{}
+	}
+
+	return RichRenderOptions{
+		Width: width,
+		Color: os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb",
+	}
+}
+
+// RenderRich renders the extended template of e, wrapping long values to fit opts.Width and highlighting
+// errors, like disconnect reasons, and warnings when opts.Color is set
+func RenderRich(wr io.Writer, e Event, opts RichRenderOptions) error {
+	buf := bytes.NewBuffer(nil)
+	err := RenderEvent(buf, e, TextExtendedFormat)
+	if err != nil {
+		return err
+	}
+
+	var out strings.Builder
+	titled := false
+	for i, line := range strings.Split(buf.String(), "\n") {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+
+		if !titled && strings.HasPrefix(line, "[") {
+			titled = true
+			out.WriteString(richColor(opts, line, text.Colors{text.Bold}))
+			continue
+		}
+
+		m := richFieldPattern.FindStringSubmatch(line)
+		if m == nil {
+			out.WriteString(richWrap(opts, line, len(line)-len(strings.TrimLeft(line, " "))))
+			continue
+		}
+
+		prefix := m[1] + m[2] + ": "
+		value := richWrap(opts, m[3], len(prefix))
+
+		switch {
+		case richErrorFields[m[2]]:
+			value = richColor(opts, value, text.Colors{text.FgHiRed})
+		case richWarningFields[m[2]] && m[3] != "0" && m[3] != "false":
+			value = richColor(opts, value, text.Colors{text.FgHiYellow})
+		}
+
+		out.WriteString(prefix + value)
+	}
+
+	_, err = io.WriteString(wr, out.String())
+	return err
+}
+
+// richWrap wraps s to fit the width after indent, continuation lines are indented by indent
+func richWrap(opts RichRenderOptions, s string, indent int) string {
+	avail := opts.Width - indent
+	if opts.Width == 0 || avail < minRichWrapWidth || text.StringWidthWithoutEscSequences(s)+indent <= opts.Width {
+		return s
+	}
+
+	lines := strings.Split(text.WrapSoft(s, avail), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return strings.Join(lines, "\n"+strings.Repeat(" ", indent))
+}
+
+// richColor colors every line of s individually so that wrapped values stay colored
+func richColor(opts RichRenderOptions, s string, colors text.Colors) string {
+	if !opts.Color {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		lines[i] = line[:len(line)-len(trimmed)] + text.Escape(trimmed, colors.EscapeSeq())
+	}
+
+	return strings.Join(lines, "\n")
+}
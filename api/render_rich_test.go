@@ -0,0 +1,65 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	srvadvisory "github.com/nats-io/jsm.go/api/server/advisory"
+)
+
+func TestRenderRich(t *testing.T) {
+	dc := &srvadvisory.DisconnectEventMsgV1{Reason: "Client Closed because the server detected a stale connection after a long period of inactivity"}
+	dc.Type = "io.nats.server.advisory.v1.client_disconnect"
+	dc.ID = "abc"
+	dc.Server.Name = "n1"
+
+	plain := bytes.NewBuffer(nil)
+	checkErr(t, RenderEvent(plain, dc, TextExtendedFormat), "render failed")
+
+	buf := bytes.NewBuffer(nil)
+	checkErr(t, RenderRich(buf, dc, RichRenderOptions{}), "render failed")
+	if buf.String() != plain.String() {
+		t.Fatalf("expected unchanged output without width or color: %q", buf.String())
+	}
+
+	buf.Reset()
+	checkErr(t, RenderRich(buf, dc, RichRenderOptions{Width: 50}), "render failed")
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Fatalf("unexpected colors: %q", buf.String())
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if len(line) > 50 {
+			t.Fatalf("line not wrapped: %q", line)
+		}
+		if strings.Contains(line, "stale") && strings.HasSuffix(line, " ") {
+			t.Fatalf("line has trailing space: %q", line)
+		}
+	}
+	if !strings.Contains(buf.String(), "\n            detected a stale") {
+		t.Fatalf("continuation not aligned with the value: %q", buf.String())
+	}
+
+	buf.Reset()
+	checkErr(t, RenderRich(buf, dc, RichRenderOptions{Color: true}), "render failed")
+	if !strings.Contains(buf.String(), "\x1b[1m[") || !strings.Contains(buf.String(), "Reason: \x1b[91mClient Closed") {
+		t.Fatalf("expected colors: %q", buf.String())
+	}
+
+	if RichRenderOptionsFor(buf) != (RichRenderOptions{}) {
+		t.Fatalf("expected no terminal options for a buffer")
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	jsadvisory "github.com/nats-io/jsm.go/api/jetstream/advisory"
+	srvadvisory "github.com/nats-io/jsm.go/api/server/advisory"
+)
+
+// SyslogSeverity is the severity of a security event as defined in RFC5424
+type SyslogSeverity int
+
+const (
+	SyslogSeverityError   SyslogSeverity = 3
+	SyslogSeverityWarning SyslogSeverity = 4
+	SyslogSeverityNotice  SyslogSeverity = 5
+	SyslogSeverityInfo    SyslogSeverity = 6
+)
+
+// syslogFacilityAuth is the security/authorization facility security events are logged to
+const syslogFacilityAuth = 4
+
+// warningDisconnectReasons are disconnect reasons that indicate a misbehaving or unauthorized client
+var warningDisconnectReasons = []string{"Authentication", "Authorization", "Slow Consumer", "Exceeded", "Violation", "Error", "Revoked", "Expired"}
+
+// securityField is a field of a security event, Key is used in CEF and Name in syslog records
+type securityField struct {
+	Key   string
+	Name  string
+	Value string
+}
+
+// securityRecord is the SIEM relevant view of a security event
+type securityRecord struct {
+	Name     string
+	Severity SyslogSeverity
+	Server   string
+	Version  string
+	Fields   []securityField
+}
+
+func (r *securityRecord) add(key string, name string, value string) {
+	if value == "" {
+		return
+	}
+
+	r.Fields = append(r.Fields, securityField{Key: key, Name: name, Value: value})
+}
+
+func (r *securityRecord) addClient(c srvadvisory.ClientInfoV1) {
+	r.add("src", "host", c.Host)
+	r.add("suser", "user", c.User)
+	if c.Account != "" {
+		r.add("cs1Label", "", "account")
+		r.add("cs1", "account", c.Account)
+	}
+	if c.ID > 0 {
+		r.add("cn1Label", "", "cid")
+		r.add("cn1", "cid", strconv.FormatUint(c.ID, 10))
+	}
+	if c.Name != "" {
+		r.add("cs2Label", "", "name")
+		r.add("cs2", "name", c.Name)
+	}
+}
+
+// securityRecordFor creates the security record for connect, disconnect, authentication error and API audit events
+func securityRecordFor(e Event) (*securityRecord, error) {
+	var r *securityRecord
+
+	switch ev := e.(type) {
+	case *srvadvisory.ConnectEventMsgV1:
+		r = &securityRecord{Name: "Client Connection", Severity: SyslogSeverityInfo, Server: ev.Server.Name, Version: ev.Server.Version}
+		r.addClient(ev.Client)
+		r.add("outcome", "outcome", "success")
+
+	case *srvadvisory.DisconnectEventMsgV1:
+		r = &securityRecord{Name: "Client Disconnection", Severity: SyslogSeverityInfo, Server: ev.Server.Name, Version: ev.Server.Version}
+		for _, reason := range warningDisconnectReasons {
+			if strings.Contains(ev.Reason, reason) {
+				r.Severity = SyslogSeverityWarning
+				break
+			}
+		}
+		r.addClient(ev.Client)
+		r.add("reason", "reason", ev.Reason)
+		r.add("in", "received_bytes", strconv.FormatInt(ev.Received.Bytes, 10))
+		r.add("out", "sent_bytes", strconv.FormatInt(ev.Sent.Bytes, 10))
+
+	case *srvadvisory.AuthErrorEventMsgV1:
+		r = &securityRecord{Name: "Authentication Error", Severity: SyslogSeverityWarning, Server: ev.Server.Name, Version: ev.Server.Version}
+		r.addClient(ev.Client)
+		r.add("reason", "reason", ev.Reason)
+		r.add("outcome", "outcome", "failure")
+
+	case *jsadvisory.JetStreamAPIAuditV1:
+		r = &securityRecord{Name: "JetStream API Access", Severity: SyslogSeverityInfo, Server: ev.Server}
+		outcome := "success"
+		var res JSApiResponse
+		if json.Unmarshal([]byte(ev.Response), &res) == nil && res.Error != nil {
+			outcome = "failure"
+			r.Severity = SyslogSeverityNotice
+		}
+		r.addClient(ev.Client)
+		r.add("request", "subject", ev.Subject)
+		r.add("outcome", "outcome", outcome)
+
+	default:
+		return nil, fmt.Errorf("%s is not a supported security event", e.EventType())
+	}
+
+	r.add("externalId", "id", e.EventID())
+
+	return r, nil
+}
+
+// RenderSyslog renders connect, disconnect, authentication error and API audit events as RFC5424 syslog records
+// using the auth facility
+func RenderSyslog(wr io.Writer, e Event) error {
+	r, err := securityRecordFor(e)
+	if err != nil {
+		return err
+	}
+
+	msg := []string{r.Name}
+	for _, f := range r.Fields {
+		if f.Name == "" {
+			continue
+		}
+		msg = append(msg, fmt.Sprintf("%s=%s", f.Name, strconv.Quote(f.Value)))
+	}
+
+	_, err = fmt.Fprintf(wr, "<%d>1 %s %s nats-server - %s - %s\n",
+		syslogFacilityAuth*8+int(r.Severity),
+		e.EventTime().UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogHeaderValue(r.Server),
+		syslogHeaderValue(e.EventType()[strings.LastIndex(e.EventType(), ".")+1:]),
+		strings.Join(msg, " "))
+
+	return err
+}
+
+// RenderCEF renders connect, disconnect, authentication error and API audit events as ArcSight Common Event
+// Format records, the syslog severity is mapped to the CEF severity scale of 0 to 10
+func RenderCEF(wr io.Writer, e Event) error {
+	r, err := securityRecordFor(e)
+	if err != nil {
+		return err
+	}
+
+	ext := []string{fmt.Sprintf("rt=%d", e.EventTime().UnixMilli())}
+	ext = append(ext, "dvchost="+cefExtensionValue(r.Server))
+	for _, f := range r.Fields {
+		ext = append(ext, f.Key+"="+cefExtensionValue(f.Value))
+	}
+
+	_, err = fmt.Fprintf(wr, "CEF:0|NATS|NATS Server|%s|%s|%s|%d|%s\n",
+		cefHeaderValue(r.Version),
+		cefHeaderValue(e.EventType()),
+		cefHeaderValue(r.Name),
+		cefSeverity(r.Severity),
+		strings.Join(ext, " "))
+
+	return err
+}
+
+func cefSeverity(s SyslogSeverity) int {
+	switch s {
+	case SyslogSeverityError:
+		return 8
+	case SyslogSeverityWarning:
+		return 6
+	case SyslogSeverityNotice:
+		return 4
+	default:
+		return 2
+	}
+}
+
+// syslogHeaderValue is v as a header field, which may not be empty or contain spaces
+func syslogHeaderValue(v string) string {
+	v = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, v)
+	if v == "" {
+		return "-"
+	}
+
+	return v
+}
+
+func cefHeaderValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ").Replace(v)
+}
+
+func cefExtensionValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`).Replace(v)
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	jsadvisory "github.com/nats-io/jsm.go/api/jetstream/advisory"
+	srvadvisory "github.com/nats-io/jsm.go/api/server/advisory"
+)
+
+func TestRenderSyslog(t *testing.T) {
+	ae := &srvadvisory.AuthErrorEventMsgV1{Reason: "Authorization Violation"}
+	ae.Type = "io.nats.server.advisory.v1.auth_error"
+	ae.ID = "abc"
+	ae.Time = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	ae.Server.Name = "n1"
+	ae.Client.Host = "192.168.1.1"
+	ae.Client.User = "bob"
+
+	buf := bytes.NewBuffer(nil)
+	checkErr(t, RenderEvent(buf, ae, SyslogFormat), "render failed")
+	expected := `<36>1 2025-01-02T03:04:05.000000Z n1 nats-server - auth_error - Authentication Error host="192.168.1.1" user="bob" reason="Authorization Violation" outcome="failure" id="abc"` + "\n"
+	if buf.String() != expected {
+		t.Fatalf("invalid syslog record: %q", buf.String())
+	}
+
+	dc := &srvadvisory.DisconnectEventMsgV1{Reason: "Client Closed"}
+	dc.Type = "io.nats.server.advisory.v1.client_disconnect"
+	buf.Reset()
+	checkErr(t, RenderSyslog(buf, dc), "render failed")
+	if !strings.HasPrefix(buf.String(), "<38>1 0001-01-01T00:00:00.000000Z - nats-server - client_disconnect - ") {
+		t.Fatalf("invalid syslog record: %q", buf.String())
+	}
+
+	dc.Reason = "Slow Consumer (Write Deadline)"
+	buf.Reset()
+	checkErr(t, RenderSyslog(buf, dc), "render failed")
+	if !strings.HasPrefix(buf.String(), "<36>1 ") {
+		t.Fatalf("expected warning severity: %q", buf.String())
+	}
+
+	sc := &srvadvisory.SlowConsumerEventMsgV1{}
+	sc.Type = "io.nats.server.advisory.v1.slow_consumer"
+	if RenderSyslog(buf, sc) == nil {
+		t.Fatalf("expected an error for unsupported events")
+	}
+}
+
+func TestRenderCEF(t *testing.T) {
+	audit := &jsadvisory.JetStreamAPIAuditV1{
+		Server:   "n|1",
+		Subject:  "$JS.API.STREAM.CREATE.X",
+		Response: `{"type":"io.nats.jetstream.api.v1.stream_create_response","error":{"code":400,"description":"bad"}}`,
+	}
+	audit.Type = "io.nats.jetstream.advisory.v1.api_audit"
+	audit.ID = "abc"
+	audit.Time = time.UnixMilli(1000)
+	audit.Client.Account = "A=B"
+	audit.Client.ID = 10
+
+	buf := bytes.NewBuffer(nil)
+	checkErr(t, RenderEvent(buf, audit, CEFFormat), "render failed")
+	expected := `CEF:0|NATS|NATS Server||io.nats.jetstream.advisory.v1.api_audit|JetStream API Access|4|rt=1000 dvchost=n|1 cs1Label=account cs1=A\=B cn1Label=cid cn1=10 request=$JS.API.STREAM.CREATE.X outcome=failure externalId=abc` + "\n"
+	if buf.String() != expected {
+		t.Fatalf("invalid cef record: %q", buf.String())
+	}
+
+	audit.Response = `{"type":"io.nats.jetstream.api.v1.stream_create_response"}`
+	buf.Reset()
+	checkErr(t, RenderCEF(buf, audit), "render failed")
+	if !strings.Contains(buf.String(), "|JetStream API Access|2|") || !strings.Contains(buf.String(), "outcome=success") {
+		t.Fatalf("invalid cef record: %q", buf.String())
+	}
+
+	ce := &srvadvisory.ConnectEventMsgV1{}
+	ce.Type = "io.nats.server.advisory.v1.client_connect"
+	ce.Server.Version = "2.11.0"
+	buf.Reset()
+	checkErr(t, RenderCEF(buf, ce), "render failed")
+	if !strings.HasPrefix(buf.String(), "CEF:0|NATS|NATS Server|2.11.0|io.nats.server.advisory.v1.client_connect|Client Connection|2|") {
+		t.Fatalf("invalid cef record: %q", buf.String())
+	}
+}
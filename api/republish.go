@@ -0,0 +1,55 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+// Validate checks that the republish configuration is valid for a stream with the given subjects, it
+// ensures the destination does not overlap the stream subjects which would form a loop
+func (r RePublish) Validate(subjects []string) error {
+	if r.Destination == "" {
+		return fmt.Errorf("republish destination is required")
+	}
+
+	src := r.Source
+	if src == "" {
+		src = ">"
+	}
+
+	err := ValidateSubjectTransform(src, r.Destination)
+	if err != nil {
+		return fmt.Errorf("invalid republish configuration: %w", err)
+	}
+
+	for _, subj := range subjects {
+		if server.SubjectsCollide(r.Destination, subj) {
+			return fmt.Errorf("republish destination %q overlaps stream subject %q and would form a loop", r.Destination, subj)
+		}
+	}
+
+	return nil
+}
+
+// ValidateRePublish checks the republish configuration against the stream subjects, see RePublish.Validate()
+func (c StreamConfig) ValidateRePublish() error {
+	if c.RePublish == nil {
+		return nil
+	}
+
+	return c.RePublish.Validate(c.Subjects)
+}
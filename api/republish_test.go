@@ -0,0 +1,48 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRePublishValidate(t *testing.T) {
+	subjects := []string{"orders.>"}
+
+	checkErr(t, RePublish{Destination: "repub.>"}.Validate(subjects), "valid republish failed")
+	checkErr(t, RePublish{Source: "orders.*", Destination: "repub.$1"}.Validate(subjects), "valid republish failed")
+	checkErr(t, RePublish{Source: "x.>", Destination: "repub.>"}.Validate(nil), "valid republish without subjects failed")
+	checkErr(t, RePublish{Source: "other.>", Destination: "repub.>"}.Validate(subjects), "republish of sourced subjects failed")
+
+	for _, c := range []struct {
+		r     RePublish
+		match string
+	}{
+		{RePublish{Source: "orders.>"}, "destination is required"},
+		{RePublish{Source: "orders.>", Destination: "orders.copy.>"}, "form a loop"},
+		{RePublish{Source: "orders.*", Destination: "repub.$2"}, "invalid republish"},
+	} {
+		err := c.r.Validate(subjects)
+		if err == nil || !strings.Contains(err.Error(), c.match) {
+			t.Fatalf("expected error matching %q for %+v, got %v", c.match, c.r, err)
+		}
+	}
+
+	cfg := StreamConfig{Subjects: subjects, RePublish: &RePublish{Destination: "orders.x"}}
+	if cfg.ValidateRePublish() == nil {
+		t.Fatalf("expected loop error")
+	}
+	checkErr(t, StreamConfig{Subjects: subjects}.ValidateRePublish(), "no republish failed")
+}
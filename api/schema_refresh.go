@@ -0,0 +1,87 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	scfs "github.com/nats-io/jsm.go/schemas"
+)
+
+// RefreshSchemas fetches the JSON schemas for types, or all types with an embedded schema when none are given, from
+// SchemasRepo and uses them instead of the embedded ones. Types that fail to fetch keep their current schema, the
+// failures are returned as a single error along with the types that were refreshed
+func RefreshSchemas(ctx context.Context, types ...string) (refreshed []string, err error) {
+	if len(types) == 0 {
+		for t := range schemaTypes {
+			_, err := Schema(t)
+			if err == nil {
+				types = append(types, t)
+			}
+		}
+		sort.Strings(types)
+	}
+
+	var errs []error
+	for _, t := range types {
+		err = refreshSchema(ctx, t)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", t, err))
+			continue
+		}
+
+		refreshed = append(refreshed, t)
+	}
+
+	return refreshed, errors.Join(errs...)
+}
+
+func refreshSchema(ctx context.Context, schemaType string) error {
+	path, err := SchemaFileForType(schemaType)
+	if err != nil {
+		return err
+	}
+
+	address, _, err := SchemaURLForType(schemaType)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %s failed: %s", address, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return scfs.Update(path, body)
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	scfs "github.com/nats-io/jsm.go/schemas"
+)
+
+func TestRefreshSchemas(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/jetstream/api/v1/stream_names_request.json":
+			w.Write([]byte(`{"title":"updated"}`))
+		case "/jetstream/api/v1/stream_list_request.json":
+			w.Write([]byte(`not json`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	orig := SchemasRepo
+	SchemasRepo = srv.URL
+	defer func() {
+		SchemasRepo = orig
+		scfs.Reset()
+	}()
+
+	embedded, err := Schema("io.nats.jetstream.api.v1.stream_list_request")
+	checkErr(t, err, "schema failed")
+
+	refreshed, err := RefreshSchemas(context.Background(), "io.nats.jetstream.api.v1.stream_names_request", "io.nats.jetstream.api.v1.stream_list_request", "io.nats.jetstream.api.v1.unknown")
+	if err == nil {
+		t.Fatalf("expected an error for failed refreshes")
+	}
+	if len(refreshed) != 1 || refreshed[0] != "io.nats.jetstream.api.v1.stream_names_request" {
+		t.Fatalf("unexpected refreshed types: %v", refreshed)
+	}
+
+	schema, err := Schema("io.nats.jetstream.api.v1.stream_names_request")
+	checkErr(t, err, "schema failed")
+	if string(schema) != `{"title":"updated"}` {
+		t.Fatalf("expected the refreshed schema got %s", schema)
+	}
+
+	schema, err = Schema("io.nats.jetstream.api.v1.stream_list_request")
+	checkErr(t, err, "schema failed")
+	if string(schema) != string(embedded) {
+		t.Fatalf("expected the embedded schema to be kept")
+	}
+
+	scfs.Reset()
+	schema, err = Schema("io.nats.jetstream.api.v1.stream_names_request")
+	checkErr(t, err, "schema failed")
+	if string(schema) == `{"title":"updated"}` {
+		t.Fatalf("expected the embedded schema after reset")
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SchemaRegistry fetches JSON schemas from a registry using the same layout as SchemasRepo, schemas are cached on
+// disk and revalidated using their ETag so that previously fetched schemas remain available when offline
+type SchemaRegistry struct {
+	url      string
+	cacheDir string
+	client   *http.Client
+	mu       sync.Mutex
+}
+
+// SchemaRegistryOption configures a SchemaRegistry
+type SchemaRegistryOption func(r *SchemaRegistry) error
+
+// SchemaRegistryURL sets the base URL of the registry, defaults to SchemasRepo
+func SchemaRegistryURL(u string) SchemaRegistryOption {
+	return func(r *SchemaRegistry) error {
+		if u == "" {
+			return fmt.Errorf("registry url is required")
+		}
+
+		r.url = strings.TrimSuffix(u, "/")
+		return nil
+	}
+}
+
+// SchemaRegistryCacheDir sets the directory schemas are cached in, defaults to jsm.go/schemas in the user cache directory
+func SchemaRegistryCacheDir(dir string) SchemaRegistryOption {
+	return func(r *SchemaRegistry) error {
+		if dir == "" {
+			return fmt.Errorf("cache directory is required")
+		}
+
+		r.cacheDir = dir
+		return nil
+	}
+}
+
+// SchemaRegistryHTTPClient sets the client used to fetch schemas
+func SchemaRegistryHTTPClient(c *http.Client) SchemaRegistryOption {
+	return func(r *SchemaRegistry) error {
+		if c == nil {
+			return fmt.Errorf("http client is required")
+		}
+
+		r.client = c
+		return nil
+	}
+}
+
+var (
+	schemaRegistry   *SchemaRegistry
+	schemaRegistryMu sync.Mutex
+)
+
+// SetSchemaRegistry configures the registry used by ValidatePayload to load schemas for types that are not
+// embedded in this package, nil disables fetching schemas
+func SetSchemaRegistry(r *SchemaRegistry) {
+	schemaRegistryMu.Lock()
+	schemaRegistry = r
+	schemaRegistryMu.Unlock()
+}
+
+// NewSchemaRegistry creates a new schema registry client
+func NewSchemaRegistry(opts ...SchemaRegistryOption) (*SchemaRegistry, error) {
+	r := &SchemaRegistry{
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+
+	for _, opt := range opts {
+		err := opt(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.url == "" {
+		r.url = strings.TrimSuffix(SchemasRepo, "/")
+	}
+
+	if r.cacheDir == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("could not determine cache directory: %w", err)
+		}
+		r.cacheDir = filepath.Join(dir, "jsm.go", "schemas")
+	}
+
+	return r, nil
+}
+
+// Schema fetches the JSON schema for schemaType, a cached copy is revalidated with the registry and used unchanged
+// when the registry cannot be reached
+func (r *SchemaRegistry) Schema(ctx context.Context, schemaType string) ([]byte, error) {
+	path, err := SchemaFileForType(schemaType)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cacheFile := filepath.Join(r.cacheDir, filepath.FromSlash(path))
+	etagFile := cacheFile + ".etag"
+
+	cached, cerr := os.ReadFile(cacheFile)
+	etag, _ := os.ReadFile(etagFile)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s", r.url, path), nil)
+	if err != nil {
+		return nil, err
+	}
+	if cerr == nil && len(etag) > 0 {
+		req.Header.Set("If-None-Match", string(etag))
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		if cerr == nil {
+			return cached, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		if cerr != nil {
+			return nil, fmt.Errorf("registry reported an unchanged schema for %s that is not cached", schemaType)
+		}
+		return cached, nil
+
+	case http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if !json.Valid(body) {
+			return nil, fmt.Errorf("registry returned invalid JSON for %s", schemaType)
+		}
+
+		err = r.store(cacheFile, body, resp.Header.Get("ETag"))
+		if err != nil {
+			return nil, err
+		}
+
+		return body, nil
+
+	default:
+		if cerr == nil && resp.StatusCode >= 500 {
+			return cached, nil
+		}
+
+		return nil, fmt.Errorf("fetching schema for %s failed: %s", schemaType, resp.Status)
+	}
+}
+
+func (r *SchemaRegistry) store(cacheFile string, body []byte, etag string) error {
+	err := os.MkdirAll(filepath.Dir(cacheFile), 0700)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(cacheFile, body, 0600)
+	if err != nil {
+		return err
+	}
+
+	if etag == "" {
+		err = os.Remove(cacheFile + ".etag")
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	return os.WriteFile(cacheFile+".etag", []byte(etag), 0600)
+}
+
+// loadSchema loads the embedded schema for schemaType falling back to the registry set using SetSchemaRegistry
+func loadSchema(schemaType string) ([]byte, error) {
+	schema, err := Schema(schemaType)
+	if err == nil {
+		return schema, nil
+	}
+
+	schemaRegistryMu.Lock()
+	r := schemaRegistry
+	schemaRegistryMu.Unlock()
+
+	if r == nil {
+		return nil, err
+	}
+
+	return r.Schema(context.Background(), schemaType)
+}
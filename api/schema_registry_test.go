@@ -0,0 +1,94 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSchemaRegistry(t *testing.T) {
+	var fetched, revalidated atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/custom/thing/v1/widget.json" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidated.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		fetched.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"type":"object","required":["name"],"properties":{"name":{"type":"string"}}}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewSchemaRegistry(SchemaRegistryURL(""))
+	if err == nil {
+		t.Fatalf("expected an error for an empty url")
+	}
+
+	dir := t.TempDir()
+	reg, err := NewSchemaRegistry(SchemaRegistryURL(srv.URL+"/"), SchemaRegistryCacheDir(dir))
+	checkErr(t, err, "registry failed")
+
+	schema, err := reg.Schema(context.Background(), "io.nats.custom.thing.v1.widget")
+	checkErr(t, err, "schema failed")
+	if fetched.Load() != 1 || len(schema) == 0 {
+		t.Fatalf("expected the schema to be fetched")
+	}
+
+	etag, err := os.ReadFile(filepath.Join(dir, "custom", "thing", "v1", "widget.json.etag"))
+	checkErr(t, err, "etag not cached")
+	if string(etag) != `"v1"` {
+		t.Fatalf("invalid etag cached: %s", etag)
+	}
+
+	cached, err := reg.Schema(context.Background(), "io.nats.custom.thing.v1.widget")
+	checkErr(t, err, "schema failed")
+	if fetched.Load() != 1 || revalidated.Load() != 1 || string(cached) != string(schema) {
+		t.Fatalf("expected the cached schema to be revalidated")
+	}
+
+	_, err = reg.Schema(context.Background(), "io.nats.custom.thing.v1.missing")
+	if err == nil {
+		t.Fatalf("expected an error for a missing schema")
+	}
+
+	SetSchemaRegistry(reg)
+	defer SetSchemaRegistry(nil)
+
+	errs, ok := ValidatePayload("io.nats.custom.thing.v1.widget", []byte(`{}`))
+	if ok || len(errs) != 1 || errs[0].Message != `missing required property "name"` {
+		t.Fatalf("expected validation using the registry schema: %v", errs)
+	}
+
+	srv.Close()
+
+	offline, err := reg.Schema(context.Background(), "io.nats.custom.thing.v1.widget")
+	checkErr(t, err, "offline schema failed")
+	if string(offline) != string(schema) {
+		t.Fatalf("expected the cached schema while offline")
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/nats-io/nats-server/v2/server"
 
+	"github.com/nats-io/jsm.go/api/event"
 	scfs "github.com/nats-io/jsm.go/schemas"
 )
 
@@ -46,10 +47,18 @@ const (
 	TextCompactFormat RenderFormat = "text/compact"
 	// TextExtendedFormat renders a multi line full view of an event
 	TextExtendedFormat RenderFormat = "text/extended"
+	// TextRichFormat renders the multi line full view of an event adjusted to the width and color support of the terminal, see RenderRich
+	TextRichFormat RenderFormat = "text/rich"
 	// ApplicationJSONFormat renders as indented JSON
 	ApplicationJSONFormat RenderFormat = "application/json"
 	// ApplicationCloudEventV1Format renders as a ApplicationCloudEventV1Format v1
 	ApplicationCloudEventV1Format RenderFormat = "application/cloudeventv1"
+	// EventFormatJSON renders a single line JSON envelope holding the type, id, time and payload of an event
+	EventFormatJSON RenderFormat = "application/x-nats-event+json"
+	// SyslogFormat renders security related events as RFC5424 syslog records, see RenderSyslog
+	SyslogFormat RenderFormat = "text/syslog"
+	// CEFFormat renders security related events as Common Event Format records, see RenderCEF
+	CEFFormat RenderFormat = "text/cef"
 )
 
 // SchemaManagedApiRequestType is a type that supports schema based introspection including API subjects
@@ -70,14 +79,15 @@ type SchemaManagedType interface {
 
 // we dont export this since it's not official, but what this produce will be loadable by the official CE
 type cloudEvent struct {
-	Type        string          `json:"type"`
-	Time        time.Time       `json:"time"`
-	ID          string          `json:"id"`
-	Source      string          `json:"source"`
-	DataSchema  string          `json:"dataschema"`
-	SpecVersion string          `json:"specversion"`
-	Subject     string          `json:"subject"`
-	Data        json.RawMessage `json:"data"`
+	Type            string          `json:"type"`
+	Time            time.Time       `json:"time"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	DataSchema      string          `json:"dataschema"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	SpecVersion     string          `json:"specversion"`
+	Subject         string          `json:"subject"`
+	Data            json.RawMessage `json:"data"`
 }
 
 type schemaDetector struct {
@@ -87,6 +97,10 @@ type schemaDetector struct {
 
 var ErrUnknownApiSubject = errors.New("unknown api subject")
 
+func init() {
+	event.SetTypeFactory(NewMessage)
+}
+
 // IsNatsSchemaType determines if a schema type is a valid NATS type.
 // The logic here is currently quite naive while we learn what works best
 func IsNatsSchemaType(schemaType string) bool {
@@ -186,15 +200,18 @@ func NewMessage(schemaType string) (any, bool) {
 	return gf(), ok
 }
 
-// ParseMessage parses a typed message m and returns event as for example *api.ConsumerAckMetric, all unknown
-// event schemas will be of type *UnknownMessage
+// ParseMessage parses a typed message m and returns event as for example *api.ConsumerAckMetric, events with an
+// unknown schema type will be of type *RawEvent and messages without a type will be of type *UnknownMessage
 func ParseMessage(m []byte) (schemaType string, msg any, err error) {
 	schemaType, err = SchemaTypeForMessage(m)
 	if err != nil {
 		return "", nil, err
 	}
 
-	msg, _ = NewMessage(schemaType)
+	msg, known := NewMessage(schemaType)
+	if !known && schemaType != "io.nats.unknown_message" {
+		msg = &RawEvent{}
+	}
 	err = json.Unmarshal(m, msg)
 
 	return schemaType, msg, err
@@ -223,13 +240,14 @@ func ToCloudEventV1(e Event) ([]byte, error) {
 	}
 
 	event := cloudEvent{
-		Type:        e.EventType(),
-		Time:        e.EventTime(),
-		ID:          e.EventID(),
-		Source:      e.EventSource(),
-		Subject:     e.EventSubject(),
-		SpecVersion: "1.0",
-		Data:        je,
+		Type:            e.EventType(),
+		Time:            e.EventTime(),
+		ID:              e.EventID(),
+		Source:          e.EventSource(),
+		Subject:         e.EventSubject(),
+		SpecVersion:     "1.0",
+		DataContentType: "application/json",
+		Data:            je,
 	}
 
 	address, _, err := SchemaURLForType(e.EventType())
@@ -240,6 +258,63 @@ func ToCloudEventV1(e Event) ([]byte, error) {
 	return json.MarshalIndent(event, "", "  ")
 }
 
+// FromCloudEventV1 parses a version 1.0 structured mode Cloud Event, as produced by ToCloudEventV1, and returns
+// the event it carries as for example *jsadvisory.JetStreamAPIAuditV1. The Cloud Event type, id and time take
+// precedence over those in the data, unknown types will be of type *RawEvent
+func FromCloudEventV1(ce []byte) (schemaType string, msg any, err error) {
+	var event cloudEvent
+	err = json.Unmarshal(ce, &event)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if event.SpecVersion != "1.0" {
+		return "", nil, fmt.Errorf("unsupported cloud event spec version %q", event.SpecVersion)
+	}
+	if event.DataContentType != "" && event.DataContentType != "application/json" {
+		return "", nil, fmt.Errorf("unsupported cloud event data content type %q", event.DataContentType)
+	}
+	if !IsNatsSchemaType(event.Type) {
+		return "", nil, fmt.Errorf("unsupported schema type %q", event.Type)
+	}
+
+	msg, known := NewMessage(event.Type)
+	if !known {
+		raw := &RawEvent{}
+		if len(event.Data) > 0 {
+			err = json.Unmarshal(event.Data, raw)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+
+		raw.Type = event.Type
+		raw.ID = event.ID
+		raw.Time = event.Time
+
+		return event.Type, raw, nil
+	}
+
+	if len(event.Data) > 0 {
+		err = json.Unmarshal(event.Data, msg)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	hdr, err := json.Marshal(map[string]any{"type": event.Type, "id": event.ID, "timestamp": event.Time})
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = json.Unmarshal(hdr, msg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return event.Type, msg, nil
+}
+
 // RenderEvent renders an event in specific format
 func RenderEvent(wr io.Writer, e Event, format RenderFormat) error {
 	switch format {
@@ -269,6 +344,18 @@ func RenderEvent(wr io.Writer, e Event, format RenderFormat) error {
 		_, err = wr.Write(ce)
 		return err
 
+	case EventFormatJSON:
+		return event.RenderJSON(wr, e)
+
+	case TextRichFormat:
+		return RenderRich(wr, e, RichRenderOptionsFor(wr))
+
+	case SyslogFormat:
+		return RenderSyslog(wr, e)
+
+	case CEFFormat:
+		return RenderCEF(wr, e)
+
 	default:
 		return fmt.Errorf("unsupported format %q", format)
 	}
@@ -21,8 +21,11 @@ var schemaTypes = map[string]func() any{
 	"io.nats.jetstream.advisory.v1.consumer_pause":               func() any { return &jsadvisory.JSConsumerPauseAdvisoryV1{} },
 	"io.nats.jetstream.advisory.v1.consumer_quorum_lost":         func() any { return &jsadvisory.JSConsumerQuorumLostV1{} },
 	"io.nats.jetstream.advisory.v1.domain_leader_elected":        func() any { return &jsadvisory.JSDomainLeaderElectedV1{} },
+	"io.nats.jetstream.advisory.v1.kv_bucket_action":             func() any { return &jsadvisory.JSKVBucketActionAdvisoryV1{} },
+	"io.nats.jetstream.advisory.v1.kv_key_purge":                 func() any { return &jsadvisory.JSKVKeyPurgeAdvisoryV1{} },
 	"io.nats.jetstream.advisory.v1.max_deliver":                  func() any { return &jsadvisory.ConsumerDeliveryExceededAdvisoryV1{} },
 	"io.nats.jetstream.advisory.v1.nak":                          func() any { return &jsadvisory.JSConsumerDeliveryNakAdvisoryV1{} },
+	"io.nats.jetstream.advisory.v1.objectstore_bucket_action":    func() any { return &jsadvisory.JSObjectStoreBucketActionAdvisoryV1{} },
 	"io.nats.jetstream.advisory.v1.restore_complete":             func() any { return &jsadvisory.JSRestoreCompleteAdvisoryV1{} },
 	"io.nats.jetstream.advisory.v1.restore_create":               func() any { return &jsadvisory.JSRestoreCreateAdvisoryV1{} },
 	"io.nats.jetstream.advisory.v1.server_out_of_space":          func() any { return &jsadvisory.JSServerOutOfSpaceAdvisoryV1{} },
@@ -88,8 +91,14 @@ var schemaTypes = map[string]func() any{
 	"io.nats.micro.v1.ping_response":                             func() any { return &micro.Ping{} },
 	"io.nats.micro.v1.stats_response":                            func() any { return &micro.Stats{} },
 	"io.nats.server.advisory.v1.account_connections":             func() any { return &srvadvisory.AccountConnectionsV1{} },
+	"io.nats.server.advisory.v1.auth_error":                      func() any { return &srvadvisory.AuthErrorEventMsgV1{} },
 	"io.nats.server.advisory.v1.client_connect":                  func() any { return &srvadvisory.ConnectEventMsgV1{} },
 	"io.nats.server.advisory.v1.client_disconnect":               func() any { return &srvadvisory.DisconnectEventMsgV1{} },
+	"io.nats.server.advisory.v1.leafnode_connect":                func() any { return &srvadvisory.LeafNodeConnectEventMsgV1{} },
+	"io.nats.server.advisory.v1.leafnode_disconnect":             func() any { return &srvadvisory.LeafNodeDisconnectEventMsgV1{} },
+	"io.nats.server.advisory.v1.server_lame_duck":                func() any { return &srvadvisory.ServerLameDuckEventMsgV1{} },
+	"io.nats.server.advisory.v1.server_shutdown":                 func() any { return &srvadvisory.ServerShutdownEventMsgV1{} },
+	"io.nats.server.advisory.v1.slow_consumer":                   func() any { return &srvadvisory.SlowConsumerEventMsgV1{} },
 	"io.nats.server.metric.v1.service_latency":                   func() any { return &srvmetric.ServiceLatencyV1{} },
 	"io.nats.server.monitor.v1.varz":                             func() any { return &zmonitor.VarzV1{} },
 	"io.nats.unknown_message":                                    func() any { return &UnknownMessage{} },
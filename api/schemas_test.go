@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
+	"text/template"
 
+	"github.com/nats-io/jsm.go/api/event"
 	jsadvisory "github.com/nats-io/jsm.go/api/jetstream/advisory"
 	scfs "github.com/nats-io/jsm.go/schemas"
 )
@@ -191,6 +194,156 @@ func TestToCloudEvent(t *testing.T) {
 	}
 }
 
+func TestFromCloudEvent(t *testing.T) {
+	ja := jsadvisory.JetStreamAPIAuditV1{}
+	err := json.Unmarshal([]byte(jetStreamAPIAuditEvent), &ja)
+	if err != nil {
+		t.Fatalf("could not unmarshal event: %s", err)
+	}
+
+	ce, err := ToCloudEventV1(&ja)
+	checkErr(t, err, "could not create cloud event")
+
+	schema, msg, err := FromCloudEventV1(ce)
+	checkErr(t, err, "could not parse cloud event")
+
+	if schema != "io.nats.jetstream.advisory.v1.api_audit" {
+		t.Fatalf("invalid schema: %s", schema)
+	}
+
+	parsed, ok := msg.(*jsadvisory.JetStreamAPIAuditV1)
+	if !ok {
+		t.Fatalf("invalid message type %T", msg)
+	}
+	if !reflect.DeepEqual(*parsed, ja) {
+		t.Fatalf("invalid data: %#v", parsed)
+	}
+
+	_, msg, err = FromCloudEventV1([]byte(`{"specversion":"1.0","type":"io.nats.jetstream.advisory.v1.api_audit","id":"x","time":"2020-04-23T16:51:18Z","data":{"subject":"$JS.API.INFO"}}`))
+	checkErr(t, err, "could not parse cloud event")
+	parsed = msg.(*jsadvisory.JetStreamAPIAuditV1)
+	if parsed.Type != "io.nats.jetstream.advisory.v1.api_audit" || parsed.ID != "x" || parsed.Time.IsZero() || parsed.Subject != "$JS.API.INFO" {
+		t.Fatalf("invalid data: %#v", parsed)
+	}
+
+	_, _, err = FromCloudEventV1([]byte(`{"specversion":"0.3","type":"io.nats.jetstream.advisory.v1.api_audit"}`))
+	if err == nil {
+		t.Fatalf("expected an error for unsupported spec version")
+	}
+}
+
+func TestOverrideTemplate(t *testing.T) {
+	ja := jsadvisory.JetStreamAPIAuditV1{}
+	err := json.Unmarshal([]byte(jetStreamAPIAuditEvent), &ja)
+	checkErr(t, err, "could not unmarshal event")
+
+	err = event.OverrideTemplate(ja.Type, string(TextCompactFormat), `{{ .Subject`)
+	if err == nil {
+		t.Fatalf("expected an error for an invalid template")
+	}
+
+	err = event.OverrideTemplate(ja.Type, string(TextCompactFormat), `{{ .Unknown }}`)
+	if err == nil {
+		t.Fatalf("expected an error for an unknown field")
+	}
+
+	err = event.OverrideTemplate(ja.Type, "text/other", `{{ .Subject }}`)
+	if err == nil {
+		t.Fatalf("expected an error for an unknown format")
+	}
+
+	original, err := ja.EventTemplate(string(TextCompactFormat))
+	checkErr(t, err, "template failed")
+	defer event.OverrideTemplate(ja.Type, string(TextCompactFormat), original.Root.String())
+
+	err = event.OverrideTemplate(ja.Type, string(TextCompactFormat), `audit {{ .Subject }} by {{ .Client.Name }}`)
+	checkErr(t, err, "override failed")
+
+	buf := bytes.NewBuffer(nil)
+	err = RenderEvent(buf, &ja, TextCompactFormat)
+	checkErr(t, err, "render failed")
+	if buf.String() != "audit $JS.STREAM.LIST by NATS CLI" {
+		t.Fatalf("invalid render: %q", buf.String())
+	}
+}
+
+func TestRegisterTemplateFuncs(t *testing.T) {
+	err := event.RegisterTemplateFuncs(template.FuncMap{"invalid name": strings.ToUpper})
+	if err == nil {
+		t.Fatalf("expected an error for an invalid name")
+	}
+
+	err = event.RegisterTemplateFuncs(template.FuncMap{"NotAFunc": "upper"})
+	if err == nil {
+		t.Fatalf("expected an error for a value that is not a function")
+	}
+
+	err = event.RegisterTextCompactTemplate("io.nats.test.v1.template_funcs", `{{ .Name | TestShout }}`)
+	if err == nil {
+		t.Fatalf("expected an error for an unknown function")
+	}
+
+	err = event.RegisterTemplateFuncs(template.FuncMap{"TestShout": func(v string) string { return strings.ToUpper(v) + "!" }})
+	checkErr(t, err, "register failed")
+
+	err = event.RegisterTemplateFuncs(template.FuncMap{"TestShout": strings.ToLower})
+	if err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Fatalf("expected an error for a duplicate function, got %v", err)
+	}
+
+	err = event.RegisterTextCompactTemplate("io.nats.test.v1.template_funcs", `{{ .Name | TestShout }}`)
+	checkErr(t, err, "register failed")
+
+	buf := bytes.NewBuffer(nil)
+	err = RenderEvent(buf, &lintTestEventV1{NATSEvent: event.NATSEvent{Type: "io.nats.test.v1.template_funcs"}, Name: "hello"}, TextCompactFormat)
+	checkErr(t, err, "render failed")
+	if buf.String() != "HELLO!" {
+		t.Fatalf("invalid render: %q", buf.String())
+	}
+}
+
+func TestRenderEventJSON(t *testing.T) {
+	ja := jsadvisory.JetStreamAPIAuditV1{}
+	err := json.Unmarshal([]byte(jetStreamAPIAuditEvent), &ja)
+	if err != nil {
+		t.Fatalf("could not unmarshal event: %s", err)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err = RenderEvent(buf, &ja, EventFormatJSON)
+	checkErr(t, err, "render failed")
+
+	if bytes.Count(buf.Bytes(), []byte("\n")) != 1 {
+		t.Fatalf("expected a single line: %q", buf.String())
+	}
+
+	env := event.JSONEnvelope{}
+	err = json.Unmarshal(buf.Bytes(), &env)
+	checkErr(t, err, "unmarshal failed")
+
+	if env.Type != "io.nats.jetstream.advisory.v1.api_audit" || env.ID != "uafvZ1UEDIW5FZV6kvLgWA" || !env.Time.Equal(ja.Time) {
+		t.Fatalf("invalid envelope: %#v", env)
+	}
+
+	payload := map[string]any{}
+	err = json.Unmarshal(env.Payload, &payload)
+	checkErr(t, err, "payload unmarshal failed")
+
+	for _, k := range []string{"type", "id", "timestamp"} {
+		if _, ok := payload[k]; ok {
+			t.Fatalf("payload has header field %q", k)
+		}
+	}
+	if payload["subject"] != "$JS.STREAM.LIST" || payload["server"] != ja.Server {
+		t.Fatalf("invalid payload: %v", payload)
+	}
+
+	err = event.RenderJSON(buf, ja.Client)
+	if err == nil {
+		t.Fatalf("expected an error for a non event")
+	}
+}
+
 func TestSchemaForEvent(t *testing.T) {
 	s, err := SchemaTypeForMessage([]byte(`{"schema":"io.nats.jetstream.metric.v1.consumer_ack"}`))
 	checkErr(t, err, "schema extract failed")
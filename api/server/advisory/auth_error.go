@@ -0,0 +1,68 @@
+package advisory
+
+import (
+	"github.com/nats-io/jsm.go/api/event"
+)
+
+// AuthErrorEventMsgV1 is sent when a client fails to authenticate, the
+// Client holds the details the server could determine before rejecting it.
+//
+// NATS Schema Type io.nats.server.advisory.v1.auth_error
+type AuthErrorEventMsgV1 struct {
+	event.NATSEvent
+
+	Server ServerInfoV1 `json:"server"`
+	Client ClientInfoV1 `json:"client"`
+	Reason string       `json:"reason"`
+}
+
+func init() {
+	err := event.RegisterTextCompactTemplate("io.nats.server.advisory.v1.auth_error", `{{ .Time | ShortTime }} [Authentication Error] {{ if .Client.User }}user: {{ .Client.User }} {{ end }}{{ if .Client.Host }}host: {{ .Client.Host }} {{ end }}on server {{ .Server.Name }}: {{ .Reason }}`)
+	if err != nil {
+		panic(err)
+	}
+
+	err = event.RegisterTextExtendedTemplate("io.nats.server.advisory.v1.auth_error", `
+[{{ .Time | ShortTime }}] [{{ .ID }}] Client Authentication Error
+
+     Reason: {{ .Reason }}
+     Server: {{ .Server.Name }}
+{{- if .Server.Cluster }}
+    Cluster: {{ .Server.Cluster }}
+{{- end }}
+
+   Client:
+{{- if .Client.ID }}
+                 ID: {{ .Client.ID }}
+{{- end }}
+{{- if .Client.User }}
+               User: {{ .Client.User }}
+{{- end }}
+{{- if .Client.Name }}
+               Name: {{ .Client.Name }}
+{{- end }}
+{{- if .Client.Account }}
+            Account: {{ .Client.Account }}
+{{- end }}
+{{- if .Client.Host }}
+               Host: {{ .Client.Host }}
+{{- end }}
+{{- if .Client.Version }}
+    Library Version: {{ .Client.Version }}  Language: {{ with .Client.Lang }}{{ . }}{{ else }}Unknown{{ end }}
+{{- end }}
+{{- if .Client.IssuerKey }}
+         Issuer Key: {{ .Client.IssuerKey }}
+{{- end }}
+{{- if .Client.NameTag }}
+           Name Tag: {{ .Client.NameTag }}
+{{- end }}
+{{- if .Client.Kind }}
+        Client Kind: {{ .Client.Kind }}
+{{- end }}
+{{- if .Client.ClientType }}
+        Client Type: {{ .Client.ClientType }}
+{{- end }}`)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,53 @@
+package advisory
+
+import (
+	"github.com/nats-io/jsm.go/api/event"
+)
+
+// LeafNodeConnectEventMsgV1 is sent when a leafnode connects to a server.
+//
+// NATS Schema Type io.nats.server.advisory.v1.leafnode_connect
+type LeafNodeConnectEventMsgV1 struct {
+	event.NATSEvent
+
+	Server   ServerInfoV1   `json:"server"`
+	LeafNode LeafNodeInfoV1 `json:"leafnode"`
+}
+
+func init() {
+	err := event.RegisterTextCompactTemplate("io.nats.server.advisory.v1.leafnode_connect", `{{ .Time | ShortTime }} [Leafnode Connection] {{ with .LeafNode.Name }}{{ . }} {{ end }}{{ if .LeafNode.IP }}from {{ HostPort .LeafNode.IP .LeafNode.Port }} {{ end }}lid: {{ .LeafNode.ID }} in account {{ .LeafNode.Account }}{{ if .LeafNode.RemoteAccount }} (remote account {{ .LeafNode.RemoteAccount }}){{ end }}`)
+	if err != nil {
+		panic(err)
+	}
+
+	err = event.RegisterTextExtendedTemplate("io.nats.server.advisory.v1.leafnode_connect", `
+[{{ .Time | ShortTime }}] [{{ .ID }}] Leafnode Connection
+
+   Server: {{ .Server.Name }}
+{{- if .Server.Cluster }}
+  Cluster: {{ .Server.Cluster }}
+{{- end }}
+
+   Leafnode:
+                 ID: {{ .LeafNode.ID }}
+{{- if .LeafNode.Name }}
+      Remote Server: {{ .LeafNode.Name }}
+{{- end }}
+{{- if .LeafNode.IP }}
+            Address: {{ HostPort .LeafNode.IP .LeafNode.Port }}
+{{- end }}
+            Account: {{ .LeafNode.Account }}
+{{- if .LeafNode.RemoteAccount }}
+     Remote Account: {{ .LeafNode.RemoteAccount }}
+{{- end }}
+              Spoke: {{ .LeafNode.IsSpoke }}
+{{- if .LeafNode.Compression }}
+        Compression: {{ .LeafNode.Compression }}
+{{- end }}
+{{- if .LeafNode.RTT }}
+                RTT: {{ .LeafNode.RTT }}
+{{- end }}`)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,60 @@
+package advisory
+
+import (
+	"github.com/nats-io/jsm.go/api/event"
+)
+
+// LeafNodeDisconnectEventMsgV1 is sent when a leafnode previously announced
+// by a LeafNodeConnectEventMsgV1 disconnects.
+//
+// NATS Schema Type io.nats.server.advisory.v1.leafnode_disconnect
+type LeafNodeDisconnectEventMsgV1 struct {
+	event.NATSEvent
+
+	Server   ServerInfoV1   `json:"server"`
+	LeafNode LeafNodeInfoV1 `json:"leafnode"`
+	Sent     DataStatsV1    `json:"sent"`
+	Received DataStatsV1    `json:"received"`
+	Reason   string         `json:"reason"`
+}
+
+func init() {
+	err := event.RegisterTextCompactTemplate("io.nats.server.advisory.v1.leafnode_disconnect", `{{ .Time | ShortTime }} [Leafnode Disconnection] {{ with .LeafNode.Name }}{{ . }} {{ end }}{{ if .LeafNode.IP }}from {{ HostPort .LeafNode.IP .LeafNode.Port }} {{ end }}lid: {{ .LeafNode.ID }} in account {{ .LeafNode.Account }}{{ if .LeafNode.RemoteAccount }} (remote account {{ .LeafNode.RemoteAccount }}){{ end }}: {{ .Reason }}`)
+	if err != nil {
+		panic(err)
+	}
+
+	err = event.RegisterTextExtendedTemplate("io.nats.server.advisory.v1.leafnode_disconnect", `
+[{{ .Time | ShortTime }}] [{{ .ID }}] Leafnode Disconnection
+{{ if .Reason }}
+    Reason: {{ .Reason }}
+{{- end }}
+    Server: {{ .Server.Name }}
+{{- if .Server.Cluster }}
+   Cluster: {{ .Server.Cluster }}
+{{- end }}
+
+   Leafnode:
+                 ID: {{ .LeafNode.ID }}
+{{- if .LeafNode.Name }}
+      Remote Server: {{ .LeafNode.Name }}
+{{- end }}
+{{- if .LeafNode.IP }}
+            Address: {{ HostPort .LeafNode.IP .LeafNode.Port }}
+{{- end }}
+            Account: {{ .LeafNode.Account }}
+{{- if .LeafNode.RemoteAccount }}
+     Remote Account: {{ .LeafNode.RemoteAccount }}
+{{- end }}
+              Spoke: {{ .LeafNode.IsSpoke }}
+
+   Stats:
+{{- if .LeafNode.RTT }}
+                  RTT: {{ .LeafNode.RTT }}
+{{- end }}
+    Leafnode Received: {{ .Received.Msgs }} messages ({{ .Received.Bytes | IBytes }})
+        Leafnode Sent: {{ .Sent.Msgs }} messages ({{ .Sent.Bytes | IBytes }})`)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -57,3 +57,16 @@ type MsgBytesV1 struct {
 	Msgs  int64 `json:"msgs"`
 	Bytes int64 `json:"bytes"`
 }
+
+// LeafNodeInfoV1 is detailed information about a leafnode connection.
+type LeafNodeInfoV1 struct {
+	ID            uint64        `json:"id"`
+	Name          string        `json:"name,omitempty"`
+	IP            string        `json:"ip,omitempty"`
+	Port          int           `json:"port,omitempty"`
+	Account       string        `json:"account"`
+	RemoteAccount string        `json:"remote_account,omitempty"`
+	IsSpoke       bool          `json:"is_spoke,omitempty"`
+	Compression   string        `json:"compression,omitempty"`
+	RTT           time.Duration `json:"rtt,omitempty"`
+}
@@ -0,0 +1,63 @@
+package advisory
+
+import (
+	"encoding/json"
+
+	"github.com/nats-io/jsm.go/api/event"
+)
+
+// ServerLameDuckEventSubject is the subject servers announce entering lame duck mode on
+const ServerLameDuckEventSubject = "$SYS.SERVER.*.LAMEDUCK"
+
+// ServerLameDuckEventMsgV1 is sent when a server enters lame duck mode, it stops accepting new
+// clients and gradually closes existing ones before shutting down. The server publishes only its
+// ServerInfoV1, use NewServerLameDuckEventMsgV1 to create the typed event from that message.
+//
+// NATS Schema Type io.nats.server.advisory.v1.server_lame_duck
+type ServerLameDuckEventMsgV1 struct {
+	event.NATSEvent
+
+	Server ServerInfoV1 `json:"server"`
+}
+
+// NewServerLameDuckEventMsgV1 creates the event from a message received on ServerLameDuckEventSubject
+func NewServerLameDuckEventMsgV1(data []byte) (*ServerLameDuckEventMsgV1, error) {
+	var si ServerInfoV1
+	err := json.Unmarshal(data, &si)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServerLameDuckEventMsgV1{
+		NATSEvent: newServerEvent("io.nats.server.advisory.v1.server_lame_duck", si),
+		Server:    si,
+	}, nil
+}
+
+func init() {
+	err := event.RegisterTextCompactTemplate("io.nats.server.advisory.v1.server_lame_duck", `{{ .Time | ShortTime }} [Lame Duck Mode] {{ .Server.Name }}{{ if .Server.Cluster }} in cluster {{ .Server.Cluster }}{{ end }} is evicting clients before shutting down`)
+	if err != nil {
+		panic(err)
+	}
+
+	err = event.RegisterTextExtendedTemplate("io.nats.server.advisory.v1.server_lame_duck", `
+[{{ .Time | ShortTime }}] [{{ .ID }}] Server Entered Lame Duck Mode
+
+     Server: {{ .Server.Name }}
+         ID: {{ .Server.ID }}
+       Host: {{ .Server.Host }}
+{{- if .Server.Cluster }}
+    Cluster: {{ .Server.Cluster }}
+{{- end }}
+{{- if .Server.Domain }}
+     Domain: {{ .Server.Domain }}
+{{- end }}
+    Version: {{ .Server.Version }}
+  JetStream: {{ .Server.JetStream }}
+{{- if .Server.Tags }}
+       Tags: {{ .Server.Tags | JoinStrings }}
+{{- end }}`)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,74 @@
+package advisory
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/nats-io/jsm.go/api/event"
+	"github.com/nats-io/nuid"
+)
+
+// ServerShutdownEventSubject is the subject servers announce their shutdown on
+const ServerShutdownEventSubject = "$SYS.SERVER.*.SHUTDOWN"
+
+// ServerShutdownEventMsgV1 is sent when a server shuts down. The server publishes only its
+// ServerInfoV1, use NewServerShutdownEventMsgV1 to create the typed event from that message.
+//
+// NATS Schema Type io.nats.server.advisory.v1.server_shutdown
+type ServerShutdownEventMsgV1 struct {
+	event.NATSEvent
+
+	Server ServerInfoV1 `json:"server"`
+}
+
+// NewServerShutdownEventMsgV1 creates the event from a message received on ServerShutdownEventSubject
+func NewServerShutdownEventMsgV1(data []byte) (*ServerShutdownEventMsgV1, error) {
+	var si ServerInfoV1
+	err := json.Unmarshal(data, &si)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServerShutdownEventMsgV1{
+		NATSEvent: newServerEvent("io.nats.server.advisory.v1.server_shutdown", si),
+		Server:    si,
+	}, nil
+}
+
+// newServerEvent creates the event header for a message that holds only the ServerInfoV1 of the publishing server
+func newServerEvent(schema string, si ServerInfoV1) event.NATSEvent {
+	ts := si.Time
+	if ts.IsZero() {
+		ts = time.Now().UTC()
+	}
+
+	return event.NATSEvent{Type: schema, ID: nuid.Next(), Time: ts}
+}
+
+func init() {
+	err := event.RegisterTextCompactTemplate("io.nats.server.advisory.v1.server_shutdown", `{{ .Time | ShortTime }} [Server Shutdown] {{ .Server.Name }}{{ if .Server.Cluster }} in cluster {{ .Server.Cluster }}{{ end }} version {{ .Server.Version }}`)
+	if err != nil {
+		panic(err)
+	}
+
+	err = event.RegisterTextExtendedTemplate("io.nats.server.advisory.v1.server_shutdown", `
+[{{ .Time | ShortTime }}] [{{ .ID }}] Server Shutdown
+
+     Server: {{ .Server.Name }}
+         ID: {{ .Server.ID }}
+       Host: {{ .Server.Host }}
+{{- if .Server.Cluster }}
+    Cluster: {{ .Server.Cluster }}
+{{- end }}
+{{- if .Server.Domain }}
+     Domain: {{ .Server.Domain }}
+{{- end }}
+    Version: {{ .Server.Version }}
+  JetStream: {{ .Server.JetStream }}
+{{- if .Server.Tags }}
+       Tags: {{ .Server.Tags | JoinStrings }}
+{{- end }}`)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,65 @@
+package advisory
+
+import (
+	"github.com/nats-io/jsm.go/api/event"
+)
+
+// SlowConsumerEventMsgV1 is sent when the server detects a slow consumer and
+// discards messages destined for it.
+//
+// NATS Schema Type io.nats.server.advisory.v1.slow_consumer
+type SlowConsumerEventMsgV1 struct {
+	event.NATSEvent
+
+	Server         ServerInfoV1 `json:"server"`
+	Client         ClientInfoV1 `json:"client"`
+	Kind           string       `json:"kind"`
+	Subject        string       `json:"subject,omitempty"`
+	PendingBytes   int64        `json:"pending_bytes,omitempty"`
+	DiscardedMsgs  int64        `json:"discarded_msgs,omitempty"`
+	DiscardedBytes int64        `json:"discarded_bytes,omitempty"`
+}
+
+func init() {
+	err := event.RegisterTextCompactTemplate("io.nats.server.advisory.v1.slow_consumer", `{{ .Time | ShortTime }} [Slow Consumer] {{ .Kind }} {{ if .Client.User }}user: {{ .Client.User }} {{ end }}cid: {{ .Client.ID }} in account {{ .Client.Account }}{{ if .Subject }} on {{ .Subject }}{{ end }} discarded {{ .DiscardedMsgs | Int64Commas }} messages ({{ .DiscardedBytes | IBytes }})`)
+	if err != nil {
+		panic(err)
+	}
+
+	err = event.RegisterTextExtendedTemplate("io.nats.server.advisory.v1.slow_consumer", `
+[{{ .Time | ShortTime }}] [{{ .ID }}] Slow Consumer Detected
+
+         Server: {{ .Server.Name }}
+{{- if .Server.Cluster }}
+        Cluster: {{ .Server.Cluster }}
+{{- end }}
+           Kind: {{ .Kind }}
+{{- if .Subject }}
+        Subject: {{ .Subject }}
+{{- end }}
+
+   Connection:
+                 ID: {{ .Client.ID }}
+{{- if .Client.User }}
+               User: {{ .Client.User }}
+{{- end }}
+{{- if .Client.Name }}
+               Name: {{ .Client.Name }}
+{{- end }}
+            Account: {{ .Client.Account }}
+{{- if .Client.Host }}
+               Host: {{ .Client.Host }}
+{{- end }}
+{{- if .Client.Version }}
+    Library Version: {{ .Client.Version }}  Language: {{ with .Client.Lang }}{{ . }}{{ else }}Unknown{{ end }}
+{{- end }}
+                RTT: {{ .Client.RTT }}
+
+   Discarded:
+      Pending Bytes: {{ .PendingBytes | IBytes }}
+           Messages: {{ .DiscardedMsgs | Int64Commas }}
+              Bytes: {{ .DiscardedBytes | IBytes }}`)
+	if err != nil {
+		panic(err)
+	}
+}
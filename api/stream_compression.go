@@ -0,0 +1,36 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+)
+
+// ValidateCompression checks that the compression algorithm is known and that it is only set on file
+// backed streams, the server accepts compression on memory streams but does not apply it
+func (c StreamConfig) ValidateCompression() error {
+	switch c.Compression {
+	case NoCompression:
+		return nil
+	case S2Compression:
+	default:
+		return fmt.Errorf("unknown compression algorithm %d", c.Compression)
+	}
+
+	if c.Storage != FileStorage {
+		return fmt.Errorf("compression is only supported for file storage")
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"testing"
+)
+
+func TestStreamConfig_ValidateCompression(t *testing.T) {
+	cases := []struct {
+		cfg   StreamConfig
+		valid bool
+	}{
+		{StreamConfig{Storage: FileStorage}, true},
+		{StreamConfig{Storage: MemoryStorage}, true},
+		{StreamConfig{Storage: FileStorage, Compression: S2Compression}, true},
+		{StreamConfig{Storage: MemoryStorage, Compression: S2Compression}, false},
+		{StreamConfig{Storage: FileStorage, Compression: Compression(10)}, false},
+	}
+
+	for i, c := range cases {
+		err := c.cfg.ValidateCompression()
+		if c.valid && err != nil {
+			t.Fatalf("case %d: expected valid: %v", i, err)
+		}
+		if !c.valid && err == nil {
+			t.Fatalf("case %d: expected invalid", i)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// StreamConfigChangeKind classifies how a change to a stream configuration can be applied
+type StreamConfigChangeKind int
+
+const (
+	// StreamChangeUpdatable can be applied to an existing stream using a stream update
+	StreamChangeUpdatable StreamConfigChangeKind = iota
+	// StreamChangeRequiresRecreate can only be applied by removing and recreating the stream
+	StreamChangeRequiresRecreate
+	// StreamChangeServerManaged is a value maintained by the server that should not be managed by users
+	StreamChangeServerManaged
+)
+
+func (k StreamConfigChangeKind) String() string {
+	switch k {
+	case StreamChangeUpdatable:
+		return "updatable in place"
+	case StreamChangeRequiresRecreate:
+		return "requires recreate"
+	case StreamChangeServerManaged:
+		return "server-managed"
+	default:
+		return "unknown"
+	}
+}
+
+// StreamConfigChange is a single difference between two stream configurations
+type StreamConfigChange struct {
+	// Field is the JSON name of the field that changed, metadata keys are given as metadata.key
+	Field string
+	// Old is the value in the original configuration
+	Old any
+	// New is the value in the desired configuration
+	New any
+	// Kind classifies how the change can be applied
+	Kind StreamConfigChangeKind
+	// Reason explains why a change can not be applied in place
+	Reason string
+}
+
+// StreamConfigDiff is a list of changes between two stream configurations
+type StreamConfigDiff []StreamConfigChange
+
+// RequiresRecreate indicates that at least one change can not be applied to an existing stream
+func (d StreamConfigDiff) RequiresRecreate() bool {
+	for _, c := range d {
+		if c.Kind == StreamChangeRequiresRecreate {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Updatable is the list of changes that can be applied using a stream update
+func (d StreamConfigDiff) Updatable() StreamConfigDiff {
+	return d.ofKind(StreamChangeUpdatable)
+}
+
+// Recreate is the list of changes that requires the stream to be recreated
+func (d StreamConfigDiff) Recreate() StreamConfigDiff {
+	return d.ofKind(StreamChangeRequiresRecreate)
+}
+
+// Drift is the list of changes excluding those to values maintained by the server
+func (d StreamConfigDiff) Drift() StreamConfigDiff {
+	var res StreamConfigDiff
+	for _, c := range d {
+		if c.Kind != StreamChangeServerManaged {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+func (d StreamConfigDiff) ofKind(k StreamConfigChangeKind) StreamConfigDiff {
+	var res StreamConfigDiff
+	for _, c := range d {
+		if c.Kind == k {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+// DiffStreamConfig compares the configuration of an existing stream with a desired configuration and classifies
+// each difference based on the rules the JetStream server applies when updating streams
+func DiffStreamConfig(current StreamConfig, desired StreamConfig) StreamConfigDiff {
+	var diff StreamConfigDiff
+
+	cv := reflect.ValueOf(current)
+	dv := reflect.ValueOf(desired)
+	t := cv.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+
+		if field.Name == "Metadata" {
+			diff = append(diff, diffStreamMetadata(current.Metadata, desired.Metadata)...)
+			continue
+		}
+
+		o := cv.Field(i)
+		n := dv.Field(i)
+		if isEmptyValue(o) && isEmptyValue(n) {
+			continue
+		}
+		if reflect.DeepEqual(o.Interface(), n.Interface()) {
+			continue
+		}
+
+		kind, reason := classifyStreamChange(field.Name, current, desired)
+		diff = append(diff, StreamConfigChange{
+			Field:  name,
+			Old:    o.Interface(),
+			New:    n.Interface(),
+			Kind:   kind,
+			Reason: reason,
+		})
+	}
+
+	return diff
+}
+
+func classifyStreamChange(field string, current StreamConfig, desired StreamConfig) (StreamConfigChangeKind, string) {
+	switch field {
+	case "Name":
+		return StreamChangeRequiresRecreate, "stream name can not be changed"
+	case "MaxConsumers":
+		return StreamChangeRequiresRecreate, "maximum consumers can not be changed"
+	case "Storage":
+		return StreamChangeRequiresRecreate, "storage type can not be changed"
+	case "Template":
+		return StreamChangeRequiresRecreate, "template ownership can not be changed"
+	case "AllowMsgCounter":
+		return StreamChangeRequiresRecreate, "message counter setting can not be changed"
+	case "PersistMode":
+		return StreamChangeRequiresRecreate, "persist mode can not be changed"
+	case "FirstSeq":
+		return StreamChangeRequiresRecreate, "first sequence is only applied when the stream is created"
+	case "Retention":
+		if current.Retention == WorkQueuePolicy || desired.Retention == WorkQueuePolicy {
+			return StreamChangeRequiresRecreate, "retention policy can not be changed to or from work queue"
+		}
+	case "Mirror":
+		if desired.Mirror != nil {
+			return StreamChangeRequiresRecreate, "mirror configuration can not be changed"
+		}
+	case "Sealed":
+		if current.Sealed {
+			return StreamChangeRequiresRecreate, "sealed streams can not be unsealed"
+		}
+	case "DenyDelete":
+		if current.DenyDelete {
+			return StreamChangeRequiresRecreate, "deny delete can not be disabled"
+		}
+	case "DenyPurge":
+		if current.DenyPurge {
+			return StreamChangeRequiresRecreate, "deny purge can not be disabled"
+		}
+	case "AllowMsgTTL":
+		if current.AllowMsgTTL {
+			return StreamChangeRequiresRecreate, "message TTLs can not be disabled"
+		}
+	case "AllowMsgSchedules":
+		if current.AllowMsgSchedules {
+			return StreamChangeRequiresRecreate, "message schedules can not be disabled"
+		}
+	}
+
+	return StreamChangeUpdatable, ""
+}
+
+func diffStreamMetadata(current map[string]string, desired map[string]string) StreamConfigDiff {
+	keys := map[string]struct{}{}
+	for k := range current {
+		keys[k] = struct{}{}
+	}
+	for k := range desired {
+		keys[k] = struct{}{}
+	}
+
+	var sorted []string
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var diff StreamConfigDiff
+	for _, k := range sorted {
+		o, ook := current[k]
+		n, nok := desired[k]
+		if ook == nok && o == n {
+			continue
+		}
+
+		change := StreamConfigChange{Field: "metadata." + k, Kind: StreamChangeUpdatable}
+		if ook {
+			change.Old = o
+		}
+		if nok {
+			change.New = n
+		}
+		if IsServerMetadataKey(k) {
+			change.Kind = StreamChangeServerManaged
+			change.Reason = "metadata is maintained by the server"
+		}
+
+		diff = append(diff, change)
+	}
+
+	return diff
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
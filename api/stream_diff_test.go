@@ -0,0 +1,92 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffStreamConfig(t *testing.T) {
+	current := StreamConfig{
+		Name:       "ORDERS",
+		Subjects:   []string{"ORDERS.*"},
+		Retention:  LimitsPolicy,
+		Storage:    FileStorage,
+		MaxAge:     time.Hour,
+		DenyDelete: true,
+		Metadata:   map[string]string{JSMetaCurrentServerVersion: "2.11.0", "owner": "a"},
+	}
+
+	diff := DiffStreamConfig(current, current)
+	if len(diff) != 0 {
+		t.Fatalf("expected no differences got %+v", diff)
+	}
+
+	empty := current
+	empty.Sources = []*StreamSource{}
+	if len(DiffStreamConfig(current, empty)) != 0 {
+		t.Fatalf("expected nil and empty slices to be equal")
+	}
+
+	desired := current
+	desired.MaxAge = 2 * time.Hour
+	desired.Metadata = map[string]string{"owner": "b"}
+
+	diff = DiffStreamConfig(current, desired)
+	if diff.RequiresRecreate() {
+		t.Fatalf("did not expect a recreate: %+v", diff)
+	}
+	if len(diff) != 3 {
+		t.Fatalf("expected 3 changes got %+v", diff)
+	}
+	if diff[0].Field != "max_age" || diff[0].Kind != StreamChangeUpdatable {
+		t.Fatalf("invalid max age change: %+v", diff[0])
+	}
+	if diff[1].Field != "metadata."+JSMetaCurrentServerVersion || diff[1].Kind != StreamChangeServerManaged {
+		t.Fatalf("invalid server metadata change: %+v", diff[1])
+	}
+	if diff[2].Field != "metadata.owner" || diff[2].Old != "a" || diff[2].New != "b" {
+		t.Fatalf("invalid metadata change: %+v", diff[2])
+	}
+
+	drift := diff.Drift()
+	if len(drift) != 2 || drift[0].Field != "max_age" || drift[1].Field != "metadata.owner" {
+		t.Fatalf("invalid drift: %+v", drift)
+	}
+
+	desired = current
+	desired.Metadata = current.UserMetadata()
+	if drift := DiffStreamConfig(current, desired).Drift(); len(drift) != 0 {
+		t.Fatalf("expected no drift got %+v", drift)
+	}
+
+	desired = current
+	desired.Storage = MemoryStorage
+	desired.DenyDelete = false
+	desired.Retention = InterestPolicy
+
+	diff = DiffStreamConfig(current, desired)
+	if !diff.RequiresRecreate() {
+		t.Fatalf("expected a recreate")
+	}
+	if len(diff.Recreate()) != 2 || len(diff.Updatable()) != 1 {
+		t.Fatalf("expected 2 recreate and 1 updatable changes got %+v", diff)
+	}
+
+	desired.Retention = WorkQueuePolicy
+	if len(DiffStreamConfig(current, desired).Recreate()) != 3 {
+		t.Fatalf("expected work queue retention change to require recreate")
+	}
+}
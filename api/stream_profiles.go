@@ -0,0 +1,170 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// StreamProfileFunc produces a new StreamConfig for a profile, no name or subjects are set
+type StreamProfileFunc func() StreamConfig
+
+var (
+	streamProfiles = map[string]StreamProfileFunc{
+		"workqueue": StreamProfileWorkQueue,
+		"kv":        StreamProfileKVBacking,
+		"audit":     StreamProfileAuditLog,
+		"events":    StreamProfileEvents,
+	}
+	streamProfilesMu sync.Mutex
+)
+
+// StreamProfileWorkQueue is a profile for streams used as work queues.
+//
+// Messages are removed once acknowledged so storage only grows with the backlog, new messages are rejected when
+// full rather than silently discarding unprocessed work and a 2 minute duplicate window protects against retried
+// publishes. Work queues allow only one consumer per subject.
+func StreamProfileWorkQueue() StreamConfig {
+	return StreamConfig{
+		Retention:    WorkQueuePolicy,
+		Discard:      DiscardNew,
+		Storage:      FileStorage,
+		MaxConsumers: -1,
+		MaxMsgs:      -1,
+		MaxMsgsPer:   -1,
+		MaxBytes:     -1,
+		MaxAge:       0,
+		MaxMsgSize:   -1,
+		Replicas:     StreamDefaultReplicas,
+		Duplicates:   2 * time.Minute,
+	}
+}
+
+// StreamProfileKVBacking is a profile matching the settings used for Key-Value buckets.
+//
+// Only the latest value per subject is kept, rollups are allowed so buckets can be purged and direct get is
+// enabled for fast reads from any replica. Reads from replicas might be slightly out of date.
+func StreamProfileKVBacking() StreamConfig {
+	return StreamConfig{
+		Retention:     LimitsPolicy,
+		Discard:       DiscardNew,
+		Storage:       FileStorage,
+		MaxConsumers:  -1,
+		MaxMsgs:       -1,
+		MaxMsgsPer:    1,
+		MaxBytes:      -1,
+		MaxMsgSize:    -1,
+		Replicas:      StreamDefaultReplicas,
+		Duplicates:    2 * time.Minute,
+		RollupAllowed: true,
+		DenyDelete:    true,
+		AllowDirect:   true,
+	}
+}
+
+// StreamProfileAuditLog is a profile for tamper resistant audit trails.
+//
+// Messages are kept for 1 year and can not be deleted or purged through the API, new messages are rejected when
+// limits are reached rather than removing history. Data is compressed as audit logs are rarely read.
+func StreamProfileAuditLog() StreamConfig {
+	return StreamConfig{
+		Retention:    LimitsPolicy,
+		Discard:      DiscardNew,
+		Storage:      FileStorage,
+		MaxConsumers: -1,
+		MaxMsgs:      -1,
+		MaxMsgsPer:   -1,
+		MaxBytes:     -1,
+		MaxAge:       24 * 365 * time.Hour,
+		MaxMsgSize:   -1,
+		Replicas:     StreamDefaultReplicas,
+		Duplicates:   2 * time.Minute,
+		DenyDelete:   true,
+		DenyPurge:    true,
+		Compression:  S2Compression,
+	}
+}
+
+// StreamProfileEvents is a profile for high volume event streams.
+//
+// Events are kept for 7 days with the oldest discarded when limits are reached, favoring availability of new
+// data over retention of old data.
+func StreamProfileEvents() StreamConfig {
+	return StreamConfig{
+		Retention:    LimitsPolicy,
+		Discard:      DiscardOld,
+		Storage:      FileStorage,
+		MaxConsumers: -1,
+		MaxMsgs:      -1,
+		MaxMsgsPer:   -1,
+		MaxBytes:     -1,
+		MaxAge:       7 * 24 * time.Hour,
+		MaxMsgSize:   -1,
+		Replicas:     StreamDefaultReplicas,
+		Duplicates:   2 * time.Minute,
+	}
+}
+
+// RegisterStreamProfile adds a new named profile, existing profiles can not be replaced
+func RegisterStreamProfile(name string, profile StreamProfileFunc) error {
+	if name == "" {
+		return fmt.Errorf("profile name is required")
+	}
+	if profile == nil {
+		return fmt.Errorf("profile function is required")
+	}
+
+	streamProfilesMu.Lock()
+	defer streamProfilesMu.Unlock()
+
+	_, ok := streamProfiles[name]
+	if ok {
+		return fmt.Errorf("stream profile %q is already registered", name)
+	}
+
+	streamProfiles[name] = profile
+
+	return nil
+}
+
+// StreamProfile retrieves a new configuration for a named profile
+func StreamProfile(name string) (StreamConfig, error) {
+	streamProfilesMu.Lock()
+	profile, ok := streamProfiles[name]
+	streamProfilesMu.Unlock()
+
+	if !ok {
+		return StreamConfig{}, fmt.Errorf("unknown stream profile %q", name)
+	}
+
+	return profile(), nil
+}
+
+// StreamProfileNames is a sorted list of known profile names
+func StreamProfileNames() []string {
+	streamProfilesMu.Lock()
+	defer streamProfilesMu.Unlock()
+
+	var names []string
+	for k := range streamProfiles {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
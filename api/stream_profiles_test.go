@@ -0,0 +1,60 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStreamProfiles(t *testing.T) {
+	for _, name := range StreamProfileNames() {
+		cfg, err := StreamProfile(name)
+		checkErr(t, err, "profile failed")
+
+		cfg.Name = "TEST"
+		cfg.Subjects = []string{"test"}
+		ok, errs := cfg.Validate(nil)
+		if !ok {
+			t.Fatalf("profile %s is not valid: %v", name, errs)
+		}
+	}
+
+	_, err := StreamProfile("unknown")
+	if err == nil {
+		t.Fatalf("expected an error for unknown profile")
+	}
+
+	err = RegisterStreamProfile("workqueue", StreamProfileWorkQueue)
+	if err == nil {
+		t.Fatalf("expected an error replacing a profile")
+	}
+
+	err = RegisterStreamProfile("custom", func() StreamConfig {
+		cfg := StreamProfileEvents()
+		cfg.Replicas = 5
+		return cfg
+	})
+	checkErr(t, err, "register failed")
+
+	if !slices.Contains(StreamProfileNames(), "custom") {
+		t.Fatalf("expected custom profile to be registered")
+	}
+
+	cfg, err := StreamProfile("custom")
+	checkErr(t, err, "profile failed")
+	if cfg.Replicas != 5 {
+		t.Fatalf("expected 5 replicas got %d", cfg.Replicas)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/nats-io/jsm.go/subject"
+)
+
+// ValidateInteractions checks settings that are only valid in combination with others, including the rules a
+// work queue stream imposes on its consumers when those are given. All violations are returned, an empty result
+// means the configuration is valid
+func (c StreamConfig) ValidateInteractions(consumers ...ConsumerConfig) []ConfigViolation {
+	var violations []ConfigViolation
+	fail := func(field string, format string, a ...any) {
+		violations = append(violations, ConfigViolation{Field: field, Message: fmt.Sprintf(format, a...)})
+	}
+
+	if c.DiscardNewPer {
+		if c.Discard != DiscardNew {
+			fail("discard_new_per_subject", "requires the discard new policy")
+		}
+		if c.MaxMsgsPer <= 0 {
+			fail("discard_new_per_subject", "requires max_msgs_per_subject to be set")
+		}
+	}
+
+	if c.MaxAge > 0 && c.Duplicates > c.MaxAge {
+		fail("duplicate_window", "can not be larger than max_age")
+	}
+
+	if c.Mirror != nil {
+		for _, v := range []struct {
+			field string
+			set   bool
+		}{
+			{"subjects", len(c.Subjects) > 0},
+			{"sources", len(c.Sources) > 0},
+			{"first_seq", c.FirstSeq > 0},
+			{"allow_msg_counter", c.AllowMsgCounter},
+			{"allow_atomic", c.AllowAtomicPublish},
+			{"allow_msg_schedules", c.AllowMsgSchedules},
+			{"subject_delete_marker_ttl", c.SubjectDeleteMarkerTTL > 0},
+		} {
+			if v.set {
+				fail(v.field, "can not be set on a mirror")
+			}
+		}
+
+		if c.Mirror.FilterSubject != "" && len(c.Mirror.SubjectTransforms) > 0 {
+			fail("mirror", "filter_subject can not be combined with subject_transforms")
+		}
+	} else if c.MirrorDirect {
+		fail("mirror_direct", "requires a mirror")
+	}
+
+	if c.Sealed {
+		for _, v := range []struct {
+			field string
+			ok    bool
+		}{
+			{"deny_delete", c.DenyDelete},
+			{"deny_purge", c.DenyPurge},
+			{"allow_rollup_hdrs", !c.RollupAllowed},
+			{"discard", c.Discard == DiscardNew},
+			{"max_age", c.MaxAge == 0},
+		} {
+			if !v.ok {
+				fail(v.field, "is changed by the server on sealed streams")
+			}
+		}
+	}
+
+	if c.DenyPurge && c.RollupAllowed {
+		fail("allow_rollup_hdrs", "requires purging to be allowed")
+	}
+
+	if c.SubjectDeleteMarkerTTL > 0 {
+		if !c.AllowMsgTTL {
+			fail("subject_delete_marker_ttl", "requires allow_msg_ttl")
+		}
+		if !c.RollupAllowed {
+			fail("subject_delete_marker_ttl", "requires allow_rollup_hdrs")
+		}
+	}
+
+	if c.AllowMsgSchedules && !c.RollupAllowed {
+		fail("allow_msg_schedules", "requires allow_rollup_hdrs")
+	}
+
+	if c.AllowMsgCounter {
+		if c.Discard == DiscardNew {
+			fail("allow_msg_counter", "can not be combined with the discard new policy")
+		}
+		if c.AllowMsgTTL {
+			fail("allow_msg_counter", "can not be combined with allow_msg_ttl")
+		}
+		if c.Retention != LimitsPolicy {
+			fail("allow_msg_counter", "requires the limits retention policy")
+		}
+	}
+
+	if c.PersistMode == AsyncPersistMode {
+		if c.Storage != FileStorage {
+			fail("persist_mode", "async requires file storage")
+		}
+		if c.Replicas > 1 {
+			fail("persist_mode", "async is not supported on replicated streams")
+		}
+		if c.AllowAtomicPublish {
+			fail("persist_mode", "async can not be combined with allow_atomic")
+		}
+	}
+
+	c.validateConsumers(consumers, fail)
+
+	return violations
+}
+
+// validateConsumers checks the consumers against the retention policy of the stream
+func (c StreamConfig) validateConsumers(consumers []ConsumerConfig, fail func(field string, format string, a ...any)) {
+	if c.Retention == LimitsPolicy {
+		return
+	}
+
+	filters := make([][]string, len(consumers))
+	unfiltered := 0
+
+	for i, cons := range consumers {
+		field := fmt.Sprintf("consumers[%d]", i)
+
+		if cons.Replicas != 0 && cons.Replicas != c.Replicas {
+			fail(field+".num_replicas", "must match the stream replicas on %s streams", c.Retention)
+		}
+
+		if c.Retention != WorkQueuePolicy || cons.Direct {
+			continue
+		}
+
+		if cons.AckPolicy != AckExplicit {
+			fail(field+".ack_policy", "must be explicit on work queue streams")
+		}
+		if cons.DeliverPolicy != DeliverAll {
+			fail(field+".deliver_policy", "must be all on work queue streams")
+		}
+
+		filters[i] = cons.FilterSubjects
+		if cons.FilterSubject != "" {
+			filters[i] = append([]string{cons.FilterSubject}, cons.FilterSubjects...)
+		}
+		if len(filters[i]) == 0 {
+			unfiltered++
+		}
+	}
+
+	if c.Retention != WorkQueuePolicy || len(consumers) < 2 {
+		return
+	}
+
+	if unfiltered > 0 {
+		fail("consumers", "work queue streams with multiple consumers require all consumers to be filtered")
+	}
+
+	for i := range filters {
+		for j := i + 1; j < len(filters); j++ {
+			for _, a := range filters[i] {
+				for _, b := range filters[j] {
+					if subject.IsValid(a) && subject.IsValid(b) && subject.Overlaps(a, b) {
+						fail(fmt.Sprintf("consumers[%d].filter_subjects", j), "%q overlaps %q of consumers[%d] on a work queue stream", b, a, i)
+					}
+				}
+			}
+		}
+	}
+}
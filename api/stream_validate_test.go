@@ -0,0 +1,89 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStreamConfigValidateInteractions(t *testing.T) {
+	cfg := StreamConfig{
+		Name:          "ORDERS",
+		Subjects:      []string{"orders.>"},
+		Retention:     WorkQueuePolicy,
+		Discard:       DiscardNew,
+		DiscardNewPer: true,
+		MaxMsgsPer:    10,
+		Replicas:      3,
+		MaxAge:        time.Hour,
+		Duplicates:    time.Minute,
+	}
+
+	consumers := []ConsumerConfig{
+		{AckPolicy: AckExplicit, DeliverPolicy: DeliverAll, FilterSubject: "orders.new"},
+		{AckPolicy: AckExplicit, DeliverPolicy: DeliverAll, FilterSubjects: []string{"orders.processed", "orders.shipped"}, Replicas: 3},
+	}
+	if v := cfg.ValidateInteractions(consumers...); len(v) != 0 {
+		t.Fatalf("expected no violations got %v", v)
+	}
+
+	consumers = append(consumers,
+		ConsumerConfig{AckPolicy: AckNone, DeliverPolicy: DeliverNew, FilterSubject: "orders.*", Replicas: 1},
+		ConsumerConfig{AckPolicy: AckExplicit, DeliverPolicy: DeliverAll},
+	)
+	cfg.MaxMsgsPer = 0
+	cfg.Discard = DiscardOld
+
+	expected := []ConfigViolation{
+		{"discard_new_per_subject", "requires the discard new policy"},
+		{"discard_new_per_subject", "requires max_msgs_per_subject to be set"},
+		{"consumers[2].num_replicas", "must match the stream replicas on WorkQueue streams"},
+		{"consumers[2].ack_policy", "must be explicit on work queue streams"},
+		{"consumers[2].deliver_policy", "must be all on work queue streams"},
+		{"consumers", "work queue streams with multiple consumers require all consumers to be filtered"},
+		{"consumers[2].filter_subjects", `"orders.*" overlaps "orders.new" of consumers[0] on a work queue stream`},
+		{"consumers[2].filter_subjects", `"orders.*" overlaps "orders.processed" of consumers[1] on a work queue stream`},
+		{"consumers[2].filter_subjects", `"orders.*" overlaps "orders.shipped" of consumers[1] on a work queue stream`},
+	}
+
+	violations := cfg.ValidateInteractions(consumers...)
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("expected %v got %v", expected, violations)
+	}
+
+	mirror := StreamConfig{
+		Name:          "MIRROR",
+		Subjects:      []string{"x"},
+		Mirror:        &StreamSource{Name: "ORDERS", FilterSubject: "orders.new", SubjectTransforms: []SubjectTransformConfig{{Source: "orders.>", Destination: "x.>"}}},
+		Sealed:        true,
+		DenyPurge:     true,
+		RollupAllowed: true,
+		Discard:       DiscardNew,
+	}
+
+	expected = []ConfigViolation{
+		{"subjects", "can not be set on a mirror"},
+		{"mirror", "filter_subject can not be combined with subject_transforms"},
+		{"deny_delete", "is changed by the server on sealed streams"},
+		{"allow_rollup_hdrs", "is changed by the server on sealed streams"},
+		{"allow_rollup_hdrs", "requires purging to be allowed"},
+	}
+
+	violations = mirror.ValidateInteractions()
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("expected %v got %v", expected, violations)
+	}
+}
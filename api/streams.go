@@ -171,6 +171,7 @@ type JSApiMsgDeleteResponse struct {
 type JSApiStreamCreateResponse struct {
 	JSApiResponse
 	*StreamInfo
+	DidCreate bool `json:"did_create,omitempty"`
 }
 
 // io.nats.jetstream.api.v1.stream_info_response
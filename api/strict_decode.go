@@ -0,0 +1,175 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownFieldsError indicates that a JSON document holds fields not known to the type it was decoded into,
+// typically because it was produced by a newer server than this package supports
+type UnknownFieldsError struct {
+	// Fields are the paths to the unknown fields like config.new_setting
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("unknown fields: %s", strings.Join(e.Fields, ", "))
+}
+
+// UnmarshalStrict decodes data into v like json.Unmarshal but fails with an *UnknownFieldsError listing all fields
+// in data that are not present in v. The known fields are decoded into v even when unknown ones are found
+func UnmarshalStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(v)
+	if err == nil {
+		return nil
+	}
+
+	if !strings.HasPrefix(err.Error(), "json: unknown field") {
+		return err
+	}
+
+	// decode again as the decoder stops reporting after the first unknown field
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return err
+	}
+
+	return &UnknownFieldsError{Fields: unknownJSONFields(data, reflect.TypeOf(v), "")}
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// unknownJSONFields finds the fields in data, found at path, that are not known to t
+func unknownJSONFields(data []byte, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	// custom unmarshalers decide what they accept
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return nil
+	}
+
+	var unknown []string
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return nil
+		}
+		for i, item := range items {
+			unknown = append(unknown, unknownJSONFields(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+
+	case reflect.Map:
+		var items map[string]json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return nil
+		}
+		for k, item := range items {
+			unknown = append(unknown, unknownJSONFields(item, t.Elem(), joinJSONPath(path, k))...)
+		}
+
+	case reflect.Struct:
+		var items map[string]json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return nil
+		}
+
+		fields := map[string]reflect.Type{}
+		collectJSONFields(t, fields)
+
+		for k, item := range items {
+			ft, ok := fields[k]
+			if !ok {
+				for name, nft := range fields {
+					if strings.EqualFold(name, k) {
+						ft, ok = nft, true
+						break
+					}
+				}
+			}
+
+			if !ok {
+				unknown = append(unknown, joinJSONPath(path, k))
+				continue
+			}
+
+			unknown = append(unknown, unknownJSONFields(item, ft, joinJSONPath(path, k))...)
+		}
+	}
+
+	sort.Strings(unknown)
+
+	return unknown
+}
+
+// collectJSONFields adds the JSON names and types of the fields of struct t, including those of embedded structs, to fields
+func collectJSONFields(t reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				collectJSONFields(ft, fields)
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		if _, ok := fields[name]; !ok {
+			fields[name] = f.Type
+		}
+	}
+}
+
+func joinJSONPath(path string, field string) string {
+	if path == "" {
+		return field
+	}
+
+	return path + "." + field
+}
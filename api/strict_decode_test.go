@@ -0,0 +1,51 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalStrict(t *testing.T) {
+	var resp JSApiStreamInfoResponse
+	err := UnmarshalStrict([]byte(`{"type":"io.nats.jetstream.api.v1.stream_info_response","config":{"name":"ORDERS","retention":"limits","new_setting":1,"sources":[{"name":"X","future":true}]},"state":{"messages":10},"cluster_v2":{}}`), &resp)
+
+	var ufe *UnknownFieldsError
+	if !errors.As(err, &ufe) {
+		t.Fatalf("expected an unknown fields error got %v", err)
+	}
+
+	expected := []string{"cluster_v2", "config.new_setting", "config.sources[0].future"}
+	if !reflect.DeepEqual(ufe.Fields, expected) {
+		t.Fatalf("expected %v got %v", expected, ufe.Fields)
+	}
+
+	if resp.Config.Name != "ORDERS" || resp.State.Msgs != 10 || resp.Config.Sources[0].Name != "X" {
+		t.Fatalf("expected known fields to be decoded: %+v", resp.StreamInfo)
+	}
+
+	resp = JSApiStreamInfoResponse{}
+	err = UnmarshalStrict([]byte(`{"type":"io.nats.jetstream.api.v1.stream_info_response","config":{"name":"ORDERS","retention":"workqueue"}}`), &resp)
+	checkErr(t, err, "unmarshal failed")
+	if resp.Config.Retention != WorkQueuePolicy {
+		t.Fatalf("expected work queue retention")
+	}
+
+	err = UnmarshalStrict([]byte(`{"config":{"retention":"bogus"}}`), &resp)
+	if err == nil || errors.As(err, &ufe) {
+		t.Fatalf("expected a decoding error got %v", err)
+	}
+}
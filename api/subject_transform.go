@@ -0,0 +1,70 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+// ValidateSubjectTransform checks that src and dest form a valid subject transform, an empty src matches all subjects
+func ValidateSubjectTransform(src string, dest string) error {
+	_, err := newSubjectTransform(src, dest)
+	return err
+}
+
+// TestSubjectTransform maps subject using the transform from src to dest using the same semantics as
+// JetStream and returns the resulting subject, an error is returned when the transform is invalid or
+// when subject does not match src
+func TestSubjectTransform(src string, dest string, subject string) (string, error) {
+	tr, err := newSubjectTransform(src, dest)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := tr.Match(subject)
+	switch {
+	case errors.Is(err, server.ErrNoTransforms):
+		return "", fmt.Errorf("subject %q does not match source %q", subject, src)
+	case err != nil:
+		return "", fmt.Errorf("invalid subject %q: %w", subject, err)
+	}
+
+	return res, nil
+}
+
+// Validate checks that the transform is valid
+func (c SubjectTransformConfig) Validate() error {
+	return ValidateSubjectTransform(c.Source, c.Destination)
+}
+
+// Transform maps subject using the transform
+func (c SubjectTransformConfig) Transform(subject string) (string, error) {
+	return TestSubjectTransform(c.Source, c.Destination, subject)
+}
+
+func newSubjectTransform(src string, dest string) (server.SubjectTransformer, error) {
+	if dest == "" {
+		return nil, fmt.Errorf("subject transform destination is required")
+	}
+
+	tr, err := server.NewSubjectTransform(src, dest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subject transform %q to %q: %w", src, dest, err)
+	}
+
+	return tr, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"testing"
+)
+
+func TestSubjectTransformMapping(t *testing.T) {
+	cases := []struct {
+		src, dest, subject, expect string
+	}{
+		{"orders.*.*", "out.{{wildcard(2)}}.{{wildcard(1)}}", "orders.eu.new", "out.new.eu"},
+		{"orders.*.*", "out.$2.$1", "orders.eu.new", "out.new.eu"},
+		{"orders.>", "archive.orders.>", "orders.eu.new", "archive.orders.eu.new"},
+		{"", "archive.>", "orders.eu", "archive.orders.eu"},
+		{"orders.*", "orders.{{partition(1,1)}}.{{wildcard(1)}}", "orders.eu", "orders.0.eu"},
+		{"orders.*", "orders.{{splitFromLeft(1,2)}}", "orders.abcd", "orders.ab.cd"},
+	}
+
+	for _, c := range cases {
+		res, err := TestSubjectTransform(c.src, c.dest, c.subject)
+		checkErr(t, err, "transform failed")
+		if res != c.expect {
+			t.Fatalf("%q > %q: expected %q got %q", c.src, c.dest, c.expect, res)
+		}
+	}
+
+	_, err := TestSubjectTransform("orders.*", "out.$1", "other.eu")
+	if err == nil {
+		t.Fatalf("expected error for non matching subject")
+	}
+
+	tr := SubjectTransformConfig{Source: "orders.*", Destination: "out.$1"}
+	res, err := tr.Transform("orders.eu")
+	checkErr(t, err, "transform failed")
+	if res != "out.eu" {
+		t.Fatalf("expected out.eu got %q", res)
+	}
+}
+
+func TestValidateSubjectTransform(t *testing.T) {
+	checkErr(t, ValidateSubjectTransform("orders.*", "out.{{wildcard(1)}}"), "valid transform failed")
+	checkErr(t, SubjectTransformConfig{Source: "orders.>", Destination: "out.>"}.Validate(), "valid transform failed")
+
+	for _, c := range [][2]string{
+		{"orders.*", ""},
+		{"orders.*", "out.$2"},
+		{"orders.>", "out.*"},
+		{"orders.*", "out.{{unknown(1)}}"},
+		{"orders..x", "out"},
+	} {
+		if ValidateSubjectTransform(c[0], c[1]) == nil {
+			t.Fatalf("expected %q > %q to be invalid", c[0], c[1])
+		}
+	}
+}
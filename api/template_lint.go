@@ -0,0 +1,87 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// TemplateProblem is a problem with the template of a schema type found by LintEventTemplates
+type TemplateProblem struct {
+	SchemaType string
+	Format     RenderFormat
+	Err        error
+}
+
+func (p TemplateProblem) Error() string {
+	return fmt.Sprintf("%s template for %s: %s", p.Format, p.SchemaType, p.Err)
+}
+
+// LintEventTemplates renders the text templates of every registered event schema type using an empty event,
+// reporting missing templates, references to fields the type does not have and other template errors
+func LintEventTemplates() []TemplateProblem {
+	var schemas []string
+	for schema := range schemaTypes {
+		schemas = append(schemas, schema)
+	}
+	sort.Strings(schemas)
+
+	var problems []TemplateProblem
+	for _, schema := range schemas {
+		msg, _ := NewMessage(schema)
+		e, ok := msg.(Event)
+		if !ok {
+			continue
+		}
+
+		problems = append(problems, lintEventTemplates(schema, e)...)
+	}
+
+	return problems
+}
+
+// LintEventTemplate renders the text templates of e, the type of e has to be set, see LintEventTemplates(). This
+// allows validating templates registered for event types not known to this package, typically in tests:
+//
+//	problems := api.LintEventTemplate(&MyEventV1{NATSEvent: event.NATSEvent{Type: "com.example.my_event.v1"}})
+func LintEventTemplate(e Event) []TemplateProblem {
+	return lintEventTemplates(e.EventType(), e)
+}
+
+func lintEventTemplates(schema string, e Event) []TemplateProblem {
+	// the type is set by unmarshalling to support any event embedding event.NATSEvent
+	err := json.Unmarshal(fmt.Appendf(nil, `{"type":%q}`, schema), e)
+	if err != nil {
+		return []TemplateProblem{{SchemaType: schema, Err: fmt.Errorf("could not create an empty event: %w", err)}}
+	}
+
+	var problems []TemplateProblem
+	for _, format := range []RenderFormat{TextCompactFormat, TextExtendedFormat} {
+		err = RenderEvent(io.Discard, e, format)
+		if err != nil && !isEmptyValueRenderError(err) {
+			problems = append(problems, TemplateProblem{SchemaType: schema, Format: format, Err: err})
+		}
+	}
+
+	return problems
+}
+
+// isEmptyValueRenderError indicates the error is caused by rendering an empty event rather than by the template
+func isEmptyValueRenderError(err error) bool {
+	return strings.Contains(err.Error(), "nil pointer evaluating")
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nats-io/jsm.go/api/event"
+)
+
+type lintTestEventV1 struct {
+	event.NATSEvent
+
+	Name string `json:"name"`
+}
+
+func TestLintEventTemplates(t *testing.T) {
+	for _, p := range LintEventTemplates() {
+		t.Errorf("template problem: %v", p)
+	}
+}
+
+func TestLintEventTemplate(t *testing.T) {
+	err := event.RegisterTextCompactTemplate("io.nats.test.v1.lint", `{{ .Name }} {{ .Missing }}`)
+	checkErr(t, err, "register failed")
+
+	problems := LintEventTemplate(&lintTestEventV1{NATSEvent: event.NATSEvent{Type: "io.nats.test.v1.lint"}})
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems got %v", problems)
+	}
+
+	if problems[0].Format != TextCompactFormat || !strings.Contains(problems[0].Error(), "can't evaluate field Missing") {
+		t.Fatalf("invalid compact problem: %v", problems[0])
+	}
+	if problems[1].Format != TextExtendedFormat || !strings.Contains(problems[1].Error(), "no template registered") {
+		t.Fatalf("invalid extended problem: %v", problems[1])
+	}
+}
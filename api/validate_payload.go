@@ -0,0 +1,330 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationError is a single violation of a JSON Schema found by ValidatePayload
+type ValidationError struct {
+	// Path is the JSON Pointer to the invalid value, empty for the document itself
+	Path string `json:"path"`
+	// Message describes the violation
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidatePayload validates data against the JSON Schema for schemaType, like io.nats.jetstream.api.v1.stream_create_request,
+// without first unmarshalling it into the matching type. This allows for example a gateway to verify requests before
+// forwarding them to the JetStream API.
+//
+// Schemas not embedded in this package are fetched from the registry set using SetSchemaRegistry. The keywords
+// used in the NATS schemas are supported, unknown keywords are ignored
+func ValidatePayload(schemaType string, data []byte) ([]ValidationError, bool) {
+	schema, err := loadSchema(schemaType)
+	if err != nil {
+		return []ValidationError{{Message: fmt.Sprintf("could not load schema for %s: %v", schemaType, err)}}, false
+	}
+
+	var s map[string]any
+	err = decodeJSONNumbers(schema, &s)
+	if err != nil {
+		return []ValidationError{{Message: fmt.Sprintf("invalid schema for %s: %v", schemaType, err)}}, false
+	}
+
+	var doc any
+	err = decodeJSONNumbers(data, &doc)
+	if err != nil {
+		return []ValidationError{{Message: fmt.Sprintf("invalid JSON: %v", err)}}, false
+	}
+
+	errs := validateSchemaValue(s, doc, "")
+
+	return errs, len(errs) == 0
+}
+
+func decodeJSONNumbers(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	err := dec.Decode(v)
+	if err != nil {
+		return err
+	}
+
+	if dec.More() {
+		return fmt.Errorf("unexpected data after the JSON document")
+	}
+
+	return nil
+}
+
+// validateSchemaValue validates v found at path against schema s
+func validateSchemaValue(s map[string]any, v any, path string) []ValidationError {
+	var errs []ValidationError
+	fail := func(format string, a ...any) {
+		errs = append(errs, ValidationError{Path: path, Message: fmt.Sprintf(format, a...)})
+	}
+
+	if t, ok := s["type"]; ok {
+		var types []string
+		switch tt := t.(type) {
+		case string:
+			types = []string{tt}
+		case []any:
+			for _, i := range tt {
+				if ts, ok := i.(string); ok {
+					types = append(types, ts)
+				}
+			}
+		}
+
+		matched := false
+		for _, t := range types {
+			if jsonValueIsType(v, t) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("expected %s but got %s", strings.Join(types, " or "), jsonValueType(v))
+			return errs
+		}
+	}
+
+	if c, ok := s["const"]; ok && !jsonValuesEqual(c, v) {
+		fail("must be %v", c)
+	}
+
+	if e, ok := s["enum"].([]any); ok {
+		found := false
+		for _, ev := range e {
+			if jsonValuesEqual(ev, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("must be one of %v", e)
+		}
+	}
+
+	switch val := v.(type) {
+	case json.Number:
+		n, _ := new(big.Float).SetString(val.String())
+		if n == nil {
+			break
+		}
+		if min, ok := schemaNumber(s, "minimum"); ok && n.Cmp(min) < 0 {
+			fail("must be at least %s", s["minimum"])
+		}
+		if max, ok := schemaNumber(s, "maximum"); ok && n.Cmp(max) > 0 {
+			fail("must be at most %s", s["maximum"])
+		}
+
+	case string:
+		l := utf8.RuneCountInString(val)
+		if min, ok := schemaNumber(s, "minLength"); ok && big.NewFloat(float64(l)).Cmp(min) < 0 {
+			fail("must be at least %s characters long", s["minLength"])
+		}
+		if max, ok := schemaNumber(s, "maxLength"); ok && big.NewFloat(float64(l)).Cmp(max) > 0 {
+			fail("must be at most %s characters long", s["maxLength"])
+		}
+		if p, ok := s["pattern"].(string); ok {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				fail("invalid pattern %q in schema: %v", p, err)
+			} else if !re.MatchString(val) {
+				fail("must match pattern %q", p)
+			}
+		}
+		if f, ok := s["format"].(string); ok && f == "date-time" {
+			_, err := time.Parse(time.RFC3339Nano, val)
+			if err != nil {
+				fail("must be a RFC3339 date-time")
+			}
+		}
+
+	case []any:
+		if items, ok := s["items"].(map[string]any); ok {
+			for i, item := range val {
+				errs = append(errs, validateSchemaValue(items, item, fmt.Sprintf("%s/%d", path, i))...)
+			}
+		}
+
+	case map[string]any:
+		if req, ok := s["required"].([]any); ok {
+			for _, r := range req {
+				if rs, ok := r.(string); ok {
+					if _, found := val[rs]; !found {
+						fail("missing required property %q", rs)
+					}
+				}
+			}
+		}
+
+		props, _ := s["properties"].(map[string]any)
+		patterns, _ := s["patternProperties"].(map[string]any)
+
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			ppath := path + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(k)
+			known := false
+
+			if ps, ok := props[k].(map[string]any); ok {
+				known = true
+				errs = append(errs, validateSchemaValue(ps, val[k], ppath)...)
+			}
+
+			for p, ps := range patterns {
+				re, err := regexp.Compile(p)
+				if err != nil || !re.MatchString(k) {
+					continue
+				}
+				known = true
+				if pss, ok := ps.(map[string]any); ok {
+					errs = append(errs, validateSchemaValue(pss, val[k], ppath)...)
+				}
+			}
+
+			if known {
+				continue
+			}
+
+			switch ap := s["additionalProperties"].(type) {
+			case bool:
+				if !ap {
+					errs = append(errs, ValidationError{Path: ppath, Message: "additional property is not allowed"})
+				}
+			case map[string]any:
+				errs = append(errs, validateSchemaValue(ap, val[k], ppath)...)
+			}
+		}
+	}
+
+	if all, ok := s["allOf"].([]any); ok {
+		for _, a := range all {
+			if as, ok := a.(map[string]any); ok {
+				errs = append(errs, validateSchemaValue(as, v, path)...)
+			}
+		}
+	}
+
+	for _, kw := range []string{"anyOf", "oneOf"} {
+		options, ok := s[kw].([]any)
+		if !ok {
+			continue
+		}
+
+		matched := 0
+		for _, o := range options {
+			if os, ok := o.(map[string]any); ok && len(validateSchemaValue(os, v, path)) == 0 {
+				matched++
+			}
+		}
+
+		switch {
+		case kw == "anyOf" && matched == 0:
+			fail("must match at least one schema in anyOf")
+		case kw == "oneOf" && matched != 1:
+			fail("must match exactly one schema in oneOf but matched %d", matched)
+		}
+	}
+
+	return errs
+}
+
+func schemaNumber(s map[string]any, keyword string) (*big.Float, bool) {
+	n, ok := s[keyword].(json.Number)
+	if !ok {
+		return nil, false
+	}
+
+	f, ok := new(big.Float).SetString(n.String())
+
+	return f, ok
+}
+
+func jsonValueType(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case json.Number:
+		if jsonValueIsType(val, "integer") {
+			return "integer"
+		}
+		return "number"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func jsonValueIsType(v any, t string) bool {
+	switch t {
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		f, ok := new(big.Float).SetString(n.String())
+		return ok && f.IsInt()
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	default:
+		return jsonValueType(v) == t
+	}
+}
+
+func jsonValuesEqual(a any, b any) bool {
+	an, aok := a.(json.Number)
+	bn, bok := b.(json.Number)
+	if aok && bok {
+		af, _ := new(big.Float).SetString(an.String())
+		bf, _ := new(big.Float).SetString(bn.String())
+		return af != nil && bf != nil && af.Cmp(bf) == 0
+	}
+
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestValidatePayload(t *testing.T) {
+	cfg := StreamConfig{
+		Name:         "ORDERS",
+		Subjects:     []string{"orders.>"},
+		Retention:    LimitsPolicy,
+		MaxConsumers: -1,
+		MaxMsgs:      -1,
+		MaxBytes:     -1,
+		MaxAge:       time.Hour,
+		MaxMsgSize:   -1,
+		MaxMsgsPer:   -1,
+		Storage:      FileStorage,
+		Replicas:     1,
+		Discard:      DiscardOld,
+		Duplicates:   time.Minute,
+	}
+	j, err := json.Marshal(cfg)
+	checkErr(t, err, "marshal failed")
+
+	errs, ok := ValidatePayload("io.nats.jetstream.api.v1.stream_create_request", j)
+	if !ok || len(errs) != 0 {
+		t.Fatalf("expected a valid payload: %v", errs)
+	}
+
+	errs, ok = ValidatePayload("io.nats.jetstream.api.v1.stream_create_request", []byte(`{"name":"ORDERS","retention":"bogus","max_age":-1,"subjects":"x","max_consumers":-1,"max_msgs":-1,"max_bytes":-1,"storage":"file","num_replicas":1,"extra":1}`))
+	if ok {
+		t.Fatalf("expected an invalid payload")
+	}
+
+	expected := []string{
+		"/extra: additional property is not allowed",
+		"/max_age: must be at least 0",
+		"/retention: must be one of [limits interest workqueue]",
+		"/subjects: expected array but got string",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors got %v", len(expected), errs)
+	}
+	for i, e := range errs {
+		if e.Error() != expected[i] {
+			t.Fatalf("expected %q got %q", expected[i], e.Error())
+		}
+	}
+
+	errs, ok = ValidatePayload("io.nats.jetstream.api.v1.stream_create_request", []byte(`{"name":"ORDERS"}`))
+	if ok || len(errs) != 7 || errs[0].Path != "" || errs[0].Message != `missing required property "retention"` {
+		t.Fatalf("expected required property errors: %v", errs)
+	}
+
+	errs, ok = ValidatePayload("io.nats.jetstream.api.v1.stream_create_request", []byte(`{"name":`))
+	if ok || len(errs) != 1 {
+		t.Fatalf("expected a JSON error: %v", errs)
+	}
+
+	errs, ok = ValidatePayload("io.nats.unknown", []byte(`{}`))
+	if ok || len(errs) != 1 {
+		t.Fatalf("expected a schema error: %v", errs)
+	}
+}
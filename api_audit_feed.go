@@ -0,0 +1,109 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/api/jetstream/advisory"
+	"github.com/nats-io/nats.go"
+)
+
+// APIAuditFeed subscribes to the JetStream API audit advisories published for every API access
+type APIAuditFeed struct {
+	handler    func(*advisory.JetStreamAPIAuditV1)
+	ch         chan<- *advisory.JetStreamAPIAuditV1
+	errHandler func(error)
+	sub        *nats.Subscription
+}
+
+// APIAuditFeedOption configures an APIAuditFeed
+type APIAuditFeedOption func(f *APIAuditFeed) error
+
+// APIAuditHandler calls cb for every audit advisory received
+func APIAuditHandler(cb func(*advisory.JetStreamAPIAuditV1)) APIAuditFeedOption {
+	return func(f *APIAuditFeed) error {
+		f.handler = cb
+		return nil
+	}
+}
+
+// APIAuditChannel delivers every audit advisory received to ch, advisories are dropped when ch is full
+func APIAuditChannel(ch chan<- *advisory.JetStreamAPIAuditV1) APIAuditFeedOption {
+	return func(f *APIAuditFeed) error {
+		f.ch = ch
+		return nil
+	}
+}
+
+// APIAuditErrorHandler calls cb with errors encountered while handling advisories
+func APIAuditErrorHandler(cb func(error)) APIAuditFeedOption {
+	return func(f *APIAuditFeed) error {
+		f.errHandler = cb
+		return nil
+	}
+}
+
+// APIAuditFeed subscribes to JetStream API audit advisories for the account, call Stop() to end the feed
+func (m *Manager) APIAuditFeed(opts ...APIAuditFeedOption) (*APIAuditFeed, error) {
+	f := &APIAuditFeed{}
+
+	for _, opt := range opts {
+		err := opt(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if f.handler == nil && f.ch == nil {
+		return nil, fmt.Errorf("a handler or channel is required")
+	}
+
+	var err error
+	f.sub, err = m.nc.Subscribe(EventSubject(api.JSAuditAdvisory, m.eventPrefix), f.handleMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *APIAuditFeed) handleMsg(m *nats.Msg) {
+	var audit advisory.JetStreamAPIAuditV1
+	err := json.Unmarshal(m.Data, &audit)
+	if err != nil {
+		if f.errHandler != nil {
+			f.errHandler(fmt.Errorf("invalid advisory received on %s: %w", m.Subject, err))
+		}
+		return
+	}
+
+	if f.handler != nil {
+		f.handler(&audit)
+	}
+
+	if f.ch != nil {
+		select {
+		case f.ch <- &audit:
+		default:
+		}
+	}
+}
+
+// Stop unsubscribes from the audit advisories
+func (f *APIAuditFeed) Stop() error {
+	return f.sub.Unsubscribe()
+}
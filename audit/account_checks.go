@@ -14,39 +14,78 @@
 package audit
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/jsm.go/audit/archive"
+	"github.com/nats-io/jwt/v2"
 	"github.com/nats-io/nats-server/v2/server"
 )
 
 func RegisterAccountChecks(collection *CheckCollection) error {
-	return collection.Register(Check{
-		Code:        "ACCOUNTS_001",
-		Suite:       "accounts",
-		Name:        "Account Limits",
-		Description: "Account usage is below the configured limits",
-		Configuration: map[string]*CheckConfiguration{
-			"connections": {
-				Key:         "connections",
-				Description: "Alerting threshold as a fraction of configured connections limit",
-				Unit:        PercentageUnit,
-				Default:     90,
+	return collection.Register(
+		Check{
+			Code:        "ACCOUNTS_001",
+			Suite:       "accounts",
+			Name:        "Account Limits",
+			Description: "Account usage is below the configured limits",
+			Tags:        []string{"capacity"},
+			Configuration: map[string]*CheckConfiguration{
+				"connections": {
+					Key:         "connections",
+					Description: "Alerting threshold as a fraction of configured connections limit",
+					Unit:        PercentageUnit,
+					Default:     90,
+				},
+				"subscriptions": {
+					Key:         "subscriptions",
+					Description: "Alerting threshold as a fraction of configured subscriptions limit",
+					Unit:        PercentageUnit,
+					Default:     90,
+				},
 			},
-			"subscriptions": {
-				Key:         "subscriptions",
-				Description: "Alerting threshold as a fraction of configured subscriptions limit",
-				Unit:        PercentageUnit,
-				Default:     90,
+			Handler: checkAccountLimits,
+		},
+		Check{
+			Code:        "ACCOUNTS_002",
+			Suite:       "accounts",
+			Name:        "Account JetStream Limits",
+			Description: "Account JetStream usage is below the configured limits",
+			Tags:        []string{"capacity"},
+			Configuration: map[string]*CheckConfiguration{
+				"memory": {
+					Key:         "memory",
+					Description: "Alerting threshold as a fraction of configured memory storage limit",
+					Unit:        PercentageUnit,
+					Default:     90,
+				},
+				"storage": {
+					Key:         "storage",
+					Description: "Alerting threshold as a fraction of configured disk storage limit",
+					Unit:        PercentageUnit,
+					Default:     90,
+				},
+				"streams": {
+					Key:         "streams",
+					Description: "Alerting threshold as a fraction of configured streams limit",
+					Unit:        PercentageUnit,
+					Default:     90,
+				},
+				"consumers": {
+					Key:         "consumers",
+					Description: "Alerting threshold as a fraction of configured consumers limit",
+					Unit:        PercentageUnit,
+					Default:     90,
+				},
 			},
+			Handler: checkAccountJetStreamLimits,
 		},
-		Handler: checkAccountLimits,
-	})
+	)
 }
 
 // checkAccountLimits verifies that the number of connections & subscriptions is not approaching the limit set for the account
-func checkAccountLimits(check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkAccountLimits(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	connectionsThreshold := check.Configuration["connections"].Value()
 	subscriptionsThreshold := check.Configuration["subscriptions"].Value()
 
@@ -71,6 +110,10 @@ func checkAccountLimits(check *Check, r *archive.Reader, examples *ExamplesColle
 			serverTag := archive.TagServer(serverName)
 
 			for _, accountName := range r.AccountNames() {
+				if err := ctx.Err(); err != nil {
+					return Skipped, err
+				}
+
 				accountTag := archive.TagAccount(accountName)
 
 				err := archive.ForEachTaggedArtifact(r, []*archive.Tag{clusterTag, serverTag, accountTag, archive.TagAccountInfo()}, func(ai *server.AccountInfo) error {
@@ -140,3 +183,139 @@ func checkAccountLimits(check *Check, r *archive.Reader, examples *ExamplesColle
 
 	return Pass, nil
 }
+
+// accountJetStreamClaim finds the JetStream limits of an account from the account information gathered from any server
+func accountJetStreamClaim(r *archive.Reader, accountName string) (*jwt.JetStreamLimits, jwt.JetStreamTieredLimits, bool) {
+	accountTag := archive.TagAccount(accountName)
+
+	for _, clusterName := range r.ClusterNames() {
+		clusterTag := archive.TagCluster(clusterName)
+
+		for _, serverName := range r.ClusterServerNames(clusterName) {
+			serverTag := archive.TagServer(serverName)
+
+			var ai server.AccountInfo
+			err := r.Load(&ai, clusterTag, serverTag, accountTag, archive.TagAccountInfo())
+			if err != nil || ai.Claim == nil {
+				continue
+			}
+
+			return &ai.Claim.Limits.JetStreamLimits, ai.Claim.Limits.JetStreamTieredLimits, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// checkAccountJetStreamLimits verifies that the JetStream usage of each account, calculated from its streams, is not approaching
+// the limits in its JWT, tiered limits are compared against the usage of streams with the matching replica count
+func checkAccountJetStreamLimits(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	memoryThreshold := check.Configuration["memory"].Value()
+	storageThreshold := check.Configuration["storage"].Value()
+	streamsThreshold := check.Configuration["streams"].Value()
+	consumersThreshold := check.Configuration["consumers"].Value()
+
+	type usage struct {
+		memory    int64
+		storage   int64
+		streams   int64
+		consumers int64
+	}
+
+	// Check value against limit threshold, create example if exceeded
+	checkLimit := func(limitName, accountName, tier string, value, limit int64, percentThreshold float64) {
+		if limit <= 0 {
+			// Limit not set
+			return
+		}
+
+		threshold := int64(float64(limit) * (percentThreshold / 100))
+		if value > threshold {
+			examples.Add("account %s%s using %.1f%% of JetStream %s limit (%d/%d)", accountName, tier, float64(value)*100/float64(limit), limitName, value, limit)
+		}
+	}
+
+	streamDetailsTag := archive.TagStreamInfo()
+
+	for _, accountName := range r.AccountNames() {
+		limits, tiers, ok := accountJetStreamClaim(r, accountName)
+		if !ok {
+			log.Debugf("No JWT claim found for account %s", accountName)
+			continue
+		}
+
+		accountTag := archive.TagAccount(accountName)
+		tierUsage := make(map[string]*usage)
+		total := &usage{}
+
+		for _, streamName := range r.AccountStreamNames(accountName) {
+			streamTag := archive.TagStream(streamName)
+
+			for _, serverName := range r.StreamServerNames(accountName, streamName) {
+				if err := ctx.Err(); err != nil {
+					return Skipped, err
+				}
+
+				serverTag := archive.TagServer(serverName)
+
+				err := archive.ForEachTaggedArtifact(r, []*archive.Tag{accountTag, streamTag, serverTag, streamDetailsTag}, func(streamDetails *api.StreamInfo) error {
+					if streamDetails.Cluster != nil && streamDetails.Cluster.Leader != "" && streamDetails.Cluster.Leader != serverName {
+						return nil
+					}
+
+					replicas := max(streamDetails.Config.Replicas, 1)
+					tier := fmt.Sprintf("R%d", replicas)
+					if tierUsage[tier] == nil {
+						tierUsage[tier] = &usage{}
+					}
+
+					// usage is accounted for every replica
+					size := int64(streamDetails.State.Bytes) * int64(replicas)
+					for _, u := range []*usage{total, tierUsage[tier]} {
+						if streamDetails.Config.Storage == api.MemoryStorage {
+							u.memory += size
+						} else {
+							u.storage += size
+						}
+						u.streams++
+						u.consumers += int64(streamDetails.State.Consumers)
+					}
+
+					return nil
+				})
+				if err != nil {
+					log.Warnf("Artifact 'STREAM_DETAILS' is missing for stream %s in account %s", streamName, accountName)
+					continue
+				}
+			}
+		}
+
+		compare := func(tier string, u *usage, l jwt.JetStreamLimits) {
+			checkLimit("memory", accountName, tier, u.memory, l.MemoryStorage, memoryThreshold)
+			checkLimit("storage", accountName, tier, u.storage, l.DiskStorage, storageThreshold)
+			checkLimit("streams", accountName, tier, u.streams, l.Streams, streamsThreshold)
+			checkLimit("consumers", accountName, tier, u.consumers, l.Consumer, consumersThreshold)
+		}
+
+		if len(tiers) == 0 {
+			compare("", total, *limits)
+			continue
+		}
+
+		for tier, l := range tiers {
+			u, ok := tierUsage[tier]
+			if !ok {
+				continue
+			}
+
+			compare(fmt.Sprintf(" tier %s", tier), u, l)
+		}
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d instances of accounts approaching JetStream limits", examples.Count())
+		return Fail, nil
+	}
+
+	return Pass, nil
+}
@@ -1,6 +1,7 @@
 package audit
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 
@@ -55,7 +56,7 @@ func setupAccountCheck(t *testing.T, checkid string, accountz *server.ServerAPIA
 	}
 
 	examples := newExamplesCollection(0)
-	result, err := check.Handler(check, reader, examples, api.NewDefaultLogger(api.ErrorLevel))
+	result, err := check.Handler(context.Background(), check, reader, examples, api.NewDefaultLogger(api.ErrorLevel))
 	if err != nil {
 		t.Fatalf("check handler failed: %v", err)
 	}
@@ -138,3 +139,63 @@ func TestACCOUNTS_001(t *testing.T) {
 		}
 	})
 }
+
+func TestACCOUNTS_002(t *testing.T) {
+	setup := func(t *testing.T, limits jwt.OperatorLimits, streams ...*api.StreamInfo) Outcome {
+		result, _ := runArchiveCheck(t, RegisterAccountChecks, "ACCOUNTS_002", func(w *archive.Writer) error {
+			info := &server.AccountInfo{
+				AccountName: "A",
+				Claim:       &jwt.AccountClaims{Account: jwt.Account{Limits: limits}},
+			}
+
+			err := w.Add(info, archive.TagCluster("C1"), archive.TagServer("N1"), archive.TagAccount("A"), archive.TagAccountInfo())
+			if err != nil {
+				return err
+			}
+
+			for _, stream := range streams {
+				err = w.Add(stream, archive.TagAccount("A"), archive.TagStream(stream.Config.Name), archive.TagServer("N1"), archive.TagCluster("C1"), archive.TagStreamInfo())
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+		return result
+	}
+
+	t.Run("Should fail when storage is near the limit", func(t *testing.T) {
+		result := setup(t, jwt.OperatorLimits{JetStreamLimits: jwt.JetStreamLimits{DiskStorage: 1000}},
+			&api.StreamInfo{Config: api.StreamConfig{Name: "S1", Storage: api.FileStorage, Replicas: 1}, State: api.StreamState{Bytes: 600}},
+			&api.StreamInfo{Config: api.StreamConfig{Name: "S2", Storage: api.FileStorage, Replicas: 1}, State: api.StreamState{Bytes: 350}},
+		)
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should fail when a tier is near its limit", func(t *testing.T) {
+		result := setup(t, jwt.OperatorLimits{JetStreamTieredLimits: jwt.JetStreamTieredLimits{
+			"R1": {DiskStorage: 10000, Streams: 10},
+			"R3": {DiskStorage: 1000, Streams: 10},
+		}},
+			&api.StreamInfo{Config: api.StreamConfig{Name: "S1", Storage: api.FileStorage, Replicas: 1}, State: api.StreamState{Bytes: 600}},
+			&api.StreamInfo{Config: api.StreamConfig{Name: "S2", Storage: api.FileStorage, Replicas: 3}, State: api.StreamState{Bytes: 350}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+		)
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should pass when usage is below limits", func(t *testing.T) {
+		result := setup(t, jwt.OperatorLimits{JetStreamLimits: jwt.JetStreamLimits{DiskStorage: 10000, MemoryStorage: 1000, Streams: 10, Consumer: 10}},
+			&api.StreamInfo{Config: api.StreamConfig{Name: "S1", Storage: api.FileStorage, Replicas: 1}, State: api.StreamState{Bytes: 600, Consumers: 2}},
+			&api.StreamInfo{Config: api.StreamConfig{Name: "S2", Storage: api.MemoryStorage, Replicas: 1}, State: api.StreamState{Bytes: 350}},
+		)
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+}
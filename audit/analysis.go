@@ -35,6 +35,8 @@ type Analysis struct {
 	SkippedSuites []string              `json:"skipped_suites"`
 	Results       []CheckResult         `json:"checks"`
 	Outcomes      map[string]int        `json:"outcomes"`
+	Verdict       Outcome               `json:"verdict"`
+	VerdictString string                `json:"verdict_string"`
 }
 
 var MarkdownFormatTemplate = `# NATS Audit Report produced {{ .Timestamp | ft}}
@@ -45,6 +47,8 @@ Report generated using archive from **{{.Metadata.ConnectURL}}** by **{{.Metadat
 
 ## Report Summary
 
+Verdict: **{{ .VerdictString }}**
+
 |Status|Count|
 |------|-----|
 |FAIL|{{index .Outcomes "FAIL"}}|
@@ -60,7 +64,13 @@ Report generated using archive from **{{.Metadata.ConnectURL}}** by **{{.Metadat
 {{   range $results }}
 #### {{ .Check.Name }}
 
-Outcome: **{{ .OutcomeString }}**
+Outcome: **{{ .OutcomeString }}**{{ if .Severity }} Severity: **{{ .Severity }}**{{ end }}
+{{     if .Check.Remediation }}
+Remediation: {{ .Check.Remediation }}{{ if .Check.URL }} ([more information]({{ .Check.URL }})){{ end }}
+{{     end -}}
+{{     if .Examples.Error }}
+Skipped: {{ .Examples.Error }}
+{{     end -}}
 {{     if .Examples.Examples }}
 |Count|Example|
 |-----|-------|
@@ -88,6 +98,19 @@ func LoadAnalysis(path string) (*Analysis, error) {
 	return &analyzes, nil
 }
 
+// ExitCode is an exit status for the analysis based on its verdict, 0 when passing, 1 when only warnings of
+// warning or critical checks were found and 2 when a critical check failed
+func (a *Analysis) ExitCode() int {
+	switch a.Verdict {
+	case Fail:
+		return 2
+	case PassWithIssues:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // ToJSON renders the report in JSON format
 func (a *Analysis) ToJSON() ([]byte, error) {
 	return json.MarshalIndent(a, "", "   ")
@@ -0,0 +1,155 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// AnalysisDiff describes how the results of an analysis changed compared to an earlier analysis
+type AnalysisDiff struct {
+	Type         string    `json:"type"`
+	OldTimestamp time.Time `json:"old_time"`
+	NewTimestamp time.Time `json:"new_time"`
+	// Changed lists all checks present in both analyses with a different outcome
+	Changed []CheckDiff `json:"changed"`
+	// NewFailures lists checks that fail in the new analysis but did not fail in the old analysis
+	NewFailures []CheckDiff `json:"new_failures"`
+	// Resolved lists checks that failed or warned in the old analysis and pass in the new analysis
+	Resolved []CheckDiff `json:"resolved"`
+	// Added lists the codes of checks only found in the new analysis
+	Added []string `json:"added"`
+	// Removed lists the codes of checks only found in the old analysis
+	Removed []string `json:"removed"`
+}
+
+// CheckDiff describes how the result of a single check changed between analyses
+type CheckDiff struct {
+	Code             string  `json:"code"`
+	Suite            string  `json:"suite"`
+	Name             string  `json:"name"`
+	OldOutcome       Outcome `json:"old_outcome"`
+	OldOutcomeString string  `json:"old_outcome_string"`
+	NewOutcome       Outcome `json:"new_outcome"`
+	NewOutcomeString string  `json:"new_outcome_string"`
+	// NewExamples are examples found in the new analysis but not in the old analysis
+	NewExamples []string `json:"new_examples,omitempty"`
+	// ResolvedExamples are examples found in the old analysis but not in the new analysis
+	ResolvedExamples []string `json:"resolved_examples,omitempty"`
+}
+
+// DiffAnalyses compares the results of two analyses of the same system, oldAnalysis should be the earlier analysis
+func DiffAnalyses(oldAnalysis *Analysis, newAnalysis *Analysis) (*AnalysisDiff, error) {
+	if oldAnalysis == nil || newAnalysis == nil {
+		return nil, fmt.Errorf("two analyses are required")
+	}
+
+	diff := &AnalysisDiff{
+		Type:         "io.nats.audit.v1.analysis_diff",
+		OldTimestamp: oldAnalysis.Timestamp,
+		NewTimestamp: newAnalysis.Timestamp,
+		Changed:      []CheckDiff{},
+		NewFailures:  []CheckDiff{},
+		Resolved:     []CheckDiff{},
+		Added:        []string{},
+		Removed:      []string{},
+	}
+
+	oldResults := make(map[string]*CheckResult, len(oldAnalysis.Results))
+	for i := range oldAnalysis.Results {
+		oldResults[oldAnalysis.Results[i].Check.Code] = &oldAnalysis.Results[i]
+	}
+
+	newCodes := make(map[string]struct{}, len(newAnalysis.Results))
+
+	for i := range newAnalysis.Results {
+		nr := &newAnalysis.Results[i]
+		newCodes[nr.Check.Code] = struct{}{}
+
+		or, ok := oldResults[nr.Check.Code]
+		if !ok {
+			diff.Added = append(diff.Added, nr.Check.Code)
+			if nr.Outcome == Fail {
+				diff.NewFailures = append(diff.NewFailures, newCheckDiff(nil, nr))
+			}
+			continue
+		}
+
+		cd := newCheckDiff(or, nr)
+
+		if or.Outcome != nr.Outcome {
+			diff.Changed = append(diff.Changed, cd)
+		}
+
+		switch {
+		case nr.Outcome == Fail && or.Outcome != Fail:
+			diff.NewFailures = append(diff.NewFailures, cd)
+		case nr.Outcome == Pass && (or.Outcome == Fail || or.Outcome == PassWithIssues):
+			diff.Resolved = append(diff.Resolved, cd)
+		}
+	}
+
+	for _, or := range oldAnalysis.Results {
+		if _, ok := newCodes[or.Check.Code]; !ok {
+			diff.Removed = append(diff.Removed, or.Check.Code)
+		}
+	}
+
+	return diff, nil
+}
+
+func newCheckDiff(oldResult *CheckResult, newResult *CheckResult) CheckDiff {
+	cd := CheckDiff{
+		Code:             newResult.Check.Code,
+		Suite:            newResult.Check.Suite,
+		Name:             newResult.Check.Name,
+		OldOutcome:       Skipped,
+		NewOutcome:       newResult.Outcome,
+		NewOutcomeString: newResult.Outcome.String(),
+	}
+
+	var oldExamples []string
+	if oldResult != nil {
+		cd.OldOutcome = oldResult.Outcome
+		oldExamples = oldResult.Examples.Examples
+	}
+	cd.OldOutcomeString = cd.OldOutcome.String()
+
+	for _, e := range newResult.Examples.Examples {
+		if !slices.Contains(oldExamples, e) {
+			cd.NewExamples = append(cd.NewExamples, e)
+		}
+	}
+
+	for _, e := range oldExamples {
+		if !slices.Contains(newResult.Examples.Examples, e) {
+			cd.ResolvedExamples = append(cd.ResolvedExamples, e)
+		}
+	}
+
+	return cd
+}
+
+// HasRegressions determines if any check started failing
+func (d *AnalysisDiff) HasRegressions() bool {
+	return len(d.NewFailures) > 0
+}
+
+// ToJSON renders the diff in JSON format
+func (d *AnalysisDiff) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "   ")
+}
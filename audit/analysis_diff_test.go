@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func analysisWithResults(results ...CheckResult) *Analysis {
+	return &Analysis{Type: "io.nats.audit.v1.analysis", Results: results}
+}
+
+func resultFor(code string, outcome Outcome, examples ...string) CheckResult {
+	return CheckResult{
+		Check:         Check{Code: code, Suite: "test", Name: code},
+		Outcome:       outcome,
+		OutcomeString: outcome.String(),
+		Examples:      ExamplesCollection{Examples: examples},
+	}
+}
+
+func TestDiffAnalyses(t *testing.T) {
+	t.Run("Should require both analyses", func(t *testing.T) {
+		_, err := DiffAnalyses(nil, analysisWithResults())
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("Should report changes", func(t *testing.T) {
+		old := analysisWithResults(
+			resultFor("T_001", Pass),
+			resultFor("T_002", Fail, "a", "b"),
+			resultFor("T_003", PassWithIssues, "x"),
+			resultFor("T_004", Fail, "y"),
+			resultFor("T_005", Pass),
+		)
+		current := analysisWithResults(
+			resultFor("T_001", Fail, "c"),
+			resultFor("T_002", Pass),
+			resultFor("T_003", PassWithIssues, "x", "z"),
+			resultFor("T_004", Fail, "y"),
+			resultFor("T_006", Fail, "d"),
+		)
+
+		diff, err := DiffAnalyses(old, current)
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+
+		codes := func(diffs []CheckDiff) []string {
+			var res []string
+			for _, d := range diffs {
+				res = append(res, d.Code)
+			}
+			return res
+		}
+
+		assertCodes := func(name string, got []string, expected ...string) {
+			t.Helper()
+			if len(got) != len(expected) {
+				t.Fatalf("expected %s %v, got %v", name, expected, got)
+			}
+			for i := range got {
+				if got[i] != expected[i] {
+					t.Fatalf("expected %s %v, got %v", name, expected, got)
+				}
+			}
+		}
+
+		assertCodes("changed", codes(diff.Changed), "T_001", "T_002")
+		assertCodes("new failures", codes(diff.NewFailures), "T_001", "T_006")
+		assertCodes("resolved", codes(diff.Resolved), "T_002")
+		assertCodes("added", diff.Added, "T_006")
+		assertCodes("removed", diff.Removed, "T_005")
+
+		if !diff.HasRegressions() {
+			t.Fatalf("expected regressions")
+		}
+
+		if diff.Resolved[0].OldOutcomeString != "FAIL" || diff.Resolved[0].NewOutcomeString != "PASS" {
+			t.Fatalf("unexpected outcomes %+v", diff.Resolved[0])
+		}
+		assertCodes("resolved examples", diff.Resolved[0].ResolvedExamples, "a", "b")
+		assertCodes("new examples", diff.NewFailures[0].NewExamples, "c")
+
+		j, err := diff.ToJSON()
+		if err != nil {
+			t.Fatalf("json failed: %v", err)
+		}
+
+		var parsed AnalysisDiff
+		err = json.Unmarshal(j, &parsed)
+		if err != nil {
+			t.Fatalf("json parse failed: %v", err)
+		}
+		if parsed.Type != "io.nats.audit.v1.analysis_diff" || len(parsed.NewFailures) != 2 {
+			t.Fatalf("unexpected parsed diff: %+v", parsed)
+		}
+	})
+
+	t.Run("Should report no regressions for identical analyses", func(t *testing.T) {
+		a := analysisWithResults(resultFor("T_001", Fail, "a"), resultFor("T_002", Pass))
+		diff, err := DiffAnalyses(a, a)
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+
+		if diff.HasRegressions() || len(diff.Changed) > 0 || len(diff.Resolved) > 0 {
+			t.Fatalf("expected no changes: %+v", diff)
+		}
+	})
+}
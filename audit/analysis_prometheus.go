@@ -0,0 +1,101 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+)
+
+// ToPrometheus renders the analysis in the Prometheus text exposition format suitable for a Pushgateway or the
+// node exporter textfile collector, metrics are prefixed with namespace which defaults to "nats".
+//
+// Check outcomes are reported using their numeric value: 0 for PASS, 1 for WARN, 2 for FAIL and 3 for SKIP
+func (a *Analysis) ToPrometheus(namespace string) ([]byte, error) {
+	if namespace == "" {
+		namespace = "nats"
+	}
+
+	labels := []string{"code", "suite", "severity"}
+
+	outcome := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: prometheus.BuildFQName(namespace, "audit", "check_outcome"),
+		Help: "Outcome of an audit check, 0 for PASS, 1 for WARN, 2 for FAIL and 3 for SKIP",
+	}, labels)
+
+	examples := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: prometheus.BuildFQName(namespace, "audit", "check_examples"),
+		Help: "Number of examples reported by an audit check",
+	}, labels)
+
+	outcomes := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: prometheus.BuildFQName(namespace, "audit", "outcomes"),
+		Help: "Number of audit checks per outcome",
+	}, []string{"outcome"})
+
+	verdict := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: prometheus.BuildFQName(namespace, "audit", "verdict"),
+		Help: "Overall audit verdict weighted by check severity, 0 for PASS, 1 for WARN and 2 for FAIL",
+	})
+
+	timestamp := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: prometheus.BuildFQName(namespace, "audit", "timestamp_seconds"),
+		Help: "Unix time the audit analysis was produced",
+	})
+
+	for _, res := range a.Results {
+		severity := res.Severity
+		if severity == "" {
+			severity = SeverityWarning
+		}
+
+		outcome.WithLabelValues(res.Check.Code, res.Check.Suite, string(severity)).Set(float64(res.Outcome))
+		examples.WithLabelValues(res.Check.Code, res.Check.Suite, string(severity)).Set(float64(res.Examples.Count()))
+	}
+
+	for _, o := range Outcomes {
+		outcomes.WithLabelValues(o.String()).Set(float64(a.Outcomes[o.String()]))
+	}
+
+	verdict.Set(float64(a.Verdict))
+
+	if !a.Timestamp.IsZero() {
+		timestamp.Set(float64(a.Timestamp.Unix()))
+	}
+
+	registry := prometheus.NewRegistry()
+	for _, c := range []prometheus.Collector{outcome, examples, outcomes, verdict, timestamp} {
+		err := registry.Register(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mfs, err := registry.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, mf := range mfs {
+		_, err = expfmt.MetricFamilyToText(&buf, mf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnalysis_ToPrometheus(t *testing.T) {
+	a := analysisWithResults(
+		resultFor("T_001", Fail, "a", "b"),
+		resultFor("T_002", Pass),
+	)
+	a.Timestamp = time.Unix(1700000000, 0)
+	a.Results[0].Severity = SeverityCritical
+	a.Outcomes = map[string]int{"FAIL": 1, "PASS": 1}
+	a.Verdict = Fail
+
+	out, err := a.ToPrometheus("")
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		`nats_audit_check_outcome{code="T_001",severity="critical",suite="test"} 2`,
+		`nats_audit_check_outcome{code="T_002",severity="warning",suite="test"} 0`,
+		`nats_audit_check_examples{code="T_001",severity="critical",suite="test"} 2`,
+		`nats_audit_outcomes{outcome="FAIL"} 1`,
+		`nats_audit_outcomes{outcome="SKIP"} 0`,
+		`nats_audit_verdict 2`,
+		`nats_audit_timestamp_seconds 1.7e+09`,
+		`# TYPE nats_audit_check_outcome gauge`,
+	} {
+		if !strings.Contains(string(out), expected) {
+			t.Errorf("expected output to contain %q:\n%s", expected, out)
+		}
+	}
+
+	out, err = a.ToPrometheus("acme")
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(string(out), "acme_audit_verdict 2") {
+		t.Errorf("expected custom namespace:\n%s", out)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+func Test_TypedAccessors(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "archive.zip")
+	aw, err := NewWriter(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %s", err)
+	}
+
+	for _, conns := range []int{1, 2} {
+		err = aw.Add(&server.ServerAPIVarzResponse{Data: &server.Varz{Connections: conns}}, TagCluster("C1"), TagServer("n1"), TagServerVars())
+		if err != nil {
+			t.Fatalf("Failed to add artifact: %s", err)
+		}
+	}
+
+	err = aw.Add(&server.ServerAPIHealthzResponse{Data: &server.HealthStatus{Status: "ok"}}, TagNoCluster(), TagServer("s1"), TagServerHealth())
+	if err != nil {
+		t.Fatalf("Failed to add artifact: %s", err)
+	}
+
+	err = aw.Add(&ServerAPIAccountInfoResponse{Data: &server.AccountInfo{AccountName: "A"}}, TagAccount("A"), TagCluster("C1"), TagServer("n1"), TagAccountInfo())
+	if err != nil {
+		t.Fatalf("Failed to add artifact: %s", err)
+	}
+
+	for _, srv := range []string{"n1", "n2"} {
+		err = aw.Add(&api.StreamInfo{Config: api.StreamConfig{Name: "S"}, State: api.StreamState{Msgs: 10}}, TagAccount("A"), TagCluster("C1"), TagServer(srv), TagStream("S"), TagStreamInfo())
+		if err != nil {
+			t.Fatalf("Failed to add artifact: %s", err)
+		}
+	}
+
+	err = aw.Close()
+	if err != nil {
+		t.Fatalf("Failed to close archive: %s", err)
+	}
+
+	ar, err := NewReader(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to open archive: %s", err)
+	}
+	defer ar.Close()
+
+	vz, err := ar.ServerVarz("C1", "n1")
+	if err != nil {
+		t.Fatalf("Failed to load varz: %s", err)
+	}
+	if vz.Data.Connections != 2 {
+		t.Fatalf("Expected the most recent sample, got %d connections", vz.Data.Connections)
+	}
+
+	hz, err := ar.ServerHealthz("", "s1")
+	if err != nil || hz.Data.Status != "ok" {
+		t.Fatalf("Failed to load unclustered healthz: %v: %+v", err, hz)
+	}
+
+	_, err = ar.ServerJsz("C1", "n1")
+	if !errors.Is(err, ErrNoMatches) {
+		t.Fatalf("Expected no matches error, got: %v", err)
+	}
+
+	info, err := ar.AccountInfo("A", "C1", "n1")
+	if err != nil || info.Data.AccountName != "A" {
+		t.Fatalf("Failed to load account info: %v: %+v", err, info)
+	}
+
+	streams, err := ar.AccountStreamInfo("A", "S")
+	if err != nil {
+		t.Fatalf("Failed to load stream info: %s", err)
+	}
+	if len(streams) != 2 || streams["n2"].State.Msgs != 10 {
+		t.Fatalf("Unexpected stream info: %+v", streams)
+	}
+
+	_, err = ar.AccountStreamInfo("A", "X")
+	if !errors.Is(err, ErrNoMatches) {
+		t.Fatalf("Expected no matches error, got: %v", err)
+	}
+}
@@ -16,6 +16,7 @@ package archive
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"math/rand"
 	"os"
@@ -23,6 +24,7 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 func expectedPagedFile(t *testing.T, extension string, tags ...*Tag) string {
@@ -502,3 +504,84 @@ func Test_IterateResourcesUsingTags(t *testing.T) {
 // TODO test creation in non-existing directory fails
 // TODO test adding twice a file with the same name (or tags)
 // TODO test with non-unique server name in different clusters
+
+func Test_ReaderRejectsUnsupportedFormats(t *testing.T) {
+	for name, content := range map[string][]byte{
+		"archive.tar.gz": {0x1f, 0x8b, 0x08, 0x00, 0x00},
+		"archive.json":   []byte(`{"capture": true}`),
+		"empty.zip":      {},
+	} {
+		archivePath := filepath.Join(t.TempDir(), name)
+		err := os.WriteFile(archivePath, content, 0600)
+		if err != nil {
+			t.Fatalf("Failed to write file: %s", err)
+		}
+
+		_, err = NewReader(archivePath)
+		if !errors.Is(err, ErrUnsupportedFormat) {
+			t.Fatalf("Expected unsupported format error for %s, got: %v", name, err)
+		}
+	}
+}
+
+func Test_WriterAddArchive(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in.zip")
+	out := filepath.Join(dir, "out.zip")
+
+	writeMergeTestArchive(t, in, time.Now().UTC(), "east", "n1", "n2")
+
+	ar, err := NewReader(in)
+	if err != nil {
+		t.Fatalf("Failed to open archive: %s", err)
+	}
+	defer ar.Close()
+
+	aw, err := NewWriter(out)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %s", err)
+	}
+	err = aw.AddArchive(ar, func(tags []*Tag) bool {
+		return !slices.ContainsFunc(tags, func(tag *Tag) bool { return *tag == *TagServer("n2") })
+	})
+	if err != nil {
+		t.Fatalf("Failed to add archive: %s", err)
+	}
+	err = aw.Add(map[string]any{"region": "east", "sample": 2}, TagCluster("C1"), TagServer("n1"), TagServerVars())
+	if err != nil {
+		t.Fatalf("Failed to add artifact: %s", err)
+	}
+	err = aw.Close()
+	if err != nil {
+		t.Fatalf("Failed to close archive: %s", err)
+	}
+
+	copied, err := NewReader(out)
+	if err != nil {
+		t.Fatalf("Failed to open copy: %s", err)
+	}
+	defer copied.Close()
+
+	var samples []float64
+	err = ForEachTaggedArtifact(copied, []*Tag{TagCluster("C1"), TagServer("n1"), TagServerVars()}, func(v *map[string]any) error {
+		samples = append(samples, (*v)["sample"].(float64))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to iterate: %s", err)
+	}
+	if fmt.Sprint(samples) != "[0 1 2]" {
+		t.Fatalf("Expected samples to be copied in order, got %v", samples)
+	}
+
+	servers := copied.ClusterServerNames("C1")
+	if len(servers) != 1 || servers[0] != "n1" {
+		t.Fatalf("Expected filtered artifacts not to be copied, got servers %v", servers)
+	}
+
+	var md AuditMetadata
+	err = copied.Load(&md, TagSpecial(gatherMetadataSpecial))
+	if !errors.Is(err, ErrNoMatches) {
+		t.Fatalf("Expected special files not to be copied, got %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// Encrypted archives hold the ZIP file sealed using NaCl secretbox in chunks so that archives of any size can be
+// written without holding them in memory, readers decrypt the archive into memory so the plain text is never stored:
+//
+//	magic | 16 byte nonce prefix | (4 byte sealed chunk length | sealed chunk)...
+//
+// Each chunk nonce is the nonce prefix followed by the chunk number, the last chunk has the high bit of the number
+// set so that truncated or reordered archives are detected.
+
+const (
+	// EncryptionKeySize is the size of keys used to encrypt archives
+	EncryptionKeySize = 32
+
+	encryptedArchiveMagic = "NATSAUDITENC1\n"
+	encryptedChunkSize    = 64 * 1024
+	encryptedNoncePrefix  = 16
+	encryptedFinalChunk   = uint64(1) << 63
+)
+
+// ErrEncryptedArchive is returned when opening an encrypted archive without a key
+var ErrEncryptedArchive = errors.New("archive is encrypted, an encryption key is required")
+
+// ErrDecryptionFailed is returned when an encrypted archive can not be decrypted using the given key
+var ErrDecryptionFailed = errors.New("archive decryption failed, invalid key or corrupt archive")
+
+// EncryptionKey sets the key used to encrypt archives being written or decrypt archives being read,
+// see GenerateEncryptionKey()
+func EncryptionKey(key []byte) Option {
+	return func(o *options) error {
+		if len(key) != EncryptionKeySize {
+			return fmt.Errorf("encryption key must be %d bytes", EncryptionKeySize)
+		}
+
+		o.key = new([EncryptionKeySize]byte)
+		copy(o.key[:], key)
+
+		return nil
+	}
+}
+
+// GenerateEncryptionKey creates a new random key suitable for EncryptionKey()
+func GenerateEncryptionKey() ([]byte, error) {
+	key := make([]byte, EncryptionKeySize)
+	_, err := io.ReadFull(rand.Reader, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func chunkNonce(prefix []byte, chunk uint64, final bool) *[24]byte {
+	nonce := new([24]byte)
+	copy(nonce[:], prefix)
+	if final {
+		chunk |= encryptedFinalChunk
+	}
+	binary.BigEndian.PutUint64(nonce[encryptedNoncePrefix:], chunk)
+
+	return nonce
+}
+
+// encryptingWriter seals everything written to it in chunks, Close() must be called to write the final chunk
+type encryptingWriter struct {
+	out    io.Writer
+	key    *[EncryptionKeySize]byte
+	prefix []byte
+	buf    []byte
+	chunk  uint64
+	closed bool
+}
+
+func newEncryptingWriter(out io.Writer, key *[EncryptionKeySize]byte) (*encryptingWriter, error) {
+	prefix := make([]byte, encryptedNoncePrefix)
+	_, err := io.ReadFull(rand.Reader, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = out.Write(append([]byte(encryptedArchiveMagic), prefix...))
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptingWriter{
+		out:    out,
+		key:    key,
+		prefix: prefix,
+		buf:    make([]byte, 0, encryptedChunkSize),
+	}, nil
+}
+
+func (w *encryptingWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, fmt.Errorf("write to closed encryption writer")
+	}
+
+	written := 0
+	for len(p) > 0 {
+		// only full chunks are sealed here, so the final chunk is always sealed by Close()
+		if len(w.buf) == encryptedChunkSize {
+			err := w.seal(false)
+			if err != nil {
+				return written, err
+			}
+		}
+
+		n := copy(w.buf[len(w.buf):encryptedChunkSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+
+	return written, nil
+}
+
+func (w *encryptingWriter) seal(final bool) error {
+	sealed := secretbox.Seal(make([]byte, 4), w.buf, chunkNonce(w.prefix, w.chunk, final), w.key)
+	binary.BigEndian.PutUint32(sealed, uint32(len(sealed)-4))
+
+	_, err := w.out.Write(sealed)
+	if err != nil {
+		return err
+	}
+
+	w.chunk++
+	w.buf = w.buf[:0]
+
+	return nil
+}
+
+func (w *encryptingWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	return w.seal(true)
+}
+
+// decryptArchive decrypts an encrypted archive read from in into out
+func decryptArchive(in io.Reader, out io.Writer, key *[EncryptionKeySize]byte) error {
+	header := make([]byte, len(encryptedArchiveMagic)+encryptedNoncePrefix)
+	_, err := io.ReadFull(in, header)
+	if err != nil || string(header[:len(encryptedArchiveMagic)]) != encryptedArchiveMagic {
+		return ErrUnsupportedFormat
+	}
+	prefix := header[len(encryptedArchiveMagic):]
+
+	size := make([]byte, 4)
+	sealed := make([]byte, encryptedChunkSize+secretbox.Overhead)
+	plain := make([]byte, 0, encryptedChunkSize)
+
+	for chunk := uint64(0); ; chunk++ {
+		_, err = io.ReadFull(in, size)
+		if err != nil {
+			// archives always end with a final chunk
+			return ErrDecryptionFailed
+		}
+
+		n := binary.BigEndian.Uint32(size)
+		if n < secretbox.Overhead || n > uint32(len(sealed)) {
+			return ErrDecryptionFailed
+		}
+
+		_, err = io.ReadFull(in, sealed[:n])
+		if err != nil {
+			return ErrDecryptionFailed
+		}
+
+		final := false
+		opened, ok := secretbox.Open(plain[:0], sealed[:n], chunkNonce(prefix, chunk, false), key)
+		if !ok {
+			opened, ok = secretbox.Open(plain[:0], sealed[:n], chunkNonce(prefix, chunk, true), key)
+			if !ok {
+				return ErrDecryptionFailed
+			}
+			final = true
+		}
+
+		_, err = out.Write(opened)
+		if err != nil {
+			return err
+		}
+
+		if final {
+			// data following the final chunk indicates tampering
+			_, err = in.Read(size[:1])
+			if !errors.Is(err, io.EOF) {
+				return ErrDecryptionFailed
+			}
+
+			return nil
+		}
+	}
+}
+
+// decryptArchiveFile decrypts the archive at archivePath into memory, the plain text archive is never written to disk
+func decryptArchiveFile(archivePath string, key *[EncryptionKeySize]byte) ([]byte, error) {
+	in, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer in.Close()
+
+	var out bytes.Buffer
+	if st, err := in.Stat(); err == nil {
+		out.Grow(int(st.Size()))
+	}
+
+	err = decryptArchive(bufio.NewReader(in), &out, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
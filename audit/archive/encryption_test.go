@@ -0,0 +1,147 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_EncryptedArchive(t *testing.T) {
+	key, err := GenerateEncryptionKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %s", err)
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "archive.zip")
+
+	_, err = NewWriter(archivePath, EncryptionKey([]byte("short")))
+	if err == nil {
+		t.Fatalf("Expected an error for an invalid key")
+	}
+
+	aw, err := NewWriter(archivePath, EncryptionKey(key))
+	if err != nil {
+		t.Fatalf("Failed to create archive: %s", err)
+	}
+
+	// spans several encrypted chunks
+	content := make([]byte, 3*encryptedChunkSize+123)
+	rand.New(rand.NewSource(123456)).Read(content)
+
+	tags := []*Tag{TagCluster("C1"), TagServer("n1"), TagServerProfile(), TagProfileName("heap")}
+	err = aw.AddRaw(bytes.NewReader(content), "prof", tags...)
+	if err != nil {
+		t.Fatalf("Failed to add artifact: %s", err)
+	}
+	err = aw.Add(map[string]string{"account": "SECRET_ACCOUNT"}, TagCluster("C1"), TagServer("n1"), TagServerVars())
+	if err != nil {
+		t.Fatalf("Failed to add artifact: %s", err)
+	}
+
+	err = aw.Close()
+	if err != nil {
+		t.Fatalf("Failed to close archive: %s", err)
+	}
+
+	raw, err := os.ReadFile(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to read archive: %s", err)
+	}
+	if bytes.Contains(raw, []byte("manifest")) || bytes.Contains(raw, []byte("SECRET_ACCOUNT")) {
+		t.Fatalf("Archive is not encrypted")
+	}
+
+	t.Run("Should require a key", func(t *testing.T) {
+		_, err := NewReader(archivePath)
+		if !errors.Is(err, ErrEncryptedArchive) {
+			t.Fatalf("Expected encrypted archive error, got: %v", err)
+		}
+	})
+
+	t.Run("Should detect invalid keys", func(t *testing.T) {
+		other, _ := GenerateEncryptionKey()
+		_, err := NewReader(archivePath, EncryptionKey(other))
+		if !errors.Is(err, ErrDecryptionFailed) {
+			t.Fatalf("Expected decryption error, got: %v", err)
+		}
+	})
+
+	t.Run("Should detect truncated archives", func(t *testing.T) {
+		truncated := filepath.Join(t.TempDir(), "truncated.zip")
+		err := os.WriteFile(truncated, raw[:len(raw)-100], 0600)
+		if err != nil {
+			t.Fatalf("Failed to write archive: %s", err)
+		}
+
+		_, err = NewReader(truncated, EncryptionKey(key))
+		if !errors.Is(err, ErrDecryptionFailed) {
+			t.Fatalf("Expected decryption error, got: %v", err)
+		}
+	})
+
+	t.Run("Should read with the key", func(t *testing.T) {
+		tmp := t.TempDir()
+		t.Setenv("TMPDIR", tmp)
+
+		ar, err := NewReader(archivePath, EncryptionKey(key))
+		if err != nil {
+			t.Fatalf("Failed to open archive: %s", err)
+		}
+		if !ar.Encrypted() {
+			t.Fatalf("Expected the archive to be reported as encrypted")
+		}
+
+		entries, err := os.ReadDir(tmp)
+		if err != nil {
+			t.Fatalf("Failed to list temporary directory: %s", err)
+		}
+		if len(entries) > 0 {
+			t.Fatalf("Expected no decrypted copy on disk, found %d files", len(entries))
+		}
+
+		var vars map[string]string
+		err = ar.Load(&vars, TagCluster("C1"), TagServer("n1"), TagServerVars())
+		if err != nil {
+			t.Fatalf("Failed to load artifact: %s", err)
+		}
+		if vars["account"] != "SECRET_ACCOUNT" {
+			t.Fatalf("Unexpected artifact: %v", vars)
+		}
+
+		name, err := createFilenameFromTags("prof", tags)
+		if err != nil {
+			t.Fatalf("Failed to create file name: %s", err)
+		}
+		f, _, err := ar.getFileReader(name)
+		if err != nil {
+			t.Fatalf("Failed to open profile: %s", err)
+		}
+		var profile bytes.Buffer
+		_, err = profile.ReadFrom(f)
+		f.Close()
+		if err != nil || !bytes.Equal(profile.Bytes(), content) {
+			t.Fatalf("Decrypted profile does not match: %v", err)
+		}
+
+		err = ar.Close()
+		if err != nil {
+			t.Fatalf("Failed to close archive: %s", err)
+		}
+	})
+}
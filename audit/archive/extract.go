@@ -0,0 +1,101 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Extract writes the raw artifacts matching all tags to destDir and returns the paths of the files written, when no
+// tags are given all artifacts are extracted.
+//
+// Files are laid out as in the archive, for example server artifacts are written to
+// clusters/<cluster>/<server>/<type>/0001.json and stream artifacts to
+// accounts/<account>/streams/<stream>/replicas/<cluster>__<server>/<type>/0001.json.
+// If no artifacts match ErrNoMatches is returned.
+func (r *Reader) Extract(destDir string, tags ...*Tag) ([]string, error) {
+	var names []string
+
+	if len(tags) == 0 {
+		names = r.fileNames()
+	} else {
+		matching, err := intersectFileSets(r.invertedIndex, tags)
+		if err != nil {
+			return nil, err
+		}
+		for name := range matching {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+	}
+
+	if len(names) == 0 {
+		return nil, ErrNoMatches
+	}
+
+	dest, err := filepath.Abs(destDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var extracted []string
+	for _, name := range names {
+		target := filepath.Join(dest, filepath.FromSlash(strings.TrimPrefix(name, rootDirectory+"/")))
+
+		// guards against entries escaping the destination directory
+		if !strings.HasPrefix(target, dest+string(filepath.Separator)) {
+			return extracted, fmt.Errorf("artifact %s is outside the destination directory", name)
+		}
+
+		err = r.extractFile(name, target)
+		if err != nil {
+			return extracted, err
+		}
+
+		extracted = append(extracted, target)
+	}
+
+	return extracted, nil
+}
+
+func (r *Reader) extractFile(name string, target string) error {
+	err := os.MkdirAll(filepath.Dir(target), 0700)
+	if err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", name, err)
+	}
+
+	in, _, err := r.getFileReader(name)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", target, err)
+	}
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		out.Close()
+		return fmt.Errorf("failed to extract %s: %w", name, err)
+	}
+
+	return out.Close()
+}
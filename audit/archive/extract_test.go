@@ -0,0 +1,92 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_Extract(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "archive.zip")
+	aw, err := NewWriter(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %s", err)
+	}
+
+	for _, srv := range []string{"n1", "n2"} {
+		for _, typeTag := range []*Tag{TagServerJetStream(), TagServerConnections()} {
+			err = aw.Add(map[string]string{"server": srv}, TagCluster("C1"), TagServer(srv), typeTag)
+			if err != nil {
+				t.Fatalf("Failed to add artifact: %s", err)
+			}
+		}
+	}
+
+	err = aw.Close()
+	if err != nil {
+		t.Fatalf("Failed to close archive: %s", err)
+	}
+
+	ar, err := NewReader(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to open archive: %s", err)
+	}
+	defer ar.Close()
+
+	t.Run("Should extract matching artifacts", func(t *testing.T) {
+		dest := t.TempDir()
+
+		extracted, err := ar.Extract(dest, TagServerJetStream())
+		if err != nil {
+			t.Fatalf("Extract failed: %s", err)
+		}
+
+		expected := []string{
+			filepath.Join(dest, "clusters", "C1", "n1", "jetstream_info", "0001.json"),
+			filepath.Join(dest, "clusters", "C1", "n2", "jetstream_info", "0001.json"),
+		}
+		if strings.Join(extracted, ",") != strings.Join(expected, ",") {
+			t.Fatalf("Expected %v, got %v", expected, extracted)
+		}
+
+		content, err := os.ReadFile(expected[1])
+		if err != nil {
+			t.Fatalf("Failed to read extracted file: %s", err)
+		}
+		if !strings.Contains(string(content), `"server": "n2"`) {
+			t.Fatalf("Unexpected content: %s", content)
+		}
+	})
+
+	t.Run("Should extract everything without tags", func(t *testing.T) {
+		extracted, err := ar.Extract(t.TempDir())
+		if err != nil {
+			t.Fatalf("Extract failed: %s", err)
+		}
+		if len(extracted) != 4 {
+			t.Fatalf("Expected 4 artifacts, got %v", extracted)
+		}
+	})
+
+	t.Run("Should report no matches", func(t *testing.T) {
+		_, err := ar.Extract(t.TempDir(), TagServer("n3"))
+		if !errors.Is(err, ErrNoMatches) {
+			t.Fatalf("Expected no matches error, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ignore
+
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"log"
+	"os"
+	"text/template"
+)
+
+// accessor describes a well-known artifact type a typed accessor is generated for
+type accessor struct {
+	// Name is the name of the accessor method
+	Name string
+	// Tag is the function creating the artifact type tag
+	Tag string
+	// Type is the type the artifact is decoded into
+	Type string
+	// Description describes the artifact in the accessor doc comment
+	Description string
+}
+
+var serverAccessors = []accessor{
+	{"ServerVarz", "TagServerVars", "server.ServerAPIVarzResponse", "VARZ"},
+	{"ServerConnz", "TagServerConnections", "server.ServerAPIConnzResponse", "CONNZ"},
+	{"ServerRoutez", "TagServerRoutes", "server.ServerAPIRoutezResponse", "ROUTEZ"},
+	{"ServerGatewayz", "TagServerGateways", "server.ServerAPIGatewayzResponse", "GATEWAYZ"},
+	{"ServerLeafz", "TagServerLeafs", "server.ServerAPILeafzResponse", "LEAFZ"},
+	{"ServerSubsz", "TagServerSubs", "server.ServerAPISubszResponse", "SUBSZ"},
+	{"ServerJsz", "TagServerJetStream", "server.ServerAPIJszResponse", "JSZ"},
+	{"ServerAccountz", "TagServerAccounts", "server.ServerAPIAccountzResponse", "ACCOUNTZ"},
+	{"ServerHealthz", "TagServerHealth", "server.ServerAPIHealthzResponse", "HEALTHZ"},
+}
+
+var accountAccessors = []accessor{
+	{"AccountConnz", "TagAccountConnections", "server.ServerAPIConnzResponse", "CONNZ"},
+	{"AccountLeafz", "TagAccountLeafs", "server.ServerAPILeafzResponse", "LEAFZ"},
+	{"AccountSubsz", "TagAccountSubs", "server.ServerAPISubszResponse", "SUBSZ"},
+	{"AccountJsz", "TagAccountJetStream", "server.ServerAPIJszResponse", "JSZ"},
+	{"AccountInfo", "TagAccountInfo", "ServerAPIAccountInfoResponse", "INFO"},
+}
+
+var accessorsTemplate = `// Code generated by gen.go; DO NOT EDIT.
+
+package archive
+
+import (
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+{{- range .Server }}
+
+// {{ .Name }} loads the {{ .Description }} response captured from a server, the most recent sample is returned when
+// several were captured. Use an empty cluster name for servers that are not clustered
+func (r *Reader) {{ .Name }}(clusterName string, serverName string) (*{{ .Type }}, error) {
+	return loadLatest[{{ .Type }}](r, clusterTag(clusterName), TagServer(serverName), {{ .Tag }}())
+}
+{{- end }}
+
+{{- range .Account }}
+
+// {{ .Name }} loads the account {{ .Description }} response captured from a server, the most recent sample is
+// returned when several were captured. Use an empty cluster name for servers that are not clustered
+func (r *Reader) {{ .Name }}(accountName string, clusterName string, serverName string) (*{{ .Type }}, error) {
+	return loadLatest[{{ .Type }}](r, TagAccount(accountName), clusterTag(clusterName), TagServer(serverName), {{ .Tag }}())
+}
+{{- end }}
+
+// AccountStreamInfo loads the stream info reported by every server hosting a replica of the stream, keyed by server
+// name, the most recent sample is returned when several were captured
+func (r *Reader) AccountStreamInfo(accountName string, streamName string) (map[string]*api.StreamInfo, error) {
+	res := make(map[string]*api.StreamInfo)
+	for _, serverName := range r.StreamServerNames(accountName, streamName) {
+		nfo, err := loadLatest[api.StreamInfo](r, TagAccount(accountName), TagStream(streamName), TagServer(serverName), TagStreamInfo())
+		if err != nil {
+			return nil, err
+		}
+		res[serverName] = nfo
+	}
+
+	if len(res) == 0 {
+		return nil, ErrNoMatches
+	}
+
+	return res, nil
+}
+`
+
+func main() {
+	tmpl := template.Must(template.New("accessors").Parse(accessorsTemplate))
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, map[string][]accessor{
+		"Server":  serverAccessors,
+		"Account": accountAccessors,
+	})
+	if err != nil {
+		log.Fatalf("could not render accessors: %s", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("could not format accessors: %s", err)
+	}
+
+	err = os.WriteFile("accessors.go", src, 0644)
+	if err != nil {
+		log.Fatalf("could not write accessors: %s", err)
+	}
+}
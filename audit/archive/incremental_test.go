@@ -0,0 +1,127 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"archive/zip"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func writeIncrementalTestArchive(t *testing.T, path string, vars map[string]string, opts ...Option) {
+	t.Helper()
+
+	aw, err := NewWriter(path, opts...)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %s", err)
+	}
+
+	for srv, v := range vars {
+		err = aw.Add(map[string]string{"value": v}, TagCluster("C1"), TagServer(srv), TagServerVars())
+		if err != nil {
+			t.Fatalf("Failed to add artifact: %s", err)
+		}
+	}
+
+	err = aw.Add(&AuditMetadata{UserName: "ops"}, TagSpecial(gatherMetadataSpecial))
+	if err != nil {
+		t.Fatalf("Failed to add metadata: %s", err)
+	}
+
+	err = aw.Close()
+	if err != nil {
+		t.Fatalf("Failed to close archive: %s", err)
+	}
+}
+
+func Test_IncrementalArchive(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.zip")
+	other := filepath.Join(dir, "other.zip")
+	incremental := filepath.Join(dir, "incremental.zip")
+
+	writeIncrementalTestArchive(t, base, map[string]string{"n1": "unchanged", "n2": "old"})
+	writeIncrementalTestArchive(t, other, map[string]string{"n1": "different", "n2": "old"})
+	writeIncrementalTestArchive(t, incremental, map[string]string{"n1": "unchanged", "n2": "new", "n3": "added"}, BaseArchive(base))
+
+	_, err := NewWriter(base, BaseArchive(base))
+	if err == nil {
+		t.Fatalf("Expected an error using the archive being written as base")
+	}
+
+	_, err = NewWriter(filepath.Join(dir, "chained.zip"), BaseArchive(incremental))
+	if err == nil {
+		t.Fatalf("Expected an error using an incremental base archive")
+	}
+
+	zr, err := zip.OpenReader(incremental)
+	if err != nil {
+		t.Fatalf("Failed to open zip: %s", err)
+	}
+	defer zr.Close()
+
+	stored := map[string]uint64{}
+	for _, f := range zr.File {
+		stored[f.Name] = f.UncompressedSize64
+	}
+
+	n1 := expectedPagedFile(t, "json", TagCluster("C1"), TagServer("n1"), TagServerVars())
+	n2 := expectedPagedFile(t, "json", TagCluster("C1"), TagServer("n2"), TagServerVars())
+	if stored[n1] != 0 {
+		t.Fatalf("Expected unchanged artifact to be stored as a reference")
+	}
+	if stored[n2] == 0 {
+		t.Fatalf("Expected changed artifact to be stored")
+	}
+
+	t.Run("Should require the base archive", func(t *testing.T) {
+		_, err := NewReader(incremental)
+		if !errors.Is(err, ErrIncrementalArchive) {
+			t.Fatalf("Expected incremental archive error, got: %v", err)
+		}
+	})
+
+	t.Run("Should detect the wrong base archive", func(t *testing.T) {
+		_, err := NewReader(incremental, BaseArchive(other))
+		if err == nil {
+			t.Fatalf("Expected an error")
+		}
+	})
+
+	t.Run("Should read artifacts from the base archive", func(t *testing.T) {
+		ar, err := NewReader(incremental, BaseArchive(base))
+		if err != nil {
+			t.Fatalf("Failed to open archive: %s", err)
+		}
+		defer ar.Close()
+
+		for srv, expected := range map[string]string{"n1": "unchanged", "n2": "new", "n3": "added"} {
+			var vars map[string]string
+			err = ar.Load(&vars, TagCluster("C1"), TagServer(srv), TagServerVars())
+			if err != nil {
+				t.Fatalf("Failed to load %s: %s", srv, err)
+			}
+			if vars["value"] != expected {
+				t.Fatalf("Expected %s for %s, got %v", expected, srv, vars)
+			}
+		}
+
+		var md AuditMetadata
+		err = ar.Load(&md, TagSpecial(gatherMetadataSpecial))
+		if err != nil || md.UserName != "ops" {
+			t.Fatalf("Failed to load metadata: %v: %+v", err, md)
+		}
+	})
+}
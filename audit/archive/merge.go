@@ -0,0 +1,229 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	gatherMetadataSpecial   = "audit_gather_metadata"
+	gatherLogSpecial        = "audit_gather_log"
+	gatherCheckpointSpecial = "audit_gather_checkpoint"
+)
+
+// mergeInput is an archive being merged
+type mergeInput struct {
+	path     string
+	reader   *Reader
+	metadata *AuditMetadata
+	ts       time.Time
+}
+
+// Merge combines the archives at inputs, for example captures of different regions, into a new archive at out.
+//
+// When several archives hold artifacts with the same tags, for example when the same server was captured in
+// multiple archives, only the artifacts from the most recent capture are kept. The capture metadata of all archives
+// is merged, using the most recent capture time, and their capture logs are combined.
+func Merge(out string, inputs ...string) error {
+	if len(inputs) < 2 {
+		return fmt.Errorf("at least 2 archives are required")
+	}
+
+	for _, in := range inputs {
+		if filepath.Clean(in) == filepath.Clean(out) {
+			return fmt.Errorf("output archive may not be an input archive")
+		}
+	}
+
+	var archives []*mergeInput
+	defer func() {
+		for _, a := range archives {
+			a.reader.Close()
+		}
+	}()
+
+	for _, in := range inputs {
+		reader, err := NewReader(in)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", in, err)
+		}
+
+		input := &mergeInput{path: in, reader: reader}
+		archives = append(archives, input)
+
+		var md AuditMetadata
+		err = reader.Load(&md, TagSpecial(gatherMetadataSpecial))
+		switch {
+		case err == nil:
+			input.metadata = &md
+			input.ts = md.Timestamp
+		case errors.Is(err, ErrNoMatches):
+			if reader.ts != nil {
+				input.ts = *reader.ts
+			}
+		default:
+			return fmt.Errorf("failed to load metadata from %s: %w", in, err)
+		}
+	}
+
+	// most recent first so their artifacts win conflicts
+	slices.SortStableFunc(archives, func(a, b *mergeInput) int {
+		return b.ts.Compare(a.ts)
+	})
+
+	writer, err := NewWriter(out)
+	if err != nil {
+		return err
+	}
+	writer.SetTime(archives[0].ts)
+
+	err = mergeArchives(writer, archives)
+	if err != nil {
+		writer.Close()
+		return err
+	}
+
+	return writer.Close()
+}
+
+func mergeArchives(writer *Writer, archives []*mergeInput) error {
+	claimed := make(map[string]struct{})
+	var logs bytes.Buffer
+
+	for _, input := range archives {
+		contributed := make(map[string]struct{})
+
+		for _, name := range input.reader.fileNames() {
+			tags := input.reader.fileTags(name)
+
+			if len(tags) == 1 && tags[0].Name == specialTagLabel {
+				switch tags[0].Value {
+				// metadata is merged below, merged archives can not be used to resume a gather
+				case gatherMetadataSpecial, gatherCheckpointSpecial:
+					continue
+
+				case gatherLogSpecial:
+					data, err := input.reader.readFile(name)
+					if err != nil {
+						return fmt.Errorf("failed to read %s from %s: %w", name, input.path, err)
+					}
+					fmt.Fprintf(&logs, "==> %s <==\n", input.path)
+					logs.Write(data)
+					continue
+				}
+			}
+
+			key := tagSetKey(tags)
+			if _, ok := claimed[key]; ok {
+				continue
+			}
+			contributed[key] = struct{}{}
+
+			data, err := input.reader.readFile(name)
+			if err != nil {
+				return fmt.Errorf("failed to read %s from %s: %w", name, input.path, err)
+			}
+
+			err = writer.AddRaw(bytes.NewReader(data), strings.TrimPrefix(filepath.Ext(name), "."), tags...)
+			if err != nil {
+				return fmt.Errorf("failed to add %s from %s: %w", name, input.path, err)
+			}
+		}
+
+		for key := range contributed {
+			claimed[key] = struct{}{}
+		}
+	}
+
+	if logs.Len() > 0 {
+		err := writer.AddRaw(&logs, "log", TagSpecial(gatherLogSpecial))
+		if err != nil {
+			return fmt.Errorf("failed to add capture log: %w", err)
+		}
+	}
+
+	md := mergeMetadata(archives)
+	if md != nil {
+		err := writer.Add(md, TagSpecial(gatherMetadataSpecial))
+		if err != nil {
+			return fmt.Errorf("failed to add metadata: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// mergeMetadata combines the metadata of all archives, distinct values are joined, failed captures are combined and
+// the most recent timestamp kept
+func mergeMetadata(archives []*mergeInput) *AuditMetadata {
+	var names, versions, urls, users, cliVersions []string
+	var failures []CaptureFailure
+	var merged *AuditMetadata
+
+	add := func(list []string, v string) []string {
+		if v == "" || slices.Contains(list, v) {
+			return list
+		}
+		return append(list, v)
+	}
+
+	for _, input := range archives {
+		md := input.metadata
+		if md == nil {
+			continue
+		}
+
+		if merged == nil || md.Timestamp.After(merged.Timestamp) {
+			merged = &AuditMetadata{Timestamp: md.Timestamp}
+		}
+
+		names = add(names, md.ConnectedServerName)
+		versions = add(versions, md.ConnectedServerVersion)
+		urls = add(urls, md.ConnectURL)
+		users = add(users, md.UserName)
+		cliVersions = add(cliVersions, md.CLIVersion)
+		failures = append(failures, md.FailedCaptures...)
+	}
+
+	if merged == nil {
+		return nil
+	}
+
+	merged.ConnectedServerName = strings.Join(names, ", ")
+	merged.ConnectedServerVersion = strings.Join(versions, ", ")
+	merged.ConnectURL = strings.Join(urls, ", ")
+	merged.UserName = strings.Join(users, ", ")
+	merged.CLIVersion = strings.Join(cliVersions, ", ")
+	merged.FailedCaptures = failures
+
+	return merged
+}
+
+// tagSetKey creates a key identifying a set of tags regardless of their order
+func tagSetKey(tags []*Tag) string {
+	parts := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		parts = append(parts, string(tag.Name)+"="+tag.Value)
+	}
+	slices.Sort(parts)
+
+	return strings.Join(parts, "\x00")
+}
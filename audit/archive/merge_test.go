@@ -0,0 +1,136 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeMergeTestArchive(t *testing.T, path string, ts time.Time, region string, servers ...string) {
+	t.Helper()
+
+	aw, err := NewWriter(path)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %s", err)
+	}
+
+	md := &AuditMetadata{
+		Timestamp:      ts,
+		ConnectURL:     "nats://" + region,
+		UserName:       "ops",
+		FailedCaptures: []CaptureFailure{{Phase: "server_endpoints", Target: region + "/VARZ", Error: "timeout"}},
+	}
+	err = aw.Add(md, TagSpecial(gatherMetadataSpecial))
+	if err != nil {
+		t.Fatalf("Failed to add metadata: %s", err)
+	}
+
+	for _, srv := range servers {
+		for i := range 2 {
+			err = aw.Add(map[string]any{"region": region, "sample": i}, TagCluster("C1"), TagServer(srv), TagServerVars())
+			if err != nil {
+				t.Fatalf("Failed to add artifact: %s", err)
+			}
+		}
+	}
+
+	err = aw.AddRaw(bytes.NewReader([]byte("gathered "+region+"\n")), "log", TagSpecial(gatherLogSpecial))
+	if err != nil {
+		t.Fatalf("Failed to add log: %s", err)
+	}
+
+	err = aw.Close()
+	if err != nil {
+		t.Fatalf("Failed to close archive: %s", err)
+	}
+}
+
+func Test_Merge(t *testing.T) {
+	dir := t.TempDir()
+	east := filepath.Join(dir, "east.zip")
+	west := filepath.Join(dir, "west.zip")
+	out := filepath.Join(dir, "merged.zip")
+
+	now := time.Now().UTC().Truncate(time.Second)
+	writeMergeTestArchive(t, east, now, "east", "n1", "n2")
+	writeMergeTestArchive(t, west, now.Add(-time.Hour), "west", "n1", "n3")
+
+	if Merge(out, east) == nil {
+		t.Fatalf("Expected an error merging a single archive")
+	}
+	if Merge(east, east, west) == nil {
+		t.Fatalf("Expected an error writing to an input archive")
+	}
+
+	err := Merge(out, west, east)
+	if err != nil {
+		t.Fatalf("Merge failed: %s", err)
+	}
+
+	ar, err := NewReader(out)
+	if err != nil {
+		t.Fatalf("Failed to open merged archive: %s", err)
+	}
+	defer ar.Close()
+
+	servers := ar.ClusterServerNames("C1")
+	if strings.Join(servers, ",") != "n1,n2,n3" {
+		t.Fatalf("Unexpected servers: %v", servers)
+	}
+
+	// n1 is in both archives, only the samples of the most recent capture are kept
+	regions := map[string][]string{}
+	for _, srv := range servers {
+		err = ForEachTaggedArtifact(ar, []*Tag{TagCluster("C1"), TagServer(srv), TagServerVars()}, func(v *map[string]any) error {
+			regions[srv] = append(regions[srv], (*v)["region"].(string))
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed to iterate %s: %s", srv, err)
+		}
+	}
+	for srv, expected := range map[string]string{"n1": "east,east", "n2": "east,east", "n3": "west,west"} {
+		if strings.Join(regions[srv], ",") != expected {
+			t.Fatalf("Expected %s samples from %s, got %v", srv, expected, regions[srv])
+		}
+	}
+
+	var md AuditMetadata
+	err = ar.Load(&md, TagSpecial(gatherMetadataSpecial))
+	if err != nil {
+		t.Fatalf("Failed to load metadata: %s", err)
+	}
+	if !md.Timestamp.Equal(now) || md.ConnectURL != "nats://east, nats://west" || md.UserName != "ops" {
+		t.Fatalf("Unexpected metadata: %+v", md)
+	}
+	if len(md.FailedCaptures) != 2 || md.FailedCaptures[0].Target == md.FailedCaptures[1].Target {
+		t.Fatalf("Unexpected failed captures: %+v", md.FailedCaptures)
+	}
+
+	name, err := createFilenameFromTags("log", []*Tag{TagSpecial(gatherLogSpecial)})
+	if err != nil {
+		t.Fatalf("Failed to create log name: %s", err)
+	}
+	log, err := ar.readFile(name)
+	if err != nil {
+		t.Fatalf("Failed to read log: %s", err)
+	}
+	if !strings.Contains(string(log), "gathered east") || !strings.Contains(string(log), "gathered west") {
+		t.Fatalf("Unexpected log: %s", log)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import "fmt"
+
+// Option configures a Reader or Writer
+type Option func(o *options) error
+
+type options struct {
+	key  *[EncryptionKeySize]byte
+	base string
+}
+
+func newOptions(opts ...Option) (*options, error) {
+	o := &options{}
+	for _, opt := range opts {
+		err := opt(o)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return o, nil
+}
+
+// BaseArchive sets the archive an incremental archive is relative to. Writers store artifacts that are unchanged
+// compared to the base archive as references to it rather than storing them again, Readers require the base archive
+// to read incremental archives. The base archive may not itself be incremental.
+func BaseArchive(path string) Option {
+	return func(o *options) error {
+		if path == "" {
+			return fmt.Errorf("base archive path is required")
+		}
+
+		o.base = path
+
+		return nil
+	}
+}
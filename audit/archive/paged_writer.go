@@ -28,12 +28,23 @@ func newPagedWriter(z *zip.Writer, dir string, ts *time.Time) *pagedWriter {
 	}
 }
 
+// nextPageName is the name of the page the next entry will be written to
+func (pw *pagedWriter) nextPageName() string {
+	return filepath.Join(pw.dir, fmt.Sprintf("%04d.json", pw.pageIndex))
+}
+
 func (pw *pagedWriter) WriteEntry(r io.Reader) error {
-	filename := filepath.Join(pw.dir, fmt.Sprintf("%04d.json", pw.pageIndex))
+	return pw.writeEntry(r, "")
+}
+
+// writeEntry writes the next page with comment set on the zip entry
+func (pw *pagedWriter) writeEntry(r io.Reader, comment string) error {
+	filename := pw.nextPageName()
 	header := &zip.FileHeader{
 		Name:     filename,
 		Method:   zip.Deflate,
 		Modified: tsToUTC(pw.ts),
+		Comment:  comment,
 	}
 
 	w, err := pw.zipWriter.CreateHeader(header)
@@ -11,10 +11,13 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+//go:generate go run gen.go
+
 package archive
 
 import (
 	"archive/zip"
+	"bytes"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -33,7 +36,8 @@ import (
 // Reader encapsulates a reader for the actual underlying archive, and also provides indices for faster and
 // more convenient iteration and querying of the archive content
 type Reader struct {
-	archiveReader       *zip.ReadCloser
+	archiveReader       *zip.Reader
+	archiveFile         io.Closer
 	path                string
 	filesMap            map[string]*zip.File
 	accountTags         []Tag
@@ -47,6 +51,11 @@ type Reader struct {
 	streamServerNames   map[string][]string
 	ts                  *time.Time
 	invertedIndex       map[Tag][]string
+	manifestMap         map[string][]Tag
+	hashes              map[string]string
+	references          map[string]string
+	base                *Reader
+	encrypted           bool
 }
 
 type AuditMetadata struct {
@@ -56,6 +65,40 @@ type AuditMetadata struct {
 	ConnectURL             string    `json:"connect_url"`
 	UserName               string    `json:"user_name"`
 	CLIVersion             string    `json:"cli_version"`
+	// FailedCaptures are the artifacts that could not be captured, even after retrying, during the gather
+	FailedCaptures []CaptureFailure `json:"failed_captures,omitempty"`
+}
+
+// StreamMessageSamples are the first and last messages of a stream captured during a gather, message bodies are not
+// captured
+type StreamMessageSamples struct {
+	// Server is the server hosting the stream leader when the samples were captured
+	Server string `json:"server"`
+	// FirstSequence and LastSequence are the stream state when the samples were captured
+	FirstSequence uint64 `json:"first_seq"`
+	LastSequence  uint64 `json:"last_seq"`
+	// Messages are the sampled messages ordered by sequence
+	Messages []MessageSample `json:"messages"`
+}
+
+// MessageSample holds the metadata and headers of a message stored in a stream
+type MessageSample struct {
+	Sequence uint64              `json:"seq"`
+	Subject  string              `json:"subject"`
+	Time     time.Time           `json:"time"`
+	Header   map[string][]string `json:"header,omitempty"`
+	// Size is the size of the message body that was not captured
+	Size int `json:"size"`
+}
+
+// CaptureFailure describes an artifact a gather failed to capture
+type CaptureFailure struct {
+	// Phase is the gather phase that attempted the capture, like server_endpoints
+	Phase string `json:"phase"`
+	// Target identifies what was captured, like a server and endpoint
+	Target string `json:"target"`
+	// Error is the last error encountered
+	Error string `json:"error"`
 }
 
 func (r *Reader) rawFilesCount() int {
@@ -64,12 +107,21 @@ func (r *Reader) rawFilesCount() int {
 
 // Close closes the reader
 func (r *Reader) Close() error {
-	if r.archiveReader != nil {
-		err := r.archiveReader.Close()
-		r.archiveReader = nil
-		return err
+	var err error
+	if r.archiveFile != nil {
+		err = r.archiveFile.Close()
+		r.archiveFile = nil
 	}
-	return nil
+
+	if r.base != nil {
+		baseErr := r.base.Close()
+		r.base = nil
+		if err == nil {
+			err = baseErr
+		}
+	}
+
+	return err
 }
 
 // getFileReader create a reader for the given filename, if it exists in the archive.
@@ -85,6 +137,38 @@ func (r *Reader) getFileReader(name string) (io.ReadCloser, uint64, error) {
 	return reader, f.UncompressedSize64, nil
 }
 
+// readFile reads the entire content of the given filename
+func (r *Reader) readFile(name string) ([]byte, error) {
+	f, _, err := r.getFileReader(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
+
+// fileTags are the tags of the given filename as recorded in the manifest
+func (r *Reader) fileTags(name string) []*Tag {
+	var tags []*Tag
+	for _, tag := range r.manifestMap[name] {
+		tags = append(tags, &tag)
+	}
+
+	return tags
+}
+
+// fileNames are the names of all files in the manifest, sorted so that pages are in order
+func (r *Reader) fileNames() []string {
+	names := make([]string, 0, len(r.manifestMap))
+	for name := range r.manifestMap {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
 // loadFile decodes the provided filename into the given value
 func (r *Reader) loadFile(name string, v any) error {
 	f, _, err := r.getFileReader(name)
@@ -102,6 +186,20 @@ func (r *Reader) loadFile(name string, v any) error {
 // ErrNoMatches is returned if no artifact matched the input combination of tags
 var ErrNoMatches = fmt.Errorf("no file matched the given query")
 
+const (
+	// hashCommentPrefix prefixes the content hash stored in the comment of archive entries
+	hashCommentPrefix = "sha256:"
+	// referenceCommentPrefix prefixes the content hash of empty entries whose content is in the base archive
+	referenceCommentPrefix = "ref:sha256:"
+)
+
+func isReferenceComment(comment string) bool {
+	return strings.HasPrefix(comment, referenceCommentPrefix)
+}
+
+// ErrIncrementalArchive is returned when opening an incremental archive without its base archive, see BaseArchive()
+var ErrIncrementalArchive = fmt.Errorf("archive is incremental, the base archive is required")
+
 // ErrMultipleMatches is returned if multiple artifact matched the input combination of tags
 var ErrMultipleMatches = fmt.Errorf("multiple files matched the given query")
 
@@ -127,16 +225,177 @@ func (r *Reader) Load(v any, queryTags ...*Tag) error {
 	return ErrMultipleMatches
 }
 
+// ServerProfile loads the raw pprof profile captured from a server, profiles captured with a debug level have it
+// appended to their name, for example goroutine_1. Use an empty cluster name for servers that are not clustered
+func (r *Reader) ServerProfile(clusterName string, serverName string, profileName string) ([]byte, error) {
+	matching, err := intersectFileSets(r.invertedIndex, []*Tag{clusterTag(clusterName), TagServer(serverName), TagServerProfile(), TagProfileName(profileName)})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matching) > 1 {
+		return nil, ErrMultipleMatches
+	}
+
+	for file := range matching {
+		return r.readFile(file)
+	}
+
+	return nil, ErrNoMatches
+}
+
+// StreamMessageSamples loads the message samples captured for a stream, the most recent samples are returned when
+// several were captured
+func (r *Reader) StreamMessageSamples(accountName string, streamName string) (*StreamMessageSamples, error) {
+	return loadLatest[StreamMessageSamples](r, TagAccount(accountName), TagStream(streamName), TagStreamMessageSamples())
+}
+
+// ErrUnsupportedFormat is returned when opening a file that is not a ZIP archive
+var ErrUnsupportedFormat = fmt.Errorf("unsupported archive format, archives are ZIP files")
+
+// checkArchiveFormat detects the format of the archive using its magic bytes so that other files are rejected
+// with a clear error rather than a generic zip error, returns true for archives encrypted by a Writer
+func checkArchiveFormat(archivePath string) (bool, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	magic := make([]byte, len(encryptedArchiveMagic))
+	n, err := io.ReadFull(f, magic)
+	if err != nil && n < 4 {
+		return false, fmt.Errorf("failed to open archive: %w", ErrUnsupportedFormat)
+	}
+
+	switch {
+	case n == len(magic) && string(magic) == encryptedArchiveMagic:
+		return true, nil
+	// a local file header, or the end of central directory record of an archive without files
+	case string(magic[:4]) == "PK\x03\x04", string(magic[:4]) == "PK\x05\x06":
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to open archive: %w", ErrUnsupportedFormat)
+	}
+}
+
+// ServerAPIAccountInfoResponse is the response type of the account INFO endpoint
+type ServerAPIAccountInfoResponse struct {
+	Server *server.ServerInfo  `json:"server"`
+	Data   *server.AccountInfo `json:"data,omitempty"`
+	Error  *server.ApiError    `json:"error,omitempty"`
+}
+
+// loadLatest loads the most recent sample of the artifact matching tags, used by the generated typed accessors
+func loadLatest[T any](r *Reader, tags ...*Tag) (*T, error) {
+	var latest *T
+	err := ForEachTaggedArtifact(r, tags, func(v *T) error {
+		latest = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return latest, nil
+}
+
+// clusterTag creates the cluster tag for clusterName, an empty name indicates an unclustered server
+func clusterTag(clusterName string) *Tag {
+	if clusterName == "" {
+		return TagNoCluster()
+	}
+
+	return TagCluster(clusterName)
+}
+
 // NewReader creates a new reader for the file at the given archivePath.
 // Reader expect the file to comply to format and content created by a Writer in this same package.
 // During creation, Reader creates in-memory indices to speed up subsequent queries.
-func NewReader(archivePath string) (*Reader, error) {
-	// Create a zip reader
-	archiveReader, err := zip.OpenReader(archivePath)
+// Encrypted archives are decrypted into memory using the key set with EncryptionKey()
+func NewReader(archivePath string, opts ...Option) (*Reader, error) {
+	o, err := newOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := checkArchiveFormat(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !encrypted {
+		archiveFile, err := zip.OpenReader(archivePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open archive: %w", err)
+		}
+
+		reader, err := openReader(archivePath, &archiveFile.Reader, o)
+		if err != nil {
+			archiveFile.Close()
+			return nil, err
+		}
+		reader.archiveFile = archiveFile
+
+		return reader, nil
+	}
+
+	if o.key == nil {
+		return nil, ErrEncryptedArchive
+	}
+
+	decrypted, err := decryptArchiveFile(archivePath, o.key)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveReader, err := zip.NewReader(bytes.NewReader(decrypted), int64(len(decrypted)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open archive: %w", err)
 	}
 
+	reader, err := openReader(archivePath, archiveReader, o)
+	if err != nil {
+		return nil, err
+	}
+	reader.encrypted = true
+
+	return reader, nil
+}
+
+// openBaseArchive opens the base archive of an incremental archive and adds the referenced artifacts to filesMap
+func openBaseArchive(o *options, references map[string]string, filesMap map[string]*zip.File) (*Reader, error) {
+	if o.base == "" {
+		return nil, ErrIncrementalArchive
+	}
+
+	base, err := NewReader(o.base, func(bo *options) error {
+		bo.key = o.key
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open base archive: %w", err)
+	}
+
+	if len(base.references) > 0 {
+		base.Close()
+		return nil, fmt.Errorf("base archive may not be incremental")
+	}
+
+	for name, hash := range references {
+		f, ok := base.filesMap[name]
+		if !ok || base.hashes[name] != hash {
+			base.Close()
+			return nil, fmt.Errorf("base archive does not match: %s is missing or changed", name)
+		}
+		filesMap[name] = f
+	}
+
+	return base, nil
+}
+
+// openReader indexes the ZIP archive read by archiveReader, the archive at archivePath or a decrypted copy of it
+func openReader(archivePath string, archiveReader *zip.Reader, o *options) (*Reader, error) {
 	// Create map of filename -> file
 	filesMap := make(map[string]*zip.File, len(archiveReader.File))
 	for _, f := range archiveReader.File {
@@ -176,6 +435,28 @@ func NewReader(archivePath string) (*Reader, error) {
 		}
 	}
 
+	// Content hashes are stored in entry comments, archives created by older versions have none
+	hashes := make(map[string]string)
+	references := make(map[string]string)
+	for name, f := range filesMap {
+		switch {
+		case strings.HasPrefix(f.Comment, referenceCommentPrefix):
+			references[name] = strings.TrimPrefix(f.Comment, referenceCommentPrefix)
+			hashes[name] = references[name]
+		case strings.HasPrefix(f.Comment, hashCommentPrefix):
+			hashes[name] = strings.TrimPrefix(f.Comment, hashCommentPrefix)
+		}
+	}
+
+	// Resolve artifacts of incremental archives that are stored in the base archive
+	var base *Reader
+	if len(references) > 0 {
+		base, err = openBaseArchive(o, references, filesMap)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Check that each file in the manifest exists in the archive
 	for fileName := range manifestMap {
 		_, present := filesMap[fileName]
@@ -311,6 +592,10 @@ func NewReader(archivePath string) (*Reader, error) {
 		streamServerNames:   streamsServers,
 		ts:                  &manifestFile.Modified,
 		invertedIndex:       invertedIndex,
+		manifestMap:         manifestMap,
+		hashes:              hashes,
+		references:          references,
+		base:                base,
 	}
 
 	return reader, nil
@@ -559,3 +844,13 @@ func intersectFileSets(index map[Tag][]string, tags []*Tag) (map[string]struct{}
 	}
 	return result, nil
 }
+
+// Path is the path to the archive being read
+func (r *Reader) Path() string {
+	return r.path
+}
+
+// Encrypted indicates the archive being read is encrypted, see EncryptionKey()
+func (r *Reader) Encrypted() bool {
+	return r.encrypted
+}
@@ -0,0 +1,257 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+// DefaultRedactionMask is the value that replaces redacted data unless RedactionRules.Mask is set
+const DefaultRedactionMask = "[REDACTED]"
+
+var (
+	ipv4Pattern = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	ipv6Pattern = regexp.MustCompile(`[0-9A-Fa-f]*:[0-9A-Fa-f:.]*:[0-9A-Fa-f:.]*`)
+	jwtPattern  = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+)
+
+// RedactionRules configures how Redact() removes or masks sensitive data in archive artifacts
+type RedactionRules struct {
+	// MaskFields are JSON object keys whose values are replaced by the mask, matched case-insensitively
+	MaskFields []string `json:"mask_fields,omitempty"`
+	// RemoveFields are JSON object keys that are removed, matched case-insensitively
+	RemoveFields []string `json:"remove_fields,omitempty"`
+	// IPs masks IPv4 and IPv6 addresses found in any value
+	IPs bool `json:"ips"`
+	// JWTs masks JWTs found in any value
+	JWTs bool `json:"jwts"`
+	// Subjects masks values and object keys that are subjects matching any of these subjects, wildcards are supported
+	Subjects []string `json:"subjects,omitempty"`
+	// Mask replaces redacted values, defaults to DefaultRedactionMask
+	Mask string `json:"mask,omitempty"`
+}
+
+// DefaultRedactionRules masks IP addresses, JWTs and fields commonly holding user names or credentials
+func DefaultRedactionRules() *RedactionRules {
+	return &RedactionRules{
+		MaskFields:   []string{"user", "username", "user_name", "authorized_user", "name_tag"},
+		RemoveFields: []string{"password", "token", "auth_token", "jwt", "nkey", "issuer_key"},
+		IPs:          true,
+		JWTs:         true,
+	}
+}
+
+func (r *RedactionRules) validate() error {
+	for _, subj := range r.Subjects {
+		if !server.IsValidSubject(subj) {
+			return fmt.Errorf("invalid redaction subject %q", subj)
+		}
+	}
+
+	return nil
+}
+
+func (r *RedactionRules) mask() string {
+	if r.Mask == "" {
+		return DefaultRedactionMask
+	}
+
+	return r.Mask
+}
+
+func (r *RedactionRules) matchesSubject(s string) bool {
+	if len(r.Subjects) == 0 || strings.ContainsAny(s, " \t\r\n") || !server.IsValidSubject(s) {
+		return false
+	}
+
+	return slices.ContainsFunc(r.Subjects, func(filter string) bool {
+		return server.SubjectMatchesFilter(s, filter)
+	})
+}
+
+// redactString masks IPs, JWTs and subjects in a string value
+func (r *RedactionRules) redactString(s string) string {
+	if r.matchesSubject(s) {
+		return r.mask()
+	}
+
+	if r.JWTs {
+		s = jwtPattern.ReplaceAllString(s, r.mask())
+	}
+
+	if r.IPs {
+		s = ipv4Pattern.ReplaceAllStringFunc(s, func(m string) string {
+			if net.ParseIP(m) == nil {
+				return m
+			}
+			return r.mask()
+		})
+
+		s = ipv6Pattern.ReplaceAllStringFunc(s, func(m string) string {
+			if net.ParseIP(m) == nil {
+				return m
+			}
+			return r.mask()
+		})
+	}
+
+	return s
+}
+
+// redactValue walks a decoded JSON value applying the rules
+func (r *RedactionRules) redactValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		res := make(map[string]any, len(val))
+		masked := 0
+
+		// sorted so that masked keys are numbered consistently
+		for _, k := range slices.Sorted(maps.Keys(val)) {
+			item := val[k]
+
+			switch {
+			case slices.ContainsFunc(r.RemoveFields, func(f string) bool { return strings.EqualFold(f, k) }):
+				continue
+			case slices.ContainsFunc(r.MaskFields, func(f string) bool { return strings.EqualFold(f, k) }):
+				res[k] = r.mask()
+				continue
+			}
+
+			// subjects used as keys, like in per subject counts, are masked while keeping the entries distinct
+			if r.matchesSubject(k) {
+				masked++
+				k = fmt.Sprintf("%s-%d", r.mask(), masked)
+			}
+
+			res[k] = r.redactValue(item)
+		}
+
+		return res
+
+	case []any:
+		res := make([]any, len(val))
+		for i, item := range val {
+			res[i] = r.redactValue(item)
+		}
+		return res
+
+	case string:
+		return r.redactString(val)
+
+	default:
+		return v
+	}
+}
+
+// redactJSON redacts every JSON document found in data
+func (r *RedactionRules) redactJSON(data []byte) (*bytes.Buffer, error) {
+	out := &bytes.Buffer{}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	for {
+		var v any
+		err := decoder.Decode(&v)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = encoder.Encode(r.redactValue(v))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// Redact creates a copy of the archive at in as out with sensitive data removed or masked according to rules, when
+// rules is nil DefaultRedactionRules() is used. JSON artifacts are redacted while other artifacts, like profiles,
+// are copied unchanged. Tags, and so server, cluster, account and stream names, are kept to allow querying the copy.
+func Redact(in string, out string, rules *RedactionRules) error {
+	if rules == nil {
+		rules = DefaultRedactionRules()
+	}
+
+	err := rules.validate()
+	if err != nil {
+		return err
+	}
+
+	if filepath.Clean(in) == filepath.Clean(out) {
+		return fmt.Errorf("output archive may not be the input archive")
+	}
+
+	reader, err := NewReader(in)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	writer, err := NewWriter(out)
+	if err != nil {
+		return err
+	}
+	if reader.ts != nil {
+		writer.SetTime(*reader.ts)
+	}
+
+	for _, name := range reader.fileNames() {
+		err = redactArtifact(reader, writer, name, rules)
+		if err != nil {
+			writer.Close()
+			return fmt.Errorf("failed to redact %s: %w", name, err)
+		}
+	}
+
+	return writer.Close()
+}
+
+func redactArtifact(reader *Reader, writer *Writer, name string, rules *RedactionRules) error {
+	data, err := reader.readFile(name)
+	if err != nil {
+		return err
+	}
+
+	tags := reader.fileTags(name)
+	extension := strings.TrimPrefix(filepath.Ext(name), ".")
+	if extension != "json" {
+		return writer.AddRaw(bytes.NewReader(data), extension, tags...)
+	}
+
+	redacted, err := rules.redactJSON(data)
+	if err != nil {
+		return err
+	}
+
+	return writer.AddRaw(redacted, extension, tags...)
+}
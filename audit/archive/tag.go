@@ -51,12 +51,13 @@ const (
 	jszArtifactType      = "jetstream_info"
 	accountzArtifactType = "accounts"
 	// Account artifacts
-	accountConnectionsArtifactType = "account_connections"
-	accountLeafsArtifactType       = "account_leafs"
-	accountSubsArtifactType        = "account_subs"
-	accountJetStreamArtifactType   = "account_jetstream_info"
-	accountInfoArtifactType        = "account_info"
-	streamDetailsArtifactType      = "stream_info"
+	accountConnectionsArtifactType   = "account_connections"
+	accountLeafsArtifactType         = "account_leafs"
+	accountSubsArtifactType          = "account_subs"
+	accountJetStreamArtifactType     = "account_jetstream_info"
+	accountInfoArtifactType          = "account_info"
+	streamDetailsArtifactType        = "stream_info"
+	streamMessageSamplesArtifactType = "stream_message_samples"
 	// Other artifacts
 	manifestArtifactName = "manifest"
 	profileArtifactType  = "profile"
@@ -290,6 +291,8 @@ func TagAccountInfo() *Tag {
 
 func TagStreamInfo() *Tag { return TagArtifactType(streamDetailsArtifactType) }
 
+func TagStreamMessageSamples() *Tag { return TagArtifactType(streamMessageSamplesArtifactType) }
+
 func internalTagManifest() *Tag {
 	return TagSpecial(manifestArtifactName)
 }
@@ -16,11 +16,14 @@ package archive
 import (
 	"archive/zip"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -29,15 +32,17 @@ import (
 type Writer struct {
 	path         string
 	fileWriter   *os.File
+	encWriter    io.WriteCloser
 	zipWriter    *zip.Writer
 	manifestMap  map[string][]*Tag
 	ts           *time.Time
 	pagedWriters map[string]*pagedWriter
+	base         *Reader
 }
 
 // Close closes the writer
 func (w *Writer) Close() error {
-	// Add manifest file to archive before closing it
+	// Add references, content hashes and manifest file to archive before closing it
 	if w.zipWriter != nil && w.fileWriter != nil {
 		err := w.Add(w.manifestMap, internalTagManifest())
 		if err != nil {
@@ -45,6 +50,11 @@ func (w *Writer) Close() error {
 		}
 	}
 
+	if w.base != nil {
+		w.base.Close()
+		w.base = nil
+	}
+
 	// Close and null the zip writer
 	if w.zipWriter != nil {
 		err := w.zipWriter.Close()
@@ -54,6 +64,15 @@ func (w *Writer) Close() error {
 		}
 	}
 
+	// Flush and null the encrypting writer
+	if w.encWriter != nil {
+		err := w.encWriter.Close()
+		w.encWriter = nil
+		if err != nil {
+			return fmt.Errorf("failed to close archive encryption writer: %w", err)
+		}
+	}
+
 	// Close and null the file writer
 	if w.fileWriter != nil {
 		err := w.fileWriter.Close()
@@ -104,11 +123,20 @@ func (w *Writer) SetTime(t time.Time) {
 // AddRaw adds the given artifact to the archive similarly to Add.
 // The artifact is assumed to be already serialized and is copied as-is byte for byte.
 // If the artifact is tagged as "special", it will be written as a single non-paged file.
+// For incremental archives artifacts identical to those in the base archive are stored as references.
 func (w *Writer) AddRaw(reader io.Reader, extension string, tags ...*Tag) error {
 	if w.zipWriter == nil {
 		return fmt.Errorf("attempting to write into a closed writer")
 	}
 
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read artifact: %w", err)
+	}
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+	reader = bytes.NewReader(content)
+
 	dir, err := dirNameFromTags(tags)
 	if err != nil {
 		return fmt.Errorf("failed to determine directory from tags: %w", err)
@@ -125,7 +153,12 @@ func (w *Writer) AddRaw(reader io.Reader, extension string, tags ...*Tag) error
 			Name:     filename,
 			Method:   zip.Deflate,
 			Modified: tsToUTC(w.ts),
+			Comment:  w.entryComment(filename, hash, tags),
+		}
+		if isReferenceComment(header.Comment) {
+			reader = bytes.NewReader(nil)
 		}
+
 		wr, err := w.zipWriter.CreateHeader(header)
 		if err != nil {
 			return fmt.Errorf("failed to create zip entry for special artifact: %w", err)
@@ -144,8 +177,12 @@ func (w *Writer) AddRaw(reader io.Reader, extension string, tags ...*Tag) error
 
 	// Everything else gets paged
 	pw := w.PagedWriter(dir)
+	comment := w.entryComment(pw.nextPageName(), hash, tags)
+	if isReferenceComment(comment) {
+		reader = bytes.NewReader(nil)
+	}
 
-	if err := pw.WriteEntry(reader); err != nil {
+	if err := pw.writeEntry(reader, comment); err != nil {
 		return fmt.Errorf("failed to write page: %w", err)
 	}
 
@@ -153,6 +190,49 @@ func (w *Writer) AddRaw(reader io.Reader, extension string, tags ...*Tag) error
 	return w.addPathToManifest(pw.pageIndex-1, extension, tags)
 }
 
+// AddArchive adds the artifacts of the archive read by r for which filter returns true, or all artifacts when filter
+// is nil, keeping their tags and page order. Special files like the capture metadata and log are not copied.
+func (w *Writer) AddArchive(r *Reader, filter func(tags []*Tag) bool) error {
+	for _, name := range r.fileNames() {
+		tags := r.fileTags(name)
+		if len(tags) == 1 && tags[0].Name == specialTagLabel {
+			continue
+		}
+		if filter != nil && !filter(tags) {
+			continue
+		}
+
+		data, err := r.readFile(name)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		err = w.AddRaw(bytes.NewReader(data), strings.TrimPrefix(filepath.Ext(name), "."), tags...)
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// entryComment creates the comment of an archive entry holding its content hash. For incremental archives, entries
+// whose content is stored under the same name in the base archive are marked as references and stored empty.
+// Special artifacts are always stored.
+func (w *Writer) entryComment(filename string, hash string, tags []*Tag) string {
+	if w.base == nil || w.base.hashes[filename] != hash || w.base.references[filename] != "" {
+		return hashCommentPrefix + hash
+	}
+
+	for _, t := range tags {
+		if t.Name == specialTagLabel {
+			return hashCommentPrefix + hash
+		}
+	}
+
+	return referenceCommentPrefix + hash
+}
+
 func isNonPagedArtifact(tags []*Tag) bool {
 	for _, t := range tags {
 		if t.Name == specialTagLabel {
@@ -168,20 +248,66 @@ func isNonPagedArtifact(tags []*Tag) bool {
 // NewWriter creates a new writer for the file at the given archivePath.
 // Writer creates a ZIP file whose content has additional structure and metadata.
 // If archivePath is an existing file, it will be overwritten.
-func NewWriter(archivePath string) (*Writer, error) {
+// When a key is set using EncryptionKey() the ZIP file is encrypted as it is written.
+// When a base archive is set using BaseArchive() an incremental archive is created.
+func NewWriter(archivePath string, opts ...Option) (*Writer, error) {
+	o, err := newOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var base *Reader
+	if o.base != "" {
+		if filepath.Clean(o.base) == filepath.Clean(archivePath) {
+			return nil, fmt.Errorf("base archive may not be the archive being written")
+		}
+
+		base, err = NewReader(o.base, func(bo *options) error {
+			bo.key = o.key
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open base archive: %w", err)
+		}
+
+		if len(base.references) > 0 {
+			base.Close()
+			return nil, fmt.Errorf("base archive may not be incremental")
+		}
+	}
+
 	fileWriter, err := os.Create(archivePath)
 	if err != nil {
+		if base != nil {
+			base.Close()
+		}
 		return nil, fmt.Errorf("failed to create archive: %w", err)
 	}
 
-	zipWriter := zip.NewWriter(fileWriter)
+	var out io.Writer = fileWriter
+	var encWriter io.WriteCloser
+	if o.key != nil {
+		encWriter, err = newEncryptingWriter(fileWriter, o.key)
+		if err != nil {
+			fileWriter.Close()
+			if base != nil {
+				base.Close()
+			}
+			return nil, fmt.Errorf("failed to create archive: %w", err)
+		}
+		out = encWriter
+	}
+
+	zipWriter := zip.NewWriter(out)
 
 	return &Writer{
 		path:         archivePath,
 		fileWriter:   fileWriter,
+		encWriter:    encWriter,
 		zipWriter:    zipWriter,
 		manifestMap:  make(map[string][]*Tag),
 		pagedWriters: make(map[string]*pagedWriter),
+		base:         base,
 	}, nil
 }
 
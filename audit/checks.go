@@ -14,7 +14,10 @@
 package audit
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"runtime"
 	"slices"
 	"sort"
 	"strings"
@@ -26,8 +29,11 @@ import (
 	"golang.org/x/exp/maps"
 )
 
-// CheckFunc implements a check over gathered audit
-type CheckFunc func(check *Check, reader *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error)
+// CheckFunc implements a check over gathered audit, ctx is cancelled when the check exceeds its time budget
+type CheckFunc func(ctx context.Context, check *Check, reader *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error)
+
+// DefaultCheckTimeout is the default time budget for a single check, see CheckCollection.SetCheckTimeout()
+const DefaultCheckTimeout = 5 * time.Minute
 
 // Check is the basic unit of analysis that is run against a data archive
 type Check struct {
@@ -35,6 +41,10 @@ type Check struct {
 	Suite         string                         `json:"suite"`
 	Name          string                         `json:"name"`
 	Description   string                         `json:"description"`
+	Severity      Severity                       `json:"severity"`
+	Remediation   string                         `json:"remediation,omitempty"`
+	URL           string                         `json:"url,omitempty"`
+	Tags          []string                       `json:"tags,omitempty"`
 	Configuration map[string]*CheckConfiguration `json:"configuration"`
 	Handler       CheckFunc                      `json:"-"`
 }
@@ -46,9 +56,41 @@ type CheckCollection struct {
 	suites        map[string][]*Check
 	skipCheck     []string
 	skipSuite     []string
+	severity      map[string]Severity
+	includeTags   [][]string
+	excludeTags   [][]string
+	workers       int
+	timeout       *time.Duration
 	mu            sync.Mutex
 }
 
+// SetCheckTimeout sets the time budget for every check, checks exceeding the budget are reported as skipped,
+// 0 disables the budget. Defaults to DefaultCheckTimeout
+func (c *CheckCollection) SetCheckTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return fmt.Errorf("check timeout can not be negative")
+	}
+
+	c.mu.Lock()
+	c.timeout = &timeout
+	c.mu.Unlock()
+
+	return nil
+}
+
+// SetConcurrency sets how many checks are run concurrently by Run(), defaults to the number of CPUs
+func (c *CheckCollection) SetConcurrency(workers int) error {
+	if workers < 1 {
+		return fmt.Errorf("at least 1 worker is required")
+	}
+
+	c.mu.Lock()
+	c.workers = workers
+	c.mu.Unlock()
+
+	return nil
+}
+
 // SkipChecks marks certain tests to be skipped while running the collection
 func (c *CheckCollection) SkipChecks(checks ...string) {
 	c.mu.Lock()
@@ -73,6 +115,98 @@ func (c *CheckCollection) SkipSuites(suites ...string) {
 	}
 }
 
+// SelectTags limits the checks being run using tag expressions, a check is run when it matches any include
+// expression, or no include expressions are given, and matches none of the exclude expressions.
+//
+// An expression is one or more tags joined by +, matching checks that have all the tags, for example
+// "capacity" or "jetstream+performance". Tags are compared case-insensitively and checks not selected
+// are reported as skipped
+func (c *CheckCollection) SelectTags(include []string, exclude []string) error {
+	inc, err := parseTagExpressions(include)
+	if err != nil {
+		return err
+	}
+
+	exc, err := parseTagExpressions(exclude)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.includeTags = inc
+	c.excludeTags = exc
+	c.mu.Unlock()
+
+	return nil
+}
+
+func parseTagExpressions(exprs []string) ([][]string, error) {
+	var res [][]string
+
+	for _, expr := range exprs {
+		var tags []string
+		for _, tag := range strings.Split(expr, "+") {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if tag == "" {
+				return nil, fmt.Errorf("invalid tag expression %q", expr)
+			}
+			tags = append(tags, tag)
+		}
+
+		res = append(res, tags)
+	}
+
+	return res, nil
+}
+
+// HasTag determines if the check has tag, the suite name is an implicit tag
+func (c *Check) HasTag(tag string) bool {
+	if strings.EqualFold(c.Suite, tag) {
+		return true
+	}
+
+	return slices.ContainsFunc(c.Tags, func(t string) bool {
+		return strings.EqualFold(t, tag)
+	})
+}
+
+func (c *Check) matchesTagExpression(expr []string) bool {
+	for _, tag := range expr {
+		if !c.HasTag(tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// selectedByTags determines if check should run based on tags set using SelectTags(), c.mu must be held
+func (c *CheckCollection) selectedByTags(check *Check) bool {
+	if len(c.includeTags) > 0 && !slices.ContainsFunc(c.includeTags, check.matchesTagExpression) {
+		return false
+	}
+
+	return !slices.ContainsFunc(c.excludeTags, check.matchesTagExpression)
+}
+
+// SetSeverity overrides the severity of the check identified by code
+func (c *CheckCollection) SetSeverity(code string, severity Severity) error {
+	if !severity.IsValid() {
+		return fmt.Errorf("invalid severity %q", severity)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.severity == nil {
+		c.severity = make(map[string]Severity)
+	}
+
+	c.severity[strings.ToUpper(code)] = severity
+
+	return nil
+}
+
 // Register adds a check to the collection
 func (c *CheckCollection) Register(checks ...Check) error {
 	c.mu.Lock()
@@ -104,6 +238,12 @@ func (c *CheckCollection) Register(checks ...Check) error {
 		if check.Handler == nil {
 			return fmt.Errorf("check implementation is required")
 		}
+		if check.Severity == "" {
+			check.Severity = SeverityWarning
+		}
+		if !check.Severity.IsValid() {
+			return fmt.Errorf("invalid severity %q", check.Severity)
+		}
 		if check.Configuration == nil {
 			check.Configuration = make(map[string]*CheckConfiguration)
 		}
@@ -150,6 +290,7 @@ func NewDefaultCheckCollection() (*CheckCollection, error) {
 		RegisterMetaChecks,
 		RegisterServerChecks,
 		RegisterJetStreamChecks,
+		RegisterConsumerChecks,
 	} {
 		err := f(c)
 		if err != nil {
@@ -210,6 +351,17 @@ func (o Outcome) String() string {
 	}
 }
 
+// ParseOutcome parses the 4-letter string value of an outcome
+func ParseOutcome(s string) (Outcome, error) {
+	for _, o := range Outcomes {
+		if strings.EqualFold(s, o.String()) {
+			return o, nil
+		}
+	}
+
+	return Skipped, fmt.Errorf("invalid outcome %q", s)
+}
+
 // ConfigurationItems loads a list of config items sorted by check
 //
 // Use in fisk applications like:
@@ -242,15 +394,55 @@ func (c *CheckCollection) ConfigurationItems() []*CheckConfiguration {
 	return res
 }
 
-// runCheck is a wrapper to run a check, handling setup and errors
-func runCheck(check *Check, ar *archive.Reader, limit uint, log api.Logger) (Outcome, *ExamplesCollection) {
-	examples := newExamplesCollection(limit)
-	outcome, err := check.Handler(check, ar, examples, log)
-	if err != nil {
-		examples.Error = err.Error()
+// runCheck is a wrapper to run a check, handling setup, errors and the time budget. Handlers that do not
+// return once their budget is exceeded are abandoned and reported as skipped
+func runCheck(ctx context.Context, check *Check, ar *archive.Reader, limit uint, timeout time.Duration, log api.Logger) (Outcome, *ExamplesCollection) {
+	if err := ctx.Err(); err != nil {
+		examples := newExamplesCollection(limit)
+		examples.Error = fmt.Sprintf("check not run: %v", err)
 		return Skipped, examples
 	}
-	return outcome, examples
+
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	type result struct {
+		outcome Outcome
+		err     error
+	}
+
+	examples := newExamplesCollection(limit)
+	done := make(chan result, 1)
+
+	go func() {
+		outcome, err := check.Handler(ctx, check, ar, examples, log)
+		done <- result{outcome, err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			examples.Error = res.err.Error()
+			return Skipped, examples
+		}
+		return res.outcome, examples
+
+	case <-ctx.Done():
+		// the abandoned handler keeps its own examples, so report a fresh collection
+		abandoned := newExamplesCollection(limit)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			abandoned.Error = fmt.Sprintf("check timed out after %v", timeout)
+			log.Errorf("Check %s timed out after %v", check.Code, timeout)
+		} else {
+			abandoned.Error = fmt.Sprintf("check interrupted: %v", ctx.Err())
+		}
+		return Skipped, abandoned
+	}
 }
 
 // CheckResult is a outcome of a single check
@@ -258,6 +450,7 @@ type CheckResult struct {
 	Check         Check              `json:"check"`
 	Outcome       Outcome            `json:"outcome"`
 	OutcomeString string             `json:"outcome_string"`
+	Severity      Severity           `json:"severity"`
 	Examples      ExamplesCollection `json:"examples"`
 }
 
@@ -279,7 +472,15 @@ func (c *CheckCollection) EachCheck(cb func(c *Check)) {
 	}
 }
 
+// Run runs all checks that are not skipped against the archive, see RunContext()
 func (c *CheckCollection) Run(ar *archive.Reader, limit uint, log api.Logger) *Analysis {
+	return c.RunContext(context.Background(), ar, limit, log)
+}
+
+// RunContext runs all checks that are not skipped against the archive, checks are run concurrently according to
+// SetConcurrency() while results are reported in the same order as EachCheck(). Each check is limited to the
+// budget set using SetCheckTimeout() and checks not yet run when ctx is cancelled are reported as skipped
+func (c *CheckCollection) RunContext(ctx context.Context, ar *archive.Reader, limit uint, log api.Logger) *Analysis {
 	result := &Analysis{
 		Type:          "io.nats.audit.v1.analysis",
 		Timestamp:     time.Now().UTC(),
@@ -304,6 +505,14 @@ func (c *CheckCollection) Run(ar *archive.Reader, limit uint, log api.Logger) *A
 		result.Outcomes[outcome.String()] = 0
 	}
 
+	type job struct {
+		check    Check
+		should   bool
+		severity Severity
+	}
+
+	var jobs []job
+
 	c.EachCheck(func(check *Check) {
 		should := !slices.ContainsFunc(c.skipCheck, func(s string) bool {
 			return strings.EqualFold(check.Code, s)
@@ -313,29 +522,74 @@ func (c *CheckCollection) Run(ar *archive.Reader, limit uint, log api.Logger) *A
 			return strings.EqualFold(check.Suite, s)
 		})
 
-		var res CheckResult
-		if should {
-			outcome, examples := runCheck(check, ar, limit, log)
-			res = CheckResult{
-				Check:   *check,
-				Outcome: outcome,
-			}
+		should = should && c.selectedByTags(check)
 
-			if examples != nil && len(examples.Examples) > 0 {
-				res.Examples = *examples
-			}
-		} else {
-			res = CheckResult{
-				Check:   *check,
-				Outcome: Skipped,
-			}
+		severity := check.Severity
+		if sev, ok := c.severity[strings.ToUpper(check.Code)]; ok {
+			severity = sev
 		}
 
-		res.OutcomeString = res.Outcome.String()
+		jobs = append(jobs, job{*check, should, severity})
+	})
 
+	c.mu.Lock()
+	workers := c.workers
+	timeout := DefaultCheckTimeout
+	if c.timeout != nil {
+		timeout = *c.timeout
+	}
+	c.mu.Unlock()
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	// results are stored by index so the order does not depend on which checks finish first
+	results := make([]CheckResult, len(jobs))
+	queue := make(chan int)
+	wg := sync.WaitGroup{}
+
+	for range min(workers, max(len(jobs), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range queue {
+				j := &jobs[i]
+				res := CheckResult{
+					Check:    j.check,
+					Outcome:  Skipped,
+					Severity: j.severity,
+				}
+				res.Check.Severity = j.severity
+
+				if j.should {
+					outcome, examples := runCheck(ctx, &j.check, ar, limit, timeout, log)
+					res.Outcome = outcome
+
+					if examples != nil && (len(examples.Examples) > 0 || examples.Error != "") {
+						res.Examples = ExamplesCollection{Examples: examples.Examples, Details: examples.Details, Error: examples.Error, Limit: examples.Limit}
+					}
+				}
+
+				res.OutcomeString = res.Outcome.String()
+				results[i] = res
+			}
+		}()
+	}
+
+	for i := range jobs {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	for _, res := range results {
 		result.Results = append(result.Results, res)
 		result.Outcomes[res.Outcome.String()]++
-	})
+		result.Verdict = max(result.Verdict, verdictFor(res.Outcome, res.Severity))
+	}
+
+	result.VerdictString = result.Verdict.String()
 
 	return result
 }
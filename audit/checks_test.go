@@ -0,0 +1,350 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/audit/archive"
+)
+
+func emptyArchiveReader(t *testing.T) *archive.Reader {
+	t.Helper()
+
+	archivePath := filepath.Join(t.TempDir(), "audit.zip")
+
+	writer, err := archive.NewWriter(archivePath)
+	if err != nil {
+		t.Fatalf("failed to create archive writer: %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close archive: %v", err)
+	}
+
+	reader, err := archive.NewReader(archivePath)
+	if err != nil {
+		t.Fatalf("failed to open archive: %v", err)
+	}
+	t.Cleanup(func() { reader.Close() })
+
+	return reader
+}
+
+func fixedOutcomeCheck(code string, severity Severity, outcome Outcome) Check {
+	return Check{
+		Code:        code,
+		Suite:       "test",
+		Name:        code,
+		Description: code,
+		Severity:    severity,
+		Handler: func(_ context.Context, _ *Check, _ *archive.Reader, _ *ExamplesCollection, _ api.Logger) (Outcome, error) {
+			return outcome, nil
+		},
+	}
+}
+
+func TestCheckCollection_Severity(t *testing.T) {
+	t.Run("Should default to warning", func(t *testing.T) {
+		cc := &CheckCollection{}
+		cc.MustRegister(fixedOutcomeCheck("T_001", "", Pass))
+
+		if cc.registered["T_001"].Severity != SeverityWarning {
+			t.Fatalf("expected warning severity, got %v", cc.registered["T_001"].Severity)
+		}
+	})
+
+	t.Run("Should reject invalid severities", func(t *testing.T) {
+		cc := &CheckCollection{}
+		if err := cc.Register(fixedOutcomeCheck("T_001", "fatal", Pass)); err == nil {
+			t.Fatalf("expected an error")
+		}
+		if err := cc.SetSeverity("T_001", "fatal"); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("Should weigh the verdict by severity", func(t *testing.T) {
+		cases := []struct {
+			checks   []Check
+			verdict  Outcome
+			exitCode int
+		}{
+			{[]Check{fixedOutcomeCheck("T_001", SeverityInfo, Fail), fixedOutcomeCheck("T_002", SeverityCritical, Pass)}, Pass, 0},
+			{[]Check{fixedOutcomeCheck("T_001", SeverityWarning, Fail), fixedOutcomeCheck("T_002", SeverityCritical, PassWithIssues)}, PassWithIssues, 1},
+			{[]Check{fixedOutcomeCheck("T_001", SeverityWarning, Fail), fixedOutcomeCheck("T_002", SeverityCritical, Fail)}, Fail, 2},
+			{[]Check{fixedOutcomeCheck("T_001", SeverityCritical, Skipped)}, Pass, 0},
+		}
+
+		for i, c := range cases {
+			cc := &CheckCollection{}
+			cc.MustRegister(c.checks...)
+
+			analysis := cc.Run(emptyArchiveReader(t), 0, api.NewDefaultLogger(api.ErrorLevel))
+			if analysis.Verdict != c.verdict {
+				t.Errorf("case %d: expected verdict %v, got %v", i, c.verdict, analysis.Verdict)
+			}
+			if analysis.VerdictString != c.verdict.String() {
+				t.Errorf("case %d: expected verdict string %v, got %v", i, c.verdict, analysis.VerdictString)
+			}
+			if analysis.ExitCode() != c.exitCode {
+				t.Errorf("case %d: expected exit code %d, got %d", i, c.exitCode, analysis.ExitCode())
+			}
+		}
+	})
+
+	t.Run("Should support overriding severity", func(t *testing.T) {
+		cc := &CheckCollection{}
+		cc.MustRegister(fixedOutcomeCheck("T_001", SeverityWarning, Fail))
+
+		err := cc.SetSeverity("t_001", SeverityCritical)
+		if err != nil {
+			t.Fatalf("set severity failed: %v", err)
+		}
+
+		analysis := cc.Run(emptyArchiveReader(t), 0, api.NewDefaultLogger(api.ErrorLevel))
+		if analysis.Verdict != Fail {
+			t.Fatalf("expected verdict %v, got %v", Fail, analysis.Verdict)
+		}
+		if analysis.Results[0].Severity != SeverityCritical {
+			t.Fatalf("expected critical result severity, got %v", analysis.Results[0].Severity)
+		}
+	})
+}
+
+func TestCheckCollection_Run(t *testing.T) {
+	t.Run("Should reject invalid concurrency", func(t *testing.T) {
+		cc := &CheckCollection{}
+		if err := cc.SetConcurrency(0); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("Should run checks concurrently", func(t *testing.T) {
+		started := sync.WaitGroup{}
+		started.Add(2)
+
+		waiting := func(_ context.Context, _ *Check, _ *archive.Reader, examples *ExamplesCollection, _ api.Logger) (Outcome, error) {
+			started.Done()
+
+			done := make(chan struct{})
+			go func() {
+				started.Wait()
+				close(done)
+			}()
+
+			select {
+			case <-done:
+				examples.Add("ran concurrently")
+				return Pass, nil
+			case <-time.After(5 * time.Second):
+				return Fail, nil
+			}
+		}
+
+		cc := &CheckCollection{}
+		cc.MustRegister(
+			Check{Code: "T_001", Suite: "test", Name: "T_001", Description: "T_001", Handler: waiting},
+			Check{Code: "T_002", Suite: "test", Name: "T_002", Description: "T_002", Handler: waiting},
+		)
+
+		err := cc.SetConcurrency(2)
+		if err != nil {
+			t.Fatalf("set concurrency failed: %v", err)
+		}
+
+		analysis := cc.Run(emptyArchiveReader(t), 0, api.NewDefaultLogger(api.ErrorLevel))
+		for _, res := range analysis.Results {
+			if res.Outcome != Pass || res.Examples.Count() != 1 {
+				t.Fatalf("expected check %s to pass concurrently, got %v", res.Check.Code, res.Outcome)
+			}
+		}
+	})
+
+	t.Run("Should report results in a deterministic order", func(t *testing.T) {
+		cc := &CheckCollection{}
+		var expected []string
+		for i := 20; i > 0; i-- {
+			code := fmt.Sprintf("T_%03d", i)
+			cc.MustRegister(fixedOutcomeCheck(code, SeverityWarning, Pass))
+			expected = append([]string{code}, expected...)
+		}
+		cc.SkipChecks("T_005")
+
+		err := cc.SetConcurrency(8)
+		if err != nil {
+			t.Fatalf("set concurrency failed: %v", err)
+		}
+
+		analysis := cc.Run(emptyArchiveReader(t), 0, api.NewDefaultLogger(api.ErrorLevel))
+		if len(analysis.Results) != len(expected) {
+			t.Fatalf("expected %d results, got %d", len(expected), len(analysis.Results))
+		}
+
+		for i, res := range analysis.Results {
+			if res.Check.Code != expected[i] {
+				t.Fatalf("expected %s at position %d, got %s", expected[i], i, res.Check.Code)
+			}
+		}
+
+		if analysis.Results[4].Outcome != Skipped || analysis.Outcomes["SKIP"] != 1 || analysis.Outcomes["PASS"] != 19 {
+			t.Fatalf("unexpected outcomes %v", analysis.Outcomes)
+		}
+	})
+}
+
+func TestCheckCollection_Remediation(t *testing.T) {
+	cc := &CheckCollection{}
+	cc.MustRegister(Check{
+		Code:        "T_001",
+		Suite:       "test",
+		Name:        "T_001",
+		Description: "T_001",
+		Remediation: "Restart the server",
+		URL:         "https://docs.nats.io",
+		Handler: func(_ context.Context, _ *Check, _ *archive.Reader, examples *ExamplesCollection, _ api.Logger) (Outcome, error) {
+			examples.AddWithContext(ExampleContext{Cluster: "C1", Server: "n1"}, "server %s is unhealthy", "n1")
+			return Fail, nil
+		},
+	})
+
+	analysis := cc.Run(emptyArchiveReader(t), 0, api.NewDefaultLogger(api.ErrorLevel))
+	res := analysis.Results[0]
+
+	if res.Check.Remediation != "Restart the server" || res.Check.URL != "https://docs.nats.io" {
+		t.Fatalf("expected remediation to be reported, got %+v", res.Check)
+	}
+
+	if len(res.Examples.Details) != 1 || res.Examples.Details[0].Server != "n1" || res.Examples.Details[0].Cluster != "C1" || res.Examples.Details[0].Message != "server n1 is unhealthy" {
+		t.Fatalf("unexpected example details %+v", res.Examples.Details)
+	}
+
+	if res.Examples.Examples[0] != "server n1 is unhealthy" {
+		t.Fatalf("unexpected examples %v", res.Examples.Examples)
+	}
+}
+
+func TestCheckCollection_SelectTags(t *testing.T) {
+	tagged := func(code string, suite string, tags ...string) Check {
+		c := fixedOutcomeCheck(code, SeverityWarning, Pass)
+		c.Suite = suite
+		c.Tags = tags
+		return c
+	}
+
+	newCollection := func() *CheckCollection {
+		cc := &CheckCollection{}
+		cc.MustRegister(
+			tagged("T_001", "server", "performance"),
+			tagged("T_002", "jetstream", "performance", "capacity"),
+			tagged("T_003", "jetstream", "security"),
+			tagged("T_004", "server", "Capacity"),
+		)
+		return cc
+	}
+
+	ran := func(t *testing.T, cc *CheckCollection) []string {
+		t.Helper()
+
+		var res []string
+		for _, r := range cc.Run(emptyArchiveReader(t), 0, api.NewDefaultLogger(api.ErrorLevel)).Results {
+			if r.Outcome != Skipped {
+				res = append(res, r.Check.Code)
+			}
+		}
+		return res
+	}
+
+	cases := []struct {
+		include  []string
+		exclude  []string
+		expected []string
+	}{
+		{nil, nil, []string{"T_002", "T_003", "T_001", "T_004"}},
+		{[]string{"capacity"}, nil, []string{"T_002", "T_004"}},
+		{[]string{"jetstream+performance"}, nil, []string{"T_002"}},
+		{[]string{"security", "server+performance"}, nil, []string{"T_003", "T_001"}},
+		{nil, []string{"performance"}, []string{"T_003", "T_004"}},
+		{[]string{"jetstream"}, []string{"capacity"}, []string{"T_003"}},
+	}
+
+	for i, c := range cases {
+		cc := newCollection()
+		err := cc.SelectTags(c.include, c.exclude)
+		if err != nil {
+			t.Fatalf("case %d: select failed: %v", i, err)
+		}
+
+		got := ran(t, cc)
+		if fmt.Sprint(got) != fmt.Sprint(c.expected) {
+			t.Errorf("case %d: expected %v, got %v", i, c.expected, got)
+		}
+	}
+
+	t.Run("Should reject invalid expressions", func(t *testing.T) {
+		cc := newCollection()
+		for _, expr := range []string{"", "a+", "+b"} {
+			if err := cc.SelectTags([]string{expr}, nil); err == nil {
+				t.Errorf("expected an error for %q", expr)
+			}
+		}
+	})
+}
+
+func TestCheckCollection_Timeout(t *testing.T) {
+	blocking := func(ctx context.Context, _ *Check, _ *archive.Reader, _ *ExamplesCollection, _ api.Logger) (Outcome, error) {
+		<-ctx.Done()
+		return Fail, nil
+	}
+
+	t.Run("Should reject negative timeouts", func(t *testing.T) {
+		cc := &CheckCollection{}
+		if err := cc.SetCheckTimeout(-1); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("Should skip checks exceeding their budget", func(t *testing.T) {
+		cc := &CheckCollection{}
+		cc.MustRegister(
+			Check{Code: "T_001", Suite: "test", Name: "T_001", Description: "T_001", Handler: blocking},
+			fixedOutcomeCheck("T_002", SeverityWarning, Pass),
+		)
+
+		err := cc.SetCheckTimeout(50 * time.Millisecond)
+		if err != nil {
+			t.Fatalf("set timeout failed: %v", err)
+		}
+
+		analysis := cc.Run(emptyArchiveReader(t), 0, api.NewDefaultLogger(api.ErrorLevel))
+		if analysis.Results[0].Outcome != Skipped {
+			t.Fatalf("expected timed out check to be skipped, got %v", analysis.Results[0].Outcome)
+		}
+		if analysis.Results[0].Examples.Error != "check timed out after 50ms" {
+			t.Fatalf("unexpected skip reason %q", analysis.Results[0].Examples.Error)
+		}
+		if analysis.Results[1].Outcome != Pass {
+			t.Fatalf("expected T_002 to pass, got %v", analysis.Results[1].Outcome)
+		}
+	})
+
+	t.Run("Should skip checks once the context is cancelled", func(t *testing.T) {
+		cc := &CheckCollection{}
+		cc.MustRegister(fixedOutcomeCheck("T_001", SeverityWarning, Pass))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		analysis := cc.RunContext(ctx, emptyArchiveReader(t), 0, api.NewDefaultLogger(api.ErrorLevel))
+		if analysis.Results[0].Outcome != Skipped {
+			t.Fatalf("expected check to be skipped, got %v", analysis.Results[0].Outcome)
+		}
+		if analysis.Results[0].Examples.Error != "check not run: context canceled" {
+			t.Fatalf("unexpected skip reason %q", analysis.Results[0].Examples.Error)
+		}
+	})
+}
@@ -14,6 +14,7 @@
 package audit
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sort"
@@ -32,6 +33,7 @@ func RegisterClusterChecks(collection *CheckCollection) error {
 			Suite:       "cluster",
 			Name:        "Cluster Memory Usage Outliers",
 			Description: "Memory usage is uniform across nodes in a cluster",
+			Tags:        []string{"performance"},
 			Configuration: map[string]*CheckConfiguration{
 				"memory": {
 					Key:         "memory",
@@ -46,6 +48,7 @@ func RegisterClusterChecks(collection *CheckCollection) error {
 			Suite:       "cluster",
 			Name:        "Cluster Uniform Gateways",
 			Description: "All nodes in a cluster share the same gateways configuration",
+			Tags:        []string{"configuration"},
 			Handler:     checkClusterUniformGatewayConfig,
 		},
 		Check{
@@ -53,6 +56,7 @@ func RegisterClusterChecks(collection *CheckCollection) error {
 			Suite:       "cluster",
 			Name:        "Cluster High HA Assets",
 			Description: "Number of HA assets is below a given threshold",
+			Tags:        []string{"capacity"},
 			Configuration: map[string]*CheckConfiguration{
 				"assets": {
 					Key:         "assets",
@@ -68,13 +72,32 @@ func RegisterClusterChecks(collection *CheckCollection) error {
 			Suite:       "cluster",
 			Name:        "Whitespace in cluster name",
 			Description: "No cluster name contains whitespace",
+			Tags:        []string{"configuration"},
 			Handler:     checkClusterNamesForWhitespace,
 		},
+		Check{
+			Code:        "CLUSTER_005",
+			Suite:       "cluster",
+			Name:        "Cluster Gateway Mesh",
+			Description: "All clusters are connected to each other by gateways in both directions",
+			Tags:        []string{"availability"},
+			Remediation: "Ensure every cluster lists all other clusters as gateways and that gateway ports are reachable between clusters",
+			Severity:    SeverityCritical,
+			Handler:     checkClusterGatewayMesh,
+		},
+		Check{
+			Code:        "CLUSTER_006",
+			Suite:       "cluster",
+			Name:        "Cluster Configuration Drift",
+			Description: "All nodes in a cluster share the same limits, TLS and JetStream settings",
+			Tags:        []string{"configuration", "security"},
+			Handler:     checkClusterConfigurationDrift,
+		},
 	)
 }
 
 // checkClusterMemoryUsageOutliers verifies the memory usage of any given node in a cluster is not significantly higher than its peers
-func checkClusterMemoryUsageOutliers(check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkClusterMemoryUsageOutliers(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	outlierThreshold := check.Configuration["memory"].Value()
 	clustering := r.ClusterNames()
 	clustersWithIssuesMap := make(map[string]any, len(clustering))
@@ -91,6 +114,10 @@ func checkClusterMemoryUsageOutliers(check *Check, r *archive.Reader, examples *
 		)
 
 		for _, serverName := range serverNames {
+			if err := ctx.Err(); err != nil {
+				return Skipped, err
+			}
+
 			serverTag := archive.TagServer(serverName)
 
 			tags := []*archive.Tag{clusterTag, serverTag, typeTag}
@@ -140,7 +167,7 @@ func checkClusterMemoryUsageOutliers(check *Check, r *archive.Reader, examples *
 }
 
 // checkClusterUniformGatewayConfig verify that gateways configuration matches for all nodes in each cluster
-func checkClusterUniformGatewayConfig(_ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkClusterUniformGatewayConfig(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	for _, clusterName := range r.ClusterNames() {
 		clusterTag := archive.TagCluster(clusterName)
 		typeTag := archive.TagServerGateways()
@@ -151,6 +178,10 @@ func checkClusterUniformGatewayConfig(_ *Check, r *archive.Reader, examples *Exa
 		configuredInboundGateways := make(map[string][]string)
 
 		for _, serverName := range r.ClusterServerNames(clusterName) {
+			if err := ctx.Err(); err != nil {
+				return Skipped, err
+			}
+
 			serverTag := archive.TagServer(serverName)
 
 			err := archive.ForEachTaggedArtifact(r, []*archive.Tag{clusterTag, serverTag, typeTag}, func(resp *server.ServerAPIGatewayzResponse) error {
@@ -233,7 +264,7 @@ func checkClusterUniformGatewayConfig(_ *Check, r *archive.Reader, examples *Exa
 }
 
 // checkClusterHighHAAssets verifies the number of HA assets is below some the given number for each known server in each known cluster
-func checkClusterHighHAAssets(check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkClusterHighHAAssets(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	haAssetsThreshold := check.Configuration["assets"].Value()
 
 	for _, clusterName := range r.ClusterNames() {
@@ -241,6 +272,10 @@ func checkClusterHighHAAssets(check *Check, r *archive.Reader, examples *Example
 		typeTag := archive.TagServerJetStream()
 
 		for _, serverName := range r.ClusterServerNames(clusterName) {
+			if err := ctx.Err(); err != nil {
+				return Skipped, err
+			}
+
 			serverTag := archive.TagServer(serverName)
 
 			err := archive.ForEachTaggedArtifact(r, []*archive.Tag{clusterTag, serverTag, typeTag}, func(jsz *server.ServerAPIJszResponse) error {
@@ -269,7 +304,7 @@ func checkClusterHighHAAssets(check *Check, r *archive.Reader, examples *Example
 	return Pass, nil
 }
 
-func checkClusterNamesForWhitespace(_ *Check, reader *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkClusterNamesForWhitespace(_ context.Context, _ *Check, reader *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	for _, clusterName := range reader.ClusterNames() {
 		if strings.ContainsAny(clusterName, " \n") {
 			examples.Add("Cluster: %s", clusterName)
@@ -283,3 +318,210 @@ func checkClusterNamesForWhitespace(_ *Check, reader *archive.Reader, examples *
 
 	return Pass, nil
 }
+
+// checkClusterGatewayMesh builds the gateway connectivity matrix and verifies every server has an outbound gateway
+// connection to every other cluster, that every cluster has inbound connections from every other cluster and that
+// the gateway names match the cluster names
+func checkClusterGatewayMesh(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	typeTag := archive.TagServerGateways()
+	outbound := make(map[string]map[string]map[string]bool)
+	inbound := make(map[string]map[string]bool)
+
+	for _, clusterName := range r.ClusterNames() {
+		clusterTag := archive.TagCluster(clusterName)
+
+		for _, serverName := range r.ClusterServerNames(clusterName) {
+			if err := ctx.Err(); err != nil {
+				return Skipped, err
+			}
+
+			serverTag := archive.TagServer(serverName)
+
+			err := archive.ForEachTaggedArtifact(r, []*archive.Tag{clusterTag, serverTag, typeTag}, func(resp *server.ServerAPIGatewayzResponse) error {
+				if resp == nil || resp.Data == nil {
+					log.Warnf("Artifact 'GATEWAYZ' is missing or empty for server %s", serverTag)
+					return nil
+				}
+
+				gateways := resp.Data
+				if gateways.Name == "" {
+					return nil
+				}
+
+				if gateways.Name != clusterName {
+					examples.Add("Cluster %s server %s: gateway name %q does not match the cluster name", clusterName, serverName, gateways.Name)
+				}
+
+				if outbound[clusterName] == nil {
+					outbound[clusterName] = make(map[string]map[string]bool)
+					inbound[clusterName] = make(map[string]bool)
+				}
+
+				connected := make(map[string]bool)
+				for remote, gw := range gateways.OutboundGateways {
+					if gw != nil && gw.Connection != nil {
+						connected[remote] = true
+					}
+				}
+				outbound[clusterName][serverName] = connected
+
+				for remote, gws := range gateways.InboundGateways {
+					for _, gw := range gws {
+						if gw != nil && gw.Connection != nil {
+							inbound[clusterName][remote] = true
+						}
+					}
+				}
+
+				return nil
+			})
+			if err != nil {
+				return Skipped, fmt.Errorf("failed to load GATEWAYZ for server %s: %w", serverTag, err)
+			}
+		}
+	}
+
+	clusters := make([]string, 0, len(outbound))
+	for clusterName := range outbound {
+		clusters = append(clusters, clusterName)
+	}
+	sort.Strings(clusters)
+
+	for _, clusterName := range clusters {
+		servers := make([]string, 0, len(outbound[clusterName]))
+		for serverName := range outbound[clusterName] {
+			servers = append(servers, serverName)
+		}
+		sort.Strings(servers)
+
+		for _, remote := range clusters {
+			if remote == clusterName {
+				continue
+			}
+
+			for _, serverName := range servers {
+				if !outbound[clusterName][serverName][remote] {
+					examples.Add("Cluster %s server %s: no outbound gateway to cluster %s", clusterName, serverName, remote)
+				}
+			}
+
+			if !inbound[clusterName][remote] {
+				examples.Add("Cluster %s: no inbound gateway from cluster %s", clusterName, remote)
+			}
+		}
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d gateway mesh problems", examples.Count())
+		return Fail, nil
+	}
+
+	return Pass, nil
+}
+
+// clusterUniformSettings extracts the settings from varz that should be the same on every node in a cluster, the
+// JetStream memory and storage limits are not included as the server sizes those from the host resources when unset
+func clusterUniformSettings(vz *server.Varz) map[string]string {
+	settings := map[string]string{
+		"max_connections":      fmt.Sprint(vz.MaxConn),
+		"max_subscriptions":    fmt.Sprint(vz.MaxSubs),
+		"max_payload":          fmt.Sprint(vz.MaxPayload),
+		"max_pending":          fmt.Sprint(vz.MaxPending),
+		"max_control_line":     fmt.Sprint(vz.MaxControlLine),
+		"ping_interval":        vz.PingInterval.String(),
+		"ping_max":             fmt.Sprint(vz.MaxPingsOut),
+		"write_deadline":       vz.WriteDeadline.String(),
+		"auth_required":        fmt.Sprint(vz.AuthRequired),
+		"tls_required":         fmt.Sprint(vz.TLSRequired),
+		"tls_verify":           fmt.Sprint(vz.TLSVerify),
+		"cluster.tls_required": fmt.Sprint(vz.Cluster.TLSRequired),
+		"cluster.tls_verify":   fmt.Sprint(vz.Cluster.TLSVerify),
+		"cluster.pool_size":    fmt.Sprint(vz.Cluster.PoolSize),
+		"jetstream":            fmt.Sprint(vz.JetStream.Config != nil),
+	}
+
+	if cfg := vz.JetStream.Config; cfg != nil {
+		settings["jetstream.sync_interval"] = cfg.SyncInterval.String()
+		settings["jetstream.sync_always"] = fmt.Sprint(cfg.SyncAlways)
+		settings["jetstream.domain"] = cfg.Domain
+		settings["jetstream.compress_ok"] = fmt.Sprint(cfg.CompressOK)
+		settings["jetstream.unique_tag"] = cfg.UniqueTag
+		settings["jetstream.strict"] = fmt.Sprint(cfg.Strict)
+	}
+
+	if limits := vz.JetStream.Limits; limits != nil {
+		settings["jetstream.limits.max_request_batch"] = fmt.Sprint(limits.MaxRequestBatch)
+		settings["jetstream.limits.max_ack_pending"] = fmt.Sprint(limits.MaxAckPending)
+		settings["jetstream.limits.max_ha_assets"] = fmt.Sprint(limits.MaxHAAssets)
+		settings["jetstream.limits.max_duplicate_window"] = limits.Duplicates.String()
+	}
+
+	return settings
+}
+
+// checkClusterConfigurationDrift verifies that the limits, TLS and JetStream settings match for all nodes in each cluster
+func checkClusterConfigurationDrift(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	typeTag := archive.TagServerVars()
+
+	for _, clusterName := range r.ClusterNames() {
+		clusterTag := archive.TagCluster(clusterName)
+
+		// setting -> value -> servers
+		values := make(map[string]map[string][]string)
+
+		for _, serverName := range r.ClusterServerNames(clusterName) {
+			if err := ctx.Err(); err != nil {
+				return Skipped, err
+			}
+
+			serverTag := archive.TagServer(serverName)
+
+			err := archive.ForEachTaggedArtifact(r, []*archive.Tag{clusterTag, serverTag, typeTag}, func(resp *server.ServerAPIVarzResponse) error {
+				if resp == nil || resp.Data == nil {
+					log.Warnf("Artifact 'VARZ' is missing or empty for server %s", serverTag)
+					return nil
+				}
+
+				for setting, value := range clusterUniformSettings(resp.Data) {
+					if values[setting] == nil {
+						values[setting] = make(map[string][]string)
+					}
+					values[setting][value] = append(values[setting][value], serverName)
+				}
+
+				return nil
+			})
+			if err != nil {
+				return Skipped, fmt.Errorf("failed to read VARZ for server %s in cluster %s: %w", serverName, clusterName, err)
+			}
+		}
+
+		settings := make([]string, 0, len(values))
+		for setting := range values {
+			settings = append(settings, setting)
+		}
+		sort.Strings(settings)
+
+		for _, setting := range settings {
+			if len(values[setting]) < 2 {
+				continue
+			}
+
+			var parts []string
+			for value, servers := range values[setting] {
+				sort.Strings(servers)
+				parts = append(parts, fmt.Sprintf("%q on %s", value, strings.Join(servers, ", ")))
+			}
+			sort.Strings(parts)
+
+			examples.Add("Cluster %s, %s differs: %s", clusterName, setting, strings.Join(parts, "; "))
+		}
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d settings that differ between nodes of the same cluster", examples.Count())
+		return Fail, nil
+	}
+
+	return Pass, nil
+}
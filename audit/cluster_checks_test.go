@@ -1,8 +1,10 @@
 package audit
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/jsm.go/audit/archive"
@@ -51,7 +53,7 @@ func setupClusterCheck(t *testing.T, checkid string, artifacts map[string]any, t
 	}
 
 	examples := newExamplesCollection(0)
-	outcome, err := check.Handler(check, reader, examples, api.NewDefaultLogger(api.ErrorLevel))
+	outcome, err := check.Handler(context.Background(), check, reader, examples, api.NewDefaultLogger(api.ErrorLevel))
 	if err != nil {
 		t.Fatalf("check handler failed: %v", err)
 	}
@@ -172,3 +174,112 @@ func TestCLUSTER_004(t *testing.T) {
 		}
 	})
 }
+
+func TestCLUSTER_005(t *testing.T) {
+	gatewayz := func(name string, outbound []string, inbound []string) *server.ServerAPIGatewayzResponse {
+		gw := &server.Gatewayz{
+			Name:             name,
+			OutboundGateways: map[string]*server.RemoteGatewayz{},
+			InboundGateways:  map[string][]*server.RemoteGatewayz{},
+		}
+		for _, c := range outbound {
+			gw.OutboundGateways[c] = &server.RemoteGatewayz{IsConfigured: true, Connection: &server.ConnInfo{}}
+		}
+		for _, c := range inbound {
+			gw.InboundGateways[c] = []*server.RemoteGatewayz{{Connection: &server.ConnInfo{}}}
+		}
+
+		return &server.ServerAPIGatewayzResponse{Data: gw}
+	}
+
+	setup := func(t *testing.T, clusters map[string]map[string]*server.ServerAPIGatewayzResponse) Outcome {
+		outcome, _ := runArchiveCheck(t, RegisterClusterChecks, "CLUSTER_005", func(w *archive.Writer) error {
+			for clusterName, servers := range clusters {
+				for serverName, gw := range servers {
+					err := w.Add(gw, archive.TagCluster(clusterName), archive.TagServer(serverName), archive.TagServerGateways())
+					if err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+
+		return outcome
+	}
+
+	t.Run("Should pass for a complete mesh", func(t *testing.T) {
+		result := setup(t, map[string]map[string]*server.ServerAPIGatewayzResponse{
+			"C1": {"s1": gatewayz("C1", []string{"C2"}, []string{"C2"}), "s2": gatewayz("C1", []string{"C2"}, nil)},
+			"C2": {"s3": gatewayz("C2", []string{"C1"}, []string{"C1"})},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+
+	t.Run("Should fail when a server has no outbound gateway", func(t *testing.T) {
+		result := setup(t, map[string]map[string]*server.ServerAPIGatewayzResponse{
+			"C1": {"s1": gatewayz("C1", []string{"C2"}, []string{"C2"}), "s2": gatewayz("C1", nil, nil)},
+			"C2": {"s3": gatewayz("C2", []string{"C1"}, []string{"C1"})},
+		})
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should fail when a cluster has no inbound gateway", func(t *testing.T) {
+		result := setup(t, map[string]map[string]*server.ServerAPIGatewayzResponse{
+			"C1": {"s1": gatewayz("C1", []string{"C2"}, nil)},
+			"C2": {"s3": gatewayz("C2", []string{"C1"}, []string{"C1"})},
+		})
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should fail when gateway names are inconsistent", func(t *testing.T) {
+		result := setup(t, map[string]map[string]*server.ServerAPIGatewayzResponse{
+			"C1": {"s1": gatewayz("X1", []string{"C2"}, []string{"C2"})},
+			"C2": {"s3": gatewayz("C2", []string{"C1"}, []string{"C1"})},
+		})
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+}
+
+func TestCLUSTER_006(t *testing.T) {
+	t.Run("Should fail when settings differ within a cluster", func(t *testing.T) {
+		result := setupClusterCheck(t, "CLUSTER_006", map[string]any{
+			"s1": &server.ServerAPIVarzResponse{Data: &server.Varz{MaxPayload: 1024, JetStream: server.JetStreamVarz{Config: &server.JetStreamConfig{SyncInterval: time.Minute}}}},
+			"s2": &server.ServerAPIVarzResponse{Data: &server.Varz{MaxPayload: 1024, JetStream: server.JetStreamVarz{Config: &server.JetStreamConfig{SyncInterval: 2 * time.Minute}}}},
+		}, archive.TagServerVars(), "T1")
+
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should fail when TLS settings differ within a cluster", func(t *testing.T) {
+		result := setupClusterCheck(t, "CLUSTER_006", map[string]any{
+			"s1": &server.ServerAPIVarzResponse{Data: &server.Varz{TLSRequired: true}},
+			"s2": &server.ServerAPIVarzResponse{Data: &server.Varz{}},
+		}, archive.TagServerVars(), "T1")
+
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should pass when settings are the same", func(t *testing.T) {
+		result := setupClusterCheck(t, "CLUSTER_006", map[string]any{
+			"s1": &server.ServerAPIVarzResponse{Data: &server.Varz{Name: "s1", MaxPayload: 1024, Mem: 100, JetStream: server.JetStreamVarz{Config: &server.JetStreamConfig{MaxStore: 1000, MaxMemory: 100}}}},
+			"s2": &server.ServerAPIVarzResponse{Data: &server.Varz{Name: "s2", MaxPayload: 1024, Mem: 200, JetStream: server.JetStreamVarz{Config: &server.JetStreamConfig{MaxStore: 2000, MaxMemory: 200}}}},
+		}, archive.TagServerVars(), "T1")
+
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+}
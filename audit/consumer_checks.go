@@ -0,0 +1,354 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/audit/archive"
+)
+
+// AuditSkipMetadataKey is a stream metadata key holding a comma separated list of check codes that should not
+// report problems for the stream or its consumers
+const AuditSkipMetadataKey = "io.nats.audit.skip"
+
+// streamDetailWithConsumers is the stream detail artifact including consumer information
+type streamDetailWithConsumers struct {
+	api.StreamInfo
+	ConsumerDetail []api.ConsumerInfo `json:"consumer_detail"`
+}
+
+func RegisterConsumerChecks(collection *CheckCollection) error {
+	return collection.Register(
+		Check{
+			Code:        "CONSUMER_001",
+			Suite:       "consumer",
+			Name:        "Consumer Ack Pending",
+			Description: "Consumers outstanding acknowledgements are below their MaxAckPending limit",
+			Tags:        []string{"performance"},
+			Remediation: "Increase the consumer MaxAckPending, add more subscribers to process messages or acknowledge messages sooner",
+			Configuration: map[string]*CheckConfiguration{
+				"ack_pending": {
+					Key:         "ack_pending",
+					Description: "Alert if outstanding acknowledgements near configured MaxAckPending",
+					Default:     80,
+					Unit:        PercentageUnit,
+				},
+			},
+			Handler: checkConsumerAckPending,
+		},
+		Check{
+			Code:        "CONSUMER_002",
+			Suite:       "consumer",
+			Name:        "Consumer Redelivery Ratio",
+			Description: "Consumers are not redelivering a large portion of their messages",
+			Tags:        []string{"performance"},
+			Remediation: "Investigate messages that can not be processed, consider a longer AckWait, a BackOff policy or terminating poison messages",
+			Configuration: map[string]*CheckConfiguration{
+				"redelivered": {
+					Key:         "redelivered",
+					Description: "Alert if redelivered messages exceed this percentage of deliveries",
+					Default:     10,
+					Unit:        PercentageUnit,
+				},
+				"deliveries": {
+					Key:         "deliveries",
+					Description: "Minimum deliveries before a consumer is considered",
+					Default:     100,
+					Unit:        UIntUnit,
+				},
+			},
+			Handler: checkConsumerRedeliveryRatio,
+		},
+		Check{
+			Code:        "CONSUMER_003",
+			Suite:       "consumer",
+			Name:        "Ephemeral Consumers Inactive Threshold",
+			Description: "Ephemeral consumers are removed when their clients disappear",
+			Tags:        []string{"capacity"},
+			Remediation: "Set an InactiveThreshold on ephemeral consumers so they are removed when their clients go away",
+			Severity:    SeverityInfo,
+			Handler:     checkEphemeralInactiveThreshold,
+		},
+		Check{
+			Code:        "CONSUMER_004",
+			Suite:       "consumer",
+			Name:        "Consumer Duplicate Window Coherence",
+			Description: "Stream duplicate windows cover the redelivery horizon of their consumers",
+			Tags:        []string{"configuration"},
+			Remediation: "Increase the stream duplicate window or reduce the consumer AckWait, BackOff or MaxDeliver",
+			Handler:     checkConsumerDuplicateWindow,
+		},
+	)
+}
+
+// auditSkipped determines if the check identified by code should be skipped according to metadata, see AuditSkipMetadataKey
+func auditSkipped(metadata map[string]string, code string) bool {
+	for _, c := range strings.Split(metadata[AuditSkipMetadataKey], ",") {
+		if strings.EqualFold(strings.TrimSpace(c), code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// consumerExampleContext identifies a consumer in examples
+func consumerExampleContext(accountName string, stream *api.StreamInfo, nfo *api.ConsumerInfo) ExampleContext {
+	ctx := ExampleContext{
+		Account:  accountName,
+		Stream:   stream.Config.Name,
+		Consumer: nfo.Name,
+	}
+
+	if nfo.Cluster != nil {
+		ctx.Cluster = nfo.Cluster.Name
+		ctx.Server = nfo.Cluster.Leader
+	}
+
+	return ctx
+}
+
+// eachLeaderConsumer calls cb for every consumer in the archive using the information reported by the consumer leader,
+// iteration stops with the context error once ctx is done
+func eachLeaderConsumer(ctx context.Context, r *archive.Reader, log api.Logger, cb func(accountName string, stream *api.StreamInfo, nfo *api.ConsumerInfo)) error {
+	streamDetailsTag := archive.TagStreamInfo()
+
+	for _, accountName := range r.AccountNames() {
+		accountTag := archive.TagAccount(accountName)
+
+		for _, streamName := range r.AccountStreamNames(accountName) {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			streamTag := archive.TagStream(streamName)
+			serverNames := r.StreamServerNames(accountName, streamName)
+
+			for _, serverName := range serverNames {
+				serverTag := archive.TagServer(serverName)
+
+				err := archive.ForEachTaggedArtifact(r, []*archive.Tag{accountTag, streamTag, serverTag, streamDetailsTag}, func(streamDetails *streamDetailWithConsumers) error {
+					for i := range streamDetails.ConsumerDetail {
+						nfo := &streamDetails.ConsumerDetail[i]
+						if nfo.Cluster != nil && nfo.Cluster.Leader != serverName {
+							continue
+						}
+
+						cb(accountName, &streamDetails.StreamInfo, nfo)
+					}
+
+					return nil
+				})
+				if err != nil {
+					log.Warnf("Artifact 'STREAM_DETAILS' is missing for stream %s in account %s", streamName, accountName)
+					continue
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkConsumerAckPending verifies that consumers outstanding acknowledgements are below a threshold of their MaxAckPending,
+// consumers at the limit are not receiving new messages and so fail the check
+func checkConsumerAckPending(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	threshold := check.Configuration["ack_pending"].Value()
+
+	type offender struct {
+		ctx     ExampleContext
+		pending int
+		max     int
+		pct     float64
+	}
+
+	var offenders []offender
+	var stalled int
+
+	err := eachLeaderConsumer(ctx, r, log, func(accountName string, stream *api.StreamInfo, nfo *api.ConsumerInfo) {
+		if nfo.Config.MaxAckPending <= 0 || nfo.Config.AckPolicy == api.AckNone {
+			return
+		}
+
+		pct := float64(nfo.NumAckPending) * 100 / float64(nfo.Config.MaxAckPending)
+		if pct < threshold {
+			return
+		}
+
+		if nfo.NumAckPending >= nfo.Config.MaxAckPending {
+			stalled++
+		}
+
+		offenders = append(offenders, offender{consumerExampleContext(accountName, stream, nfo), nfo.NumAckPending, nfo.Config.MaxAckPending, pct})
+	})
+	if err != nil {
+		return Skipped, err
+	}
+
+	sort.SliceStable(offenders, func(i, j int) bool {
+		return offenders[i].pct > offenders[j].pct
+	})
+
+	for _, o := range offenders {
+		examples.AddWithContext(o.ctx, "consumer %s > %s (in %s) has %d of %d (%.1f%%) acknowledgements outstanding", o.ctx.Stream, o.ctx.Consumer, o.ctx.Account, o.pending, o.max, o.pct)
+	}
+
+	if stalled > 0 {
+		log.Errorf("Found %d consumers at their MaxAckPending limit", stalled)
+		return Fail, nil
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d consumers with outstanding acknowledgements exceeding %.0f%% of MaxAckPending", examples.Count(), threshold)
+		return PassWithIssues, nil
+	}
+
+	return Pass, nil
+}
+
+// checkConsumerRedeliveryRatio verifies that redelivered messages are a small portion of the messages delivered by each consumer,
+// a high ratio indicates poison messages or an AckWait that is too short for the processing time
+func checkConsumerRedeliveryRatio(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	threshold := check.Configuration["redelivered"].Value()
+	minDeliveries := check.Configuration["deliveries"].Value()
+
+	type offender struct {
+		ctx         ExampleContext
+		redelivered int
+		delivered   uint64
+		pct         float64
+	}
+
+	var offenders []offender
+
+	err := eachLeaderConsumer(ctx, r, log, func(accountName string, stream *api.StreamInfo, nfo *api.ConsumerInfo) {
+		delivered := nfo.Delivered.Consumer
+		if delivered == 0 || float64(delivered) < minDeliveries {
+			return
+		}
+
+		pct := float64(nfo.NumRedelivered) * 100 / float64(delivered)
+		if pct <= threshold {
+			return
+		}
+
+		offenders = append(offenders, offender{consumerExampleContext(accountName, stream, nfo), nfo.NumRedelivered, delivered, pct})
+	})
+	if err != nil {
+		return Skipped, err
+	}
+
+	sort.SliceStable(offenders, func(i, j int) bool {
+		return offenders[i].pct > offenders[j].pct
+	})
+
+	for _, o := range offenders {
+		examples.AddWithContext(o.ctx, "consumer %s > %s (in %s) redelivered %d of %d (%.1f%%) deliveries", o.ctx.Stream, o.ctx.Consumer, o.ctx.Account, o.redelivered, o.delivered, o.pct)
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d consumers with redeliveries exceeding %.0f%% of deliveries", examples.Count(), threshold)
+		return Fail, nil
+	}
+
+	return Pass, nil
+}
+
+// checkEphemeralInactiveThreshold finds ephemeral consumers without an inactive threshold, these are never removed when
+// their clients go away, streams can be excluded by listing the check in their AuditSkipMetadataKey metadata
+func checkEphemeralInactiveThreshold(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	err := eachLeaderConsumer(ctx, r, log, func(accountName string, stream *api.StreamInfo, nfo *api.ConsumerInfo) {
+		if nfo.Config.Durable != "" || nfo.Config.InactiveThreshold > 0 {
+			return
+		}
+
+		if auditSkipped(stream.Config.Metadata, check.Code) {
+			log.Debugf("Skipping consumer %s > %s in %s based on stream metadata", stream.Config.Name, nfo.Name, accountName)
+			return
+		}
+
+		examples.AddWithContext(consumerExampleContext(accountName, stream, nfo), "ephemeral consumer %s > %s (in %s) has no inactive threshold", stream.Config.Name, nfo.Name, accountName)
+	})
+	if err != nil {
+		return Skipped, err
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d ephemeral consumers without an inactive threshold", examples.Count())
+		return PassWithIssues, nil
+	}
+
+	return Pass, nil
+}
+
+// consumerRetryHorizon calculates how long after the first delivery a message may still be redelivered by the consumer,
+// when deliveries are unlimited this is the delay before the first redelivery
+func consumerRetryHorizon(cfg *api.ConsumerConfig) time.Duration {
+	delay := func(attempt int) time.Duration {
+		if len(cfg.BackOff) == 0 {
+			return cfg.AckWait
+		}
+
+		return cfg.BackOff[min(attempt, len(cfg.BackOff)-1)]
+	}
+
+	if cfg.MaxDeliver <= 1 {
+		return delay(0)
+	}
+
+	var horizon time.Duration
+	for i := 0; i < cfg.MaxDeliver-1; i++ {
+		horizon += delay(i)
+	}
+
+	return horizon
+}
+
+// checkConsumerDuplicateWindow finds consumers that may redeliver messages after the duplicate window of their stream
+// has passed, at that point publishers retrying the same message are no longer deduplicated which breaks exactly once
+// processing assumptions, streams can be excluded by listing the check in their AuditSkipMetadataKey metadata
+func checkConsumerDuplicateWindow(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	err := eachLeaderConsumer(ctx, r, log, func(accountName string, stream *api.StreamInfo, nfo *api.ConsumerInfo) {
+		if nfo.Config.AckPolicy == api.AckNone || stream.Config.Duplicates <= 0 {
+			return
+		}
+
+		if auditSkipped(stream.Config.Metadata, check.Code) {
+			log.Debugf("Skipping consumer %s > %s in %s based on stream metadata", stream.Config.Name, nfo.Name, accountName)
+			return
+		}
+
+		horizon := consumerRetryHorizon(&nfo.Config)
+		if horizon <= stream.Config.Duplicates {
+			return
+		}
+
+		examples.AddWithContext(consumerExampleContext(accountName, stream, nfo), "consumer %s > %s (in %s) may redeliver for %v but the stream duplicate window is %v", stream.Config.Name, nfo.Name, accountName, horizon, stream.Config.Duplicates)
+	})
+	if err != nil {
+		return Skipped, err
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d consumers with a redelivery horizon longer than their stream duplicate window", examples.Count())
+		return PassWithIssues, nil
+	}
+
+	return Pass, nil
+}
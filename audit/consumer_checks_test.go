@@ -0,0 +1,305 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/audit/archive"
+)
+
+func setupConsumerCheck(t *testing.T, checkid string, streams map[string]any) (Outcome, *ExamplesCollection) {
+	return runArchiveCheck(t, RegisterConsumerChecks, checkid, func(w *archive.Writer) error {
+		for serverName, stream := range streams {
+			err := w.Add(stream, archive.TagAccount("A"), archive.TagStream("S1"), archive.TagServer(serverName), archive.TagCluster("C1"), archive.TagStreamInfo())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func ackPendingConsumer(name string, pending int, max int) api.ConsumerInfo {
+	return api.ConsumerInfo{
+		Name:          name,
+		Stream:        "S1",
+		Cluster:       &api.ClusterInfo{Leader: "N1"},
+		Config:        api.ConsumerConfig{AckPolicy: api.AckExplicit, MaxAckPending: max},
+		NumAckPending: pending,
+	}
+}
+
+func TestCONSUMER_001(t *testing.T) {
+	t.Run("Should fail when a consumer is at its limit", func(t *testing.T) {
+		result, _ := setupConsumerCheck(t, "CONSUMER_001", map[string]any{
+			"N1": &streamWithConsumers{
+				StreamInfo:     api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+				ConsumerDetail: []api.ConsumerInfo{ackPendingConsumer("C1", 1000, 1000)},
+			},
+		})
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should warn and list the worst consumers first", func(t *testing.T) {
+		result, examples := setupConsumerCheck(t, "CONSUMER_001", map[string]any{
+			"N1": &streamWithConsumers{
+				StreamInfo: api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+				ConsumerDetail: []api.ConsumerInfo{
+					ackPendingConsumer("C1", 850, 1000),
+					ackPendingConsumer("C2", 950, 1000),
+					ackPendingConsumer("C3", 10, 1000),
+				},
+			},
+		})
+		if result != PassWithIssues {
+			t.Errorf("expected result %v, got %v", PassWithIssues, result)
+		}
+		if examples.Count() != 2 {
+			t.Fatalf("expected 2 examples, got %d", examples.Count())
+		}
+		if examples.Examples[0] != "consumer S1 > C2 (in A) has 950 of 1000 (95.0%) acknowledgements outstanding" {
+			t.Errorf("unexpected first example: %s", examples.Examples[0])
+		}
+	})
+
+	t.Run("Should only consider the consumer leader", func(t *testing.T) {
+		replica := ackPendingConsumer("C1", 1000, 1000)
+		result, _ := setupConsumerCheck(t, "CONSUMER_001", map[string]any{
+			"N2": &streamWithConsumers{
+				StreamInfo:     api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+				ConsumerDetail: []api.ConsumerInfo{replica},
+			},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+
+	t.Run("Should pass when consumers are below the threshold", func(t *testing.T) {
+		result, _ := setupConsumerCheck(t, "CONSUMER_001", map[string]any{
+			"N1": &streamWithConsumers{
+				StreamInfo:     api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+				ConsumerDetail: []api.ConsumerInfo{ackPendingConsumer("C1", 100, 1000), ackPendingConsumer("C2", 5, 0)},
+			},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+}
+
+func redeliveredConsumer(name string, redelivered int, delivered uint64) api.ConsumerInfo {
+	return api.ConsumerInfo{
+		Name:           name,
+		Stream:         "S1",
+		Cluster:        &api.ClusterInfo{Leader: "N1"},
+		Delivered:      api.SequenceInfo{Consumer: delivered},
+		NumRedelivered: redelivered,
+	}
+}
+
+func TestCONSUMER_002(t *testing.T) {
+	t.Run("Should fail when redeliveries are too high", func(t *testing.T) {
+		result, examples := setupConsumerCheck(t, "CONSUMER_002", map[string]any{
+			"N1": &streamWithConsumers{
+				StreamInfo: api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+				ConsumerDetail: []api.ConsumerInfo{
+					redeliveredConsumer("C1", 20, 1000),
+					redeliveredConsumer("C2", 500, 1000),
+					redeliveredConsumer("C3", 150, 1000),
+				},
+			},
+		})
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+		if examples.Count() != 2 {
+			t.Fatalf("expected 2 examples, got %d", examples.Count())
+		}
+		if examples.Examples[0] != "consumer S1 > C2 (in A) redelivered 500 of 1000 (50.0%) deliveries" {
+			t.Errorf("unexpected first example: %s", examples.Examples[0])
+		}
+	})
+
+	t.Run("Should ignore consumers with few deliveries", func(t *testing.T) {
+		result, _ := setupConsumerCheck(t, "CONSUMER_002", map[string]any{
+			"N1": &streamWithConsumers{
+				StreamInfo:     api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+				ConsumerDetail: []api.ConsumerInfo{redeliveredConsumer("C1", 5, 10)},
+			},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+}
+
+func TestCONSUMER_003(t *testing.T) {
+	consumers := []api.ConsumerInfo{
+		{Name: "EPHEMERAL", Stream: "S1", Config: api.ConsumerConfig{}},
+		{Name: "LIMITED", Stream: "S1", Config: api.ConsumerConfig{InactiveThreshold: time.Minute}},
+		{Name: "DURABLE", Stream: "S1", Config: api.ConsumerConfig{Durable: "DURABLE"}},
+	}
+
+	t.Run("Should warn for ephemeral consumers without inactive threshold", func(t *testing.T) {
+		result, examples := setupConsumerCheck(t, "CONSUMER_003", map[string]any{
+			"N1": &streamWithConsumers{StreamInfo: api.StreamInfo{Config: api.StreamConfig{Name: "S1"}}, ConsumerDetail: consumers},
+		})
+		if result != PassWithIssues {
+			t.Errorf("expected result %v, got %v", PassWithIssues, result)
+		}
+		if examples.Count() != 1 {
+			t.Errorf("expected 1 example, got %v", examples.Examples)
+		}
+		if len(examples.Details) != 1 {
+			t.Fatalf("expected 1 example detail, got %v", examples.Details)
+		}
+		if d := examples.Details[0]; d.Account != "A" || d.Stream != "S1" || d.Consumer != "EPHEMERAL" || d.Message != examples.Examples[0] {
+			t.Errorf("unexpected example detail %+v", d)
+		}
+	})
+
+	t.Run("Should skip streams excluded by metadata", func(t *testing.T) {
+		result, _ := setupConsumerCheck(t, "CONSUMER_003", map[string]any{
+			"N1": &streamWithConsumers{
+				StreamInfo:     api.StreamInfo{Config: api.StreamConfig{Name: "S1", Metadata: map[string]string{AuditSkipMetadataKey: "CONSUMER_001, consumer_003"}}},
+				ConsumerDetail: consumers,
+			},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+}
+
+func TestCONSUMER_004(t *testing.T) {
+	stream := func(consumers ...api.ConsumerInfo) map[string]any {
+		return map[string]any{
+			"N1": &streamWithConsumers{
+				StreamInfo:     api.StreamInfo{Config: api.StreamConfig{Name: "S1", Duplicates: 2 * time.Minute}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+				ConsumerDetail: consumers,
+			},
+		}
+	}
+
+	consumer := func(name string, ackWait time.Duration, maxDeliver int, backoff ...time.Duration) api.ConsumerInfo {
+		return api.ConsumerInfo{
+			Name:    name,
+			Stream:  "S1",
+			Cluster: &api.ClusterInfo{Leader: "N1"},
+			Config:  api.ConsumerConfig{AckPolicy: api.AckExplicit, AckWait: ackWait, MaxDeliver: maxDeliver, BackOff: backoff},
+		}
+	}
+
+	t.Run("Should warn when the ack wait exceeds the duplicate window", func(t *testing.T) {
+		result, examples := setupConsumerCheck(t, "CONSUMER_004", stream(consumer("C1", 5*time.Minute, -1)))
+		if result != PassWithIssues {
+			t.Errorf("expected result %v, got %v", PassWithIssues, result)
+		}
+		if examples.Count() != 1 {
+			t.Errorf("expected 1 example, got %d", examples.Count())
+		}
+	})
+
+	t.Run("Should warn when redeliveries exceed the duplicate window", func(t *testing.T) {
+		result, _ := setupConsumerCheck(t, "CONSUMER_004", stream(consumer("C1", 30*time.Second, 10, time.Second, 30*time.Second)))
+		if result != PassWithIssues {
+			t.Errorf("expected result %v, got %v", PassWithIssues, result)
+		}
+	})
+
+	t.Run("Should pass when redeliveries are within the duplicate window", func(t *testing.T) {
+		result, _ := setupConsumerCheck(t, "CONSUMER_004", stream(
+			consumer("C1", 30*time.Second, -1),
+			consumer("C2", 30*time.Second, 4),
+			consumer("C3", 30*time.Second, 5, time.Second, 10*time.Second),
+		))
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+}
+
+func TestConsumerRetryHorizon(t *testing.T) {
+	cases := []struct {
+		cfg      api.ConsumerConfig
+		expected time.Duration
+	}{
+		{api.ConsumerConfig{AckWait: 30 * time.Second, MaxDeliver: -1}, 30 * time.Second},
+		{api.ConsumerConfig{AckWait: 30 * time.Second, MaxDeliver: 1}, 30 * time.Second},
+		{api.ConsumerConfig{AckWait: 30 * time.Second, MaxDeliver: 3}, time.Minute},
+		{api.ConsumerConfig{AckWait: 30 * time.Second, MaxDeliver: 4, BackOff: []time.Duration{time.Second, 5 * time.Second}}, 11 * time.Second},
+	}
+
+	for _, c := range cases {
+		if h := consumerRetryHorizon(&c.cfg); h != c.expected {
+			t.Errorf("expected %v for %+v, got %v", c.expected, c.cfg, h)
+		}
+	}
+}
+
+func TestEachLeaderConsumer(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "audit.zip")
+
+	writer, err := archive.NewWriter(archivePath)
+	if err != nil {
+		t.Fatalf("failed to create archive writer: %v", err)
+	}
+
+	stream := &streamWithConsumers{
+		StreamInfo:     api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+		ConsumerDetail: []api.ConsumerInfo{ackPendingConsumer("C1", 10, 1000)},
+	}
+	err = writer.Add(stream, archive.TagAccount("A"), archive.TagStream("S1"), archive.TagServer("N1"), archive.TagCluster("C1"), archive.TagStreamInfo())
+	if err != nil {
+		t.Fatalf("failed to add stream: %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close archive: %v", err)
+	}
+
+	reader, err := archive.NewReader(archivePath)
+	if err != nil {
+		t.Fatalf("failed to open archive: %v", err)
+	}
+	defer reader.Close()
+
+	log := api.NewDefaultLogger(api.ErrorLevel)
+
+	t.Run("Should visit leader consumers", func(t *testing.T) {
+		var seen []string
+		err := eachLeaderConsumer(context.Background(), reader, log, func(_ string, _ *api.StreamInfo, nfo *api.ConsumerInfo) {
+			seen = append(seen, nfo.Name)
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(seen) != 1 || seen[0] != "C1" {
+			t.Fatalf("expected consumer C1, got %v", seen)
+		}
+	})
+
+	t.Run("Should stop once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		called := false
+		err := eachLeaderConsumer(ctx, reader, log, func(_ string, _ *api.StreamInfo, _ *api.ConsumerInfo) {
+			called = true
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context canceled error, got %v", err)
+		}
+		if called {
+			t.Fatalf("expected no consumers to be visited")
+		}
+	})
+}
@@ -16,15 +16,36 @@ package audit
 import (
 	"fmt"
 	"strings"
+	"sync"
 )
 
 // ExamplesCollection stores examples of issues found by a check as it scans entities in an archive.
 // A limit can be passed to avoid accumulating hundreds of example.
 // After the limit is reached, further examples are just counted but not stored.
+// Examples may be added concurrently to collections created by newExamplesCollection.
 type ExamplesCollection struct {
 	Examples []string `json:"examples,omitempty"`
-	Error    string   `json:"error"`
-	Limit    uint     `json:"-"`
+	// Details holds structured context for examples added using AddWithContext()
+	Details []ExampleDetail `json:"details,omitempty"`
+	Error   string          `json:"error"`
+	Limit   uint            `json:"-"`
+
+	mu *sync.Mutex
+}
+
+// ExampleContext identifies the entities an example relates to, unknown or irrelevant fields are left empty
+type ExampleContext struct {
+	Cluster  string `json:"cluster,omitempty"`
+	Server   string `json:"server,omitempty"`
+	Account  string `json:"account,omitempty"`
+	Stream   string `json:"stream,omitempty"`
+	Consumer string `json:"consumer,omitempty"`
+}
+
+// ExampleDetail is an example with structured context
+type ExampleDetail struct {
+	ExampleContext
+	Message string `json:"message"`
 }
 
 // newExamplesCollection creates a new empty collection of examples.
@@ -33,17 +54,41 @@ func newExamplesCollection(limit uint) *ExamplesCollection {
 	return &ExamplesCollection{
 		Limit:    limit,
 		Examples: []string{},
+		mu:       &sync.Mutex{},
 	}
 }
 
+func (c *ExamplesCollection) lock() func() {
+	if c.mu == nil {
+		return func() {}
+	}
+
+	c.mu.Lock()
+	return c.mu.Unlock
+}
+
 // Add adds a example issue to the collection
 func (c *ExamplesCollection) Add(format string, a ...any) {
+	defer c.lock()()
+
 	c.Examples = append(c.Examples, fmt.Sprintf(format, a...))
 }
 
+// AddWithContext adds an example issue to the collection along with the entities it relates to
+func (c *ExamplesCollection) AddWithContext(ctx ExampleContext, format string, a ...any) {
+	defer c.lock()()
+
+	msg := fmt.Sprintf(format, a...)
+	c.Examples = append(c.Examples, msg)
+	c.Details = append(c.Details, ExampleDetail{ExampleContext: ctx, Message: msg})
+}
+
 // Clear removes all added examples
 func (c *ExamplesCollection) Clear() {
+	defer c.lock()()
+
 	c.Examples = []string{}
+	c.Details = nil
 }
 
 // Count the number of examples added to this collection (including the omitted ones)
@@ -52,6 +97,8 @@ func (c *ExamplesCollection) Count() int {
 		return 0
 	}
 
+	defer c.lock()()
+
 	return len(c.Examples)
 }
 
@@ -0,0 +1,215 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gather
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/nats-io/jsm.go/audit/archive"
+)
+
+// checkpointSpecial is the special file recording the progress of a gather
+const checkpointSpecial = "audit_gather_checkpoint"
+
+// checkpoint records the progress of a gather in its archive so that an interrupted gather can be resumed
+type checkpoint struct {
+	// Complete indicates the gather finished and every target was captured
+	Complete bool `json:"complete"`
+	// Completed are the targets captured without failures, as phase/target
+	Completed []string `json:"completed"`
+}
+
+// checkpointState tracks the targets captured during a gather
+type checkpointState struct {
+	resumed   map[string]struct{}
+	completed map[string]struct{}
+	failed    map[string]archive.CaptureFailure
+	mu        sync.Mutex
+}
+
+func newCheckpointState() *checkpointState {
+	return &checkpointState{
+		resumed:   make(map[string]struct{}),
+		completed: make(map[string]struct{}),
+		failed:    make(map[string]archive.CaptureFailure),
+	}
+}
+
+func checkpointKey(phase Phase, target string) string {
+	return string(phase) + "/" + target
+}
+
+// resumeFrom copies the artifacts of targets completed by the gather that wrote the archive at path
+func (g *gather) resumeFrom(path string) error {
+	if filepath.Clean(path) == filepath.Clean(g.cfg.TargetPath) {
+		return fmt.Errorf("resumed archive may not be the target archive")
+	}
+
+	var opts []archive.Option
+	if len(g.cfg.EncryptionKey) > 0 {
+		opts = append(opts, archive.EncryptionKey(g.cfg.EncryptionKey))
+	}
+	if g.cfg.BaseArchivePath != "" {
+		opts = append(opts, archive.BaseArchive(g.cfg.BaseArchivePath))
+	}
+
+	reader, err := archive.NewReader(path, opts...)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	var cp checkpoint
+	err = reader.Load(&cp, archive.TagSpecial(checkpointSpecial))
+	if errors.Is(err, archive.ErrNoMatches) {
+		return fmt.Errorf("archive %s has no gather checkpoint", path)
+	} else if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	if cp.Complete {
+		return fmt.Errorf("archive %s is from a gather that completed", path)
+	}
+
+	for _, key := range cp.Completed {
+		g.checkpoint.resumed[key] = struct{}{}
+	}
+
+	err = g.aw.AddArchive(reader, func(tags []*archive.Tag) bool {
+		phase, target := g.artifactTarget(tags)
+		_, ok := g.checkpoint.resumed[checkpointKey(phase, target)]
+		return ok
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy captured artifacts: %w", err)
+	}
+
+	g.log.Infof("Resuming gather from %s, %d targets were captured", path, len(cp.Completed))
+
+	return nil
+}
+
+// artifactTarget determines the phase and target that captured an artifact with the given tags
+func (g *gather) artifactTarget(tags []*archive.Tag) (Phase, string) {
+	values := make(map[archive.TagLabel]string, len(tags))
+	for _, tag := range tags {
+		values[tag.Name] = tag.Value
+	}
+
+	account := values[archive.TagAccount("").Name]
+	server := values[archive.TagServer("").Name]
+	artifactType := values[archive.TagArtifactType("").Name]
+
+	suffix := func(endpoints []EndpointCaptureConfig) string {
+		for _, endpoint := range endpoints {
+			if endpoint.TypeTag.Value == artifactType {
+				return endpoint.ApiSuffix
+			}
+		}
+		return artifactType
+	}
+
+	switch {
+	case g.customEndpoint(artifactType) != nil && account != "":
+		return PhaseCustomEndpoints, account + "/" + server + "/" + artifactType
+	case g.customEndpoint(artifactType) != nil:
+		return PhaseCustomEndpoints, server + "/" + artifactType
+	case values[archive.TagStream("").Name] != "":
+		return PhaseStreams, account
+	case account != "":
+		return PhaseAccountEndpoints, account + "/" + suffix(g.cfg.AccountEndpointConfigs)
+	case artifactType == archive.TagServerProfile().Value:
+		return PhaseServerProfiles, server + "/" + values[archive.TagProfileName("").Name]
+	default:
+		return PhaseServerEndpoints, server + "/" + suffix(g.cfg.ServerEndpointConfigs)
+	}
+}
+
+// resumed reports whether a target was captured by the resumed gather, such targets are carried over as completed
+func (g *gather) resumed(phase Phase, target string) bool {
+	key := checkpointKey(phase, target)
+
+	g.checkpoint.mu.Lock()
+	defer g.checkpoint.mu.Unlock()
+
+	_, ok := g.checkpoint.resumed[key]
+	if ok {
+		g.checkpoint.completed[key] = struct{}{}
+		g.log.Debugf("Skipping %s %s captured by the resumed gather", phase, target)
+	}
+
+	return ok
+}
+
+// targetCompleted records a target as captured unless capturing it failed or the gather was interrupted
+func (g *gather) targetCompleted(phase Phase, target string) {
+	if g.ctx.Err() != nil {
+		return
+	}
+
+	key := checkpointKey(phase, target)
+
+	g.checkpoint.mu.Lock()
+	defer g.checkpoint.mu.Unlock()
+
+	if _, failed := g.checkpoint.failed[key]; !failed {
+		g.checkpoint.completed[key] = struct{}{}
+	}
+}
+
+// targetFailed records a failure capturing a target so that it is captured again when resuming
+func (g *gather) targetFailed(phase Phase, target string, err error) {
+	g.checkpoint.mu.Lock()
+	g.checkpoint.failed[checkpointKey(phase, target)] = archive.CaptureFailure{
+		Phase:  string(phase),
+		Target: target,
+		Error:  err.Error(),
+	}
+	g.checkpoint.mu.Unlock()
+}
+
+// failedCaptures are the targets that failed to be captured sorted by phase and target
+func (g *gather) failedCaptures() []archive.CaptureFailure {
+	g.checkpoint.mu.Lock()
+	defer g.checkpoint.mu.Unlock()
+
+	var failures []archive.CaptureFailure
+	for _, key := range slices.Sorted(maps.Keys(g.checkpoint.failed)) {
+		failures = append(failures, g.checkpoint.failed[key])
+	}
+
+	return failures
+}
+
+// writeCheckpoint adds the checkpoint to the archive, complete indicates the gather finished
+func (g *gather) writeCheckpoint(complete bool) error {
+	g.checkpoint.mu.Lock()
+	defer g.checkpoint.mu.Unlock()
+
+	cp := checkpoint{
+		Complete:  complete && len(g.checkpoint.failed) == 0,
+		Completed: make([]string, 0, len(g.checkpoint.completed)),
+	}
+	for key := range g.checkpoint.completed {
+		cp.Completed = append(cp.Completed, key)
+	}
+	slices.Sort(cp.Completed)
+
+	return g.aw.Add(&cp, archive.TagSpecial(checkpointSpecial))
+}
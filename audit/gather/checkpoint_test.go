@@ -0,0 +1,236 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/nats-io/jsm.go/audit/archive"
+)
+
+func TestArtifactTarget(t *testing.T) {
+	cfg := NewCaptureConfiguration()
+	err := cfg.RegisterCustomEndpoint(CustomEndpoint{Subject: "site.monitor", TypeTag: archive.TagArtifactType("site_monitor")})
+	if err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+
+	g := testGather(context.Background(), cfg)
+
+	cases := []struct {
+		name   string
+		tags   []*archive.Tag
+		phase  Phase
+		target string
+	}{
+		{
+			name:   "server endpoint",
+			tags:   []*archive.Tag{archive.TagServer("n1"), archive.TagCluster("C1"), archive.TagServerVars()},
+			phase:  PhaseServerEndpoints,
+			target: "n1/VARZ",
+		},
+		{
+			name:   "server profile",
+			tags:   []*archive.Tag{archive.TagServer("n1"), archive.TagCluster("C1"), archive.TagServerProfile(), archive.TagProfileName("goroutine_1")},
+			phase:  PhaseServerProfiles,
+			target: "n1/goroutine_1",
+		},
+		{
+			name:   "account endpoint",
+			tags:   []*archive.Tag{archive.TagAccount("A"), archive.TagServer("n1"), archive.TagAccountInfo()},
+			phase:  PhaseAccountEndpoints,
+			target: "A/INFO",
+		},
+		{
+			name:   "stream",
+			tags:   []*archive.Tag{archive.TagAccount("A"), archive.TagStream("S1"), archive.TagServer("n1"), archive.TagStreamInfo()},
+			phase:  PhaseStreams,
+			target: "A",
+		},
+		{
+			name:   "server custom endpoint",
+			tags:   []*archive.Tag{archive.TagServer("n1"), archive.TagArtifactType("site_monitor")},
+			phase:  PhaseCustomEndpoints,
+			target: "n1/site_monitor",
+		},
+		{
+			name:   "account custom endpoint",
+			tags:   []*archive.Tag{archive.TagAccount("A"), archive.TagServer("n1"), archive.TagArtifactType("site_monitor")},
+			phase:  PhaseCustomEndpoints,
+			target: "A/n1/site_monitor",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			phase, target := g.artifactTarget(c.tags)
+			if phase != c.phase || target != c.target {
+				t.Fatalf("expected %s %s, got %s %s", c.phase, c.target, phase, target)
+			}
+		})
+	}
+}
+
+// writeInterruptedArchive creates an archive holding the VARZ of servers and the given checkpoint
+func writeInterruptedArchive(t *testing.T, path string, cp *checkpoint, servers ...string) {
+	t.Helper()
+
+	w, err := archive.NewWriter(path)
+	if err != nil {
+		t.Fatalf("failed to create archive: %v", err)
+	}
+
+	for _, server := range servers {
+		err = w.Add(map[string]string{"server": server}, archive.TagServer(server), archive.TagCluster("C1"), archive.TagServerVars())
+		if err != nil {
+			t.Fatalf("failed to add artifact: %v", err)
+		}
+	}
+
+	if cp != nil {
+		err = w.Add(cp, archive.TagSpecial(checkpointSpecial))
+		if err != nil {
+			t.Fatalf("failed to add checkpoint: %v", err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("failed to close archive: %v", err)
+	}
+}
+
+func TestResumeFrom(t *testing.T) {
+	// resume resumes the archive at path into a new archive which is closed once cb returns
+	resume := func(t *testing.T, path string, cb func(g *gather)) (string, error) {
+		t.Helper()
+
+		cfg := NewCaptureConfiguration()
+		cfg.TargetPath = filepath.Join(t.TempDir(), "resumed.zip")
+
+		g := testGather(context.Background(), cfg)
+		g.checkpoint = newCheckpointState()
+
+		var err error
+		g.aw, err = archive.NewWriter(cfg.TargetPath)
+		if err != nil {
+			t.Fatalf("failed to create archive: %v", err)
+		}
+
+		err = g.resumeFrom(path)
+		if err == nil && cb != nil {
+			cb(g)
+		}
+
+		closeErr := g.aw.Close()
+		if closeErr != nil {
+			t.Fatalf("failed to close archive: %v", closeErr)
+		}
+
+		return cfg.TargetPath, err
+	}
+
+	t.Run("Should copy completed targets and skip them", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "interrupted.zip")
+		writeInterruptedArchive(t, path, &checkpoint{Completed: []string{"server_endpoints/n1/VARZ"}}, "n1", "n2")
+
+		target, err := resume(t, path, func(g *gather) {
+			if !g.resumed(PhaseServerEndpoints, "n1/VARZ") {
+				t.Fatalf("expected n1/VARZ to be resumed")
+			}
+			if g.resumed(PhaseServerEndpoints, "n2/VARZ") {
+				t.Fatalf("expected n2/VARZ to be captured again")
+			}
+
+			g.targetCompleted(PhaseServerEndpoints, "n2/VARZ")
+			g.targetFailed(PhaseServerEndpoints, "n3/VARZ", fmt.Errorf("timeout"))
+			g.targetCompleted(PhaseServerEndpoints, "n3/VARZ")
+
+			err := g.writeCheckpoint(true)
+			if err != nil {
+				t.Fatalf("failed to write checkpoint: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("resume failed: %v", err)
+		}
+
+		r, err := archive.NewReader(target)
+		if err != nil {
+			t.Fatalf("failed to open archive: %v", err)
+		}
+		defer r.Close()
+
+		var vars map[string]string
+		err = r.Load(&vars, archive.TagServer("n1"), archive.TagCluster("C1"), archive.TagServerVars())
+		if err != nil || vars["server"] != "n1" {
+			t.Fatalf("expected the n1 artifact to be copied: %v %v", vars, err)
+		}
+
+		err = r.Load(&vars, archive.TagServer("n2"), archive.TagCluster("C1"), archive.TagServerVars())
+		if !errors.Is(err, archive.ErrNoMatches) {
+			t.Fatalf("expected the n2 artifact not to be copied: %v", err)
+		}
+
+		var cp checkpoint
+		err = r.Load(&cp, archive.TagSpecial(checkpointSpecial))
+		if err != nil {
+			t.Fatalf("failed to load checkpoint: %v", err)
+		}
+		if cp.Complete {
+			t.Fatalf("expected the checkpoint to be incomplete after a failure")
+		}
+		if !slices.Equal(cp.Completed, []string{"server_endpoints/n1/VARZ", "server_endpoints/n2/VARZ"}) {
+			t.Fatalf("unexpected completed targets %v", cp.Completed)
+		}
+	})
+
+	cases := []struct {
+		name string
+		cp   *checkpoint
+	}{
+		{"Should reject archives without a checkpoint", nil},
+		{"Should reject archives of completed gathers", &checkpoint{Complete: true, Completed: []string{"server_endpoints/n1/VARZ"}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "interrupted.zip")
+			writeInterruptedArchive(t, path, c.cp, "n1")
+
+			_, err := resume(t, path, nil)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+
+	t.Run("Should reject resuming into the same archive", func(t *testing.T) {
+		cfg := NewCaptureConfiguration()
+		cfg.TargetPath = filepath.Join(t.TempDir(), "audit.zip")
+
+		g := testGather(context.Background(), cfg)
+		g.checkpoint = newCheckpointState()
+
+		err := g.resumeFrom(cfg.TargetPath)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gather
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/nats-io/jsm.go/audit/archive"
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+// CustomEndpointScope determines how often a custom endpoint is captured
+type CustomEndpointScope int
+
+const (
+	// ServerScope captures a custom endpoint once from every server
+	ServerScope CustomEndpointScope = iota
+	// AccountScope captures a custom endpoint once for every account on every server the account is on
+	AccountScope
+)
+
+// PhaseCustomEndpoints captures custom endpoints
+const PhaseCustomEndpoints Phase = "custom_endpoints"
+
+// CustomEndpoint is a site specific endpoint captured during a gather and stored in the archive so that custom
+// checks can use it.
+//
+// The Subject may hold the placeholders {server_id}, {server_name} and {cluster} and, for AccountScope endpoints,
+// {account} which are replaced before every request, for example "site.monitor.{server_name}". A single JSON
+// response is expected for every request.
+type CustomEndpoint struct {
+	// Subject is the subject requests are sent to
+	Subject string
+	// Scope determines if the endpoint is captured per server or per account
+	Scope CustomEndpointScope
+	// TypeTag tags the captured artifacts, see archive.TagArtifactType()
+	TypeTag *archive.Tag
+	// Timeout overrides the configured Timeout for requests to this endpoint when set
+	Timeout time.Duration
+	// Response creates the value responses are unmarshalled into to validate them, responses are not validated when
+	// nil. The responses are stored as received rather than the unmarshalled value
+	Response func() any
+}
+
+// RegisterCustomEndpoint adds a site specific endpoint to capture, its artifact type must not be used by another
+// endpoint
+func (c *Configuration) RegisterCustomEndpoint(endpoint CustomEndpoint) error {
+	if endpoint.Subject == "" {
+		return fmt.Errorf("custom endpoint subject is required")
+	}
+	if endpoint.Scope != ServerScope && endpoint.Scope != AccountScope {
+		return fmt.Errorf("invalid scope for custom endpoint %s", endpoint.Subject)
+	}
+	if endpoint.TypeTag == nil || endpoint.TypeTag.Name != archive.TagArtifactType("").Name || endpoint.TypeTag.Value == "" {
+		return fmt.Errorf("custom endpoint %s requires an artifact type tag", endpoint.Subject)
+	}
+
+	used := []string{archive.TagServerProfile().Value, archive.TagStreamInfo().Value}
+	for _, e := range slices.Concat(c.ServerEndpointConfigs, c.AccountEndpointConfigs) {
+		used = append(used, e.TypeTag.Value)
+	}
+	for _, e := range c.CustomEndpoints {
+		used = append(used, e.TypeTag.Value)
+	}
+	if slices.Contains(used, endpoint.TypeTag.Value) {
+		return fmt.Errorf("artifact type %s is already captured", endpoint.TypeTag.Value)
+	}
+
+	c.CustomEndpoints = append(c.CustomEndpoints, endpoint)
+
+	return nil
+}
+
+// subject resolves the placeholders in the endpoint subject
+func (e *CustomEndpoint) subject(serverInfo *server.ServerInfo, account string) string {
+	return strings.NewReplacer(
+		"{server_id}", serverInfo.ID,
+		"{server_name}", serverInfo.Name,
+		"{cluster}", serverInfo.Cluster,
+		"{account}", account,
+	).Replace(e.Subject)
+}
+
+// customEndpoint finds the custom endpoint storing artifacts of the given type
+func (g *gather) customEndpoint(artifactType string) *CustomEndpoint {
+	for i, endpoint := range g.cfg.CustomEndpoints {
+		if endpoint.TypeTag.Value == artifactType {
+			return &g.cfg.CustomEndpoints[i]
+		}
+	}
+
+	return nil
+}
+
+// Capture the custom endpoints from every server or account, server scoped endpoints are not captured in account
+// scoped mode
+func (g *gather) captureCustomEndpoints(serverInfoMap map[string]*server.ServerInfo, accountIdsToServersCountMap map[string]int, accountScoped bool) error {
+	g.log.Infof("Querying %d custom endpoints...", len(g.cfg.CustomEndpoints))
+
+	expected := 0
+	for _, endpoint := range g.cfg.CustomEndpoints {
+		switch endpoint.Scope {
+		case ServerScope:
+			if !accountScoped {
+				expected += len(serverInfoMap)
+			}
+		case AccountScope:
+			for accountId := range accountIdsToServersCountMap {
+				expected += len(g.accountServers[accountId])
+			}
+		}
+	}
+	g.progress.PhaseStarted(PhaseCustomEndpoints, expected)
+
+	var capturedCount atomic.Int64
+
+	err := eachParallel(g, slices.Collect(maps.Keys(serverInfoMap)), func(serverId string) error {
+		serverInfo := serverInfoMap[serverId]
+
+		for _, endpoint := range g.cfg.CustomEndpoints {
+			switch {
+			case endpoint.Scope == ServerScope && !accountScoped:
+				ok, err := g.captureCustomEndpoint(&endpoint, serverInfo, "")
+				if err != nil {
+					return err
+				}
+				if ok {
+					capturedCount.Add(1)
+				}
+
+			case endpoint.Scope == AccountScope:
+				for accountId := range accountIdsToServersCountMap {
+					if !slices.Contains(g.accountServers[accountId], serverId) {
+						continue
+					}
+
+					ok, err := g.captureCustomEndpoint(&endpoint, serverInfo, accountId)
+					if err != nil {
+						return err
+					}
+					if ok {
+						capturedCount.Add(1)
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	g.log.Infof("Captured %d custom endpoint responses", capturedCount.Load())
+	g.progress.PhaseCompleted(PhaseCustomEndpoints, int(capturedCount.Load()))
+
+	return nil
+}
+
+// captureCustomEndpoint requests a custom endpoint from a server, for account scoped endpoints account is set.
+// Errors are only returned when the archive can not be written, failed requests are logged and reported as not captured
+func (g *gather) captureCustomEndpoint(endpoint *CustomEndpoint, serverInfo *server.ServerInfo, account string) (bool, error) {
+	target := fmt.Sprintf("%s/%s", serverInfo.Name, endpoint.TypeTag.Value)
+	if account != "" {
+		target = fmt.Sprintf("%s/%s", account, target)
+	}
+
+	if g.resumed(PhaseCustomEndpoints, target) {
+		return false, nil
+	}
+
+	subject := endpoint.subject(serverInfo, account)
+	response, err := g.requestServer(serverInfo.ID, endpoint.Timeout, nil, subject)
+	if err != nil {
+		g.captureFailed(PhaseCustomEndpoints, target, "Failed to request %s from server %s: %s", subject, serverInfo.Name, err)
+		return false, nil
+	}
+
+	if endpoint.Response != nil {
+		err = json.Unmarshal(response, endpoint.Response())
+		if err != nil {
+			g.captureFailed(PhaseCustomEndpoints, target, "Failed to deserialize %s response from server %s: %s", subject, serverInfo.Name, err)
+			return false, nil
+		}
+	}
+
+	buff := new(bytes.Buffer)
+	err = json.Indent(buff, response, "", "  ")
+	if err != nil {
+		g.captureFailed(PhaseCustomEndpoints, target, "Failed to indent %s response from server %s: %s", subject, serverInfo.Name, err)
+		return false, nil
+	}
+
+	tags := []*archive.Tag{
+		archive.TagServer(serverInfo.Name),
+		endpoint.TypeTag,
+	}
+	if serverInfo.Cluster != "" {
+		tags = append(tags, archive.TagCluster(serverInfo.Cluster))
+	} else {
+		tags = append(tags, archive.TagNoCluster())
+	}
+	if account != "" {
+		tags = append(tags, archive.TagAccount(account))
+	}
+
+	err = g.addRaw(PhaseCustomEndpoints, target, buff, "json", tags...)
+	if err != nil {
+		return false, fmt.Errorf("failed to add %s response to archive: %w", subject, err)
+	}
+
+	g.targetCompleted(PhaseCustomEndpoints, target)
+
+	return true, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gather
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nats-io/jsm.go/audit/archive"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+func TestRegisterCustomEndpoint(t *testing.T) {
+	t.Run("Should register valid endpoints", func(t *testing.T) {
+		cfg := NewCaptureConfiguration()
+		err := cfg.RegisterCustomEndpoint(CustomEndpoint{Subject: "site.monitor.{server_name}", TypeTag: archive.TagArtifactType("site_monitor")})
+		if err != nil {
+			t.Fatalf("register failed: %v", err)
+		}
+		err = cfg.RegisterCustomEndpoint(CustomEndpoint{Subject: "site.limits.{account}", Scope: AccountScope, TypeTag: archive.TagArtifactType("site_limits")})
+		if err != nil {
+			t.Fatalf("register failed: %v", err)
+		}
+		if len(cfg.CustomEndpoints) != 2 {
+			t.Fatalf("expected 2 endpoints, got %d", len(cfg.CustomEndpoints))
+		}
+	})
+
+	cases := []struct {
+		name     string
+		endpoint CustomEndpoint
+		err      string
+	}{
+		{"Should require a subject", CustomEndpoint{TypeTag: archive.TagArtifactType("site_monitor")}, "subject is required"},
+		{"Should reject invalid scopes", CustomEndpoint{Subject: "site.monitor", Scope: 10, TypeTag: archive.TagArtifactType("site_monitor")}, "invalid scope"},
+		{"Should require a type tag", CustomEndpoint{Subject: "site.monitor"}, "requires an artifact type tag"},
+		{"Should require an artifact type tag", CustomEndpoint{Subject: "site.monitor", TypeTag: archive.TagServer("n1")}, "requires an artifact type tag"},
+		{"Should reject built-in artifact types", CustomEndpoint{Subject: "site.monitor", TypeTag: archive.TagServerVars()}, "already captured"},
+		{"Should reject artifact types of other custom endpoints", CustomEndpoint{Subject: "site.other", TypeTag: archive.TagArtifactType("site_monitor")}, "already captured"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := NewCaptureConfiguration()
+			err := cfg.RegisterCustomEndpoint(CustomEndpoint{Subject: "site.monitor", TypeTag: archive.TagArtifactType("site_monitor")})
+			if err != nil {
+				t.Fatalf("register failed: %v", err)
+			}
+
+			err = cfg.RegisterCustomEndpoint(c.endpoint)
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Fatalf("expected error matching %q, got %v", c.err, err)
+			}
+			if len(cfg.CustomEndpoints) != 1 {
+				t.Fatalf("expected the endpoint not to be registered")
+			}
+		})
+	}
+}
+
+func TestGatherCustomEndpoints(t *testing.T) {
+	withGatherServer(t, func(srv *server.Server, sys *nats.Conn, _ *nats.Conn) {
+		for _, subj := range []string{"site.monitor.s1", "site.limits.*"} {
+			_, err := sys.Subscribe(subj, func(m *nats.Msg) {
+				m.Respond([]byte(`{"subject":"` + m.Subject + `"}`))
+			})
+			if err != nil {
+				t.Fatalf("subscribe failed: %v", err)
+			}
+		}
+		_, err := sys.Subscribe("site.invalid.s1", func(m *nats.Msg) {
+			m.Respond([]byte("not json"))
+		})
+		if err != nil {
+			t.Fatalf("subscribe failed: %v", err)
+		}
+
+		cfg := testCaptureConfiguration(t)
+		for _, e := range []CustomEndpoint{
+			{Subject: "site.monitor.{server_name}", TypeTag: archive.TagArtifactType("site_monitor")},
+			{Subject: "site.limits.{account}", Scope: AccountScope, TypeTag: archive.TagArtifactType("site_limits")},
+			{Subject: "site.invalid.{server_name}", TypeTag: archive.TagArtifactType("site_invalid")},
+			{Subject: "site.missing.{server_name}", TypeTag: archive.TagArtifactType("site_missing")},
+		} {
+			err = cfg.RegisterCustomEndpoint(e)
+			if err != nil {
+				t.Fatalf("register failed: %v", err)
+			}
+		}
+
+		err = GatherContext(context.Background(), sys, cfg)
+		if err != nil {
+			t.Fatalf("gather failed: %v", err)
+		}
+
+		r, err := archive.NewReader(cfg.TargetPath)
+		if err != nil {
+			t.Fatalf("failed to open archive: %v", err)
+		}
+		defer r.Close()
+
+		t.Run("Should tag server scoped artifacts", func(t *testing.T) {
+			var res map[string]string
+			err = r.Load(&res, archive.TagServer("s1"), archive.TagNoCluster(), archive.TagArtifactType("site_monitor"))
+			if err != nil || res["subject"] != "site.monitor.s1" {
+				t.Fatalf("expected the site_monitor response, got %v: %v", res, err)
+			}
+		})
+
+		t.Run("Should tag account scoped artifacts", func(t *testing.T) {
+			for _, account := range []string{"USERS", "OTHER"} {
+				var res map[string]string
+				err = r.Load(&res, archive.TagAccount(account), archive.TagServer("s1"), archive.TagArtifactType("site_limits"))
+				if err != nil || res["subject"] != "site.limits."+account {
+					t.Fatalf("expected the %s site_limits response, got %v: %v", account, res, err)
+				}
+			}
+		})
+
+		t.Run("Should record failed captures in the metadata", func(t *testing.T) {
+			for _, artifactType := range []string{"site_invalid", "site_missing"} {
+				var res map[string]any
+				err = r.Load(&res, archive.TagServer("s1"), archive.TagArtifactType(artifactType))
+				if !errors.Is(err, archive.ErrNoMatches) {
+					t.Fatalf("expected no %s artifact, got %v", artifactType, err)
+				}
+			}
+
+			var md archive.AuditMetadata
+			err = r.Load(&md, archive.TagSpecial("audit_gather_metadata"))
+			if err != nil {
+				t.Fatalf("failed to load metadata: %v", err)
+			}
+
+			failed := map[string]bool{}
+			for _, f := range md.FailedCaptures {
+				if f.Phase != string(PhaseCustomEndpoints) || f.Error == "" {
+					t.Fatalf("unexpected failure %+v", f)
+				}
+				failed[f.Target] = true
+			}
+			if len(failed) != 2 || !failed["s1/site_invalid"] || !failed["s1/site_missing"] {
+				t.Fatalf("unexpected failed captures %+v", md.FailedCaptures)
+			}
+		})
+	})
+}
@@ -19,14 +19,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"os/user"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/klauspost/compress/s2"
+	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/jsm.go/audit/archive"
 	"github.com/nats-io/nats-server/v2/server"
@@ -45,6 +49,8 @@ type EndpointSelection struct {
 type EndpointCaptureConfig struct {
 	ApiSuffix string
 	TypeTag   *archive.Tag
+	// Timeout overrides the configured Timeout for requests to this endpoint when set
+	Timeout time.Duration
 }
 
 type Configuration struct {
@@ -56,6 +62,40 @@ type Configuration struct {
 	AccountEndpointConfigs []EndpointCaptureConfig
 	ServerProfileNames     []profileConfiguration
 	Detailed               bool
+	// EncryptionKey encrypts the archive when set, see archive.GenerateEncryptionKey()
+	EncryptionKey []byte
+	// BaseArchivePath creates an incremental archive relative to an earlier archive when set, see archive.BaseArchive()
+	BaseArchivePath string
+	// Progress is notified about the progress of the gather when set
+	Progress Progress
+	// Accounts limits the gather to artifacts of these accounts when set, server endpoints and profiles are not
+	// captured as they hold data about the entire system
+	Accounts []string
+	// Concurrency is the number of servers or accounts captured in parallel, defaults to 1
+	Concurrency int
+	// ServerRequestRate limits the number of requests per second sent to each server, 0 for no limit
+	ServerRequestRate float64
+	// BandwidthLimit limits the bytes per second received across all requests, 0 for no limit
+	BandwidthLimit int64
+	// CustomEndpoints are site specific endpoints captured in addition to the built-in ones, see RegisterCustomEndpoint()
+	CustomEndpoints []CustomEndpoint
+	// ResumeArchivePath resumes the interrupted gather that wrote this archive when set, targets it captured are
+	// copied into the new archive rather than captured again. The configuration should match the interrupted gather
+	ResumeArchivePath string
+	// Retries is the number of times a failed request is retried before the artifact is recorded as failed in the
+	// archive metadata, requests that received no response are retried too. Defaults to 0, failed requests are not
+	// retried
+	Retries int
+	// RetryBackoff is the delay before the first retry, it doubles after every further failed attempt. Defaults to 1s
+	RetryBackoff time.Duration
+	// MessageSamples captures the headers of the first and last MessageSamples messages of every stream when set,
+	// message bodies are not captured. Only accounts with a connection in SampleConnections are sampled
+	MessageSamples int
+	// SampleStreams limits message sampling to the streams with these names when set
+	SampleStreams []string
+	// SampleConnections are connections to the accounts whose streams are sampled keyed by account, the system
+	// account can not read the messages of other accounts
+	SampleConnections map[string]*nats.Conn
 }
 
 // endpointPagingInfo maps a given endpoint's API suffix to the JSON field path that contains
@@ -75,69 +115,53 @@ type profileConfiguration struct {
 
 func (p *profileConfiguration) Name() string { return p.name }
 
+// ProfileNames are the names of the profiles that can be captured from servers
+var ProfileNames = []string{"goroutine", "heap", "allocs", "mutex", "threadcreate", "block", "cpu"}
+
+// SelectProfiles limits the profiles captured from each server to those named, see ProfileNames. Profiles are only
+// captured when Include.ServerProfiles is set
+func (c *Configuration) SelectProfiles(names ...string) error {
+	var selected []profileConfiguration
+
+	for _, name := range names {
+		if !slices.Contains(ProfileNames, name) {
+			return fmt.Errorf("unknown profile %q", name)
+		}
+
+		for _, profile := range NewCaptureConfiguration().ServerProfileNames {
+			if profile.name == name {
+				selected = append(selected, profile)
+			}
+		}
+	}
+
+	c.ServerProfileNames = selected
+
+	return nil
+}
+
 func NewCaptureConfiguration() *Configuration {
 	return &Configuration{
-		LogLevel: api.InfoLevel,
-		Timeout:  5 * time.Second,
+		LogLevel:     api.InfoLevel,
+		Timeout:      5 * time.Second,
+		RetryBackoff: time.Second,
 		ServerEndpointConfigs: []EndpointCaptureConfig{
-			{
-				"VARZ",
-				archive.TagServerVars(),
-			},
-			{
-				"CONNZ",
-				archive.TagServerConnections(),
-			},
-			{
-				"ROUTEZ",
-				archive.TagServerRoutes(),
-			},
-			{
-				"GATEWAYZ",
-				archive.TagServerGateways(),
-			},
-			{
-				"LEAFZ",
-				archive.TagServerLeafs(),
-			},
-			{
-				"SUBSZ",
-				archive.TagServerSubs(),
-			},
-			{
-				"JSZ",
-				archive.TagServerJetStream(),
-			},
-			{
-				"ACCOUNTZ",
-				archive.TagServerAccounts(),
-			},
-			{
-				"HEALTHZ",
-				archive.TagServerHealth(),
-			},
+			{ApiSuffix: "VARZ", TypeTag: archive.TagServerVars()},
+			{ApiSuffix: "CONNZ", TypeTag: archive.TagServerConnections()},
+			{ApiSuffix: "ROUTEZ", TypeTag: archive.TagServerRoutes()},
+			{ApiSuffix: "GATEWAYZ", TypeTag: archive.TagServerGateways()},
+			{ApiSuffix: "LEAFZ", TypeTag: archive.TagServerLeafs()},
+			{ApiSuffix: "SUBSZ", TypeTag: archive.TagServerSubs()},
+			{ApiSuffix: "JSZ", TypeTag: archive.TagServerJetStream()},
+			{ApiSuffix: "ACCOUNTZ", TypeTag: archive.TagServerAccounts()},
+			{ApiSuffix: "HEALTHZ", TypeTag: archive.TagServerHealth()},
 		},
 		AccountEndpointConfigs: []EndpointCaptureConfig{
-			{
-				"CONNZ",
-				archive.TagAccountConnections(),
-			},
-			{
-				"LEAFZ",
-				archive.TagAccountLeafs(),
-			},
-			{
-				"SUBSZ",
-				archive.TagAccountSubs(),
-			},
-			{
-				"INFO",
-				archive.TagAccountInfo(),
-			},
-			{
-				"JSZ",
-				archive.TagAccountJetStream(),
-			},
+			{ApiSuffix: "CONNZ", TypeTag: archive.TagAccountConnections()},
+			{ApiSuffix: "LEAFZ", TypeTag: archive.TagAccountLeafs()},
+			{ApiSuffix: "SUBSZ", TypeTag: archive.TagAccountSubs()},
+			{ApiSuffix: "INFO", TypeTag: archive.TagAccountInfo()},
+			{ApiSuffix: "JSZ", TypeTag: archive.TagAccountJetStream()},
 		},
 		ServerProfileNames: []profileConfiguration{
 			{"goroutine", 1}, // includes aggregated goroutines with tags
@@ -154,11 +178,19 @@ func NewCaptureConfiguration() *Configuration {
 }
 
 type gather struct {
-	cfg     *Configuration
-	aw      *archive.Writer
-	nc      *nats.Conn
-	capture *bytes.Buffer
-	log     api.Logger
+	cfg      *Configuration
+	aw       *archive.Writer
+	nc       *nats.Conn
+	capture  *bytes.Buffer
+	log      api.Logger
+	progress Progress
+	limits   *limits
+	// awMu guards the archive writer while artifacts are captured in parallel
+	awMu       sync.Mutex
+	ctx        context.Context
+	checkpoint *checkpointState
+	// accountServers are the ids of the servers each account was discovered on
+	accountServers map[string][]string
 }
 
 func (g *gather) start() error {
@@ -169,13 +201,33 @@ func (g *gather) start() error {
 	}
 	target := g.cfg.TargetPath
 
+	// Creating the writer truncates the target, so it must not be the archive being resumed
+	if g.cfg.ResumeArchivePath != "" && filepath.Clean(g.cfg.ResumeArchivePath) == filepath.Clean(target) {
+		return fmt.Errorf("failed to resume gather: resumed archive may not be the target archive")
+	}
+
 	// Create an archive writer
 	var err error
-	g.aw, err = archive.NewWriter(target)
+	var opts []archive.Option
+	if len(g.cfg.EncryptionKey) > 0 {
+		opts = append(opts, archive.EncryptionKey(g.cfg.EncryptionKey))
+	}
+	if g.cfg.BaseArchivePath != "" {
+		opts = append(opts, archive.BaseArchive(g.cfg.BaseArchivePath))
+	}
+
+	g.aw, err = archive.NewWriter(target, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to create archive: %w", err)
 	}
+	complete := false
 	defer func() {
+		// Record the progress so far so an interrupted gather can be resumed
+		err := g.writeCheckpoint(complete)
+		if err != nil {
+			fmt.Printf("Failed to add checkpoint: %s\n", err)
+		}
+
 		// Add the output of this command (so far) to the archive as additional log artifact
 		err = g.aw.AddRaw(bytes.NewReader(g.capture.Bytes()), "log", archive.TagSpecial("audit_gather_log"))
 		if err != nil {
@@ -183,7 +235,7 @@ func (g *gather) start() error {
 		}
 		g.capture.Reset()
 
-		err := g.aw.Close()
+		err = g.aw.Close()
 		if err != nil {
 			fmt.Printf("Failed to close archive: %s\n", err)
 		}
@@ -191,6 +243,13 @@ func (g *gather) start() error {
 	}()
 	g.aw.SetTime(ts)
 
+	if g.cfg.ResumeArchivePath != "" {
+		err = g.resumeFrom(g.cfg.ResumeArchivePath)
+		if err != nil {
+			return fmt.Errorf("failed to resume gather: %w", err)
+		}
+	}
+
 	// Discover servers, create map with servers info
 	serverInfoMap, err := g.discoverServers()
 	if err != nil {
@@ -203,22 +262,46 @@ func (g *gather) start() error {
 		return fmt.Errorf("failed to discover accounts: %w", err)
 	}
 
+	accountScoped := len(g.cfg.Accounts) > 0
+	if accountScoped {
+		accountIdsToServersCountMap = g.selectAccounts(accountIdsToServersCountMap)
+	}
+
+	err = g.interrupted()
+	if err != nil {
+		return err
+	}
+
 	// Capture server endpoints
-	if g.cfg.Include.ServerEndpoints {
+	if g.cfg.Include.ServerEndpoints && accountScoped {
+		g.log.Infof("Skipping servers endpoints data gathering in account scoped mode")
+	} else if g.cfg.Include.ServerEndpoints {
 		err := g.captureServerEndpoints(serverInfoMap, g.cfg.Detailed)
 		if err != nil {
 			return fmt.Errorf("failed to capture server endpoints: %w", err)
 		}
+
+		err = g.interrupted()
+		if err != nil {
+			return err
+		}
 	} else {
 		g.log.Infof("Skipping servers endpoints data gathering")
 	}
 
 	// Capture server profiles
-	if g.cfg.Include.ServerProfiles {
+	if g.cfg.Include.ServerProfiles && accountScoped {
+		g.log.Infof("Skipping server profiles gathering in account scoped mode")
+	} else if g.cfg.Include.ServerProfiles {
 		err := g.captureServerProfiles(serverInfoMap)
 		if err != nil {
 			return fmt.Errorf("failed to capture server profiles: %w", err)
 		}
+
+		err = g.interrupted()
+		if err != nil {
+			return err
+		}
 	} else {
 		g.log.Infof("Skipping server profiles gathering")
 	}
@@ -229,23 +312,55 @@ func (g *gather) start() error {
 		if err != nil {
 			return fmt.Errorf("failed to capture account endpoints: %w", err)
 		}
+
+		err = g.interrupted()
+		if err != nil {
+			return err
+		}
 	} else {
 		g.log.Infof("Skipping accounts endpoints data gathering")
 	}
 
+	// Capture custom endpoints
+	if len(g.cfg.CustomEndpoints) > 0 {
+		err := g.captureCustomEndpoints(serverInfoMap, accountIdsToServersCountMap, accountScoped)
+		if err != nil {
+			return fmt.Errorf("failed to capture custom endpoints: %w", err)
+		}
+
+		err = g.interrupted()
+		if err != nil {
+			return err
+		}
+	}
+
 	// Discover and capture streams in each account
 	if g.cfg.Include.Streams {
 		g.log.Infof("Gathering streams data...")
+		g.progress.PhaseStarted(PhaseStreams, 0)
 
-		for accountId, numServers := range accountIdsToServersCountMap {
+		var captured atomic.Int64
+		err = eachParallel(g, slices.Collect(maps.Keys(accountIdsToServersCountMap)), func(accountId string) error {
 			// Skip system account, JetStream is probably not enabled
-			if accountId == systemAccount {
-				continue
+			if accountId == systemAccount || g.resumed(PhaseStreams, accountId) {
+				return nil
 			}
-			err := g.captureAccountStreams(serverInfoMap, accountId, numServers)
+			count, err := g.captureAccountStreams(serverInfoMap, accountId, accountIdsToServersCountMap[accountId])
+			captured.Add(int64(count))
 			if err != nil {
-				g.log.Errorf("Failed to capture streams for account %s: %v", accountId, err)
+				return err
 			}
+			g.targetCompleted(PhaseStreams, accountId)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to capture streams: %w", err)
+		}
+		g.progress.PhaseCompleted(PhaseStreams, int(captured.Load()))
+
+		err = g.interrupted()
+		if err != nil {
+			return err
 		}
 	} else {
 		g.log.Infof("Skipping streams data gathering")
@@ -257,9 +372,40 @@ func (g *gather) start() error {
 		return fmt.Errorf("failed to capture metadata: %w", err)
 	}
 
+	complete = true
+
+	return nil
+}
+
+// interrupted returns an error when the gather context was cancelled
+func (g *gather) interrupted() error {
+	err := g.ctx.Err()
+	if err != nil {
+		return fmt.Errorf("gather interrupted, resume it using the archive: %w", err)
+	}
+
 	return nil
 }
 
+// selectAccounts limits the discovered accounts to those configured in Accounts
+func (g *gather) selectAccounts(accountIdsToServersCountMap map[string]int) map[string]int {
+	selected := make(map[string]int, len(g.cfg.Accounts))
+
+	for _, accountId := range g.cfg.Accounts {
+		serversCount, ok := accountIdsToServersCountMap[accountId]
+		if !ok {
+			g.log.Warnf("Account %s was not discovered on any server, skipping", accountId)
+			continue
+		}
+
+		selected[accountId] = serversCount
+	}
+
+	g.log.Infof("Limiting gathering to %d of %d discovered accounts", len(selected), len(accountIdsToServersCountMap))
+
+	return selected
+}
+
 // Capture runtime information about the capture
 func (g *gather) captureMetadata() error {
 	username := "?"
@@ -276,6 +422,7 @@ func (g *gather) captureMetadata() error {
 		ConnectedServerVersion: g.nc.ConnectedServerVersion(),
 		ConnectURL:             g.nc.ConnectedUrlRedacted(),
 		UserName:               username,
+		FailedCaptures:         g.failedCaptures(),
 	}
 
 	err = g.aw.Add(&metadata, archive.TagSpecial("audit_gather_metadata"))
@@ -286,8 +433,9 @@ func (g *gather) captureMetadata() error {
 	return nil
 }
 
-// Discover streams in given account, and capture info for each one
-func (g *gather) captureAccountStreams(serverInfoMap map[string]*server.ServerInfo, accountId string, numServers int) error {
+// Discover streams in given account, and capture info for each one. Failures to discover the streams are recorded as
+// failed captures, errors are only returned when the archive can not be written
+func (g *gather) captureAccountStreams(serverInfoMap map[string]*server.ServerInfo, accountId string, numServers int) (int, error) {
 	jszOptions := server.JSzOptions{
 		Account:    accountId,
 		Streams:    true,
@@ -297,7 +445,7 @@ func (g *gather) captureAccountStreams(serverInfoMap map[string]*server.ServerIn
 	}
 
 	jsInfoResponses := make(map[string]*server.ServerAPIJszResponse, numServers)
-	err := g.doReqAsync(context.TODO(), jszOptions, "$SYS.REQ.SERVER.PING.JSZ", numServers, func(b []byte) {
+	err := g.requestServers(slices.Collect(maps.Keys(serverInfoMap)), 0, jszOptions, "$SYS.REQ.SERVER.PING.JSZ", numServers, func(b []byte) {
 		var apiResponse server.ServerAPIJszResponse
 		err := json.Unmarshal(b, &apiResponse)
 		if err != nil {
@@ -337,10 +485,13 @@ func (g *gather) captureAccountStreams(serverInfoMap map[string]*server.ServerIn
 		jsInfoResponses[serverName] = &apiResponse
 	})
 	if err != nil {
-		return fmt.Errorf("failed to retrieve account %s streams: %w", accountId, err)
+		g.captureFailed(PhaseStreams, accountId, "Failed to retrieve streams for account %s: %v", accountId, err)
+		return 0, nil
 	}
 
 	streamNamesSet := make(map[string]any)
+	sampled := make(map[string]*sampledStream)
+	capturedCount := 0
 
 	// Capture stream info from each known replica
 	for serverName, jsInfo := range jsInfoResponses {
@@ -368,18 +519,39 @@ func (g *gather) captureAccountStreams(serverInfoMap map[string]*server.ServerIn
 				archive.TagStreamInfo(),
 			}
 
-			err = g.aw.Add(streamInfo, tags...)
+			err = g.add(PhaseStreams, fmt.Sprintf("%s/%s/%s", accountId, streamName, serverName), streamInfo, tags...)
 			if err != nil {
-				return fmt.Errorf("failed to add stream %s info to archive: %w", streamName, err)
+				return capturedCount, fmt.Errorf("failed to add stream %s info to archive: %w", streamName, err)
 			}
 
 			streamNamesSet[streamName] = nil
+			capturedCount++
+
+			// Prefer sampling messages from the stream leader
+			isLeader := streamInfo.Cluster != nil && streamInfo.Cluster.Leader == serverName
+			if g.shouldSampleStream(streamName) && (sampled[streamName] == nil || isLeader) {
+				sampled[streamName] = &sampledStream{serverName: serverName, clusterTag: clusterTag, detail: streamInfo}
+			}
 		}
 	}
 
+	sampleConn := g.sampleConnection(accountId, len(sampled))
+	for _, streamName := range slices.Sorted(maps.Keys(sampled)) {
+		if sampleConn == nil {
+			break
+		}
+
+		err = g.captureStreamMessageSamples(sampleConn, accountId, sampled[streamName])
+		if err != nil {
+			g.captureFailed(PhaseStreams, accountId, "Failed to capture stream %s message samples in account %s: %s", streamName, accountId, err)
+			continue
+		}
+		capturedCount++
+	}
+
 	g.log.Infof("Discovered %d streams in account %s", len(streamNamesSet), accountId)
 
-	return nil
+	return capturedCount, nil
 }
 
 // Capture configured endpoints for each known account
@@ -388,15 +560,30 @@ func (g *gather) captureAccountEndpoints(serverInfoMap map[string]*server.Server
 		ClusterName string
 		ServerName  string
 	}
-	capturedCount := 0
+	var capturedCount atomic.Int64
 	g.log.Infof("Querying %d endpoints for %d known accounts...", len(g.cfg.AccountEndpointConfigs), len(accountIdsToServersCountMap))
 
-	for accountId, serversCount := range accountIdsToServersCountMap {
+	expected := 0
+	for _, serversCount := range accountIdsToServersCountMap {
+		expected += serversCount * len(g.cfg.AccountEndpointConfigs)
+	}
+	g.progress.PhaseStarted(PhaseAccountEndpoints, expected)
+
+	serverIds := slices.Collect(maps.Keys(serverInfoMap))
+
+	err := eachParallel(g, slices.Collect(maps.Keys(accountIdsToServersCountMap)), func(accountId string) error {
+		serversCount := accountIdsToServersCountMap[accountId]
+
 		for _, endpoint := range g.cfg.AccountEndpointConfigs {
 			subject := fmt.Sprintf("$SYS.REQ.ACCOUNT.%s.%s", accountId, endpoint.ApiSuffix)
+			target := fmt.Sprintf("%s/%s", accountId, endpoint.ApiSuffix)
 			endpointResponses := make(map[Responder]io.Reader, serversCount)
 
-			err := g.doReqAsync(context.TODO(), nil, subject, serversCount, func(b []byte) {
+			if g.resumed(PhaseAccountEndpoints, target) {
+				continue
+			}
+
+			err := g.requestServers(serverIds, endpoint.Timeout, nil, subject, serversCount, func(b []byte) {
 				var apiResponse server.ServerAPIResponse
 				err := json.Unmarshal(b, &apiResponse)
 				if err != nil {
@@ -439,7 +626,7 @@ func (g *gather) captureAccountEndpoints(serverInfoMap map[string]*server.Server
 				endpointResponses[responder] = buff
 			})
 			if err != nil {
-				g.log.Errorf("Failed to request %s for account %s: %s", endpoint.ApiSuffix, accountId, err)
+				g.captureFailed(PhaseAccountEndpoints, target, "Failed to request %s for account %s: %s", endpoint.ApiSuffix, accountId, err)
 				continue
 			}
 
@@ -457,17 +644,25 @@ func (g *gather) captureAccountEndpoints(serverInfoMap map[string]*server.Server
 					endpoint.TypeTag,
 				}
 
-				err = g.aw.AddRaw(endpointResponse, "json", tags...)
+				err = g.addRaw(PhaseAccountEndpoints, fmt.Sprintf("%s/%s/%s", accountId, responder.ServerName, endpoint.ApiSuffix), endpointResponse, "json", tags...)
 				if err != nil {
 					return fmt.Errorf("failed to add response to %s to archive: %w", subject, err)
 				}
 
-				capturedCount += 1
+				capturedCount.Add(1)
 			}
+
+			g.targetCompleted(PhaseAccountEndpoints, target)
 		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	g.log.Infof("Captured %d endpoint responses from %d accounts", capturedCount, len(accountIdsToServersCountMap))
+	g.log.Infof("Captured %d endpoint responses from %d accounts", capturedCount.Load(), len(accountIdsToServersCountMap))
+	g.progress.PhaseCompleted(PhaseAccountEndpoints, int(capturedCount.Load()))
 
 	return nil
 }
@@ -476,10 +671,12 @@ func (g *gather) captureAccountEndpoints(serverInfoMap map[string]*server.Server
 func (g *gather) captureServerProfiles(serverInfoMap map[string]*server.ServerInfo) error {
 	g.log.Infof("Capturing %d profiles on %d known servers...", len(g.cfg.ServerProfileNames), len(serverInfoMap))
 
-	capturedCount := 0
-	timeout := g.cfg.Timeout
+	g.progress.PhaseStarted(PhaseServerProfiles, len(serverInfoMap)*len(g.cfg.ServerProfileNames))
+
+	var capturedCount atomic.Int64
 
-	for serverId, serverInfo := range serverInfoMap {
+	err := eachParallel(g, slices.Collect(maps.Keys(serverInfoMap)), func(serverId string) error {
+		serverInfo := serverInfoMap[serverId]
 		serverName := serverInfo.Name
 		clusterTag := archive.TagNoCluster()
 		if serverInfo.Cluster != "" {
@@ -493,25 +690,26 @@ func (g *gather) captureServerProfiles(serverInfoMap map[string]*server.ServerIn
 				Debug: profile.debug,
 			}
 
-			if profile.name == "cpu" {
-				payload.Duration = timeout
-				g.cfg.Timeout = timeout + 2*time.Second
-			} else {
-				g.cfg.Timeout = timeout
+			target := fmt.Sprintf("%s/%s", serverName, profile.name)
+			if profile.debug > 0 {
+				target += fmt.Sprintf("_%d", profile.debug)
 			}
 
-			responses, err := g.doReq(context.TODO(), payload, subject, 1)
-			if err != nil {
-				g.log.Errorf("Failed to request %v (%d) profile from server %s: %s", profile, profile.debug, serverName, err)
+			if g.resumed(PhaseServerProfiles, target) {
 				continue
 			}
 
-			if len(responses) != 1 {
-				g.log.Errorf("Unexpected number of responses to %v profile from server %s: %d", profile, serverName, len(responses))
-				continue
+			timeout := g.cfg.Timeout
+			if profile.name == "cpu" {
+				payload.Duration = g.cfg.Timeout
+				timeout += 2 * time.Second
 			}
 
-			responseBytes := responses[0]
+			responseBytes, err := g.requestServer(serverId, timeout, payload, subject)
+			if err != nil {
+				g.captureFailed(PhaseServerProfiles, target, "Failed to request %v (%d) profile from server %s: %s", profile, profile.debug, serverName, err)
+				continue
+			}
 
 			var apiResponse struct {
 				Server *server.ServerInfo     `json:"server"`
@@ -520,17 +718,17 @@ func (g *gather) captureServerProfiles(serverInfoMap map[string]*server.ServerIn
 			}
 
 			if err = json.Unmarshal(responseBytes, &apiResponse); err != nil {
-				g.log.Errorf("Failed to deserialize %v profile response from server %s: %s", profile, serverName, err)
+				g.captureFailed(PhaseServerProfiles, target, "Failed to deserialize %v profile response from server %s: %s", profile, serverName, err)
 				continue
 			}
 			if apiResponse.Error != nil {
-				g.log.Errorf("Failed to retrieve %v profile from server %s: %s", profile, serverName, apiResponse.Error.Description)
+				g.captureFailed(PhaseServerProfiles, target, "Failed to retrieve %v profile from server %s: %s", profile, serverName, apiResponse.Error.Description)
 				continue
 			}
 
 			profileStatus := apiResponse.Data
 			if profileStatus.Error != "" {
-				g.log.Errorf("Failed to retrieve %v profile from server %s: %s", profile, serverName, profileStatus.Error)
+				g.captureFailed(PhaseServerProfiles, target, "Failed to retrieve %v profile from server %s: %s", profile, serverName, profileStatus.Error)
 				continue
 			}
 
@@ -546,16 +744,23 @@ func (g *gather) captureServerProfiles(serverInfoMap map[string]*server.ServerIn
 				clusterTag,
 			}
 
-			err = g.aw.AddRaw(bytes.NewReader(profileStatus.Profile), "prof", tags...)
+			err = g.addRaw(PhaseServerProfiles, target, bytes.NewReader(profileStatus.Profile), "prof", tags...)
 			if err != nil {
 				return fmt.Errorf("failed to add %s profile from to archive: %w", profile.name, err)
 			}
 
-			capturedCount += 1
+			capturedCount.Add(1)
+			g.targetCompleted(PhaseServerProfiles, target)
 		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	g.log.Infof("Captured %d server profiles from %d servers", capturedCount, len(serverInfoMap))
+	g.log.Infof("Captured %d server profiles from %d servers", capturedCount.Load(), len(serverInfoMap))
+	g.progress.PhaseCompleted(PhaseServerProfiles, int(capturedCount.Load()))
 
 	return nil
 }
@@ -655,10 +860,13 @@ func (g *gather) captureServerEndpoints(serverInfoMap map[string]*server.ServerI
 	}
 
 	g.log.Infof("Querying %d endpoints on %d known servers...", len(g.cfg.ServerEndpointConfigs), len(serverInfoMap))
-	capturedCount := 0
+	g.progress.PhaseStarted(PhaseServerEndpoints, len(serverInfoMap)*len(g.cfg.ServerEndpointConfigs))
+
+	var capturedCount atomic.Int64
 	const pageLimit = 1024
 
-	for serverId, serverInfo := range serverInfoMap {
+	err := eachParallel(g, slices.Collect(maps.Keys(serverInfoMap)), func(serverId string) error {
+		serverInfo := serverInfoMap[serverId]
 		serverName := serverInfo.Name
 
 		for _, endpoint := range g.cfg.ServerEndpointConfigs {
@@ -668,36 +876,36 @@ func (g *gather) captureServerEndpoints(serverInfoMap map[string]*server.ServerI
 			}
 
 			subject := fmt.Sprintf("$SYS.REQ.SERVER.%s.%s", serverId, endpoint.ApiSuffix)
+			target := fmt.Sprintf("%s/%s", serverName, endpoint.ApiSuffix)
 			offset := 0
 
+			if g.resumed(PhaseServerEndpoints, target) {
+				continue
+			}
+
 			for {
 				opts := buildServerOptions(endpoint.ApiSuffix, offset, pageLimit, detail)
 
-				responses, err := g.doReq(context.TODO(), opts, subject, 1)
+				responseBytes, err := g.requestServer(serverId, endpoint.Timeout, opts, subject)
 				if err != nil {
-					g.log.Errorf("Failed to request %s from server %s: %s", endpoint.ApiSuffix, serverName, err)
-					break
-				}
-				if len(responses) != 1 {
-					g.log.Errorf("Unexpected number of responses to %s from server %s: %d", endpoint.ApiSuffix, serverName, len(responses))
+					g.captureFailed(PhaseServerEndpoints, target, "Failed to request %s from server %s: %s", endpoint.ApiSuffix, serverName, err)
 					break
 				}
 
-				responseBytes := responses[0]
 				var apiResponse server.ServerAPIResponse
 				if err := json.Unmarshal(responseBytes, &apiResponse); err != nil {
-					g.log.Errorf("Failed to deserialize %s response from server %s: %s", endpoint.ApiSuffix, serverName, err)
+					g.captureFailed(PhaseServerEndpoints, target, "Failed to deserialize %s response from server %s: %s", endpoint.ApiSuffix, serverName, err)
 					break
 				}
 				if apiResponse.Error != nil {
-					g.log.Errorf("Received error from server %s: (%d) %s", serverName, apiResponse.Error.ErrCode, apiResponse.Error.Description)
+					g.captureFailed(PhaseServerEndpoints, target, "Received error from server %s: (%d) %s", serverName, apiResponse.Error.ErrCode, apiResponse.Error.Description)
 					break
 				}
 
 				// Pretty-print JSON
 				buff := new(bytes.Buffer)
 				if err := json.Indent(buff, responseBytes, "", "  "); err != nil {
-					g.log.Errorf("Failed to indent %s response from server %s: %s", endpoint.ApiSuffix, serverName, err)
+					g.captureFailed(PhaseServerEndpoints, target, "Failed to indent %s response from server %s: %s", endpoint.ApiSuffix, serverName, err)
 					break
 				}
 
@@ -712,10 +920,10 @@ func (g *gather) captureServerEndpoints(serverInfoMap map[string]*server.ServerI
 					tags = append(tags, archive.TagNoCluster())
 				}
 
-				if err := g.aw.AddRaw(buff, "json", tags...); err != nil {
+				if err := g.addRaw(PhaseServerEndpoints, target, buff, "json", tags...); err != nil {
 					return fmt.Errorf("failed to add endpoint %s response to archive: %w", subject, err)
 				}
-				capturedCount++
+				capturedCount.Add(1)
 
 				g.log.Debugf("Checking paging for endpoint %s", endpoint.ApiSuffix)
 				hasMore, err := g.hasNextPage(endpoint.ApiSuffix, responseBytes, pageLimit)
@@ -728,10 +936,18 @@ func (g *gather) captureServerEndpoints(serverInfoMap map[string]*server.ServerI
 				}
 				offset += pageLimit
 			}
+
+			g.targetCompleted(PhaseServerEndpoints, target)
 		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	g.log.Infof("Captured %d endpoint responses from %d servers", capturedCount, len(serverInfoMap))
+	g.log.Infof("Captured %d endpoint responses from %d servers", capturedCount.Load(), len(serverInfoMap))
+	g.progress.PhaseCompleted(PhaseServerEndpoints, int(capturedCount.Load()))
 	return nil
 }
 
@@ -742,7 +958,7 @@ func (g *gather) discoverAccounts(serverInfoMap map[string]*server.ServerInfo) (
 	g.log.Infof("Broadcasting PING to discover accounts... ")
 	var accountIdsToServersCountMap = make(map[string]int)
 	var systemAccount = ""
-	err := g.doReqAsync(context.TODO(), nil, "$SYS.REQ.SERVER.PING.ACCOUNTZ", len(serverInfoMap), func(b []byte) {
+	err := g.doReqAsync(g.ctx, nil, "$SYS.REQ.SERVER.PING.ACCOUNTZ", len(serverInfoMap), func(b []byte) {
 		var apiResponse server.ServerAPIAccountzResponse
 		err := json.Unmarshal(b, &apiResponse)
 		if err != nil {
@@ -774,6 +990,7 @@ func (g *gather) discoverAccounts(serverInfoMap map[string]*server.ServerInfo) (
 				accountIdsToServersCountMap[accountId] = 0
 			}
 			accountIdsToServersCountMap[accountId] += 1
+			g.accountServers[accountId] = append(g.accountServers[accountId], serverId)
 		}
 
 		// Track system account (normally, only one for the entire ensemble)
@@ -792,6 +1009,7 @@ func (g *gather) discoverAccounts(serverInfoMap map[string]*server.ServerInfo) (
 	}
 
 	g.log.Infof("Discovered %d accounts over %d servers", len(accountIdsToServersCountMap), len(serverInfoMap))
+	g.progress.AccountsDiscovered(len(accountIdsToServersCountMap))
 
 	return accountIdsToServersCountMap, systemAccount, nil
 }
@@ -800,7 +1018,7 @@ func (g *gather) discoverServers() (map[string]*server.ServerInfo, error) {
 	var serverInfoMap = make(map[string]*server.ServerInfo)
 
 	g.log.Infof("Broadcasting PING to discover servers... (this may take a few seconds)")
-	err := g.doReqAsync(context.TODO(), nil, "$SYS.REQ.SERVER.PING", doReqAsyncWaitFullTimeoutInterval, func(b []byte) {
+	err := g.doReqAsync(g.ctx, nil, "$SYS.REQ.SERVER.PING", doReqAsyncWaitFullTimeoutInterval, func(b []byte) {
 		var apiResponse server.ServerAPIResponse
 		if err := json.Unmarshal(b, &apiResponse); err != nil {
 			g.log.Errorf("Failed to deserialize PING response: %s", err)
@@ -827,17 +1045,38 @@ func (g *gather) discoverServers() (map[string]*server.ServerInfo, error) {
 		return nil, fmt.Errorf("failed to gather server responses: %w", err)
 	}
 	g.log.Infof("Discovered %d servers", len(serverInfoMap))
+	g.progress.ServersDiscovered(len(serverInfoMap))
 	return serverInfoMap, nil
 }
 
 func Gather(nc *nats.Conn, conf *Configuration) error {
+	return GatherContext(context.Background(), nc, conf)
+}
+
+// GatherContext gathers like Gather() until ctx is cancelled, the archive of an interrupted gather can be used to
+// resume it, see Configuration.ResumeArchivePath
+func GatherContext(ctx context.Context, nc *nats.Conn, conf *Configuration) error {
 	var captureLogBuffer bytes.Buffer
 
 	g := &gather{
-		cfg:     conf,
-		nc:      nc,
-		capture: &captureLogBuffer,
-		log:     newLogger(&captureLogBuffer, conf.LogLevel),
+		cfg:            conf,
+		nc:             nc,
+		capture:        &captureLogBuffer,
+		log:            newLogger(&captureLogBuffer, conf.LogLevel),
+		ctx:            ctx,
+		checkpoint:     newCheckpointState(),
+		accountServers: make(map[string][]string),
+	}
+
+	g.progress = conf.Progress
+	if g.progress == nil {
+		g.progress = noopProgress{}
+	}
+
+	var err error
+	g.limits, err = newLimits(conf)
+	if err != nil {
+		return err
 	}
 
 	return g.start()
@@ -855,6 +1094,11 @@ const doReqAsyncWaitFullTimeoutInterval = -1
 //	waitFor == 0 : (adaptive timeout), after each response, wait a short amount of time for more, then stop
 //	waitFor > 0  : stops listening before the timeout if the given number of responses are received
 func (g *gather) doReqAsync(ctx context.Context, req any, subj string, waitFor int, cb func([]byte)) error {
+	return g.doReqAsyncTimeout(ctx, g.cfg.Timeout, req, subj, waitFor, cb)
+}
+
+// doReqAsyncTimeout is like doReqAsync but listens for responses for up to timeout rather than the configured Timeout
+func (g *gather) doReqAsyncTimeout(ctx context.Context, timeout time.Duration, req any, subj string, waitFor int, cb func([]byte)) error {
 	jreq := []byte("{}")
 	var err error
 
@@ -872,34 +1116,21 @@ func (g *gather) doReqAsync(ctx context.Context, req any, subj string, waitFor i
 
 	g.log.Debugf(">>> %s: %s\n", subj, string(jreq))
 
-	var (
-		mu       sync.Mutex
-		ctr      = 0
-		finisher *time.Timer
-	)
-
-	// Set deadline, max amount of time this function waits for responses
-	ctx, cancel := context.WithTimeout(ctx, g.cfg.Timeout)
-	defer cancel()
-
-	// Activate "adaptive timeout". Finisher may trigger early termination
-	if waitFor == 0 {
-		// First response can take up to Timeout to arrive
-		finisher = time.NewTimer(g.cfg.Timeout)
-		go func() {
-			select {
-			case <-finisher.C:
-				cancel()
-			case <-ctx.Done():
-				return
-			}
-		}()
+	opts := []jsm.RequestManyOption{jsm.RequestManyMaxWait(timeout)}
+	switch {
+	case waitFor > 0:
+		opts = append(opts, jsm.RequestManyExpect(waitFor))
+	case waitFor < 0:
+		// a stall as long as the timeout keeps listening for the full interval
+		opts = append(opts, jsm.RequestManyStall(timeout))
+	}
+	if subj != "$SYS.REQ.SERVER.PING" && !strings.HasPrefix(subj, "$SYS.REQ.ACCOUNT") {
+		opts = append(opts, jsm.RequestManyHeader("Accept-Encoding", "snappy"))
 	}
 
-	errs := make(chan error)
-	sub, err := g.nc.Subscribe(g.nc.NewRespInbox(), func(m *nats.Msg) {
-		mu.Lock()
-		defer mu.Unlock()
+	ctr := 0
+	err = jsm.RequestMany(ctx, g.nc, subj, jreq, func(m *nats.Msg) error {
+		g.received(len(m.Data))
 
 		data := m.Data
 		compressed := false
@@ -907,8 +1138,7 @@ func (g *gather) doReqAsync(ctx context.Context, req any, subj string, waitFor i
 			compressed = true
 			ud, err := io.ReadAll(s2.NewReader(bytes.NewBuffer(data)))
 			if err != nil {
-				errs <- err
-				return
+				return err
 			}
 			data = ud
 		}
@@ -923,66 +1153,29 @@ func (g *gather) doReqAsync(ctx context.Context, req any, subj string, waitFor i
 			g.log.Debugf("<<< Header: %+v", m.Header)
 		}
 
-		// If adaptive timeout is active, set deadline for next response
-		if finisher != nil {
-			// Stop listening and return if no further responses arrive within this interval
-			finisher.Reset(300 * time.Millisecond)
-		}
-
-		if m.Header.Get("Status") == "503" {
-			errs <- nats.ErrNoResponders
-			return
-		}
-
 		cb(data)
 		ctr++
 
-		// Stop listening if the requested number of responses have been received
-		if waitFor > 0 && ctr == waitFor {
-			cancel()
-		}
-	})
-	if err != nil {
-		return err
-	}
-	defer sub.Unsubscribe()
-
-	if waitFor > 0 {
-		sub.AutoUnsubscribe(waitFor)
-	}
-
-	msg := nats.NewMsg(subj)
-	msg.Data = jreq
-	if subj != "$SYS.REQ.SERVER.PING" && !strings.HasPrefix(subj, "$SYS.REQ.ACCOUNT") {
-		msg.Header.Set("Accept-Encoding", "snappy")
+		return nil
+	}, opts...)
+	if err == nats.ErrNoResponders && strings.HasPrefix(subj, "$SYS") {
+		return fmt.Errorf("server request failed, ensure the account used has system privileges and appropriate permissions")
 	}
-	msg.Reply = sub.Subject
-
-	err = g.nc.PublishMsg(msg)
 	if err != nil {
 		return err
 	}
 
-	select {
-	case err = <-errs:
-		if err == nats.ErrNoResponders && strings.HasPrefix(subj, "$SYS") {
-			return fmt.Errorf("server request failed, ensure the account used has system privileges and appropriate permissions")
-		}
-
-		return err
-	case <-ctx.Done():
-	}
-
 	g.log.Debugf("=== Received %d responses", ctr)
 
 	return nil
 }
 
-func (g *gather) doReq(ctx context.Context, req any, subj string, waitFor int) ([][]byte, error) {
+// doReqTimeout sends a request and collects up to waitFor responses received within timeout, see doReqAsync()
+func (g *gather) doReqTimeout(ctx context.Context, timeout time.Duration, req any, subj string, waitFor int) ([][]byte, error) {
 	res := [][]byte{}
 	mu := sync.Mutex{}
 
-	err := g.doReqAsync(ctx, req, subj, waitFor, func(r []byte) {
+	err := g.doReqAsyncTimeout(ctx, timeout, req, subj, waitFor, func(r []byte) {
 		mu.Lock()
 		res = append(res, r)
 		mu.Unlock()
@@ -0,0 +1,253 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gather
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/audit/archive"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+// withGatherServer starts a JetStream enabled server named s1 with the SYSTEM account and the accounts USERS and
+// OTHER, cb is called with a system account connection and a connection to USERS
+func withGatherServer(t *testing.T, cb func(srv *server.Server, sys *nats.Conn, nc *nats.Conn)) {
+	t.Helper()
+
+	sa := server.NewAccount("SYSTEM")
+	ua := server.NewAccount("USERS")
+	oa := server.NewAccount("OTHER")
+
+	srv, err := server.NewServer(&server.Options{
+		Port:          -1,
+		ServerName:    "s1",
+		StoreDir:      t.TempDir(),
+		JetStream:     true,
+		Accounts:      []*server.Account{sa, ua, oa},
+		SystemAccount: "SYSTEM",
+		Users: []*server.User{
+			{Account: sa, Username: "SYS", Password: "PASS"},
+			{Account: ua, Username: "USER", Password: "PASS"},
+			{Account: oa, Username: "OTHER", Password: "PASS"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("could not create server: %v", err)
+	}
+
+	go srv.Start()
+	if !srv.ReadyForConnections(10 * time.Second) {
+		t.Fatalf("nats server did not start")
+	}
+	defer func() {
+		srv.Shutdown()
+		srv.WaitForShutdown()
+	}()
+
+	for _, name := range []string{"USERS", "OTHER"} {
+		acct, err := srv.LookupAccount(name)
+		if err != nil {
+			t.Fatalf("could not find account %s: %v", name, err)
+		}
+		err = acct.EnableJetStream(map[string]server.JetStreamAccountLimits{"": {MaxMemory: -1, MaxStore: -1, MaxStreams: -1, MaxConsumers: -1}})
+		if err != nil {
+			t.Fatalf("could not enable JetStream for %s: %v", name, err)
+		}
+	}
+
+	sys, err := nats.Connect(srv.ClientURL(), nats.UserInfo("SYS", "PASS"))
+	if err != nil {
+		t.Fatalf("could not connect: %v", err)
+	}
+	defer sys.Close()
+
+	nc, err := nats.Connect(srv.ClientURL(), nats.UserInfo("USER", "PASS"))
+	if err != nil {
+		t.Fatalf("could not connect: %v", err)
+	}
+	defer nc.Close()
+
+	cb(srv, sys, nc)
+}
+
+// testCaptureConfiguration is a configuration capturing nothing into a temporary archive, tests include what they need
+func testCaptureConfiguration(t *testing.T) *Configuration {
+	t.Helper()
+
+	cfg := NewCaptureConfiguration()
+	cfg.LogLevel = api.ErrorLevel
+	cfg.Timeout = time.Second
+	cfg.TargetPath = filepath.Join(t.TempDir(), "audit.zip")
+
+	return cfg
+}
+
+func TestGatherResume(t *testing.T) {
+	withGatherServer(t, func(_ *server.Server, sys *nats.Conn, _ *nats.Conn) {
+		interrupted := filepath.Join(t.TempDir(), "interrupted.zip")
+		writeInterruptedArchive(t, interrupted, &checkpoint{Completed: []string{"server_endpoints/s1/VARZ"}}, "s1")
+
+		original, err := os.ReadFile(interrupted)
+		if err != nil {
+			t.Fatalf("read failed: %v", err)
+		}
+
+		t.Run("Should not truncate the resumed archive when it is the target", func(t *testing.T) {
+			cfg := testCaptureConfiguration(t)
+			cfg.Include.ServerEndpoints = true
+			cfg.TargetPath = interrupted
+			cfg.ResumeArchivePath = interrupted
+
+			err := GatherContext(context.Background(), sys, cfg)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+
+			after, err := os.ReadFile(interrupted)
+			if err != nil {
+				t.Fatalf("read failed: %v", err)
+			}
+			if !bytes.Equal(original, after) {
+				t.Fatalf("expected the resumed archive to be unchanged")
+			}
+		})
+
+		t.Run("Should copy completed targets and capture the rest", func(t *testing.T) {
+			cfg := testCaptureConfiguration(t)
+			cfg.Include.ServerEndpoints = true
+			cfg.ServerEndpointConfigs = []EndpointCaptureConfig{
+				{ApiSuffix: "VARZ", TypeTag: archive.TagServerVars()},
+				{ApiSuffix: "HEALTHZ", TypeTag: archive.TagServerHealth()},
+			}
+			cfg.ResumeArchivePath = interrupted
+
+			err := GatherContext(context.Background(), sys, cfg)
+			if err != nil {
+				t.Fatalf("gather failed: %v", err)
+			}
+
+			r, err := archive.NewReader(cfg.TargetPath)
+			if err != nil {
+				t.Fatalf("failed to open archive: %v", err)
+			}
+			defer r.Close()
+
+			var vars map[string]any
+			err = r.Load(&vars, archive.TagServer("s1"), archive.TagServerVars())
+			if err != nil || vars["server"] != "s1" {
+				t.Fatalf("expected the VARZ of the resumed archive, got %v: %v", vars, err)
+			}
+
+			var health server.ServerAPIHealthzResponse
+			err = r.Load(&health, archive.TagServer("s1"), archive.TagServerHealth())
+			if err != nil || health.Data == nil {
+				t.Fatalf("expected HEALTHZ to be captured: %v", err)
+			}
+
+			var cp checkpoint
+			err = r.Load(&cp, archive.TagSpecial(checkpointSpecial))
+			if err != nil || !cp.Complete {
+				t.Fatalf("expected a complete checkpoint, got %+v: %v", cp, err)
+			}
+		})
+	})
+}
+
+func TestSelectAccounts(t *testing.T) {
+	g := testGather(context.Background(), &Configuration{Accounts: []string{"USERS", "MISSING", "SYSTEM"}})
+
+	selected := g.selectAccounts(map[string]int{"USERS": 2, "OTHER": 1, "SYSTEM": 3})
+	if len(selected) != 2 || selected["USERS"] != 2 || selected["SYSTEM"] != 3 {
+		t.Fatalf("unexpected selected accounts %v", selected)
+	}
+}
+
+func TestGatherAccountScoped(t *testing.T) {
+	withGatherServer(t, func(srv *server.Server, sys *nats.Conn, nc *nats.Conn) {
+		other, err := nats.Connect(srv.ClientURL(), nats.UserInfo("OTHER", "PASS"))
+		if err != nil {
+			t.Fatalf("could not connect: %v", err)
+		}
+		defer other.Close()
+
+		for _, conn := range []*nats.Conn{nc, other} {
+			js, err := conn.JetStream()
+			if err != nil {
+				t.Fatalf("jetstream failed: %v", err)
+			}
+			_, err = js.AddStream(&nats.StreamConfig{Name: "ORDERS", Subjects: []string{"orders.>"}})
+			if err != nil {
+				t.Fatalf("stream create failed: %v", err)
+			}
+		}
+
+		cfg := testCaptureConfiguration(t)
+		cfg.Include.ServerEndpoints = true
+		cfg.Include.AccountEndpoints = true
+		cfg.Include.Streams = true
+		cfg.AccountEndpointConfigs = []EndpointCaptureConfig{{ApiSuffix: "INFO", TypeTag: archive.TagAccountInfo()}}
+		cfg.Accounts = []string{"USERS", "MISSING", "SYSTEM"}
+
+		err = GatherContext(context.Background(), sys, cfg)
+		if err != nil {
+			t.Fatalf("gather failed: %v", err)
+		}
+
+		r, err := archive.NewReader(cfg.TargetPath)
+		if err != nil {
+			t.Fatalf("failed to open archive: %v", err)
+		}
+		defer r.Close()
+
+		t.Run("Should only capture the selected accounts", func(t *testing.T) {
+			for _, account := range []string{"USERS", "SYSTEM"} {
+				var info map[string]any
+				err = r.Load(&info, archive.TagAccount(account), archive.TagServer("s1"), archive.TagAccountInfo())
+				if err != nil {
+					t.Fatalf("expected account info for %s: %v", account, err)
+				}
+			}
+
+			if slices.Contains(r.AccountNames(), "OTHER") || slices.Contains(r.AccountNames(), "MISSING") {
+				t.Fatalf("expected only selected accounts, got %v", r.AccountNames())
+			}
+		})
+
+		t.Run("Should capture streams of selected accounts except the system account", func(t *testing.T) {
+			if !slices.Equal(r.AccountStreamNames("USERS"), []string{"ORDERS"}) {
+				t.Fatalf("expected the USERS streams, got %v", r.AccountStreamNames("USERS"))
+			}
+			if len(r.AccountStreamNames("OTHER")) != 0 || len(r.AccountStreamNames("SYSTEM")) != 0 {
+				t.Fatalf("expected no streams for other accounts")
+			}
+		})
+
+		t.Run("Should not capture server endpoints", func(t *testing.T) {
+			var vars map[string]any
+			err = r.Load(&vars, archive.TagServer("s1"), archive.TagServerVars())
+			if !errors.Is(err, archive.ErrNoMatches) {
+				t.Fatalf("expected no server endpoints, got %v", err)
+			}
+		})
+	})
+}
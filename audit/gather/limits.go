@@ -0,0 +1,158 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gather
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// limits paces requests sent during a gather
+type limits struct {
+	// servers limit the request rate per server id, nil when unlimited
+	servers map[string]*rate.Limiter
+	// bandwidth limits the bytes received per second, nil when unlimited
+	bandwidth *rate.Limiter
+	mu        sync.Mutex
+}
+
+func newLimits(cfg *Configuration) (*limits, error) {
+	if cfg.Concurrency < 0 {
+		return nil, fmt.Errorf("concurrency may not be negative")
+	}
+	if cfg.ServerRequestRate < 0 {
+		return nil, fmt.Errorf("server request rate may not be negative")
+	}
+	if cfg.BandwidthLimit < 0 {
+		return nil, fmt.Errorf("bandwidth limit may not be negative")
+	}
+	if cfg.Retries < 0 {
+		return nil, fmt.Errorf("retries may not be negative")
+	}
+	if cfg.RetryBackoff < 0 {
+		return nil, fmt.Errorf("retry backoff may not be negative")
+	}
+	if cfg.MessageSamples < 0 {
+		return nil, fmt.Errorf("message samples may not be negative")
+	}
+
+	l := &limits{}
+	if cfg.ServerRequestRate > 0 {
+		l.servers = make(map[string]*rate.Limiter)
+	}
+	if cfg.BandwidthLimit > 0 {
+		l.bandwidth = rate.NewLimiter(rate.Limit(cfg.BandwidthLimit), int(min(cfg.BandwidthLimit, math.MaxInt32)))
+	}
+
+	return l, nil
+}
+
+// throttle waits until the bandwidth used by earlier responses is paid back and a request may be sent to each of the
+// servers identified by serverIds
+func (g *gather) throttle(ctx context.Context, serverIds ...string) error {
+	if g.limits.bandwidth != nil {
+		err := g.limits.bandwidth.Wait(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if g.limits.servers == nil {
+		return nil
+	}
+
+	for _, serverId := range serverIds {
+		g.limits.mu.Lock()
+		limiter, ok := g.limits.servers[serverId]
+		if !ok {
+			limiter = rate.NewLimiter(rate.Limit(g.cfg.ServerRequestRate), 1)
+			g.limits.servers[serverId] = limiter
+		}
+		g.limits.mu.Unlock()
+
+		err := limiter.Wait(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// received accounts for bytes received, later requests are delayed by throttle() until the bandwidth is available
+func (g *gather) received(size int) {
+	if g.limits.bandwidth == nil {
+		return
+	}
+
+	// reservations beyond the burst are not allowed so large responses are accounted for in several reservations
+	now := time.Now()
+	for size > 0 {
+		n := min(size, g.limits.bandwidth.Burst())
+		g.limits.bandwidth.ReserveN(now, n)
+		size -= n
+	}
+}
+
+// eachParallel calls cb for every item using up to Concurrency goroutines until the gather is interrupted, the first
+// error encountered is returned once all started calls completed
+func eachParallel[T any](g *gather, items []T, cb func(T) error) error {
+	workers := max(g.cfg.Concurrency, 1)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, workers)
+	)
+
+	for _, item := range items {
+		// wait for a free worker before checking for failures so items do not start after an earlier one failed
+		sem <- struct{}{}
+
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed || g.ctx.Err() != nil {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := cb(item)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return firstErr
+}
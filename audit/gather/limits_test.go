@@ -0,0 +1,108 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEachParallel(t *testing.T) {
+	items := make([]int, 20)
+	for i := range items {
+		items[i] = i
+	}
+
+	for _, concurrency := range []int{0, 1, 4} {
+		t.Run(fmt.Sprintf("Should run at most %d items concurrently", max(concurrency, 1)), func(t *testing.T) {
+			g := testGather(context.Background(), &Configuration{Concurrency: concurrency})
+
+			var running, peak, calls atomic.Int64
+			err := eachParallel(g, items, func(int) error {
+				calls.Add(1)
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+
+				time.Sleep(5 * time.Millisecond)
+				running.Add(-1)
+
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if calls.Load() != int64(len(items)) {
+				t.Fatalf("expected %d calls, got %d", len(items), calls.Load())
+			}
+			if peak.Load() > int64(max(concurrency, 1)) {
+				t.Fatalf("expected at most %d concurrent calls, got %d", max(concurrency, 1), peak.Load())
+			}
+			if concurrency > 1 && peak.Load() < 2 {
+				t.Fatalf("expected calls to run concurrently")
+			}
+		})
+	}
+
+	t.Run("Should return the first error and stop starting items", func(t *testing.T) {
+		g := testGather(context.Background(), &Configuration{Concurrency: 1})
+
+		var calls atomic.Int64
+		err := eachParallel(g, items, func(i int) error {
+			calls.Add(1)
+			if i == 2 {
+				return fmt.Errorf("item %d failed", i)
+			}
+			return nil
+		})
+		if err == nil || err.Error() != "item 2 failed" {
+			t.Fatalf("expected the item 2 error, got %v", err)
+		}
+		if calls.Load() != 3 {
+			t.Fatalf("expected 3 calls, got %d", calls.Load())
+		}
+	})
+
+	t.Run("Should stop once the gather is interrupted", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		g := testGather(ctx, &Configuration{Concurrency: 1})
+
+		var calls atomic.Int64
+		err := eachParallel(g, items, func(i int) error {
+			calls.Add(1)
+			if i == 2 {
+				cancel()
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls.Load() != 3 {
+			t.Fatalf("expected 3 calls, got %d", calls.Load())
+		}
+		if !errors.Is(ctx.Err(), context.Canceled) {
+			t.Fatalf("expected the context to be cancelled")
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"sync"
 
 	"github.com/nats-io/jsm.go/api"
 )
@@ -12,6 +13,8 @@ type logger struct {
 	capture io.Writer
 	lvl     api.Level
 	logFunc func(format string, a ...any)
+	// mu guards capture as artifacts are captured in parallel
+	mu sync.Mutex
 }
 
 func newLogger(capture io.Writer, level api.Level) api.Logger {
@@ -30,7 +33,7 @@ func (l *logger) Tracef(format string, a ...any) {
 
 		// only capture these when enabled
 		if l.capture != nil {
-			fmt.Fprintf(l.capture, format+"\n", a...)
+			l.captureLine(format, a...)
 		}
 	}
 }
@@ -41,7 +44,7 @@ func (l *logger) Debugf(format string, a ...any) {
 
 		// only capture these when enabled
 		if l.capture != nil {
-			fmt.Fprintf(l.capture, format+"\n", a...)
+			l.captureLine(format, a...)
 		}
 	}
 }
@@ -53,7 +56,7 @@ func (l *logger) Infof(format string, a ...any) {
 
 	// always capture these
 	if l.capture != nil {
-		fmt.Fprintf(l.capture, format+"\n", a...)
+		l.captureLine(format, a...)
 	}
 }
 
@@ -64,7 +67,7 @@ func (l *logger) Warnf(format string, a ...any) {
 
 	// always capture these
 	if l.capture != nil {
-		fmt.Fprintf(l.capture, format+"\n", a...)
+		l.captureLine(format, a...)
 	}
 }
 
@@ -75,6 +78,12 @@ func (l *logger) Errorf(format string, a ...any) {
 
 	// always capture these
 	if l.capture != nil {
-		fmt.Fprintf(l.capture, format+"\n", a...)
+		l.captureLine(format, a...)
 	}
 }
+
+func (l *logger) captureLine(format string, a ...any) {
+	l.mu.Lock()
+	fmt.Fprintf(l.capture, format+"\n", a...)
+	l.mu.Unlock()
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gather
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/nats-io/jsm.go/audit/archive"
+)
+
+// Phase is a stage of a gather
+type Phase string
+
+const (
+	// PhaseServerEndpoints captures server monitoring endpoints
+	PhaseServerEndpoints Phase = "server_endpoints"
+	// PhaseServerProfiles captures server profiles
+	PhaseServerProfiles Phase = "server_profiles"
+	// PhaseAccountEndpoints captures account monitoring endpoints
+	PhaseAccountEndpoints Phase = "account_endpoints"
+	// PhaseStreams captures stream details
+	PhaseStreams Phase = "streams"
+)
+
+// Progress receives notifications while a gather runs, allowing callers to render progress bars or structured logs.
+// Implementations must be safe for concurrent use.
+type Progress interface {
+	// ServersDiscovered is called once servers are discovered
+	ServersDiscovered(count int)
+	// AccountsDiscovered is called once accounts are discovered
+	AccountsDiscovered(count int)
+	// PhaseStarted is called when a phase starts with the number of artifacts expected, 0 when unknown
+	PhaseStarted(phase Phase, expected int)
+	// ArtifactCaptured is called for every artifact written to the archive with the number of bytes written
+	ArtifactCaptured(phase Phase, target string, size int64)
+	// CaptureFailed is called when capturing an artifact failed, the gather continues with the next artifact
+	CaptureFailed(phase Phase, target string, err error)
+	// PhaseCompleted is called when a phase completes with the number of artifacts captured
+	PhaseCompleted(phase Phase, captured int)
+}
+
+type noopProgress struct{}
+
+func (noopProgress) ServersDiscovered(int)                 {}
+func (noopProgress) AccountsDiscovered(int)                {}
+func (noopProgress) PhaseStarted(Phase, int)               {}
+func (noopProgress) ArtifactCaptured(Phase, string, int64) {}
+func (noopProgress) CaptureFailed(Phase, string, error)    {}
+func (noopProgress) PhaseCompleted(Phase, int)             {}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// addRaw adds an artifact to the archive and notifies the progress of its size
+func (g *gather) addRaw(phase Phase, target string, r io.Reader, extension string, tags ...*archive.Tag) error {
+	cr := &countingReader{r: r}
+
+	g.awMu.Lock()
+	err := g.aw.AddRaw(cr, extension, tags...)
+	g.awMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	g.progress.ArtifactCaptured(phase, target, cr.n)
+
+	return nil
+}
+
+// add encodes an artifact like archive.Writer.Add() and adds it using addRaw
+func (g *gather) add(phase Phase, target string, artifact any, tags ...*archive.Tag) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(artifact)
+	if err != nil {
+		return fmt.Errorf("failed to encode: %w", err)
+	}
+
+	return g.addRaw(phase, target, &buf, "json", tags...)
+}
+
+// captureFailed logs a failure to capture an artifact and notifies the progress
+func (g *gather) captureFailed(phase Phase, target string, format string, a ...any) {
+	err := fmt.Errorf(format, a...)
+	g.log.Errorf("%s", err)
+	g.targetFailed(phase, target, err)
+	g.progress.CaptureFailed(phase, target, err)
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gather
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/nats-io/jsm.go/audit/archive"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+// recordingProgress records every progress notification as a string
+type recordingProgress struct {
+	events []string
+	sizes  int64
+	mu     sync.Mutex
+}
+
+func (p *recordingProgress) record(format string, a ...any) {
+	p.mu.Lock()
+	p.events = append(p.events, fmt.Sprintf(format, a...))
+	p.mu.Unlock()
+}
+
+func (p *recordingProgress) ServersDiscovered(count int) {
+	p.record("servers %d", count)
+}
+
+func (p *recordingProgress) AccountsDiscovered(count int) {
+	p.record("accounts %d", count)
+}
+
+func (p *recordingProgress) PhaseStarted(phase Phase, expected int) {
+	p.record("started %s %d", phase, expected)
+}
+
+func (p *recordingProgress) ArtifactCaptured(phase Phase, target string, size int64) {
+	p.mu.Lock()
+	p.sizes += size
+	p.mu.Unlock()
+	p.record("captured %s %s", phase, target)
+}
+
+func (p *recordingProgress) CaptureFailed(phase Phase, target string, err error) {
+	p.record("failed %s %s", phase, target)
+}
+
+func (p *recordingProgress) PhaseCompleted(phase Phase, captured int) {
+	p.record("completed %s %d", phase, captured)
+}
+
+func TestGatherProgress(t *testing.T) {
+	withGatherServer(t, func(_ *server.Server, sys *nats.Conn, _ *nats.Conn) {
+		progress := &recordingProgress{}
+
+		cfg := testCaptureConfiguration(t)
+		cfg.Progress = progress
+		cfg.Include.ServerEndpoints = true
+		cfg.ServerEndpointConfigs = []EndpointCaptureConfig{
+			{ApiSuffix: "VARZ", TypeTag: archive.TagServerVars()},
+			{ApiSuffix: "HEALTHZ", TypeTag: archive.TagServerHealth()},
+		}
+		err := cfg.RegisterCustomEndpoint(CustomEndpoint{Subject: "site.missing.{server_name}", TypeTag: archive.TagArtifactType("site_missing")})
+		if err != nil {
+			t.Fatalf("register failed: %v", err)
+		}
+
+		err = GatherContext(context.Background(), sys, cfg)
+		if err != nil {
+			t.Fatalf("gather failed: %v", err)
+		}
+
+		expected := []string{
+			"servers 1",
+			"accounts 4",
+			"started server_endpoints 2",
+			"captured server_endpoints s1/VARZ",
+			"captured server_endpoints s1/HEALTHZ",
+			"completed server_endpoints 2",
+			"started custom_endpoints 1",
+			"failed custom_endpoints s1/site_missing",
+			"completed custom_endpoints 0",
+		}
+
+		if !slices.Equal(progress.events, expected) {
+			t.Fatalf("unexpected progress events:\n%v\nexpected:\n%v", progress.events, expected)
+		}
+		if progress.sizes == 0 {
+			t.Fatalf("expected captured sizes to be reported")
+		}
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gather
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxRetryBackoff caps the delay between attempts as it doubles after every failed attempt
+const maxRetryBackoff = 30 * time.Second
+
+// withRetry calls fn until it succeeds, the gather is interrupted or Retries retries were made. The delay between
+// attempts starts at RetryBackoff and doubles after every failed attempt, the last error is returned
+func (g *gather) withRetry(description string, fn func() error) error {
+	backoff := g.cfg.RetryBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt > g.cfg.Retries || g.ctx.Err() != nil {
+			return err
+		}
+
+		g.log.Warnf("Attempt %d to request %s failed, retrying in %v: %s", attempt, description, backoff, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-g.ctx.Done():
+			return err
+		}
+
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+}
+
+// requestServer sends a request to a single server and waits up to timeout for its response, failed requests and
+// requests that received no response are retried
+func (g *gather) requestServer(serverId string, timeout time.Duration, req any, subj string) ([]byte, error) {
+	if timeout <= 0 {
+		timeout = g.cfg.Timeout
+	}
+
+	var response []byte
+	err := g.withRetry(subj, func() error {
+		err := g.throttle(g.ctx, serverId)
+		if err != nil {
+			return err
+		}
+
+		responses, err := g.doReqTimeout(g.ctx, timeout, req, subj, 1)
+		if err != nil {
+			return err
+		}
+		if len(responses) != 1 {
+			return fmt.Errorf("unexpected number of responses: %d", len(responses))
+		}
+
+		response = responses[0]
+
+		return nil
+	})
+
+	return response, err
+}
+
+// requestServers broadcasts a request expecting up to waitFor responses, see doReqAsync(). Failed requests and
+// requests that received no response at all are retried, cb is called for responses of every attempt
+func (g *gather) requestServers(serverIds []string, timeout time.Duration, req any, subj string, waitFor int, cb func([]byte)) error {
+	if timeout <= 0 {
+		timeout = g.cfg.Timeout
+	}
+
+	return g.withRetry(subj, func() error {
+		err := g.throttle(g.ctx, serverIds...)
+		if err != nil {
+			return err
+		}
+
+		received := 0
+		err = g.doReqAsyncTimeout(g.ctx, timeout, req, subj, waitFor, func(b []byte) {
+			received++
+			cb(b)
+		})
+		if err != nil {
+			return err
+		}
+		if received == 0 {
+			return fmt.Errorf("no responses received")
+		}
+
+		return nil
+	})
+}
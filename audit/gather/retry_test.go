@@ -0,0 +1,106 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gather
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+func testGather(ctx context.Context, cfg *Configuration) *gather {
+	return &gather{
+		cfg: cfg,
+		ctx: ctx,
+		log: api.NewDefaultLogger(api.ErrorLevel),
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	failing := func(failures int, calls *int) func() error {
+		return func() error {
+			*calls++
+			if *calls <= failures {
+				return fmt.Errorf("attempt %d failed", *calls)
+			}
+			return nil
+		}
+	}
+
+	t.Run("Should not retry by default", func(t *testing.T) {
+		g := testGather(context.Background(), NewCaptureConfiguration())
+
+		calls := 0
+		err := g.withRetry("test", failing(1, &calls))
+		if err == nil || err.Error() != "attempt 1 failed" {
+			t.Fatalf("expected the first error, got %v", err)
+		}
+		if calls != 1 {
+			t.Fatalf("expected 1 call, got %d", calls)
+		}
+	})
+
+	cases := []struct {
+		name     string
+		retries  int
+		failures int
+		calls    int
+		err      string
+	}{
+		{"Should not retry successful calls", 2, 0, 1, ""},
+		{"Should retry until the call succeeds", 2, 2, 3, ""},
+		{"Should return the last error once retries are exhausted", 2, 5, 3, "attempt 3 failed"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			g := testGather(context.Background(), &Configuration{Retries: c.retries, RetryBackoff: time.Millisecond})
+
+			calls := 0
+			err := g.withRetry("test", failing(c.failures, &calls))
+			switch {
+			case c.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case c.err != "" && (err == nil || err.Error() != c.err):
+				t.Fatalf("expected error %q, got %v", c.err, err)
+			}
+			if calls != c.calls {
+				t.Fatalf("expected %d calls, got %d", c.calls, calls)
+			}
+		})
+	}
+
+	t.Run("Should stop retrying once the gather is interrupted", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		g := testGather(ctx, &Configuration{Retries: 5, RetryBackoff: time.Hour})
+
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		calls := 0
+		start := time.Now()
+		err := g.withRetry("test", failing(10, &calls))
+		if err == nil || err.Error() != "attempt 1 failed" {
+			t.Fatalf("expected the first error, got %v", err)
+		}
+		if calls != 1 {
+			t.Fatalf("expected 1 call, got %d", calls)
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("expected the backoff to be interrupted")
+		}
+	})
+}
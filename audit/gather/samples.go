@@ -0,0 +1,172 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/audit/archive"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+// sampledStream is the stream detail message samples are captured for
+type sampledStream struct {
+	serverName string
+	clusterTag *archive.Tag
+	detail     server.StreamDetail
+}
+
+// sampleConnection is the connection used to sample the streams of an account, nil when the account has no
+// streams to sample or no connection was configured for it
+func (g *gather) sampleConnection(accountId string, streams int) *nats.Conn {
+	if streams == 0 {
+		return nil
+	}
+
+	nc := g.cfg.SampleConnections[accountId]
+	if nc == nil {
+		g.log.Warnf("No connection configured for account %s, skipping message samples", accountId)
+	}
+
+	return nc
+}
+
+// shouldSampleStream determines if messages of the stream are sampled, see MessageSamples and SampleStreams
+func (g *gather) shouldSampleStream(streamName string) bool {
+	if g.cfg.MessageSamples == 0 {
+		return false
+	}
+
+	return len(g.cfg.SampleStreams) == 0 || slices.Contains(g.cfg.SampleStreams, streamName)
+}
+
+// captureStreamMessageSamples reads the first and last MessageSamples messages of a stream using nc, a connection to
+// the account, and adds their headers to the archive
+func (g *gather) captureStreamMessageSamples(nc *nats.Conn, accountId string, stream *sampledStream) error {
+	state := stream.detail.State
+	samples := archive.StreamMessageSamples{
+		Server:        stream.serverName,
+		FirstSequence: state.FirstSeq,
+		LastSequence:  state.LastSeq,
+		Messages:      []archive.MessageSample{},
+	}
+
+	// scan reads up to MessageSamples messages starting at seq, the sequence following the last message read is returned
+	scan := func(seq uint64) (uint64, error) {
+		for range g.cfg.MessageSamples {
+			if state.Msgs == 0 || seq > state.LastSeq {
+				break
+			}
+
+			msg, err := g.nextStreamMessage(nc, stream.detail.Name, seq)
+			if err != nil {
+				return seq, err
+			}
+			if msg == nil || msg.Sequence > state.LastSeq {
+				break
+			}
+
+			sample := archive.MessageSample{
+				Sequence: msg.Sequence,
+				Subject:  msg.Subject,
+				Time:     msg.Time,
+				Size:     len(msg.Data),
+			}
+			if len(msg.Header) > 0 {
+				hdr, err := nats.DecodeHeadersMsg(msg.Header)
+				if err != nil {
+					return seq, fmt.Errorf("invalid headers in message %d: %w", msg.Sequence, err)
+				}
+				sample.Header = hdr
+			}
+
+			samples.Messages = append(samples.Messages, sample)
+			seq = msg.Sequence + 1
+		}
+
+		return seq, nil
+	}
+
+	next, err := scan(state.FirstSeq)
+	if err != nil {
+		return err
+	}
+
+	last := uint64(0)
+	if state.LastSeq >= uint64(g.cfg.MessageSamples) {
+		last = state.LastSeq - uint64(g.cfg.MessageSamples) + 1
+	}
+	_, err = scan(max(next, last))
+	if err != nil {
+		return err
+	}
+
+	tags := []*archive.Tag{
+		archive.TagAccount(accountId),
+		archive.TagServer(stream.serverName),
+		stream.clusterTag,
+		archive.TagStream(stream.detail.Name),
+		archive.TagStreamMessageSamples(),
+	}
+
+	return g.add(PhaseStreams, fmt.Sprintf("%s/%s/samples", accountId, stream.detail.Name), samples, tags...)
+}
+
+// nextStreamMessage loads the first message with a sequence of at least seq from a stream, nil when there is none
+func (g *gather) nextStreamMessage(nc *nats.Conn, streamName string, seq uint64) (*api.StoredMsg, error) {
+	subject := fmt.Sprintf(api.JSApiMsgGetT, streamName)
+
+	req, err := json.Marshal(api.JSApiMsgGetRequest{Seq: seq, NextFor: ">"})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp api.JSApiMsgGetResponse
+	err = g.withRetry(subject, func() error {
+		err := g.throttle(g.ctx)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(g.ctx, g.cfg.Timeout)
+		defer cancel()
+
+		msg, err := nc.RequestWithContext(ctx, subject, req)
+		if err != nil {
+			return err
+		}
+		g.received(len(msg.Data))
+
+		resp = api.JSApiMsgGetResponse{}
+		return json.Unmarshal(msg.Data, &resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != nil {
+		if resp.Error.NatsErrorCode() == api.ErrNoMessageFound.ErrCode {
+			return nil, nil
+		}
+
+		return nil, resp.Error
+	}
+
+	return resp.Message, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gather
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/nats-io/jsm.go/audit/archive"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+func TestGatherMessageSamples(t *testing.T) {
+	withGatherServer(t, func(srv *server.Server, sys *nats.Conn, nc *nats.Conn) {
+		other, err := nats.Connect(srv.ClientURL(), nats.UserInfo("OTHER", "PASS"))
+		if err != nil {
+			t.Fatalf("could not connect: %v", err)
+		}
+		defer other.Close()
+
+		// createStream creates a stream holding count messages with a header and a 5 byte body
+		createStream := func(conn *nats.Conn, name string, count int) {
+			t.Helper()
+
+			js, err := conn.JetStream()
+			if err != nil {
+				t.Fatalf("jetstream failed: %v", err)
+			}
+			_, err = js.AddStream(&nats.StreamConfig{Name: name, Subjects: []string{name + ".>"}})
+			if err != nil {
+				t.Fatalf("stream create failed: %v", err)
+			}
+
+			for i := 1; i <= count; i++ {
+				msg := nats.NewMsg(fmt.Sprintf("%s.%d", name, i))
+				msg.Header.Set("Nats-Msg-Id", fmt.Sprintf("msg-%d", i))
+				msg.Data = []byte("hello")
+				_, err = js.PublishMsg(msg)
+				if err != nil {
+					t.Fatalf("publish failed: %v", err)
+				}
+			}
+		}
+
+		createStream(nc, "ORDERS", 10)
+		createStream(nc, "SHORT", 4)
+		createStream(nc, "EMPTY", 0)
+		createStream(other, "ORDERS", 10)
+
+		cfg := testCaptureConfiguration(t)
+		cfg.Include.Streams = true
+		cfg.MessageSamples = 3
+		cfg.SampleConnections = map[string]*nats.Conn{"USERS": nc}
+
+		err = GatherContext(context.Background(), sys, cfg)
+		if err != nil {
+			t.Fatalf("gather failed: %v", err)
+		}
+
+		r, err := archive.NewReader(cfg.TargetPath)
+		if err != nil {
+			t.Fatalf("failed to open archive: %v", err)
+		}
+		defer r.Close()
+
+		sequences := func(samples *archive.StreamMessageSamples) []uint64 {
+			var seqs []uint64
+			for _, msg := range samples.Messages {
+				seqs = append(seqs, msg.Sequence)
+			}
+			return seqs
+		}
+
+		t.Run("Should sample the first and last messages", func(t *testing.T) {
+			samples, err := r.StreamMessageSamples("USERS", "ORDERS")
+			if err != nil {
+				t.Fatalf("failed to load samples: %v", err)
+			}
+			if samples.FirstSequence != 1 || samples.LastSequence != 10 || samples.Server != "s1" {
+				t.Fatalf("unexpected samples state %+v", samples)
+			}
+			if !slices.Equal(sequences(samples), []uint64{1, 2, 3, 8, 9, 10}) {
+				t.Fatalf("unexpected sampled sequences %v", sequences(samples))
+			}
+		})
+
+		t.Run("Should sample short streams once", func(t *testing.T) {
+			samples, err := r.StreamMessageSamples("USERS", "SHORT")
+			if err != nil {
+				t.Fatalf("failed to load samples: %v", err)
+			}
+			if !slices.Equal(sequences(samples), []uint64{1, 2, 3, 4}) {
+				t.Fatalf("unexpected sampled sequences %v", sequences(samples))
+			}
+		})
+
+		t.Run("Should capture headers and sizes without bodies", func(t *testing.T) {
+			samples, err := r.StreamMessageSamples("USERS", "ORDERS")
+			if err != nil {
+				t.Fatalf("failed to load samples: %v", err)
+			}
+
+			for _, msg := range samples.Messages {
+				if msg.Subject != fmt.Sprintf("ORDERS.%d", msg.Sequence) || msg.Time.IsZero() {
+					t.Fatalf("unexpected sample %+v", msg)
+				}
+				if !slices.Equal(msg.Header["Nats-Msg-Id"], []string{fmt.Sprintf("msg-%d", msg.Sequence)}) {
+					t.Fatalf("unexpected sample headers %v", msg.Header)
+				}
+				if msg.Size != 5 {
+					t.Fatalf("expected size 5, got %d", msg.Size)
+				}
+			}
+		})
+
+		t.Run("Should store empty samples for empty streams", func(t *testing.T) {
+			samples, err := r.StreamMessageSamples("USERS", "EMPTY")
+			if err != nil {
+				t.Fatalf("failed to load samples: %v", err)
+			}
+			if len(samples.Messages) != 0 {
+				t.Fatalf("expected no samples, got %+v", samples.Messages)
+			}
+		})
+
+		t.Run("Should not sample accounts without a connection", func(t *testing.T) {
+			_, err := r.StreamMessageSamples("OTHER", "ORDERS")
+			if err == nil {
+				t.Fatalf("expected no samples for the OTHER account")
+			}
+		})
+	})
+}
@@ -14,11 +14,19 @@
 package audit
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/dustin/go-humanize"
+	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/jsm.go/audit/archive"
 	"github.com/nats-io/jsm.go/monitor"
+	"github.com/nats-io/nats-server/v2/server"
 )
 
 func RegisterJetStreamChecks(collection *CheckCollection) error {
@@ -28,6 +36,9 @@ func RegisterJetStreamChecks(collection *CheckCollection) error {
 			Suite:       "jetstream",
 			Name:        "Stream Lagging Replicas",
 			Description: "All replicas of a stream are keeping up",
+			Tags:        []string{"availability"},
+			Remediation: "Investigate the health and connectivity of the lagging servers, stuck replicas can be forced to catch up by removing the peer from the stream",
+			Severity:    SeverityCritical,
 			Configuration: map[string]*CheckConfiguration{
 				"last_seq": {
 					Key:         "last_seq",
@@ -35,6 +46,18 @@ func RegisterJetStreamChecks(collection *CheckCollection) error {
 					Default:     10,
 					Unit:        PercentageUnit,
 				},
+				"lag": {
+					Key:         "lag",
+					Description: "How many operations a replica may be behind the leader",
+					Default:     10_000,
+					Unit:        UIntUnit,
+				},
+				"active": {
+					Key:         "active",
+					Description: "How many seconds may pass since a replica was last seen by the leader",
+					Default:     30,
+					Unit:        UIntUnit,
+				},
 			},
 			Handler: checkStreamLaggingReplicas,
 		},
@@ -43,6 +66,8 @@ func RegisterJetStreamChecks(collection *CheckCollection) error {
 			Suite:       "jetstream",
 			Name:        "Stream High Cardinality",
 			Description: "Streams unique subjects do not exceed a given threshold",
+			Tags:        []string{"performance"},
+			Severity:    SeverityInfo,
 			Configuration: map[string]*CheckConfiguration{
 				"subjects": {
 					Key:         "subjects",
@@ -50,6 +75,18 @@ func RegisterJetStreamChecks(collection *CheckCollection) error {
 					Default:     1_000_000,
 					Unit:        IntUnit,
 				},
+				"growth": {
+					Key:         "growth",
+					Description: "Alerting threshold for unique subjects growth between samples of a stream",
+					Default:     50,
+					Unit:        PercentageUnit,
+				},
+				"growth_subjects": {
+					Key:         "growth_subjects",
+					Description: "Minimum unique subjects before growth is considered",
+					Default:     10_000,
+					Unit:        UIntUnit,
+				},
 			},
 			Handler: checkStreamHighCardinality,
 		},
@@ -58,6 +95,7 @@ func RegisterJetStreamChecks(collection *CheckCollection) error {
 			Suite:       "jetstream",
 			Name:        "Stream Limits",
 			Description: "Stream usage is below the configured limits",
+			Tags:        []string{"capacity"},
 			Configuration: map[string]*CheckConfiguration{
 				"messages": {
 					Key:         "messages",
@@ -85,6 +123,7 @@ func RegisterJetStreamChecks(collection *CheckCollection) error {
 			Suite:       "jetstream",
 			Name:        "Stream Metadata based monitoring",
 			Description: "Stream health using the 'nats server check stream' metadata",
+			Tags:        []string{"monitoring"},
 			Handler:     checkStreamMetadataMonitoring,
 		},
 		Check{
@@ -92,16 +131,85 @@ func RegisterJetStreamChecks(collection *CheckCollection) error {
 			Suite:       "jetstream",
 			Name:        "Consumer Metadata based monitoring",
 			Description: "Consumer health using the 'nats server check consumer' metadata",
+			Tags:        []string{"monitoring"},
 			Handler:     checkConsumerMetadataMonitoring,
 		},
+		Check{
+			Code:        "JETSTREAM_006",
+			Suite:       "jetstream",
+			Name:        "Stream Replica Placement",
+			Description: "Replicas of R3 and larger streams are spread across availability zones",
+			Tags:        []string{"availability"},
+			Handler:     checkStreamReplicaZones,
+		},
+		Check{
+			Code:        "JETSTREAM_007",
+			Suite:       "jetstream",
+			Name:        "Streams Without Consumers",
+			Description: "Limits based streams holding many messages have consumers",
+			Tags:        []string{"capacity"},
+			Severity:    SeverityInfo,
+			Configuration: map[string]*CheckConfiguration{
+				"messages": {
+					Key:         "messages",
+					Description: "Alert if a stream without consumers holds more messages",
+					Default:     100_000,
+					Unit:        UIntUnit,
+				},
+			},
+			Handler: checkStreamsWithoutConsumers,
+		},
+		Check{
+			Code:        "JETSTREAM_008",
+			Suite:       "jetstream",
+			Name:        "Unreplicated Memory Streams",
+			Description: "Memory based streams holding many messages are replicated",
+			Tags:        []string{"availability"},
+			Configuration: map[string]*CheckConfiguration{
+				"messages": {
+					Key:         "messages",
+					Description: "Alert if an unreplicated memory stream holds more messages",
+					Default:     1000,
+					Unit:        UIntUnit,
+				},
+			},
+			Handler: checkUnreplicatedMemoryStreams,
+		},
+		Check{
+			Code:        "JETSTREAM_009",
+			Suite:       "jetstream",
+			Name:        "Stalled Mirrors and Sources",
+			Description: "Stream mirrors and sources are active, error free and keeping up with their origin",
+			Tags:        []string{"availability"},
+			Configuration: map[string]*CheckConfiguration{
+				"inactive": {
+					Key:         "inactive",
+					Description: "Alert if a mirror or source was not active for this many seconds",
+					Default:     300,
+					Unit:        UIntUnit,
+				},
+			},
+			Handler: checkStalledStreamSources,
+		},
+		Check{
+			Code:        "JETSTREAM_010",
+			Suite:       "jetstream",
+			Name:        "Even Sized RAFT Groups",
+			Description: "Streams, consumers and the meta cluster use an odd number of replicas",
+			Tags:        []string{"availability"},
+			Handler:     checkEvenRaftGroups,
+		},
 	)
 }
 
 // checkStreamLaggingReplicas verifies that in each known stream no replica is too far behind the most up to date (based on stream last sequence)
-func checkStreamLaggingReplicas(check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+// and that the leader reports no replica as lagging or inactive
+func checkStreamLaggingReplicas(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	typeTag := archive.TagStreamInfo()
 	accountNames := r.AccountNames()
 	lastSequenceLagThreshold := check.Configuration["last_seq"].Value()
+	lagThreshold := uint64(check.Configuration["lag"].Value())
+	activeThreshold := time.Duration(check.Configuration["active"].Value()) * time.Second
 
 	if len(accountNames) == 0 {
 		log.Infof("No accounts found in archive")
@@ -131,6 +239,10 @@ func checkStreamLaggingReplicas(check *Check, r *archive.Reader, examples *Examp
 			streamIsEmpty := true
 
 			for _, serverName := range serverNames {
+				if err := ctx.Err(); err != nil {
+					return Skipped, err
+				}
+
 				serverTag := archive.TagServer(serverName)
 
 				err := archive.ForEachTaggedArtifact(r, []*archive.Tag{accountTag, streamTag, serverTag, typeTag}, func(streamDetails *api.StreamInfo) error {
@@ -175,13 +287,35 @@ func checkStreamLaggingReplicas(check *Check, r *archive.Reader, examples *Examp
 					}
 				}
 			}
+
+			// Check the replica state as seen by the leader to find followers that are stuck
+			for serverName, streamDetail := range replicasStreamDetails {
+				if streamDetail.Cluster == nil || streamDetail.Cluster.Leader != serverName {
+					continue
+				}
+
+				for _, peer := range streamDetail.Cluster.Replicas {
+					if peer == nil || peer.Offline {
+						continue
+					}
+
+					switch {
+					case lagThreshold > 0 && peer.Lag > lagThreshold:
+						examples.Add("%s/%s server %s is %d operations behind leader %s", accountName, streamName, peer.Name, peer.Lag, serverName)
+						laggingReplicas += 1
+					case activeThreshold > 0 && peer.Active > activeThreshold:
+						examples.Add("%s/%s server %s was last seen by leader %s %v ago", accountName, streamName, peer.Name, serverName, peer.Active.Round(time.Second))
+						laggingReplicas += 1
+					}
+				}
+			}
 		}
 	}
 
 	log.Infof("Inspected %d streams across %d accounts", len(streamsInspected), len(accountsWithStreams))
 
 	if laggingReplicas > 0 {
-		log.Errorf("Found %d replicas lagging >%.0f%% behind", laggingReplicas, lastSequenceLagThreshold)
+		log.Errorf("Found %d lagging or inactive replicas", laggingReplicas)
 		return Fail, nil
 	}
 
@@ -189,9 +323,18 @@ func checkStreamLaggingReplicas(check *Check, r *archive.Reader, examples *Examp
 }
 
 // checkStreamHighCardinality verifies that the number of unique subjects is below some magic number for each known stream
-func checkStreamHighCardinality(check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+// and, when the archive holds several samples of a stream taken at different times, that it is not growing quickly.
+// Growth is measured using the stream leader's samples only as replicas are captured at the same time
+func checkStreamHighCardinality(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	streamDetailsTag := archive.TagStreamInfo()
 	numSubjectsThreshold := check.Configuration["subjects"].Value()
+	growthThreshold := check.Configuration["growth"].Value()
+	growthMinimum := check.Configuration["growth_subjects"].Value()
+
+	type sample struct {
+		ts       time.Time
+		subjects int
+	}
 
 	for _, accountName := range r.AccountNames() {
 		accountTag := archive.TagAccount(accountName)
@@ -200,13 +343,25 @@ func checkStreamHighCardinality(check *Check, r *archive.Reader, examples *Examp
 			streamTag := archive.TagStream(streamName)
 			serverNames := r.StreamServerNames(accountName, streamName)
 
+			var samples []sample
+
 			for _, serverName := range serverNames {
+				if err := ctx.Err(); err != nil {
+					return Skipped, err
+				}
+
 				serverTag := archive.TagServer(serverName)
 
 				err := archive.ForEachTaggedArtifact(r, []*archive.Tag{accountTag, streamTag, serverTag, streamDetailsTag}, func(streamDetails *api.StreamInfo) error {
 					if float64(streamDetails.State.NumSubjects) > numSubjectsThreshold {
 						examples.Add("%s/%s: %d subjects", accountName, streamName, streamDetails.State.NumSubjects)
 					}
+
+					leader := streamDetails.Cluster == nil || streamDetails.Cluster.Leader == "" || streamDetails.Cluster.Leader == serverName
+					if leader && !streamDetails.TimeStamp.IsZero() {
+						samples = append(samples, sample{streamDetails.TimeStamp, streamDetails.State.NumSubjects})
+					}
+
 					return nil
 				})
 				if err != nil {
@@ -214,31 +369,57 @@ func checkStreamHighCardinality(check *Check, r *archive.Reader, examples *Examp
 					continue
 				}
 			}
+
+			if len(samples) < 2 {
+				continue
+			}
+
+			slices.SortStableFunc(samples, func(a, b sample) int {
+				return a.ts.Compare(b.ts)
+			})
+
+			first, last := samples[0], samples[len(samples)-1]
+			if first.subjects == 0 || last.subjects <= first.subjects || float64(last.subjects) < growthMinimum {
+				continue
+			}
+
+			growth := float64(last.subjects-first.subjects) * 100 / float64(first.subjects)
+			if growth > growthThreshold {
+				examples.Add("%s/%s: subjects grew %.0f%% from %d to %d in %v", accountName, streamName, growth, first.subjects, last.subjects, last.ts.Sub(first.ts).Round(time.Millisecond))
+			}
 		}
 	}
 
 	if examples.Count() > 0 {
-		log.Errorf("Found %d streams with subjects cardinality exceeding %f", examples.Count(), numSubjectsThreshold)
+		log.Errorf("Found %d instances of streams with subjects cardinality exceeding %.0f or growing quickly", examples.Count(), numSubjectsThreshold)
 		return PassWithIssues, nil
 	}
 
 	return Pass, nil
 }
 
-// checkStreamLimits verifies that the number of messages/bytes/consumers is below a given threshold from the the configured limit for each known stream
-func checkStreamLimits(check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+// checkStreamLimits verifies that the number of messages/bytes/consumers is below a given threshold from the the configured limit for each known stream,
+// streams with the new discard policy nearing their message or byte limits will soon reject publishes and so fail the check
+func checkStreamLimits(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	messagesThreshold := check.Configuration["messages"].Value()
 	bytesThreshold := check.Configuration["bytes"].Value()
 	consumersThreshold := check.Configuration["consumers"].Value()
+	rejecting := 0
 
 	// Check value against limit threshold, create example if exceeded
-	checkLimit := func(limitName, accountName, streamName, serverName string, value, limit int64, percentThreshold float64) {
+	checkLimit := func(limitName, accountName, streamName, serverName string, value, limit int64, percentThreshold float64, discardNew bool) {
 		if limit <= 0 {
 			// Limit not set
 			return
 		}
 		threshold := int64(float64(limit) * (percentThreshold / 100))
 		if value > threshold {
+			if discardNew {
+				rejecting++
+				examples.Add("stream %s (in %s on %s) using %.1f%% of %s limit (%d/%d) and will reject new messages", streamName, accountName, serverName, float64(value)*100/float64(limit), limitName, value, limit)
+				return
+			}
+
 			examples.Add("stream %s (in %s on %s) using %.1f%% of %s limit (%d/%d)", streamName, accountName, serverName, float64(value)*100/float64(limit), limitName, value, limit)
 		}
 	}
@@ -253,9 +434,20 @@ func checkStreamLimits(check *Check, r *archive.Reader, examples *ExamplesCollec
 			serverNames := r.StreamServerNames(accountName, streamName)
 
 			for _, serverName := range serverNames {
+				if err := ctx.Err(); err != nil {
+					return Skipped, err
+				}
+
 				serverTag := archive.TagServer(serverName)
 
 				err := archive.ForEachTaggedArtifact(r, []*archive.Tag{accountTag, streamTag, serverTag, streamDetailsTag}, func(streamDetails *api.StreamInfo) error {
+					// all replicas share the same limits so only the leader is inspected
+					if streamDetails.Cluster != nil && streamDetails.Cluster.Leader != "" && streamDetails.Cluster.Leader != serverName {
+						return nil
+					}
+
+					discardNew := streamDetails.Config.Discard == api.DiscardNew
+
 					checkLimit(
 						"messages",
 						accountName,
@@ -264,6 +456,7 @@ func checkStreamLimits(check *Check, r *archive.Reader, examples *ExamplesCollec
 						int64(streamDetails.State.Msgs),
 						streamDetails.Config.MaxMsgs,
 						messagesThreshold,
+						discardNew,
 					)
 
 					checkLimit(
@@ -274,6 +467,7 @@ func checkStreamLimits(check *Check, r *archive.Reader, examples *ExamplesCollec
 						int64(streamDetails.State.Bytes),
 						streamDetails.Config.MaxBytes,
 						bytesThreshold,
+						discardNew,
 					)
 
 					checkLimit(
@@ -284,6 +478,7 @@ func checkStreamLimits(check *Check, r *archive.Reader, examples *ExamplesCollec
 						int64(streamDetails.State.Consumers),
 						int64(streamDetails.Config.MaxConsumers),
 						consumersThreshold,
+						false,
 					)
 
 					return nil
@@ -296,6 +491,11 @@ func checkStreamLimits(check *Check, r *archive.Reader, examples *ExamplesCollec
 		}
 	}
 
+	if rejecting > 0 {
+		log.Errorf("Found %d instances of streams with discard policy new approaching limit", rejecting)
+		return Fail, nil
+	}
+
 	if examples.Count() > 0 {
 		log.Errorf("Found %d instances of streams approaching limit", examples.Count())
 		return PassWithIssues, nil
@@ -304,7 +504,7 @@ func checkStreamLimits(check *Check, r *archive.Reader, examples *ExamplesCollec
 	return Pass, nil
 }
 
-func checkStreamMetadataMonitoring(_ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkStreamMetadataMonitoring(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	streamDetailsTag := archive.TagStreamInfo()
 	var foundCrit bool
 
@@ -316,6 +516,10 @@ func checkStreamMetadataMonitoring(_ *Check, r *archive.Reader, examples *Exampl
 			serverNames := r.StreamServerNames(accountName, streamName)
 
 			for _, serverName := range serverNames {
+				if err := ctx.Err(); err != nil {
+					return Skipped, err
+				}
+
 				serverTag := archive.TagServer(serverName)
 
 				err := archive.ForEachTaggedArtifact(r, []*archive.Tag{accountTag, streamTag, serverTag, streamDetailsTag}, func(streamDetails *api.StreamInfo) error {
@@ -367,7 +571,7 @@ func checkStreamMetadataMonitoring(_ *Check, r *archive.Reader, examples *Exampl
 	return Pass, nil
 }
 
-func checkConsumerMetadataMonitoring(_ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkConsumerMetadataMonitoring(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	streamDetailsTag := archive.TagStreamInfo()
 	var foundCrit bool
 
@@ -384,6 +588,10 @@ func checkConsumerMetadataMonitoring(_ *Check, r *archive.Reader, examples *Exam
 			serverNames := r.StreamServerNames(accountName, streamName)
 
 			for _, serverName := range serverNames {
+				if err := ctx.Err(); err != nil {
+					return Skipped, err
+				}
+
 				serverTag := archive.TagServer(serverName)
 
 				err := archive.ForEachTaggedArtifact(r, []*archive.Tag{accountTag, streamTag, serverTag, streamDetailsTag}, func(streamDetails *streamWithConsumers) error {
@@ -437,3 +645,413 @@ func checkConsumerMetadataMonitoring(_ *Check, r *archive.Reader, examples *Exam
 
 	return Pass, nil
 }
+
+// defaultZoneTagPrefix is the tag prefix used to identify the availability zone of servers without a JetStream unique tag
+const defaultZoneTagPrefix = "az:"
+
+// serverZones maps server names to the tag placing them in an availability zone, the JetStream unique_tag setting of each
+// server determines the tag to use
+func serverZones(ctx context.Context, r *archive.Reader, log api.Logger) (map[string]string, error) {
+	zones := make(map[string]string)
+
+	_, err := r.EachClusterServerVarz(func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, vz *server.ServerAPIVarzResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if errors.Is(err, archive.ErrNoMatches) {
+			log.Warnf("Artifact 'VARZ' is missing for server %s", serverTag)
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to load variables for server %s: %w", serverTag, err)
+		}
+
+		prefix := defaultZoneTagPrefix
+		if vz.Data.JetStream.Config != nil && vz.Data.JetStream.Config.UniqueTag != "" {
+			prefix = vz.Data.JetStream.Config.UniqueTag
+		}
+
+		for _, tag := range vz.Data.Tags {
+			if strings.HasPrefix(strings.ToLower(tag), strings.ToLower(prefix)) {
+				zones[serverTag.Value] = strings.ToLower(tag)
+				break
+			}
+		}
+
+		return nil
+	})
+
+	return zones, err
+}
+
+// checkStreamReplicaZones verifies that the replicas of each R3+ stream are not all placed in the same availability zone
+func checkStreamReplicaZones(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	zones, err := serverZones(ctx, r, log)
+	if err != nil {
+		return Skipped, err
+	}
+
+	if len(zones) == 0 {
+		log.Infof("No servers with availability zone tags found in archive")
+		return Pass, nil
+	}
+
+	streamDetailsTag := archive.TagStreamInfo()
+
+	for _, accountName := range r.AccountNames() {
+		accountTag := archive.TagAccount(accountName)
+
+		for _, streamName := range r.AccountStreamNames(accountName) {
+			streamTag := archive.TagStream(streamName)
+			serverNames := r.StreamServerNames(accountName, streamName)
+
+			for _, serverName := range serverNames {
+				if err := ctx.Err(); err != nil {
+					return Skipped, err
+				}
+
+				serverTag := archive.TagServer(serverName)
+
+				err := archive.ForEachTaggedArtifact(r, []*archive.Tag{accountTag, streamTag, serverTag, streamDetailsTag}, func(streamDetails *api.StreamInfo) error {
+					if streamDetails.Config.Replicas < 3 || streamDetails.Cluster == nil || streamDetails.Cluster.Leader != serverName {
+						return nil
+					}
+
+					peers := []string{streamDetails.Cluster.Leader}
+					for _, peer := range streamDetails.Cluster.Replicas {
+						peers = append(peers, peer.Name)
+					}
+
+					var placed []string
+					for _, peer := range peers {
+						zone, ok := zones[peer]
+						if !ok {
+							log.Debugf("Stream %s in %s has replica %s without an availability zone tag", streamName, accountName, peer)
+							return nil
+						}
+
+						placed = append(placed, zone)
+					}
+
+					slices.Sort(placed)
+					placed = slices.Compact(placed)
+					if len(placed) == 1 {
+						examples.Add("stream %s (in %s) has all %d replicas in %s", streamName, accountName, len(peers), placed[0])
+					}
+
+					return nil
+				})
+				if err != nil {
+					log.Warnf("Artifact 'STREAM_DETAILS' is missing for stream %s in account %s", streamName, accountName)
+					continue
+				}
+			}
+		}
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d streams with all replicas in a single availability zone", examples.Count())
+		return Fail, nil
+	}
+
+	return Pass, nil
+}
+
+// checkStreamsWithoutConsumers finds limits based streams that accumulate messages while nothing consumes them, mirrors and
+// internal streams like KV buckets are accessed without consumers and are not considered
+func checkStreamsWithoutConsumers(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	messagesThreshold := check.Configuration["messages"].Value()
+	streamDetailsTag := archive.TagStreamInfo()
+
+	for _, accountName := range r.AccountNames() {
+		accountTag := archive.TagAccount(accountName)
+
+		for _, streamName := range r.AccountStreamNames(accountName) {
+			if jsm.IsInternalStream(streamName) {
+				continue
+			}
+
+			streamTag := archive.TagStream(streamName)
+			serverNames := r.StreamServerNames(accountName, streamName)
+
+			for _, serverName := range serverNames {
+				if err := ctx.Err(); err != nil {
+					return Skipped, err
+				}
+
+				serverTag := archive.TagServer(serverName)
+
+				err := archive.ForEachTaggedArtifact(r, []*archive.Tag{accountTag, streamTag, serverTag, streamDetailsTag}, func(streamDetails *api.StreamInfo) error {
+					if streamDetails.Cluster != nil && streamDetails.Cluster.Leader != "" && streamDetails.Cluster.Leader != serverName {
+						return nil
+					}
+
+					if streamDetails.Config.Retention != api.LimitsPolicy || streamDetails.Config.Mirror != nil {
+						return nil
+					}
+
+					if streamDetails.State.Consumers > 0 || float64(streamDetails.State.Msgs) <= messagesThreshold {
+						return nil
+					}
+
+					examples.Add("stream %s (in %s) holds %d messages (%s) without any consumers", streamName, accountName, streamDetails.State.Msgs, humanize.IBytes(streamDetails.State.Bytes))
+
+					return nil
+				})
+				if err != nil {
+					log.Warnf("Artifact 'STREAM_DETAILS' is missing for stream %s in account %s", streamName, accountName)
+					continue
+				}
+			}
+		}
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d streams holding more than %.0f messages without consumers", examples.Count(), messagesThreshold)
+		return PassWithIssues, nil
+	}
+
+	return Pass, nil
+}
+
+// checkUnreplicatedMemoryStreams finds R1 memory streams that would lose their data when their server restarts, streams that
+// mirror or source other streams can recover their data and are not considered
+func checkUnreplicatedMemoryStreams(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	messagesThreshold := check.Configuration["messages"].Value()
+	streamDetailsTag := archive.TagStreamInfo()
+
+	for _, accountName := range r.AccountNames() {
+		accountTag := archive.TagAccount(accountName)
+
+		for _, streamName := range r.AccountStreamNames(accountName) {
+			streamTag := archive.TagStream(streamName)
+			serverNames := r.StreamServerNames(accountName, streamName)
+
+			for _, serverName := range serverNames {
+				if err := ctx.Err(); err != nil {
+					return Skipped, err
+				}
+
+				serverTag := archive.TagServer(serverName)
+
+				err := archive.ForEachTaggedArtifact(r, []*archive.Tag{accountTag, streamTag, serverTag, streamDetailsTag}, func(streamDetails *api.StreamInfo) error {
+					cfg := streamDetails.Config
+					if cfg.Storage != api.MemoryStorage || cfg.Replicas > 1 || cfg.Mirror != nil || len(cfg.Sources) > 0 {
+						return nil
+					}
+
+					if float64(streamDetails.State.Msgs) <= messagesThreshold {
+						return nil
+					}
+
+					examples.Add("stream %s (in %s on %s) holds %d messages in memory without replicas", streamName, accountName, serverName, streamDetails.State.Msgs)
+
+					return nil
+				})
+				if err != nil {
+					log.Warnf("Artifact 'STREAM_DETAILS' is missing for stream %s in account %s", streamName, accountName)
+					continue
+				}
+			}
+		}
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d unreplicated memory streams holding more than %.0f messages", examples.Count(), messagesThreshold)
+		return PassWithIssues, nil
+	}
+
+	return Pass, nil
+}
+
+// checkStalledStreamSources verifies that mirrors and sources report no errors and were recently active, when the
+// archive holds several samples of a stream taken at different times a lag that grows in every sample is also reported.
+// Only the stream leader's view is considered since replicas do not process sources and would otherwise be mistaken
+// for samples taken at different times
+func checkStalledStreamSources(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	inactiveThreshold := time.Duration(check.Configuration["inactive"].Value()) * time.Second
+	streamDetailsTag := archive.TagStreamInfo()
+
+	type sample struct {
+		ts     time.Time
+		mirror bool
+		source *api.StreamSourceInfo
+	}
+
+	for _, accountName := range r.AccountNames() {
+		accountTag := archive.TagAccount(accountName)
+
+		for _, streamName := range r.AccountStreamNames(accountName) {
+			streamTag := archive.TagStream(streamName)
+			samples := make(map[string][]sample)
+
+			for _, serverName := range r.StreamServerNames(accountName, streamName) {
+				if err := ctx.Err(); err != nil {
+					return Skipped, err
+				}
+
+				serverTag := archive.TagServer(serverName)
+
+				err := archive.ForEachTaggedArtifact(r, []*archive.Tag{accountTag, streamTag, serverTag, streamDetailsTag}, func(streamDetails *api.StreamInfo) error {
+					if streamDetails.Cluster != nil && streamDetails.Cluster.Leader != "" && streamDetails.Cluster.Leader != serverName {
+						return nil
+					}
+
+					if streamDetails.Mirror != nil {
+						samples[streamDetails.Mirror.Name] = append(samples[streamDetails.Mirror.Name], sample{streamDetails.TimeStamp, true, streamDetails.Mirror})
+					}
+
+					for _, source := range streamDetails.Sources {
+						if source == nil {
+							continue
+						}
+
+						key := source.Name
+						if source.External != nil {
+							key = source.External.ApiPrefix + ">" + source.Name
+						}
+
+						samples[key] = append(samples[key], sample{streamDetails.TimeStamp, false, source})
+					}
+
+					return nil
+				})
+				if err != nil {
+					log.Warnf("Artifact 'STREAM_DETAILS' is missing for stream %s in account %s", streamName, accountName)
+					continue
+				}
+			}
+
+			keys := make([]string, 0, len(samples))
+			for key := range samples {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+
+			for _, key := range keys {
+				sourceSamples := samples[key]
+				slices.SortStableFunc(sourceSamples, func(a, b sample) int {
+					return a.ts.Compare(b.ts)
+				})
+
+				latest := sourceSamples[len(sourceSamples)-1]
+				kind := "source"
+				if latest.mirror {
+					kind = "mirror"
+				}
+
+				switch {
+				case latest.source.Error != nil:
+					examples.Add("stream %s (in %s) %s %s reports an error: %v", streamName, accountName, kind, latest.source.Name, latest.source.Error)
+				case latest.source.Active < 0:
+					examples.Add("stream %s (in %s) %s %s was never active", streamName, accountName, kind, latest.source.Name)
+				case inactiveThreshold > 0 && latest.source.Active > inactiveThreshold:
+					examples.Add("stream %s (in %s) %s %s was last active %v ago", streamName, accountName, kind, latest.source.Name, latest.source.Active.Round(time.Second))
+				}
+
+				growing := len(sourceSamples) > 1
+				for i := 1; i < len(sourceSamples) && growing; i++ {
+					prev, cur := sourceSamples[i-1], sourceSamples[i]
+					growing = cur.ts.After(prev.ts) && cur.source.Lag > prev.source.Lag
+				}
+
+				if growing {
+					examples.Add("stream %s (in %s) %s %s lag grew from %d to %d between %d samples", streamName, accountName, kind, latest.source.Name, sourceSamples[0].source.Lag, latest.source.Lag, len(sourceSamples))
+				}
+			}
+		}
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d stalled or failing mirrors and sources", examples.Count())
+		return Fail, nil
+	}
+
+	return Pass, nil
+}
+
+// checkEvenRaftGroups finds streams, consumers and meta clusters with an even number of replicas, these tolerate no more
+// failures than the next smaller odd size while requiring more servers to form a quorum
+func checkEvenRaftGroups(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	streamDetailsTag := archive.TagStreamInfo()
+
+	metaSizes := make(map[string]int)
+	_, err := r.EachClusterServerJsz(func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, jsz *server.ServerAPIJszResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if errors.Is(err, archive.ErrNoMatches) {
+			log.Warnf("Artifact 'JSZ' is missing for server %s", serverTag)
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to load JSZ for server %s: %w", serverTag, err)
+		}
+
+		if jsz.Data == nil || jsz.Data.Disabled || jsz.Data.Meta == nil {
+			return nil
+		}
+
+		metaSizes[jsz.Data.Meta.Name] = max(metaSizes[jsz.Data.Meta.Name], jsz.Data.Meta.Size)
+
+		return nil
+	})
+	if err != nil {
+		return Skipped, err
+	}
+
+	for name, size := range metaSizes {
+		if size > 1 && size%2 == 0 {
+			examples.Add("meta cluster %s has %d servers", name, size)
+		}
+	}
+
+	for _, accountName := range r.AccountNames() {
+		accountTag := archive.TagAccount(accountName)
+
+		for _, streamName := range r.AccountStreamNames(accountName) {
+			streamTag := archive.TagStream(streamName)
+
+			for _, serverName := range r.StreamServerNames(accountName, streamName) {
+				if err := ctx.Err(); err != nil {
+					return Skipped, err
+				}
+
+				serverTag := archive.TagServer(serverName)
+
+				err := archive.ForEachTaggedArtifact(r, []*archive.Tag{accountTag, streamTag, serverTag, streamDetailsTag}, func(streamDetails *api.StreamInfo) error {
+					if streamDetails.Cluster != nil && streamDetails.Cluster.Leader != "" && streamDetails.Cluster.Leader != serverName {
+						return nil
+					}
+
+					if streamDetails.Config.Replicas > 1 && streamDetails.Config.Replicas%2 == 0 {
+						examples.Add("stream %s (in %s) has %d replicas", streamName, accountName, streamDetails.Config.Replicas)
+					}
+
+					return nil
+				})
+				if err != nil {
+					log.Warnf("Artifact 'STREAM_DETAILS' is missing for stream %s in account %s", streamName, accountName)
+					continue
+				}
+			}
+		}
+	}
+
+	// consumers without replicas set inherit the stream replicas and are reported with the stream
+	err = eachLeaderConsumer(ctx, r, log, func(accountName string, stream *api.StreamInfo, nfo *api.ConsumerInfo) {
+		if nfo.Config.Replicas > 1 && nfo.Config.Replicas%2 == 0 {
+			examples.Add("consumer %s > %s (in %s) has %d replicas", stream.Config.Name, nfo.Name, accountName, nfo.Config.Replicas)
+		}
+	})
+	if err != nil {
+		return Skipped, err
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d RAFT groups with an even number of replicas", examples.Count())
+		return PassWithIssues, nil
+	}
+
+	return Pass, nil
+}
@@ -1,11 +1,14 @@
 package audit
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/jsm.go/audit/archive"
+	"github.com/nats-io/nats-server/v2/server"
 )
 
 type streamWithConsumers struct {
@@ -62,7 +65,7 @@ func setupJetstreamCheck(t *testing.T, checkid string, streams map[string]any) O
 	}
 
 	examples := newExamplesCollection(0)
-	result, err := check.Handler(check, reader, examples, api.NewDefaultLogger(api.WarnLevel))
+	result, err := check.Handler(context.Background(), check, reader, examples, api.NewDefaultLogger(api.WarnLevel))
 	if err != nil {
 		t.Fatalf("check handler failed: %v", err)
 	}
@@ -70,6 +73,55 @@ func setupJetstreamCheck(t *testing.T, checkid string, streams map[string]any) O
 	return result
 }
 
+// runArchiveCheck creates an archive using add and runs checkid from the checks registered by register against it
+func runArchiveCheck(t *testing.T, register func(*CheckCollection) error, checkid string, add func(w *archive.Writer) error) (Outcome, *ExamplesCollection) {
+	t.Helper()
+
+	archivePath := filepath.Join(t.TempDir(), "audit.zip")
+
+	writer, err := archive.NewWriter(archivePath)
+	if err != nil {
+		t.Fatalf("failed to create archive writer: %v", err)
+	}
+
+	if err := add(writer); err != nil {
+		t.Fatalf("failed to add artifacts: %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close archive: %v", err)
+	}
+
+	reader, err := archive.NewReader(archivePath)
+	if err != nil {
+		t.Fatalf("failed to open archive: %v", err)
+	}
+	defer reader.Close()
+
+	cc := &CheckCollection{}
+	if err := register(cc); err != nil {
+		t.Fatalf("failed to register checks: %v", err)
+	}
+
+	var check *Check
+	cc.EachCheck(func(c *Check) {
+		if c.Code == checkid {
+			check = c
+		}
+	})
+	if check == nil {
+		t.Fatalf("check %s not found", checkid)
+	}
+
+	examples := newExamplesCollection(0)
+	result, err := check.Handler(context.Background(), check, reader, examples, api.NewDefaultLogger(api.WarnLevel))
+	if err != nil {
+		t.Fatalf("check handler failed: %v", err)
+	}
+
+	return result, examples
+}
+
 func TestJETSTREAM_001(t *testing.T) {
 	t.Run("Should fail when one replica is too far behind", func(t *testing.T) {
 		result := setupJetstreamCheck(t, "JETSTREAM_001", map[string]any{
@@ -90,6 +142,30 @@ func TestJETSTREAM_001(t *testing.T) {
 			t.Errorf("expected result %v, got %v", Pass, result)
 		}
 	})
+
+	t.Run("Should fail when the leader reports a lagging or inactive replica", func(t *testing.T) {
+		for _, peer := range []*api.PeerInfo{
+			{Name: "N2", Lag: 20_000, Active: time.Second},
+			{Name: "N2", Active: time.Minute},
+		} {
+			result := setupJetstreamCheck(t, "JETSTREAM_001", map[string]any{
+				"N1": &api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, State: api.StreamState{LastSeq: 100}, Cluster: &api.ClusterInfo{Leader: "N1", Replicas: []*api.PeerInfo{peer}}},
+			})
+			if result != Fail {
+				t.Errorf("expected result %v, got %v", Fail, result)
+			}
+		}
+	})
+
+	t.Run("Should only consider replicas reported by the leader", func(t *testing.T) {
+		result := setupJetstreamCheck(t, "JETSTREAM_001", map[string]any{
+			"N1": &api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, State: api.StreamState{LastSeq: 100}, Cluster: &api.ClusterInfo{Leader: "N1", Replicas: []*api.PeerInfo{{Name: "N2", Current: true, Active: time.Second}}}},
+			"N2": &api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, State: api.StreamState{LastSeq: 100}, Cluster: &api.ClusterInfo{Leader: "N1", Replicas: []*api.PeerInfo{{Name: "N1", Active: time.Hour}}}},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
 }
 
 func TestJETSTREAM_002(t *testing.T) {
@@ -110,6 +186,48 @@ func TestJETSTREAM_002(t *testing.T) {
 			t.Errorf("expected result %v, got %v", Pass, result)
 		}
 	})
+
+	// samples adds a stream info artifact per subject count, captured a minute apart from the leader N1
+	samples := func(subjects ...int) func(w *archive.Writer) error {
+		return func(w *archive.Writer) error {
+			now := time.Now()
+			for i, count := range subjects {
+				nfo := &api.StreamInfo{Config: api.StreamConfig{Name: "S1", Replicas: 3}, Cluster: &api.ClusterInfo{Leader: "N1"}, TimeStamp: now.Add(time.Duration(i) * time.Minute), State: api.StreamState{NumSubjects: count}}
+				err := w.Add(nfo, archive.TagAccount("A"), archive.TagStream("S1"), archive.TagServer("N1"), archive.TagCluster("C1"), archive.TagStreamInfo())
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	t.Run("Should warn when subject count grows quickly between samples", func(t *testing.T) {
+		result, _ := runArchiveCheck(t, RegisterJetStreamChecks, "JETSTREAM_002", samples(20_000, 50_000))
+		if result != PassWithIssues {
+			t.Errorf("expected result %v, got %v", PassWithIssues, result)
+		}
+	})
+
+	t.Run("Should ignore growth of small or stable streams", func(t *testing.T) {
+		for _, subjects := range [][2]int{{100, 1000}, {20_000, 21_000}, {50_000, 20_000}} {
+			result, _ := runArchiveCheck(t, RegisterJetStreamChecks, "JETSTREAM_002", samples(subjects[0], subjects[1]))
+			if result != Pass {
+				t.Errorf("expected result %v for %v, got %v", Pass, subjects, result)
+			}
+		}
+	})
+
+	t.Run("Should ignore replicas captured in the same gather", func(t *testing.T) {
+		now := time.Now()
+		result := setupJetstreamCheck(t, "JETSTREAM_002", map[string]any{
+			"N1": &api.StreamInfo{Config: api.StreamConfig{Name: "S1", Replicas: 2}, Cluster: &api.ClusterInfo{Leader: "N1"}, TimeStamp: now, State: api.StreamState{NumSubjects: 20_000}},
+			"N2": &api.StreamInfo{Config: api.StreamConfig{Name: "S1", Replicas: 2}, Cluster: &api.ClusterInfo{Leader: "N1"}, TimeStamp: now.Add(time.Millisecond), State: api.StreamState{NumSubjects: 50_000}},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
 }
 
 func TestJETSTREAM_003(t *testing.T) {
@@ -152,6 +270,37 @@ func TestJETSTREAM_003(t *testing.T) {
 		}
 	})
 
+	t.Run("Should fail when discard new stream is near limit", func(t *testing.T) {
+		result := setupJetstreamCheck(t, "JETSTREAM_003", map[string]any{
+			"N1": &api.StreamInfo{
+				Config:  api.StreamConfig{Name: "S1", MaxBytes: 1000, Discard: api.DiscardNew},
+				State:   api.StreamState{Bytes: 950},
+				Cluster: &api.ClusterInfo{Leader: "N1"},
+			},
+		})
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should only inspect the stream leader", func(t *testing.T) {
+		result := setupJetstreamCheck(t, "JETSTREAM_003", map[string]any{
+			"N1": &api.StreamInfo{
+				Config:  api.StreamConfig{Name: "S1", MaxMsgs: 1000},
+				State:   api.StreamState{Msgs: 100},
+				Cluster: &api.ClusterInfo{Leader: "N1"},
+			},
+			"N2": &api.StreamInfo{
+				Config:  api.StreamConfig{Name: "S1", MaxMsgs: 1000},
+				State:   api.StreamState{Msgs: 950},
+				Cluster: &api.ClusterInfo{Leader: "N1"},
+			},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+
 	t.Run("Should pass when usage is below limits", func(t *testing.T) {
 		result := setupJetstreamCheck(t, "JETSTREAM_003", map[string]any{
 			"N1": &api.StreamInfo{
@@ -263,3 +412,229 @@ func TestJETSTREAM_005(t *testing.T) {
 		}
 	})
 }
+
+func TestJETSTREAM_006(t *testing.T) {
+	setup := func(t *testing.T, zones map[string]string, uniqueTag string) Outcome {
+		result, _ := runArchiveCheck(t, RegisterJetStreamChecks, "JETSTREAM_006", func(w *archive.Writer) error {
+			for serverName, zone := range zones {
+				vz := &server.ServerAPIVarzResponse{Data: &server.Varz{Name: serverName, Tags: []string{zone, "other:x"}}}
+				if uniqueTag != "" {
+					vz.Data.JetStream.Config = &server.JetStreamConfig{UniqueTag: uniqueTag}
+				}
+
+				err := w.Add(vz, archive.TagCluster("C1"), archive.TagServer(serverName), archive.TagServerVars())
+				if err != nil {
+					return err
+				}
+			}
+
+			return w.Add(&api.StreamInfo{
+				Config:  api.StreamConfig{Name: "S1", Replicas: 3},
+				Cluster: &api.ClusterInfo{Leader: "N1", Replicas: []*api.PeerInfo{{Name: "N2"}, {Name: "N3"}}},
+			}, archive.TagAccount("A"), archive.TagStream("S1"), archive.TagServer("N1"), archive.TagCluster("C1"), archive.TagStreamInfo())
+		})
+
+		return result
+	}
+
+	t.Run("Should fail when all replicas share a zone", func(t *testing.T) {
+		result := setup(t, map[string]string{"N1": "az:1", "N2": "az:1", "N3": "AZ:1"}, "")
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should pass when replicas are spread", func(t *testing.T) {
+		result := setup(t, map[string]string{"N1": "az:1", "N2": "az:1", "N3": "az:2"}, "")
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+
+	t.Run("Should use the configured unique tag", func(t *testing.T) {
+		result := setup(t, map[string]string{"N1": "zone:1", "N2": "zone:1", "N3": "zone:1"}, "zone:")
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should pass when zones are unknown", func(t *testing.T) {
+		result := setup(t, map[string]string{"N1": "az:1", "N2": "az:1", "N3": "rack:1"}, "")
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+}
+
+func TestJETSTREAM_007(t *testing.T) {
+	t.Run("Should warn when a stream without consumers holds many messages", func(t *testing.T) {
+		result := setupJetstreamCheck(t, "JETSTREAM_007", map[string]any{
+			"N1": &api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, State: api.StreamState{Msgs: 200_000}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+		})
+		if result != PassWithIssues {
+			t.Errorf("expected result %v, got %v", PassWithIssues, result)
+		}
+	})
+
+	t.Run("Should pass when the stream has consumers", func(t *testing.T) {
+		result := setupJetstreamCheck(t, "JETSTREAM_007", map[string]any{
+			"N1": &api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, State: api.StreamState{Msgs: 200_000, Consumers: 1}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+
+	t.Run("Should pass for work queues and mirrors", func(t *testing.T) {
+		result := setupJetstreamCheck(t, "JETSTREAM_007", map[string]any{
+			"N1": &api.StreamInfo{Config: api.StreamConfig{Name: "S1", Retention: api.WorkQueuePolicy}, State: api.StreamState{Msgs: 200_000}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+
+		result = setupJetstreamCheck(t, "JETSTREAM_007", map[string]any{
+			"N1": &api.StreamInfo{Config: api.StreamConfig{Name: "S1", Mirror: &api.StreamSource{Name: "ORIGIN"}}, State: api.StreamState{Msgs: 200_000}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+
+	t.Run("Should pass for small streams", func(t *testing.T) {
+		result := setupJetstreamCheck(t, "JETSTREAM_007", map[string]any{
+			"N1": &api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, State: api.StreamState{Msgs: 10}, Cluster: &api.ClusterInfo{Leader: "N1"}},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+}
+
+func TestJETSTREAM_008(t *testing.T) {
+	t.Run("Should warn for unreplicated memory streams", func(t *testing.T) {
+		result := setupJetstreamCheck(t, "JETSTREAM_008", map[string]any{
+			"N1": &api.StreamInfo{Config: api.StreamConfig{Name: "S1", Storage: api.MemoryStorage, Replicas: 1}, State: api.StreamState{Msgs: 5000}},
+		})
+		if result != PassWithIssues {
+			t.Errorf("expected result %v, got %v", PassWithIssues, result)
+		}
+	})
+
+	t.Run("Should pass for replicated, sourced, file or small streams", func(t *testing.T) {
+		for _, nfo := range []*api.StreamInfo{
+			{Config: api.StreamConfig{Name: "S1", Storage: api.MemoryStorage, Replicas: 3}, State: api.StreamState{Msgs: 5000}},
+			{Config: api.StreamConfig{Name: "S1", Storage: api.MemoryStorage, Replicas: 1, Sources: []*api.StreamSource{{Name: "ORIGIN"}}}, State: api.StreamState{Msgs: 5000}},
+			{Config: api.StreamConfig{Name: "S1", Storage: api.FileStorage, Replicas: 1}, State: api.StreamState{Msgs: 5000}},
+			{Config: api.StreamConfig{Name: "S1", Storage: api.MemoryStorage, Replicas: 1}, State: api.StreamState{Msgs: 10}},
+		} {
+			result := setupJetstreamCheck(t, "JETSTREAM_008", map[string]any{"N1": nfo})
+			if result != Pass {
+				t.Errorf("expected result %v, got %v", Pass, result)
+			}
+		}
+	})
+}
+
+func TestJETSTREAM_009(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Should fail for errored mirrors", func(t *testing.T) {
+		result := setupJetstreamCheck(t, "JETSTREAM_009", map[string]any{
+			"N1": &api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, Mirror: &api.StreamSourceInfo{Name: "ORIGIN", Active: time.Second, Error: &api.ApiError{Code: 500, Description: "stream not found"}}},
+		})
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should fail for inactive sources", func(t *testing.T) {
+		result := setupJetstreamCheck(t, "JETSTREAM_009", map[string]any{
+			"N1": &api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, Sources: []*api.StreamSourceInfo{{Name: "ORIGIN", Active: time.Hour}}},
+		})
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should fail when lag grows between samples", func(t *testing.T) {
+		result, _ := runArchiveCheck(t, RegisterJetStreamChecks, "JETSTREAM_009", func(w *archive.Writer) error {
+			for i, lag := range []uint64{10, 100} {
+				nfo := &api.StreamInfo{Config: api.StreamConfig{Name: "S1", Replicas: 3}, Cluster: &api.ClusterInfo{Leader: "N1"}, TimeStamp: now.Add(time.Duration(i) * time.Second), Sources: []*api.StreamSourceInfo{{Name: "ORIGIN", Active: time.Second, Lag: lag}}}
+				err := w.Add(nfo, archive.TagAccount("A"), archive.TagStream("S1"), archive.TagServer("N1"), archive.TagCluster("C1"), archive.TagStreamInfo())
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should pass when replicas report a higher lag than the leader", func(t *testing.T) {
+		result := setupJetstreamCheck(t, "JETSTREAM_009", map[string]any{
+			"N1": &api.StreamInfo{Config: api.StreamConfig{Name: "S1", Replicas: 2}, Cluster: &api.ClusterInfo{Leader: "N1"}, TimeStamp: now, Sources: []*api.StreamSourceInfo{{Name: "ORIGIN", Active: time.Second, Lag: 10}}},
+			"N2": &api.StreamInfo{Config: api.StreamConfig{Name: "S1", Replicas: 2}, Cluster: &api.ClusterInfo{Leader: "N1"}, TimeStamp: now.Add(time.Second), Sources: []*api.StreamSourceInfo{{Name: "ORIGIN", Active: time.Hour, Lag: 100}}},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+
+	t.Run("Should pass for healthy mirrors and sources", func(t *testing.T) {
+		result := setupJetstreamCheck(t, "JETSTREAM_009", map[string]any{
+			"N1": &api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, TimeStamp: now, Sources: []*api.StreamSourceInfo{{Name: "ORIGIN", Active: time.Second, Lag: 100}}},
+			"N2": &api.StreamInfo{Config: api.StreamConfig{Name: "S1"}, TimeStamp: now.Add(time.Second), Sources: []*api.StreamSourceInfo{{Name: "ORIGIN", Active: time.Second, Lag: 10}}},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+}
+
+func TestJETSTREAM_010(t *testing.T) {
+	t.Run("Should warn for even sized streams and consumers", func(t *testing.T) {
+		for _, nfo := range []any{
+			&api.StreamInfo{Config: api.StreamConfig{Name: "S1", Replicas: 2}},
+			&streamWithConsumers{
+				StreamInfo:     api.StreamInfo{Config: api.StreamConfig{Name: "S1", Replicas: 3}},
+				ConsumerDetail: []api.ConsumerInfo{{Name: "C1", Stream: "S1", Config: api.ConsumerConfig{Replicas: 4}}},
+			},
+		} {
+			result := setupJetstreamCheck(t, "JETSTREAM_010", map[string]any{"N1": nfo})
+			if result != PassWithIssues {
+				t.Errorf("expected result %v, got %v", PassWithIssues, result)
+			}
+		}
+	})
+
+	t.Run("Should warn for even sized meta clusters", func(t *testing.T) {
+		result, _ := runArchiveCheck(t, RegisterJetStreamChecks, "JETSTREAM_010", func(w *archive.Writer) error {
+			for _, name := range []string{"N1", "N2", "N3", "N4"} {
+				jsz := &server.ServerAPIJszResponse{Data: &server.JSInfo{Meta: &server.MetaClusterInfo{Name: "C1", Size: 4}}}
+				err := w.Add(jsz, archive.TagCluster("C1"), archive.TagServer(name), archive.TagServerJetStream())
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if result != PassWithIssues {
+			t.Errorf("expected result %v, got %v", PassWithIssues, result)
+		}
+	})
+
+	t.Run("Should pass for odd sized groups", func(t *testing.T) {
+		result := setupJetstreamCheck(t, "JETSTREAM_010", map[string]any{
+			"N1": &streamWithConsumers{
+				StreamInfo:     api.StreamInfo{Config: api.StreamConfig{Name: "S1", Replicas: 3}},
+				ConsumerDetail: []api.ConsumerInfo{{Name: "C1", Stream: "S1"}, {Name: "C2", Stream: "S1", Config: api.ConsumerConfig{Replicas: 1}}},
+			},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+}
@@ -14,6 +14,10 @@
 package audit
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/nats-io/jsm.go/api"
@@ -29,18 +33,32 @@ func RegisterLeafnodeChecks(collection *CheckCollection) error {
 			Suite:       "leaf",
 			Name:        "Whitespace in leafnode server names",
 			Description: "No Leafnode contains whitespace in its name",
+			Tags:        []string{"configuration"},
 			Handler:     checkLeafnodeServerNamesForWhitespace,
 		},
+		Check{
+			Code:        "LEAF_002",
+			Suite:       "leaf",
+			Name:        "Leafnode connectivity",
+			Description: "All configured leafnode remotes are connected and both sides agree on the connections",
+			Tags:        []string{"availability"},
+			Remediation: "Investigate the connectivity between the leafnode and its hub, repeated connections often indicate authentication or duplicate configuration problems",
+			Handler:     checkLeafnodeConnectivity,
+		},
 	)
 }
 
-func checkLeafnodeServerNamesForWhitespace(_ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkLeafnodeServerNamesForWhitespace(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	for _, clusterName := range r.ClusterNames() {
 		clusterTag := archive.TagCluster(clusterName)
 
 		leafnodesWithWhitespace := map[string]struct{}{}
 
 		for _, serverName := range r.ClusterServerNames(clusterName) {
+			if err := ctx.Err(); err != nil {
+				return Skipped, err
+			}
+
 			serverTag := archive.TagServer(serverName)
 
 			err := archive.ForEachTaggedArtifact(r, []*archive.Tag{clusterTag, serverTag, archive.TagServerLeafs()}, func(resp *server.ServerAPILeafzResponse) error {
@@ -77,3 +95,126 @@ func checkLeafnodeServerNamesForWhitespace(_ *Check, r *archive.Reader, examples
 
 	return Pass, nil
 }
+
+// checkLeafnodeConnectivity verifies that every server has as many connected leafnode remotes as it has configured, and that
+// when both ends of a leafnode connection are in the archive they agree the connection exists. Multiple connections between
+// the same pair of servers indicate a connection that is flapping
+func checkLeafnodeConnectivity(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	type link struct {
+		spoke string
+		hub   string
+	}
+
+	configured := make(map[string]map[string]int)
+	connected := make(map[string]map[string]int)
+	spokeView := make(map[link]int)
+	hubView := make(map[link]int)
+	servers := make(map[string]struct{})
+
+	_, err := r.EachClusterServerVarz(func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, vz *server.ServerAPIVarzResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if errors.Is(err, archive.ErrNoMatches) {
+			log.Warnf("Artifact 'VARZ' is missing for server %s", serverTag)
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to load variables for server %s: %w", serverTag, err)
+		}
+
+		for _, remote := range vz.Data.LeafNode.Remotes {
+			account := remote.LocalAccount
+			if account == "" {
+				account = server.DEFAULT_GLOBAL_ACCOUNT
+			}
+
+			if configured[serverTag.Value] == nil {
+				configured[serverTag.Value] = make(map[string]int)
+			}
+			configured[serverTag.Value][account]++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Skipped, err
+	}
+
+	_, err = r.EachClusterServerLeafz(func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, lz *server.ServerAPILeafzResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if errors.Is(err, archive.ErrNoMatches) {
+			log.Warnf("Artifact 'LEAFZ' is missing for server %s", serverTag)
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to load leafz for server %s: %w", serverTag, err)
+		}
+
+		if lz == nil || lz.Data == nil {
+			return nil
+		}
+
+		servers[serverTag.Value] = struct{}{}
+
+		for _, leaf := range lz.Data.Leafs {
+			if leaf.IsSpoke {
+				if connected[serverTag.Value] == nil {
+					connected[serverTag.Value] = make(map[string]int)
+				}
+				connected[serverTag.Value][leaf.Account]++
+				spokeView[link{spoke: serverTag.Value, hub: leaf.Name}]++
+			} else {
+				hubView[link{spoke: leaf.Name, hub: serverTag.Value}]++
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Skipped, err
+	}
+
+	var found []string
+
+	for serverName, accounts := range configured {
+		for account, count := range accounts {
+			if _, ok := servers[serverName]; !ok {
+				continue
+			}
+
+			if connected[serverName][account] < count {
+				found = append(found, fmt.Sprintf("%s has %d leafnode remotes configured for account %s but %d are connected", serverName, count, account, connected[serverName][account]))
+			}
+		}
+	}
+
+	for l, count := range spokeView {
+		if _, ok := servers[l.hub]; ok && hubView[l] == 0 {
+			found = append(found, fmt.Sprintf("%s reports a leafnode connection to %s that %s does not report", l.spoke, l.hub, l.hub))
+		}
+		if count > 1 {
+			found = append(found, fmt.Sprintf("%s has %d leafnode connections to %s, the connection may be flapping", l.spoke, count, l.hub))
+		}
+	}
+
+	for l := range hubView {
+		if _, ok := servers[l.spoke]; ok && spokeView[l] == 0 {
+			found = append(found, fmt.Sprintf("%s reports a leafnode connection from %s that %s does not report", l.hub, l.spoke, l.spoke))
+		}
+	}
+
+	sort.Strings(found)
+	for _, f := range found {
+		examples.Add("%s", f)
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d leafnode connectivity problems", examples.Count())
+		return Fail, nil
+	}
+
+	return Pass, nil
+}
@@ -1,6 +1,7 @@
 package audit
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 
@@ -51,7 +52,7 @@ func setupLeafCheck(t *testing.T, checkid string, artifacts map[string]any, tag
 	}
 
 	examples := newExamplesCollection(0)
-	outcome, err := check.Handler(check, reader, examples, api.NewDefaultLogger(api.ErrorLevel))
+	outcome, err := check.Handler(context.Background(), check, reader, examples, api.NewDefaultLogger(api.ErrorLevel))
 	if err != nil {
 		t.Fatalf("check handler failed: %v", err)
 	}
@@ -90,3 +91,69 @@ func TestLEAF_001(t *testing.T) {
 		}
 	})
 }
+
+func TestLEAF_002(t *testing.T) {
+	type srv struct {
+		remotes []server.RemoteLeafOptsVarz
+		leafs   []*server.LeafInfo
+	}
+
+	setup := func(t *testing.T, servers map[string]srv) (Outcome, *ExamplesCollection) {
+		return runArchiveCheck(t, RegisterLeafnodeChecks, "LEAF_002", func(w *archive.Writer) error {
+			for name, s := range servers {
+				vz := &server.ServerAPIVarzResponse{Data: &server.Varz{Name: name, LeafNode: server.LeafNodeOptsVarz{Remotes: s.remotes}}}
+				err := w.Add(vz, archive.TagCluster("C1"), archive.TagServer(name), archive.TagServerVars())
+				if err != nil {
+					return err
+				}
+
+				lz := &server.ServerAPILeafzResponse{Data: &server.Leafz{Leafs: s.leafs}}
+				err = w.Add(lz, archive.TagCluster("C1"), archive.TagServer(name), archive.TagServerLeafs())
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	t.Run("Should pass when both sides agree", func(t *testing.T) {
+		result, examples := setup(t, map[string]srv{
+			"SPOKE": {remotes: []server.RemoteLeafOptsVarz{{}}, leafs: []*server.LeafInfo{{Name: "HUB", IsSpoke: true, Account: "$G"}}},
+			"HUB":   {leafs: []*server.LeafInfo{{Name: "SPOKE", Account: "A"}}},
+		})
+		if result != Pass {
+			t.Errorf("expected result %v, got %v: %v", Pass, result, examples.Examples)
+		}
+	})
+
+	t.Run("Should fail when a remote is not connected", func(t *testing.T) {
+		result, _ := setup(t, map[string]srv{
+			"SPOKE": {remotes: []server.RemoteLeafOptsVarz{{}, {LocalAccount: "B"}}, leafs: []*server.LeafInfo{{Name: "HUB", IsSpoke: true, Account: "$G"}}},
+			"HUB":   {leafs: []*server.LeafInfo{{Name: "SPOKE", Account: "A"}}},
+		})
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should fail when the views are one sided", func(t *testing.T) {
+		result, _ := setup(t, map[string]srv{
+			"SPOKE": {remotes: []server.RemoteLeafOptsVarz{{}}, leafs: []*server.LeafInfo{{Name: "HUB", IsSpoke: true, Account: "$G"}}},
+			"HUB":   {},
+		})
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should fail for duplicate connections", func(t *testing.T) {
+		result, _ := setup(t, map[string]srv{
+			"SPOKE": {remotes: []server.RemoteLeafOptsVarz{{}}, leafs: []*server.LeafInfo{{Name: "HUB", IsSpoke: true, Account: "$G"}, {Name: "HUB", IsSpoke: true, Account: "$G"}}},
+		})
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+}
@@ -14,7 +14,11 @@
 package audit
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/jsm.go/audit/archive"
@@ -28,6 +32,8 @@ func RegisterMetaChecks(collection *CheckCollection) error {
 			Suite:       "meta",
 			Name:        "Meta cluster offline replicas",
 			Description: "All nodes part of the meta group are online",
+			Tags:        []string{"availability"},
+			Severity:    SeverityCritical,
 			Handler:     checkMetaClusterOfflineReplicas,
 		},
 		Check{
@@ -35,13 +41,37 @@ func RegisterMetaChecks(collection *CheckCollection) error {
 			Suite:       "meta",
 			Name:        "Meta cluster leader",
 			Description: "All nodes part of the meta group agree on the meta cluster leader",
+			Tags:        []string{"availability"},
+			Severity:    SeverityCritical,
 			Handler:     checkMetaClusterLeader,
 		},
+		Check{
+			Code:        "META_003",
+			Suite:       "meta",
+			Name:        "Cluster server version skew",
+			Description: "All nodes in a cluster run compatible server versions",
+			Tags:        []string{"configuration"},
+			Configuration: map[string]*CheckConfiguration{
+				"minor": {
+					Key:         "minor",
+					Description: "Allowed difference in minor versions within a cluster before warning",
+					Default:     0,
+					Unit:        UIntUnit,
+				},
+				"major": {
+					Key:         "major",
+					Description: "Allowed difference in major versions within a cluster before failing",
+					Default:     0,
+					Unit:        UIntUnit,
+				},
+			},
+			Handler: checkClusterVersionSkew,
+		},
 	)
 }
 
 // checkMetaClusterLeader verify that all server agree on the same meta group leader in each known cluster
-func checkMetaClusterLeader(_ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkMetaClusterLeader(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	jsTag := archive.TagServerJetStream()
 
 	for _, clusterName := range r.ClusterNames() {
@@ -49,6 +79,10 @@ func checkMetaClusterLeader(_ *Check, r *archive.Reader, examples *ExamplesColle
 		leaderFollowers := make(map[string][]string)
 
 		for _, serverName := range r.ClusterServerNames(clusterName) {
+			if err := ctx.Err(); err != nil {
+				return Skipped, err
+			}
+
 			serverTag := archive.TagServer(serverName)
 
 			err := archive.ForEachTaggedArtifact(r, []*archive.Tag{clusterTag, serverTag, jsTag}, func(resp *server.ServerAPIJszResponse) error {
@@ -91,13 +125,17 @@ func checkMetaClusterLeader(_ *Check, r *archive.Reader, examples *ExamplesColle
 }
 
 // checkMetaClusterOfflineReplicas verify that all meta-cluster replicas are online for each known cluster
-func checkMetaClusterOfflineReplicas(_ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkMetaClusterOfflineReplicas(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	jszTag := archive.TagServerJetStream()
 
 	for _, clusterName := range r.ClusterNames() {
 		clusterTag := archive.TagCluster(clusterName)
 
 		for _, serverName := range r.ClusterServerNames(clusterName) {
+			if err := ctx.Err(); err != nil {
+				return Skipped, err
+			}
+
 			serverTag := archive.TagServer(serverName)
 
 			err := archive.ForEachTaggedArtifact(r, []*archive.Tag{clusterTag, serverTag, jszTag}, func(resp *server.ServerAPIJszResponse) error {
@@ -132,3 +170,108 @@ func checkMetaClusterOfflineReplicas(_ *Check, r *archive.Reader, examples *Exam
 
 	return Pass, nil
 }
+
+// parseServerVersion parses the major and minor components of a server version like v2.10.1-beta
+func parseServerVersion(version string) (major int, minor int, err error) {
+	v := strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("invalid version %q", version)
+	}
+
+	major, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid version %q", version)
+	}
+
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid version %q", version)
+	}
+
+	return major, minor, nil
+}
+
+// checkClusterVersionSkew verify that the servers in each known cluster run versions within the allowed skew, mixed minor
+// versions are a warning while mixed major versions fail
+func checkClusterVersionSkew(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	allowedMinor := int(check.Configuration["minor"].Value())
+	allowedMajor := int(check.Configuration["major"].Value())
+
+	type serverVersion struct {
+		name  string
+		major int
+		minor int
+	}
+
+	versions := make(map[string][]serverVersion)
+
+	_, err := r.EachClusterServerVarz(func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, vz *server.ServerAPIVarzResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if errors.Is(err, archive.ErrNoMatches) {
+			log.Warnf("Artifact 'VARZ' is missing for server %s", serverTag)
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to load variables for server %s: %w", serverTag, err)
+		}
+
+		major, minor, err := parseServerVersion(vz.Data.Version)
+		if err != nil {
+			log.Warnf("Could not parse version of server %s: %v", serverTag, err)
+			return nil
+		}
+
+		versions[clusterTag.Value] = append(versions[clusterTag.Value], serverVersion{serverTag.Value, major, minor})
+
+		return nil
+	})
+	if err != nil {
+		return Skipped, err
+	}
+
+	var majorSkew bool
+
+	for _, clusterName := range r.ClusterNames() {
+		servers := versions[clusterName]
+		if len(servers) < 2 {
+			continue
+		}
+
+		lowest, highest := servers[0], servers[0]
+		for _, srv := range servers[1:] {
+			if srv.major < lowest.major || (srv.major == lowest.major && srv.minor < lowest.minor) {
+				lowest = srv
+			}
+			if srv.major > highest.major || (srv.major == highest.major && srv.minor > highest.minor) {
+				highest = srv
+			}
+		}
+
+		switch {
+		case highest.major-lowest.major > allowedMajor:
+			majorSkew = true
+			examples.Add("Cluster %s mixes major versions: %s runs %d.%d while %s runs %d.%d", clusterName, lowest.name, lowest.major, lowest.minor, highest.name, highest.major, highest.minor)
+		case highest.major == lowest.major && highest.minor-lowest.minor > allowedMinor:
+			examples.Add("Cluster %s mixes minor versions: %s runs %d.%d while %s runs %d.%d", clusterName, lowest.name, lowest.major, lowest.minor, highest.name, highest.major, highest.minor)
+		}
+	}
+
+	if majorSkew {
+		log.Errorf("Found %d clusters with mixed server versions", examples.Count())
+		return Fail, nil
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d clusters with mixed minor server versions", examples.Count())
+		return PassWithIssues, nil
+	}
+
+	return Pass, nil
+}
@@ -1,6 +1,7 @@
 package audit
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 
@@ -51,7 +52,7 @@ func setupMetaCheck(t *testing.T, checkid string, artifacts map[string]any) Outc
 	}
 
 	examples := newExamplesCollection(0)
-	result, err := check.Handler(check, reader, examples, api.NewDefaultLogger(api.ErrorLevel))
+	result, err := check.Handler(context.Background(), check, reader, examples, api.NewDefaultLogger(api.ErrorLevel))
 	if err != nil {
 		t.Fatalf("check handler failed: %v", err)
 	}
@@ -132,3 +133,58 @@ func TestMETA_002(t *testing.T) {
 		}
 	})
 }
+
+func TestMETA_003(t *testing.T) {
+	setup := func(t *testing.T, versions map[string]string, minor float64, major float64) Outcome {
+		result, _ := runArchiveCheck(t, func(cc *CheckCollection) error {
+			err := RegisterMetaChecks(cc)
+			if err != nil {
+				return err
+			}
+
+			cc.registered["Cluster server version skew"].Configuration["minor"].SetValue = &minor
+			cc.registered["Cluster server version skew"].Configuration["major"].SetValue = &major
+
+			return nil
+		}, "META_003", func(w *archive.Writer) error {
+			for serverName, version := range versions {
+				err := w.Add(&server.ServerAPIVarzResponse{Data: &server.Varz{Name: serverName, Version: version}}, archive.TagCluster("C1"), archive.TagServer(serverName), archive.TagServerVars())
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+		return result
+	}
+
+	t.Run("Should pass when versions match", func(t *testing.T) {
+		result := setup(t, map[string]string{"N1": "2.10.1", "N2": "2.10.4", "N3": "v2.10.0-beta"}, 0, 0)
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+
+	t.Run("Should warn on mixed minor versions", func(t *testing.T) {
+		result := setup(t, map[string]string{"N1": "2.10.1", "N2": "2.11.0"}, 0, 0)
+		if result != PassWithIssues {
+			t.Errorf("expected result %v, got %v", PassWithIssues, result)
+		}
+	})
+
+	t.Run("Should allow configured minor skew", func(t *testing.T) {
+		result := setup(t, map[string]string{"N1": "2.10.1", "N2": "2.11.0"}, 1, 0)
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+
+	t.Run("Should fail on mixed major versions", func(t *testing.T) {
+		result := setup(t, map[string]string{"N1": "2.10.1", "N2": "3.0.0"}, 0, 0)
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+}
@@ -0,0 +1,310 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/audit/archive"
+)
+
+// External checks are implemented by executables named with the PluginPrefix. Each invocation of a plugin
+// receives a single PluginRequest as JSON on stdin and must write a single JSON response to stdout:
+//
+//   - for the "describe" action a PluginDescription listing the checks the plugin implements
+//   - for the "run" action a PluginResponse holding the outcome of the requested check
+//
+// The plugin is expected to open the archive found at PluginRequest.Archive using archive.NewReader(), encrypted
+// archives are not supported as the key is not shared with plugins so their checks are skipped
+
+const (
+	// PluginPrefix is the file name prefix of external check executables
+	PluginPrefix = "audit-check-"
+
+	// PluginActionDescribe requests the plugin to describe the checks it implements
+	PluginActionDescribe = "describe"
+	// PluginActionRun requests the plugin to run a check against an archive
+	PluginActionRun = "run"
+
+	// PluginRequestType is the type of PluginRequest
+	PluginRequestType = "io.nats.audit.v1.plugin_request"
+	// PluginDescriptionType is the type of PluginDescription
+	PluginDescriptionType = "io.nats.audit.v1.plugin_description"
+	// PluginResponseType is the type of PluginResponse
+	PluginResponseType = "io.nats.audit.v1.plugin_response"
+
+	// pluginDescribeTimeout is how long a plugin has to describe its checks
+	pluginDescribeTimeout = 10 * time.Second
+)
+
+// PluginRequest is sent to a plugin on stdin
+type PluginRequest struct {
+	Type   string `json:"type"`
+	Action string `json:"action"`
+	// Check is the code of the check to run
+	Check string `json:"check,omitempty"`
+	// Archive is the path to the archive to analyze
+	Archive string `json:"archive,omitempty"`
+	// Configuration holds the current value of each configuration item of the check
+	Configuration map[string]float64 `json:"configuration,omitempty"`
+	// Limit is the number of examples that will be reported, 0 for unlimited
+	Limit uint `json:"limit,omitempty"`
+}
+
+// PluginDescription is the response of a plugin to the describe action
+type PluginDescription struct {
+	Type   string        `json:"type"`
+	Checks []PluginCheck `json:"checks"`
+}
+
+// PluginCheck describes a check implemented by a plugin
+type PluginCheck struct {
+	Code          string                `json:"code"`
+	Suite         string                `json:"suite"`
+	Name          string                `json:"name"`
+	Description   string                `json:"description"`
+	Severity      Severity              `json:"severity,omitempty"`
+	Remediation   string                `json:"remediation,omitempty"`
+	URL           string                `json:"url,omitempty"`
+	Tags          []string              `json:"tags,omitempty"`
+	Configuration []*CheckConfiguration `json:"configuration,omitempty"`
+}
+
+// PluginResponse is the response of a plugin to the run action
+type PluginResponse struct {
+	Type string `json:"type"`
+	// Outcome is one of PASS, WARN, FAIL or SKIP
+	Outcome  string          `json:"outcome"`
+	Examples []string        `json:"examples,omitempty"`
+	Details  []ExampleDetail `json:"details,omitempty"`
+	// Error reports why the check could not be run, the check is reported as skipped
+	Error string `json:"error,omitempty"`
+}
+
+// FindPlugins finds executables named with PluginPrefix in dirs, when no dirs are given the directories in PATH
+// are searched. When the same plugin is found in multiple directories the first one is used
+func FindPlugins(dirs ...string) ([]string, error) {
+	if len(dirs) == 0 {
+		dirs = filepath.SplitList(os.Getenv("PATH"))
+	}
+
+	seen := map[string]struct{}{}
+	var found []string
+
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("could not search %s for plugins: %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			name := entry.Name()
+			if !strings.HasPrefix(name, PluginPrefix) || entry.IsDir() {
+				continue
+			}
+
+			if _, ok := seen[name]; ok {
+				continue
+			}
+
+			path := filepath.Join(dir, name)
+			nfo, err := os.Stat(path)
+			if err != nil || nfo.IsDir() || nfo.Mode()&0111 == 0 {
+				continue
+			}
+
+			seen[name] = struct{}{}
+			found = append(found, path)
+		}
+	}
+
+	sort.Strings(found)
+
+	return found, nil
+}
+
+// LoadPlugin asks the plugin at path to describe its checks and creates checks that invoke the plugin when run
+func LoadPlugin(path string) ([]Check, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), pluginDescribeTimeout)
+	defer cancel()
+
+	var desc PluginDescription
+	err := invokePlugin(ctx, path, &PluginRequest{Type: PluginRequestType, Action: PluginActionDescribe}, &desc)
+	if err != nil {
+		return nil, err
+	}
+
+	if desc.Type != PluginDescriptionType {
+		return nil, fmt.Errorf("plugin %s returned an invalid description type %q", path, desc.Type)
+	}
+
+	if len(desc.Checks) == 0 {
+		return nil, fmt.Errorf("plugin %s does not implement any checks", path)
+	}
+
+	var checks []Check
+	for _, pc := range desc.Checks {
+		check := Check{
+			Code:          pc.Code,
+			Suite:         pc.Suite,
+			Name:          pc.Name,
+			Description:   pc.Description,
+			Severity:      pc.Severity,
+			Remediation:   pc.Remediation,
+			URL:           pc.URL,
+			Tags:          pc.Tags,
+			Configuration: make(map[string]*CheckConfiguration),
+			Handler:       pluginCheckHandler(path),
+		}
+
+		for _, cfg := range pc.Configuration {
+			if cfg == nil {
+				continue
+			}
+			cfg.SetValue = nil
+			check.Configuration[cfg.Key] = cfg
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+// RegisterPlugins finds plugins in dirs, see FindPlugins(), and registers their checks
+func (c *CheckCollection) RegisterPlugins(dirs ...string) error {
+	plugins, err := FindPlugins(dirs...)
+	if err != nil {
+		return err
+	}
+
+	for _, plugin := range plugins {
+		checks, err := LoadPlugin(plugin)
+		if err != nil {
+			return err
+		}
+
+		err = c.Register(checks...)
+		if err != nil {
+			return fmt.Errorf("could not register checks from plugin %s: %w", plugin, err)
+		}
+	}
+
+	return nil
+}
+
+// pluginCheckHandler creates a CheckFunc that runs a check implemented by the plugin at path
+func pluginCheckHandler(path string) CheckFunc {
+	return func(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, _ api.Logger) (Outcome, error) {
+		if r.Path() == "" {
+			return Skipped, fmt.Errorf("archive path is unknown")
+		}
+		if r.Encrypted() {
+			return Skipped, fmt.Errorf("plugin checks do not support encrypted archives")
+		}
+
+		req := &PluginRequest{
+			Type:          PluginRequestType,
+			Action:        PluginActionRun,
+			Check:         check.Code,
+			Archive:       r.Path(),
+			Configuration: make(map[string]float64, len(check.Configuration)),
+			Limit:         examples.Limit,
+		}
+		for k, cfg := range check.Configuration {
+			req.Configuration[k] = cfg.Value()
+		}
+
+		var res PluginResponse
+		err := invokePlugin(ctx, path, req, &res)
+		if err != nil {
+			return Skipped, err
+		}
+
+		if res.Type != PluginResponseType {
+			return Skipped, fmt.Errorf("plugin %s returned an invalid response type %q", path, res.Type)
+		}
+
+		if res.Error != "" {
+			return Skipped, fmt.Errorf("%s", res.Error)
+		}
+
+		outcome, err := ParseOutcome(res.Outcome)
+		if err != nil {
+			return Skipped, fmt.Errorf("plugin %s returned an invalid outcome: %w", path, err)
+		}
+
+		// details are examples with context, so examples repeating their messages are not added twice
+		detailed := make(map[string]struct{}, len(res.Details))
+		for _, d := range res.Details {
+			examples.AddWithContext(d.ExampleContext, "%s", d.Message)
+			detailed[d.Message] = struct{}{}
+		}
+		for _, e := range res.Examples {
+			if _, ok := detailed[e]; !ok {
+				examples.Add("%s", e)
+			}
+		}
+
+		return outcome, nil
+	}
+}
+
+// invokePlugin runs the plugin at path sending req on stdin and decoding stdout into res
+func invokePlugin(ctx context.Context, path string, req *PluginRequest, res any) error {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("plugin %s failed: %w: %s", path, err, msg)
+		}
+		return fmt.Errorf("plugin %s failed: %w", path, err)
+	}
+
+	err = json.Unmarshal(stdout.Bytes(), res)
+	if err != nil {
+		return fmt.Errorf("plugin %s returned invalid JSON: %w", path, err)
+	}
+
+	return nil
+}
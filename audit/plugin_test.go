@@ -0,0 +1,182 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/audit/archive"
+)
+
+// TestPluginHelperProcess is not a real test, it acts as a plugin when invoked by the script created in writeTestPlugin
+func TestPluginHelperProcess(t *testing.T) {
+	if os.Getenv("AUDIT_PLUGIN_HELPER") != "1" {
+		t.Skip("helper process")
+	}
+
+	var req PluginRequest
+	err := json.NewDecoder(os.Stdin).Decode(&req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid request: %v", err)
+		os.Exit(1)
+	}
+
+	var res any
+	switch req.Action {
+	case PluginActionDescribe:
+		res = PluginDescription{
+			Type: PluginDescriptionType,
+			Checks: []PluginCheck{{
+				Code:        "EXT_001",
+				Suite:       "external",
+				Name:        "External Check",
+				Description: "External check",
+				Severity:    SeverityCritical,
+				Tags:        []string{"configuration"},
+				Configuration: []*CheckConfiguration{
+					{Key: "limit", Description: "Example limit", Default: 10, Unit: UIntUnit},
+				},
+			}},
+		}
+
+	case PluginActionRun:
+		_, err := archive.NewReader(req.Archive)
+		if err != nil {
+			res = PluginResponse{Type: PluginResponseType, Error: err.Error()}
+			break
+		}
+
+		res = PluginResponse{
+			Type:     PluginResponseType,
+			Outcome:  "FAIL",
+			Examples: []string{fmt.Sprintf("limit is %v", req.Configuration["limit"])},
+			Details:  []ExampleDetail{{ExampleContext: ExampleContext{Server: "n1"}, Message: "server n1 is misconfigured"}},
+		}
+	}
+
+	json.NewEncoder(os.Stdout).Encode(res)
+	os.Exit(0)
+}
+
+func writeTestPlugin(t *testing.T, dir string, name string) {
+	t.Helper()
+
+	script := fmt.Sprintf("#!/bin/sh\nAUDIT_PLUGIN_HELPER=1 exec %q -test.run='^TestPluginHelperProcess$'\n", os.Args[0])
+	err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755)
+	if err != nil {
+		t.Fatalf("could not write plugin: %v", err)
+	}
+}
+
+func TestFindPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins tests require a shell")
+	}
+
+	first := t.TempDir()
+	second := t.TempDir()
+
+	writeTestPlugin(t, first, PluginPrefix+"a")
+	writeTestPlugin(t, second, PluginPrefix+"a")
+	writeTestPlugin(t, second, PluginPrefix+"b")
+	writeTestPlugin(t, second, "other")
+	err := os.WriteFile(filepath.Join(second, PluginPrefix+"c"), []byte("not executable"), 0644)
+	if err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	found, err := FindPlugins(first, second, filepath.Join(first, "missing"))
+	if err != nil {
+		t.Fatalf("find failed: %v", err)
+	}
+
+	expected := []string{filepath.Join(first, PluginPrefix+"a"), filepath.Join(second, PluginPrefix+"b")}
+	if fmt.Sprint(found) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v, got %v", expected, found)
+	}
+}
+
+func TestCheckCollection_RegisterPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins tests require a shell")
+	}
+
+	dir := t.TempDir()
+	writeTestPlugin(t, dir, PluginPrefix+"test")
+
+	cc := &CheckCollection{}
+	err := cc.RegisterPlugins(dir)
+	if err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+
+	check, ok := cc.registered["External Check"]
+	if !ok {
+		t.Fatalf("plugin check was not registered")
+	}
+	if check.Severity != SeverityCritical || !check.HasTag("configuration") {
+		t.Fatalf("unexpected check %+v", check)
+	}
+
+	cfg := cc.ConfigurationItems()
+	if len(cfg) != 1 || cfg[0].Check != "EXT_001" {
+		t.Fatalf("unexpected configuration %+v", cfg)
+	}
+	err = cfg[0].Set("5")
+	if err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	analysis := cc.Run(emptyArchiveReader(t), 0, api.NewDefaultLogger(api.ErrorLevel))
+	res := analysis.Results[0]
+	if res.Outcome != Fail || analysis.Verdict != Fail {
+		t.Fatalf("expected plugin check to fail, got %v: %v", res.Outcome, res.Examples.Error)
+	}
+
+	if fmt.Sprint(res.Examples.Examples) != "[server n1 is misconfigured limit is 5]" {
+		t.Fatalf("unexpected examples %v", res.Examples.Examples)
+	}
+	if len(res.Examples.Details) != 1 || res.Examples.Details[0].Server != "n1" {
+		t.Fatalf("unexpected details %+v", res.Examples.Details)
+	}
+
+	t.Run("Should skip encrypted archives", func(t *testing.T) {
+		key, err := archive.GenerateEncryptionKey()
+		if err != nil {
+			t.Fatalf("key failed: %v", err)
+		}
+
+		archivePath := filepath.Join(t.TempDir(), "audit.zip")
+		writer, err := archive.NewWriter(archivePath, archive.EncryptionKey(key))
+		if err != nil {
+			t.Fatalf("failed to create archive writer: %v", err)
+		}
+		err = writer.Close()
+		if err != nil {
+			t.Fatalf("failed to close archive: %v", err)
+		}
+
+		reader, err := archive.NewReader(archivePath, archive.EncryptionKey(key))
+		if err != nil {
+			t.Fatalf("failed to open archive: %v", err)
+		}
+		defer reader.Close()
+
+		res := cc.Run(reader, 0, api.NewDefaultLogger(api.ErrorLevel)).Results[0]
+		if res.Outcome != Skipped || !strings.Contains(res.Examples.Error, "encrypted archives") {
+			t.Fatalf("expected the plugin check to be skipped, got %v: %v", res.Outcome, res.Examples.Error)
+		}
+	})
+
+	t.Run("Should reject duplicate checks", func(t *testing.T) {
+		err := cc.RegisterPlugins(dir)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package report renders audit analyses into standalone reports that can be shared without additional tooling
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nats-io/jsm.go/audit"
+)
+
+// Option configures the report
+type Option func(o *options) error
+
+type options struct {
+	title        string
+	exampleLimit uint
+}
+
+// Title sets the report title, defaults to "NATS Audit Report"
+func Title(title string) Option {
+	return func(o *options) error {
+		if title == "" {
+			return fmt.Errorf("title is required")
+		}
+
+		o.title = title
+		return nil
+	}
+}
+
+// ExampleLimit limits the number of examples shown for each check, 0 shows all examples
+func ExampleLimit(limit uint) Option {
+	return func(o *options) error {
+		o.exampleLimit = limit
+		return nil
+	}
+}
+
+type suite struct {
+	Name    string
+	Results []audit.CheckResult
+}
+
+type reportData struct {
+	Title    string
+	Analysis *audit.Analysis
+	Suites   []suite
+	Outcomes []audit.Outcome
+}
+
+// HTML renders the analysis as a standalone HTML document with a section per check, collapsible examples and
+// outcomes colored by severity
+func HTML(analysis *audit.Analysis, opts ...Option) ([]byte, error) {
+	if analysis == nil {
+		return nil, fmt.Errorf("analysis is required")
+	}
+
+	o := &options{title: "NATS Audit Report"}
+	for _, opt := range opts {
+		err := opt(o)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	suites := map[string][]audit.CheckResult{}
+	for _, res := range analysis.Results {
+		suites[res.Check.Suite] = append(suites[res.Check.Suite], res)
+	}
+
+	names := make([]string, 0, len(suites))
+	for name := range suites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	data := reportData{
+		Title:    o.title,
+		Analysis: analysis,
+		Outcomes: []audit.Outcome{audit.Fail, audit.PassWithIssues, audit.Pass, audit.Skipped},
+	}
+
+	for _, name := range names {
+		data.Suites = append(data.Suites, suite{Name: name, Results: suites[name]})
+	}
+
+	t, err := template.New("report.html").Funcs(template.FuncMap{
+		"ft": func(t time.Time) string {
+			if t.IsZero() {
+				return "unknown"
+			}
+			return t.Format(time.RFC822Z)
+		},
+		"outcomeClass": func(o audit.Outcome) string {
+			return strings.ToLower(o.String())
+		},
+		"severityClass": func(s audit.Severity) string {
+			if s == "" {
+				return string(audit.SeverityWarning)
+			}
+			return string(s)
+		},
+		"count": func(o audit.Outcome) int {
+			return analysis.Outcomes[o.String()]
+		},
+		"limitExamples": func(examples []string) []string {
+			if o.exampleLimit == 0 || uint(len(examples)) <= o.exampleLimit {
+				return examples
+			}
+			return examples[:o.exampleLimit]
+		},
+		"omitted": func(examples []string) int {
+			if o.exampleLimit == 0 || uint(len(examples)) <= o.exampleLimit {
+				return 0
+			}
+			return len(examples) - int(o.exampleLimit)
+		},
+	}).Parse(htmlTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &bytes.Buffer{}
+	err = t.Execute(out, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 1100px; color: #222; }
+h1 { margin-bottom: 0.2em; }
+table { border-collapse: collapse; }
+td, th { padding: 0.3em 0.8em; border: 1px solid #ddd; text-align: left; }
+.meta td:first-child { font-weight: bold; }
+.check { border: 1px solid #ddd; border-left-width: 6px; border-radius: 4px; margin: 0.8em 0; padding: 0.5em 1em; }
+.check h4 { margin: 0.2em 0; }
+.check p { margin: 0.3em 0; color: #555; }
+.badge { display: inline-block; padding: 0.1em 0.6em; border-radius: 3px; color: #fff; font-size: 0.85em; font-weight: bold; }
+.badge.fail { background: #c62828; }
+.badge.warn { background: #ef8f00; }
+.badge.pass { background: #2e7d32; }
+.badge.skip { background: #757575; }
+.badge.severity { background: #fff; color: #222; border: 1px solid #999; }
+.check.fail.critical { border-left-color: #b71c1c; background: #fdecea; }
+.check.fail.warning, .check.warn.critical { border-left-color: #e65100; background: #fff4e5; }
+.check.fail.info, .check.warn.warning, .check.warn.info { border-left-color: #f9a825; background: #fffde7; }
+.check.pass { border-left-color: #2e7d32; }
+.check.skip { border-left-color: #9e9e9e; }
+.error { color: #c62828; }
+.check p.remediation { color: #222; }
+details ul { margin: 0.3em 0; }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<p>Produced {{ .Analysis.Timestamp | ft }}</p>
+
+<h2>Archive Details</h2>
+<table class="meta">
+<tr><td>Connect URL</td><td>{{ .Analysis.Metadata.ConnectURL }}</td></tr>
+<tr><td>User</td><td>{{ .Analysis.Metadata.UserName }}</td></tr>
+<tr><td>Connected Server</td><td>{{ .Analysis.Metadata.ConnectedServerName }} {{ .Analysis.Metadata.ConnectedServerVersion }}</td></tr>
+<tr><td>Captured</td><td>{{ .Analysis.Metadata.Timestamp | ft }}</td></tr>
+{{- if .Analysis.Metadata.CLIVersion }}
+<tr><td>CLI Version</td><td>{{ .Analysis.Metadata.CLIVersion }}</td></tr>
+{{- end }}
+</table>
+
+<h2>Summary</h2>
+{{- if .Analysis.VerdictString }}
+<p>Verdict: <span class="badge {{ .Analysis.Verdict | outcomeClass }}">{{ .Analysis.VerdictString }}</span></p>
+{{- end }}
+<table>
+<tr><th>Status</th><th>Count</th></tr>
+{{- range .Outcomes }}
+<tr><td><span class="badge {{ . | outcomeClass }}">{{ . }}</span></td><td>{{ . | count }}</td></tr>
+{{- end }}
+</table>
+
+<h2>Results</h2>
+{{- range .Suites }}
+<h3>Check Suite: {{ .Name }}</h3>
+{{-   range .Results }}
+<div class="check {{ .Outcome | outcomeClass }} {{ .Severity | severityClass }}" id="{{ .Check.Code }}">
+<h4><span class="badge {{ .Outcome | outcomeClass }}">{{ .OutcomeString }}</span> {{ .Check.Name }} <small>({{ .Check.Code }})</small> <span class="badge severity">{{ .Severity | severityClass }}</span></h4>
+<p>{{ .Check.Description }}</p>
+{{-     if .Check.Remediation }}
+<p class="remediation">Remediation: {{ .Check.Remediation }}{{ if .Check.URL }} <a href="{{ .Check.URL }}">More information</a>{{ end }}</p>
+{{-     end }}
+{{-     if .Examples.Error }}
+<p class="error">Error: {{ .Examples.Error }}</p>
+{{-     end }}
+{{-     if .Examples.Examples }}
+<details>
+<summary>{{ len .Examples.Examples }} examples</summary>
+<ul>
+{{-       range (.Examples.Examples | limitExamples) }}
+<li>{{ . }}</li>
+{{-       end }}
+{{-       with (.Examples.Examples | omitted) }}
+<li>... and {{ . }} more ...</li>
+{{-       end }}
+</ul>
+</details>
+{{-     end }}
+</div>
+{{-   end }}
+{{- end }}
+</body>
+</html>
+`
@@ -0,0 +1,74 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go/audit"
+	"github.com/nats-io/jsm.go/audit/archive"
+)
+
+func TestHTML(t *testing.T) {
+	analysis := &audit.Analysis{
+		Type:          "io.nats.audit.v1.analysis",
+		Timestamp:     time.Now(),
+		Metadata:      archive.AuditMetadata{ConnectURL: "nats://demo.nats.io:4222", UserName: "bob"},
+		Verdict:       audit.Fail,
+		VerdictString: audit.Fail.String(),
+		Outcomes:      map[string]int{"FAIL": 1, "PASS": 1, "WARN": 0, "SKIP": 0},
+		Results: []audit.CheckResult{
+			{
+				Check:         audit.Check{Code: "SERVER_001", Suite: "server", Name: "Server Health", Description: "All known nodes are healthy"},
+				Outcome:       audit.Fail,
+				OutcomeString: audit.Fail.String(),
+				Severity:      audit.SeverityCritical,
+				Examples:      audit.ExamplesCollection{Examples: []string{"n1 <unhealthy>", "n2", "n3"}},
+			},
+			{
+				Check:         audit.Check{Code: "CLUSTER_001", Suite: "cluster", Name: "Cluster Memory", Description: "Memory is uniform"},
+				Outcome:       audit.Pass,
+				OutcomeString: audit.Pass.String(),
+				Severity:      audit.SeverityWarning,
+			},
+		},
+	}
+
+	t.Run("Should require an analysis", func(t *testing.T) {
+		_, err := HTML(nil)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("Should render the report", func(t *testing.T) {
+		out, err := HTML(analysis, Title("Demo Report"), ExampleLimit(2))
+		if err != nil {
+			t.Fatalf("render failed: %v", err)
+		}
+
+		report := string(out)
+
+		for _, expected := range []string{
+			"<title>Demo Report</title>",
+			"nats://demo.nats.io:4222",
+			`class="check fail critical" id="SERVER_001"`,
+			"<summary>3 examples</summary>",
+			"n1 &lt;unhealthy&gt;",
+			"... and 1 more ...",
+			"Check Suite: cluster",
+		} {
+			if !strings.Contains(report, expected) {
+				t.Errorf("expected report to contain %q", expected)
+			}
+		}
+
+		if strings.Contains(report, "<li>n3</li>") {
+			t.Errorf("expected examples to be limited")
+		}
+
+		if strings.Index(report, "Check Suite: cluster") > strings.Index(report, "Check Suite: server") {
+			t.Errorf("expected suites to be sorted")
+		}
+	})
+}
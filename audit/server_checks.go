@@ -14,8 +14,11 @@
 package audit
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/dustin/go-humanize"
@@ -31,6 +34,8 @@ func RegisterServerChecks(collection *CheckCollection) error {
 			Suite:       "server",
 			Name:        "Server Health",
 			Description: "All known nodes are healthy",
+			Tags:        []string{"availability"},
+			Severity:    SeverityCritical,
 			Handler:     checkServerHealth,
 		},
 		Check{
@@ -38,6 +43,7 @@ func RegisterServerChecks(collection *CheckCollection) error {
 			Suite:       "server",
 			Name:        "Server Version",
 			Description: "All known nodes are running the same software version",
+			Tags:        []string{"configuration"},
 			Handler:     checkServerVersion,
 		},
 		Check{
@@ -45,6 +51,7 @@ func RegisterServerChecks(collection *CheckCollection) error {
 			Suite:       "server",
 			Name:        "Server CPU Usage",
 			Description: "CPU usage for all known nodes is below a given threshold",
+			Tags:        []string{"performance"},
 			Configuration: map[string]*CheckConfiguration{
 				"cpu": {
 					Key:         "cpu",
@@ -60,13 +67,23 @@ func RegisterServerChecks(collection *CheckCollection) error {
 			Suite:       "server",
 			Name:        "Server Slow Consumers",
 			Description: "No node is reporting slow consumers",
-			Handler:     checkSlowConsumers,
+			Tags:        []string{"performance"},
+			Configuration: map[string]*CheckConfiguration{
+				"slow_consumers": {
+					Key:         "slow_consumers",
+					Description: "Number of slow consumers a server or connection may report before alerting",
+					Default:     0,
+					Unit:        UIntUnit,
+				},
+			},
+			Handler: checkSlowConsumers,
 		},
 		Check{
 			Code:        "SERVER_005",
 			Suite:       "server",
 			Name:        "Server Resources Limits ",
 			Description: "Resource are below a given threshold compared to the configured limit",
+			Tags:        []string{"capacity"},
 			Configuration: map[string]*CheckConfiguration{
 				"memory": {
 					Key:         "memory",
@@ -88,6 +105,7 @@ func RegisterServerChecks(collection *CheckCollection) error {
 			Suite:       "server",
 			Name:        "Whitespace in JetStream domains",
 			Description: "No JetStream server is configured with whitespace in its domain",
+			Tags:        []string{"configuration"},
 			Handler:     checkJetStreamDomainsForWhitespace,
 		},
 		Check{
@@ -95,14 +113,54 @@ func RegisterServerChecks(collection *CheckCollection) error {
 			Suite:       "server",
 			Name:        "Authentication required",
 			Description: "Each server requires authentication",
+			Tags:        []string{"security"},
 			Handler:     checkServerAuthRequired,
 		},
+		Check{
+			Code:        "SERVER_008",
+			Suite:       "server",
+			Name:        "JetStream Store Headroom",
+			Description: "JetStream servers have free space left before reaching their maximum file store size",
+			Tags:        []string{"capacity"},
+			Remediation: "Increase the JetStream max_file_store, add storage or reduce the limits of streams on the server",
+			Severity:    SeverityCritical,
+			Configuration: map[string]*CheckConfiguration{
+				"free": {
+					Key:         "free",
+					Description: "Minimum free space as a percentage of the maximum file store size",
+					Default:     10,
+					Unit:        PercentageUnit,
+				},
+			},
+			Handler: checkJetStreamStoreHeadroom,
+		},
+		Check{
+			Code:        "SERVER_009",
+			Suite:       "server",
+			Name:        "Server Goroutines",
+			Description: "No server is running an extreme number of goroutines, requires captured goroutine profiles",
+			Tags:        []string{"performance"},
+			Remediation: "Inspect the goroutine profiles of the servers for stuck connections, subscriptions or routes",
+			Configuration: map[string]*CheckConfiguration{
+				"goroutines": {
+					Key:         "goroutines",
+					Description: "Number of goroutines a server may run before alerting",
+					Default:     100000,
+					Unit:        UIntUnit,
+				},
+			},
+			Handler: checkServerGoroutines,
+		},
 	)
 }
 
 // checkServerHealth verify all known servers are reporting healthy
-func checkServerHealth(_ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkServerHealth(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	total, err := r.EachClusterServerHealthz(func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, hz *server.ServerAPIHealthzResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		if errors.Is(err, archive.ErrNoMatches) {
 			log.Warnf("Artifact 'Healthz' is missing for server %s", serverTag)
 			return nil
@@ -131,11 +189,15 @@ func checkServerHealth(_ *Check, r *archive.Reader, examples *ExamplesCollection
 }
 
 // checkServerVersions verify all known servers are running the same version
-func checkServerVersion(_ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkServerVersion(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	seenVersions := make(map[string]struct{})
 	var lastVersionSeen string
 
 	_, err := r.EachClusterServerVarz(func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, vz *server.ServerAPIVarzResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		if errors.Is(err, archive.ErrNoMatches) {
 			log.Warnf("Artifact 'VARZ' is missing for server %s", serverTag)
 			return nil
@@ -173,10 +235,14 @@ func checkServerVersion(_ *Check, r *archive.Reader, examples *ExamplesCollectio
 }
 
 // checkServerCPUUsage verify CPU usage is below the given threshold for each server
-func checkServerCPUUsage(check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkServerCPUUsage(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	cpuThreshold := check.Configuration["cpu"].Value()
 
 	_, err := r.EachClusterServerVarz(func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, vz *server.ServerAPIVarzResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		if errors.Is(err, archive.ErrNoMatches) {
 			log.Warnf("Artifact 'VARZ' is missing for server %s", serverTag)
 			return nil
@@ -206,8 +272,14 @@ func checkServerCPUUsage(check *Check, r *archive.Reader, examples *ExamplesColl
 }
 
 // checkSlowConsumers verify that no server is reporting slow consumers
-func checkSlowConsumers(_ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkSlowConsumers(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	threshold := int64(check.Configuration["slow_consumers"].Value())
+
 	_, err := r.EachClusterServerVarz(func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, vz *server.ServerAPIVarzResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		if errors.Is(err, archive.ErrNoMatches) {
 			log.Warnf("Artifact 'VARZ' is missing for server %s", serverTag)
 			return nil
@@ -215,7 +287,14 @@ func checkSlowConsumers(_ *Check, r *archive.Reader, examples *ExamplesCollectio
 			return fmt.Errorf("failed to load variables for server %s: %w", serverTag, err)
 		}
 
-		if slowConsumers := vz.Data.SlowConsumers; slowConsumers > 0 {
+		slowConsumers := vz.Data.SlowConsumers
+		if slowConsumers <= threshold {
+			return nil
+		}
+
+		if stats := vz.Data.SlowConsumersStats; stats != nil {
+			examples.Add("%s/%s: %d slow consumers (clients: %d, routes: %d, gateways: %d, leafs: %d)", clusterTag, serverTag, slowConsumers, stats.Clients, stats.Routes, stats.Gateways, stats.Leafs)
+		} else {
 			examples.Add("%s/%s: %d slow consumers", clusterTag, serverTag, slowConsumers)
 		}
 
@@ -225,6 +304,48 @@ func checkSlowConsumers(_ *Check, r *archive.Reader, examples *ExamplesCollectio
 		return Skipped, err
 	}
 
+	// connections are only present when closed connections were gathered, the reason tells if they were slow
+	_, err = archive.EachClusterServerArtifact(r, archive.TagServerConnections(), func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, connz *server.ServerAPIConnzResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if errors.Is(err, archive.ErrNoMatches) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to load connections for server %s: %w", serverTag, err)
+		}
+
+		if connz.Data == nil {
+			return nil
+		}
+
+		slow := make(map[string]int64)
+		for _, conn := range connz.Data.Conns {
+			if !strings.HasPrefix(conn.Reason, "Slow Consumer") {
+				continue
+			}
+
+			name := conn.Name
+			if name == "" {
+				name = fmt.Sprintf("cid:%d", conn.Cid)
+			}
+
+			slow[fmt.Sprintf("%s (%s) in %s", name, conn.IP, conn.Account)]++
+		}
+
+		for conn, count := range slow {
+			if count > threshold {
+				examples.Add("%s/%s: connection %s had %d slow consumer disconnects", clusterTag, serverTag, conn, count)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Skipped, err
+	}
+
 	if examples.Count() > 0 {
 		log.Errorf("Total slow consumers: %d", examples.Count())
 		return PassWithIssues, nil
@@ -236,11 +357,15 @@ func checkSlowConsumers(_ *Check, r *archive.Reader, examples *ExamplesCollectio
 }
 
 // checkServerResourceLimits verifies that the resource usage of memory and store is not approaching the reserved amount for each known server
-func checkServerResourceLimits(check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkServerResourceLimits(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	memoryUsageThreshold := check.Configuration["memory"].Value()
 	storeUsageThreshold := check.Configuration["store"].Value()
 
 	_, err := r.EachClusterServerJsz(func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, jsz *server.ServerAPIJszResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		if errors.Is(err, archive.ErrNoMatches) {
 			log.Warnf("Artifact 'JSZ' is missing for server %s", serverTag)
 			return nil
@@ -277,8 +402,12 @@ func checkServerResourceLimits(check *Check, r *archive.Reader, examples *Exampl
 }
 
 // checkJetStreamDomainsForWhitespace verifies that no JetStream server is configured with whitespace in its domain
-func checkJetStreamDomainsForWhitespace(_ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkJetStreamDomainsForWhitespace(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	_, err := r.EachClusterServerJsz(func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, jsz *server.ServerAPIJszResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		if errors.Is(err, archive.ErrNoMatches) {
 			log.Warnf("Artifact 'JSZ' is missing for server %s", serverTag)
 			return nil
@@ -306,8 +435,12 @@ func checkJetStreamDomainsForWhitespace(_ *Check, r *archive.Reader, examples *E
 }
 
 // checkServerAuthRequired verifies that all servers require authentication.
-func checkServerAuthRequired(_ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+func checkServerAuthRequired(ctx context.Context, _ *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
 	total, err := r.EachClusterServerVarz(func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, vz *server.ServerAPIVarzResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		if errors.Is(err, archive.ErrNoMatches) {
 			log.Warnf("Artifact 'VARZ' is missing for server %s", serverTag)
 			return nil
@@ -332,3 +465,113 @@ func checkServerAuthRequired(_ *Check, r *archive.Reader, examples *ExamplesColl
 	log.Infof("%d/%d servers require authentication", total, total)
 	return Pass, nil
 }
+
+// checkJetStreamStoreHeadroom verifies that every JetStream server has enough free space left in its store directory,
+// when max_file_store is not configured the server sizes it from the available disk space so the limit reflects the disk
+func checkJetStreamStoreHeadroom(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	freeThreshold := check.Configuration["free"].Value()
+
+	_, err := r.EachClusterServerJsz(func(clusterTag *archive.Tag, serverTag *archive.Tag, err error, jsz *server.ServerAPIJszResponse) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if errors.Is(err, archive.ErrNoMatches) {
+			log.Warnf("Artifact 'JSZ' is missing for server %s", serverTag)
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to load JSZ for server %s: %w", serverTag, err)
+		}
+
+		if jsz.Data == nil || jsz.Data.Disabled || jsz.Data.Config.MaxStore <= 0 {
+			return nil
+		}
+
+		limit := uint64(jsz.Data.Config.MaxStore)
+		used := max(jsz.Data.Store, jsz.Data.ReservedStore)
+
+		var free uint64
+		if used < limit {
+			free = limit - used
+		}
+
+		if float64(free) < float64(limit)*(freeThreshold/100) {
+			dir := jsz.Data.Config.StoreDir
+			if dir == "" {
+				dir = "unknown store directory"
+			}
+
+			examples.Add("%s: %s free of %s in %s", serverTag, humanize.IBytes(free), humanize.IBytes(limit), dir)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Skipped, err
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d servers with less than %.0f%% free JetStream storage", examples.Count(), freeThreshold)
+		return Fail, nil
+	}
+
+	return Pass, nil
+}
+
+// goroutineProfileTotal extracts the number of goroutines from a goroutine profile captured with debug level 1
+func goroutineProfileTotal(profile []byte) (int, error) {
+	const header = "goroutine profile: total "
+
+	line, _, _ := bytes.Cut(profile, []byte("\n"))
+	if !bytes.HasPrefix(line, []byte(header)) {
+		return 0, fmt.Errorf("unexpected goroutine profile format")
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(line[len(header):])))
+}
+
+// checkServerGoroutines verify that no server runs more goroutines than the given threshold using the goroutine
+// profiles captured from every server
+func checkServerGoroutines(ctx context.Context, check *Check, r *archive.Reader, examples *ExamplesCollection, log api.Logger) (Outcome, error) {
+	threshold := int(check.Configuration["goroutines"].Value())
+	checked := 0
+
+	for _, clusterName := range r.ClusterNames() {
+		for _, serverName := range r.ClusterServerNames(clusterName) {
+			if err := ctx.Err(); err != nil {
+				return Skipped, err
+			}
+
+			profile, err := r.ServerProfile(clusterName, serverName, "goroutine_1")
+			if errors.Is(err, archive.ErrNoMatches) {
+				log.Debugf("Goroutine profile is missing for server %s", serverName)
+				continue
+			} else if err != nil {
+				return Skipped, fmt.Errorf("failed to load goroutine profile for server %s: %w", serverName, err)
+			}
+
+			total, err := goroutineProfileTotal(profile)
+			if err != nil {
+				log.Warnf("Could not parse goroutine profile for server %s: %v", serverName, err)
+				continue
+			}
+			checked++
+
+			if total > threshold {
+				examples.Add("%s - %s: %s goroutines", clusterName, serverName, humanize.Comma(int64(total)))
+			}
+		}
+	}
+
+	if checked == 0 {
+		log.Warnf("No goroutine profiles found, gather server profiles to enable this check")
+		return Skipped, nil
+	}
+
+	if examples.Count() > 0 {
+		log.Errorf("Found %d servers with more than %d goroutines", examples.Count(), threshold)
+		return Fail, nil
+	}
+
+	return Pass, nil
+}
@@ -1,7 +1,9 @@
 package audit
 
 import (
+	"context"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/nats-io/jsm.go/api"
@@ -54,7 +56,7 @@ func setupServerCheck(t *testing.T, checkid string, artifacts map[string]any, ty
 	}
 
 	examples := newExamplesCollection(0)
-	result, err := check.Handler(check, reader, examples, api.NewDefaultLogger(api.ErrorLevel))
+	result, err := check.Handler(context.Background(), check, reader, examples, api.NewDefaultLogger(api.ErrorLevel))
 	if err != nil {
 		t.Fatalf("check handler failed: %v", err)
 	}
@@ -160,6 +162,46 @@ func TestSERVER_004(t *testing.T) {
 			t.Errorf("expected result %v, got %v", Pass, result)
 		}
 	})
+	setup := func(t *testing.T, threshold float64, slow int64, reasons ...string) (Outcome, *ExamplesCollection) {
+		return runArchiveCheck(t, func(cc *CheckCollection) error {
+			err := RegisterServerChecks(cc)
+			if err != nil {
+				return err
+			}
+
+			cc.registered["Server Slow Consumers"].Configuration["slow_consumers"].SetValue = &threshold
+			return nil
+		}, "SERVER_004", func(w *archive.Writer) error {
+			err := w.Add(&server.ServerAPIVarzResponse{Data: &server.Varz{SlowConsumers: slow, SlowConsumersStats: &server.SlowConsumersStats{Clients: uint64(slow)}}}, archive.TagCluster("C1"), archive.TagServer("n1"), archive.TagServerVars())
+			if err != nil {
+				return err
+			}
+
+			connz := &server.Connz{}
+			for i, reason := range reasons {
+				connz.Conns = append(connz.Conns, &server.ConnInfo{Cid: uint64(i), Name: "app", Account: "A", Reason: reason})
+			}
+
+			return w.Add(&server.ServerAPIConnzResponse{Data: connz}, archive.TagCluster("C1"), archive.TagServer("n1"), archive.TagServerConnections())
+		})
+	}
+
+	t.Run("Should pass when slow consumers are below the threshold", func(t *testing.T) {
+		result, _ := setup(t, 5, 3, "Slow Consumer (Write Deadline)", "Client Closed")
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+
+	t.Run("Should warn about slow connections", func(t *testing.T) {
+		result, examples := setup(t, 1, 0, "Slow Consumer (Write Deadline)", "Slow Consumer (Pending Bytes)", "Client Closed")
+		if result != PassWithIssues {
+			t.Errorf("expected result %v, got %v", PassWithIssues, result)
+		}
+		if examples.Count() != 1 || !strings.Contains(examples.Examples[0], "app () in A had 2 slow consumer disconnects") {
+			t.Errorf("unexpected examples: %v", examples.Examples)
+		}
+	})
 }
 
 func TestSERVER_005(t *testing.T) {
@@ -276,3 +318,126 @@ func TestSERVER_007(t *testing.T) {
 		}
 	})
 }
+
+func TestSERVER_008(t *testing.T) {
+	jsz := func(maxStore int64, store uint64, reserved uint64) *server.ServerAPIJszResponse {
+		return &server.ServerAPIJszResponse{
+			Data: &server.JSInfo{
+				JetStreamStats: server.JetStreamStats{Store: store, ReservedStore: reserved},
+				Config:         server.JetStreamConfig{MaxStore: maxStore, StoreDir: "/data/jetstream"},
+			},
+		}
+	}
+
+	t.Run("Should fail when store usage leaves too little headroom", func(t *testing.T) {
+		result := setupServerCheck(t, "SERVER_008", map[string]any{
+			"n1": jsz(1000, 950, 0),
+		}, archive.TagServerJetStream())
+
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should fail when reservations leave too little headroom", func(t *testing.T) {
+		result := setupServerCheck(t, "SERVER_008", map[string]any{
+			"n1": jsz(1000, 100, 1000),
+		}, archive.TagServerJetStream())
+
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should pass when there is enough headroom", func(t *testing.T) {
+		result := setupServerCheck(t, "SERVER_008", map[string]any{
+			"n1": jsz(1000, 500, 800),
+			"n2": jsz(0, 500, 0),
+		}, archive.TagServerJetStream())
+
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+}
+
+func TestSERVER_009(t *testing.T) {
+	setup := func(t *testing.T, profiles map[string]string) Outcome {
+		archivePath := filepath.Join(t.TempDir(), "audit.zip")
+
+		writer, err := archive.NewWriter(archivePath)
+		if err != nil {
+			t.Fatalf("failed to create writer: %v", err)
+		}
+
+		for serverName, profile := range profiles {
+			err := writer.AddRaw(strings.NewReader(profile), "prof",
+				archive.TagCluster("C1"),
+				archive.TagServer(serverName),
+				archive.TagServerProfile(),
+				archive.TagProfileName("goroutine_1"))
+			if err != nil {
+				t.Fatalf("failed to add profile: %v", err)
+			}
+		}
+
+		// servers without profiles are ignored
+		err = writer.Add(&server.ServerAPIVarzResponse{}, archive.TagCluster("C1"), archive.TagServer("n3"), archive.TagServerVars())
+		if err != nil {
+			t.Fatalf("failed to add artifact: %v", err)
+		}
+
+		if err := writer.Close(); err != nil {
+			t.Fatalf("failed to close writer: %v", err)
+		}
+
+		reader, err := archive.NewReader(archivePath)
+		if err != nil {
+			t.Fatalf("failed to open archive: %v", err)
+		}
+		defer reader.Close()
+
+		cc := &CheckCollection{}
+		if err := RegisterServerChecks(cc); err != nil {
+			t.Fatalf("failed to register checks: %v", err)
+		}
+
+		check := cc.registered["Server Goroutines"]
+		result, err := check.Handler(context.Background(), check, reader, newExamplesCollection(0), api.NewDefaultLogger(api.ErrorLevel))
+		if err != nil {
+			t.Fatalf("check handler failed: %v", err)
+		}
+
+		return result
+	}
+
+	t.Run("Should fail when a server runs too many goroutines", func(t *testing.T) {
+		result := setup(t, map[string]string{
+			"n1": "goroutine profile: total 150000\n1 @ 0x1\n",
+			"n2": "goroutine profile: total 1000\n1 @ 0x1\n",
+		})
+
+		if result != Fail {
+			t.Errorf("expected result %v, got %v", Fail, result)
+		}
+	})
+
+	t.Run("Should pass when goroutines are below the threshold", func(t *testing.T) {
+		result := setup(t, map[string]string{
+			"n1": "goroutine profile: total 1000\n1 @ 0x1\n",
+			"n2": "not a profile",
+		})
+
+		if result != Pass {
+			t.Errorf("expected result %v, got %v", Pass, result)
+		}
+	})
+
+	t.Run("Should skip without goroutine profiles", func(t *testing.T) {
+		result := setup(t, nil)
+
+		if result != Skipped {
+			t.Errorf("expected result %v, got %v", Skipped, result)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity indicates how important problems found by a check are
+type Severity string
+
+const (
+	// SeverityInfo is for checks that report informational findings, these do not influence the verdict
+	SeverityInfo Severity = "info"
+	// SeverityWarning is for checks that report problems that should be addressed, the default
+	SeverityWarning Severity = "warning"
+	// SeverityCritical is for checks that report problems impacting availability or correctness
+	SeverityCritical Severity = "critical"
+)
+
+// Severities is the list of possible severity values
+var Severities = [...]Severity{
+	SeverityInfo,
+	SeverityWarning,
+	SeverityCritical,
+}
+
+// ParseSeverity parses a severity name
+func ParseSeverity(s string) (Severity, error) {
+	for _, sev := range Severities {
+		if strings.EqualFold(s, string(sev)) {
+			return sev, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid severity %q", s)
+}
+
+// IsValid determines if s is a known severity
+func (s Severity) IsValid() bool {
+	_, err := ParseSeverity(string(s))
+	return err == nil
+}
+
+// verdictFor determines the contribution of a single check outcome to the overall verdict, failures of critical
+// checks fail the verdict, other failures and warnings of warning or critical checks warn while info checks
+// never influence the verdict
+func verdictFor(outcome Outcome, severity Severity) Outcome {
+	switch {
+	case severity == SeverityInfo:
+		return Pass
+	case outcome == Fail && severity == SeverityCritical:
+		return Fail
+	case outcome == Fail, outcome == PassWithIssues:
+		return PassWithIssues
+	default:
+		return Pass
+	}
+}
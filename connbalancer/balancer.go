@@ -18,9 +18,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"sync"
 	"time"
 
+	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/nats-server/v2/server"
 	"github.com/nats-io/nats.go"
@@ -291,82 +291,20 @@ func (c *balancer) reqMany(ctx context.Context, subj string, req any, expect int
 
 	c.log.Tracef(">>> %s: %s", subj, string(jreq))
 
-	var (
-		mu       sync.Mutex
-		ctr      int
-		finisher *time.Timer
-		errs     = make(chan error)
-		sub      *nats.Subscription
-		res      []*nats.Msg
-	)
-
-	to, cancel := context.WithTimeout(ctx, 2*time.Second)
-	defer cancel()
-
-	if expect == 0 {
-		finisher = time.NewTimer(2 * time.Second)
-		go func(ctx context.Context, cancel context.CancelFunc, finisher *time.Timer) {
-			select {
-			case <-finisher.C:
-				cancel()
-			case <-ctx.Done():
-				return
-			}
-		}(to, cancel, finisher)
-	}
-
-	sub, err = c.nc.Subscribe(c.nc.NewRespInbox(), func(m *nats.Msg) {
-		mu.Lock()
-		defer mu.Unlock()
-
+	var res []*nats.Msg
+	err = jsm.RequestMany(ctx, c.nc, subj, jreq, func(m *nats.Msg) error {
 		c.log.Tracef("<<< (%dB) %s", len(m.Data), string(m.Data))
-		if finisher != nil {
-			finisher.Reset(300 * time.Millisecond)
-		}
-
-		if m.Header.Get("Status") == "503" {
-			errs <- nats.ErrNoResponders
-			return
-		}
-
 		res = append(res, m)
-		ctr++
-
-		if expect > 0 && ctr == expect {
-			cancel()
-		}
-	})
-	if err != nil {
-		return nil, err
+		return nil
+	}, jsm.RequestManyExpect(expect))
+	if errors.Is(err, nats.ErrNoResponders) {
+		return nil, fmt.Errorf("server request failed, ensure the account used has system privileges and appropriate permissions")
 	}
-
-	if expect > 0 {
-		sub.AutoUnsubscribe(expect)
-	}
-
-	msg := nats.NewMsg(subj)
-	msg.Reply = sub.Subject
-	msg.Data = jreq
-
-	err = c.nc.PublishMsg(msg)
 	if err != nil {
 		return nil, err
 	}
 
-	select {
-	case err = <-errs:
-		if errors.Is(err, nats.ErrNoResponders) {
-			return nil, fmt.Errorf("server request failed, ensure the account used has system privileges and appropriate permissions")
-		}
-
-		return nil, err
-	case <-to.Done():
-		sub.Unsubscribe()
-	}
-
-	mu.Lock()
-	c.log.Debugf("=== Received %d responses", ctr)
-	mu.Unlock()
+	c.log.Debugf("=== Received %d responses", len(res))
 
 	return res, nil
 }
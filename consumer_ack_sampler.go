@@ -0,0 +1,198 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsm
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nats-io/jsm.go/api/jetstream/metric"
+	"github.com/nats-io/nats.go"
+)
+
+// AckSampleStats are statistics about the ack samples received within the sampler window
+type AckSampleStats struct {
+	// Samples is how many samples were received in the window
+	Samples int `json:"samples"`
+	// Redelivered is how many of the samples were for messages delivered more than once
+	Redelivered int `json:"redelivered"`
+	// Window is the period the statistics cover
+	Window time.Duration `json:"window"`
+	// Mean is the average time between delivery and acknowledgement
+	Mean time.Duration `json:"mean"`
+	// P50 is the 50th percentile time between delivery and acknowledgement
+	P50 time.Duration `json:"p50"`
+	// P95 is the 95th percentile time between delivery and acknowledgement
+	P95 time.Duration `json:"p95"`
+	// Max is the longest time between delivery and acknowledgement
+	Max time.Duration `json:"max"`
+}
+
+type ackSample struct {
+	received   time.Time
+	delay      time.Duration
+	deliveries uint64
+}
+
+// AckSampler subscribes to the ack samples published for a consumer with sampling enabled
+type AckSampler struct {
+	consumer *Consumer
+	window   time.Duration
+	handler  func(*metric.ConsumerAckMetricV1)
+	ch       chan<- *metric.ConsumerAckMetricV1
+	sub      *nats.Subscription
+	samples  []ackSample
+
+	sync.Mutex
+}
+
+// AckSamplerOption configures an AckSampler
+type AckSamplerOption func(s *AckSampler) error
+
+// AckSamplerWindow sets the period over which statistics are calculated, defaults to 1 minute
+func AckSamplerWindow(w time.Duration) AckSamplerOption {
+	return func(s *AckSampler) error {
+		if w <= 0 {
+			return fmt.Errorf("window must be positive")
+		}
+
+		s.window = w
+		return nil
+	}
+}
+
+// AckSamplerHandler calls cb for every sample received
+func AckSamplerHandler(cb func(*metric.ConsumerAckMetricV1)) AckSamplerOption {
+	return func(s *AckSampler) error {
+		s.handler = cb
+		return nil
+	}
+}
+
+// AckSamplerChannel delivers every sample received to ch, samples are dropped when ch is full
+func AckSamplerChannel(ch chan<- *metric.ConsumerAckMetricV1) AckSamplerOption {
+	return func(s *AckSampler) error {
+		s.ch = ch
+		return nil
+	}
+}
+
+// AckSampler subscribes to ack samples for the consumer, sampling has to be enabled on the consumer
+func (c *Consumer) AckSampler(opts ...AckSamplerOption) (*AckSampler, error) {
+	if !c.IsSampled() {
+		return nil, fmt.Errorf("consumer %s > %s does not have ack sampling enabled", c.StreamName(), c.Name())
+	}
+
+	s := &AckSampler{
+		consumer: c,
+		window:   time.Minute,
+	}
+
+	for _, opt := range opts {
+		err := opt(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	s.sub, err = c.mgr.nc.Subscribe(EventSubject(c.AckSampleSubject(), c.mgr.eventPrefix), s.handleMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *AckSampler) handleMsg(m *nats.Msg) {
+	var sample metric.ConsumerAckMetricV1
+	err := json.Unmarshal(m.Data, &sample)
+	if err != nil {
+		return
+	}
+
+	s.Lock()
+	now := time.Now()
+	s.samples = append(s.expireLocked(now), ackSample{
+		received:   now,
+		delay:      time.Duration(sample.Delay),
+		deliveries: sample.Deliveries,
+	})
+	s.Unlock()
+
+	if s.handler != nil {
+		s.handler(&sample)
+	}
+
+	if s.ch != nil {
+		select {
+		case s.ch <- &sample:
+		default:
+		}
+	}
+}
+
+func (s *AckSampler) expireLocked(now time.Time) []ackSample {
+	cutoff := now.Add(-s.window)
+	idx := sort.Search(len(s.samples), func(i int) bool {
+		return s.samples[i].received.After(cutoff)
+	})
+
+	return s.samples[idx:]
+}
+
+// Stats calculates statistics for the samples received within the window
+func (s *AckSampler) Stats() AckSampleStats {
+	s.Lock()
+	s.samples = s.expireLocked(time.Now())
+	samples := make([]ackSample, len(s.samples))
+	copy(samples, s.samples)
+	s.Unlock()
+
+	stats := AckSampleStats{Window: s.window, Samples: len(samples)}
+	if len(samples) == 0 {
+		return stats
+	}
+
+	delays := make([]time.Duration, len(samples))
+	var total time.Duration
+	for i, sample := range samples {
+		delays[i] = sample.delay
+		total += sample.delay
+		if sample.deliveries > 1 {
+			stats.Redelivered++
+		}
+	}
+
+	sort.Slice(delays, func(i, j int) bool { return delays[i] < delays[j] })
+
+	percentile := func(p float64) time.Duration {
+		return delays[int(p*float64(len(delays)-1))]
+	}
+
+	stats.Mean = total / time.Duration(len(delays))
+	stats.P50 = percentile(0.50)
+	stats.P95 = percentile(0.95)
+	stats.Max = delays[len(delays)-1]
+
+	return stats
+}
+
+// Stop unsubscribes from the ack samples
+func (s *AckSampler) Stop() error {
+	return s.sub.Unsubscribe()
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+// ConsumerBulkResult is the outcome of a bulk operation against a single consumer
+type ConsumerBulkResult struct {
+	Stream   string
+	Consumer string
+	Error    error
+}
+
+// ConsumerBulkResults are the outcomes of a bulk operation sorted by consumer name
+type ConsumerBulkResults []ConsumerBulkResult
+
+// Err combines the errors of all failed operations, nil when all succeeded
+func (r ConsumerBulkResults) Err() error {
+	var errs []error
+	for _, res := range r {
+		if res.Error != nil {
+			errs = append(errs, fmt.Errorf("%s > %s: %w", res.Stream, res.Consumer, res.Error))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Failed is the list of results that had errors
+func (r ConsumerBulkResults) Failed() ConsumerBulkResults {
+	var failed ConsumerBulkResults
+	for _, res := range r {
+		if res.Error != nil {
+			failed = append(failed, res)
+		}
+	}
+
+	return failed
+}
+
+type bulkOptions struct {
+	concurrency int
+}
+
+// BulkOption configures bulk consumer operations
+type BulkOption func(o *bulkOptions) error
+
+// BulkConcurrency sets how many consumers are operated on at the same time, defaults to 10
+func BulkConcurrency(c int) BulkOption {
+	return func(o *bulkOptions) error {
+		if c < 1 {
+			return fmt.Errorf("concurrency must be at least 1")
+		}
+
+		o.concurrency = c
+		return nil
+	}
+}
+
+// PauseAllConsumers pauses every consumer on stream until the deadline
+func (m *Manager) PauseAllConsumers(stream string, until time.Time, opts ...BulkOption) (ConsumerBulkResults, error) {
+	names, err := m.ConsumerNames(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.bulkConsumerOperation(stream, names, opts, func(c *Consumer) error {
+		_, err := c.Pause(until)
+		return err
+	})
+}
+
+// ResumeAllConsumers resumes every consumer on stream
+func (m *Manager) ResumeAllConsumers(stream string, opts ...BulkOption) (ConsumerBulkResults, error) {
+	names, err := m.ConsumerNames(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.bulkConsumerOperation(stream, names, opts, func(c *Consumer) error {
+		return c.Resume()
+	})
+}
+
+// DeleteConsumersMatching deletes every consumer on stream for which filter returns true
+func (m *Manager) DeleteConsumersMatching(stream string, filter func(*Consumer) bool, opts ...BulkOption) (ConsumerBulkResults, error) {
+	if filter == nil {
+		return nil, fmt.Errorf("filter is required")
+	}
+
+	consumers, _, _, err := m.Consumers(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, c := range consumers {
+		if filter(c) {
+			names = append(names, c.Name())
+		}
+	}
+
+	return m.bulkConsumerOperation(stream, names, opts, func(c *Consumer) error {
+		return m.DeleteConsumer(stream, c.Name())
+	})
+}
+
+func (m *Manager) bulkConsumerOperation(stream string, names []string, opts []BulkOption, op func(*Consumer) error) (ConsumerBulkResults, error) {
+	bopts := &bulkOptions{concurrency: 10}
+	for _, opt := range opts {
+		err := opt(bopts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		results = make(ConsumerBulkResults, len(names))
+		limiter = make(chan struct{}, bopts.concurrency)
+		wg      sync.WaitGroup
+	)
+
+	for i, name := range names {
+		wg.Add(1)
+		limiter <- struct{}{}
+
+		go func(i int, name string) {
+			defer func() { <-limiter }()
+			defer wg.Done()
+
+			results[i] = ConsumerBulkResult{
+				Stream:   stream,
+				Consumer: name,
+				Error:    op(m.consumerFromCfg(stream, name, &api.ConsumerConfig{})),
+			}
+		}(i, name)
+	}
+
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Consumer < results[j].Consumer
+	})
+
+	return results, nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+// ConsumerHealthReport is the outcome of assessing the health of a consumer using ConsumerHealth
+type ConsumerHealthReport struct {
+	Stream   string `json:"stream"`
+	Consumer string `json:"consumer"`
+	// Healthy is true when no issues were found
+	Healthy bool `json:"healthy"`
+	// Issues lists all problems found
+	Issues []string `json:"issues,omitempty"`
+	// AckFloorLag is the number of stream messages delivered but not yet past the ack floor
+	AckFloorLag uint64 `json:"ack_floor_lag"`
+	// AckPending is the number of messages delivered and awaiting acknowledgement
+	AckPending int `json:"ack_pending"`
+	// Pending is the number of messages not yet delivered
+	Pending uint64 `json:"pending"`
+	// PendingGrowth is the change in Pending since the previous information, only set when HealthPreviousInfo() is used
+	PendingGrowth int64 `json:"pending_growth"`
+	// RedeliveryRatio is the ratio of redelivered messages to delivered messages
+	RedeliveryRatio float64 `json:"redelivery_ratio"`
+	// Paused indicates the consumer is paused
+	Paused bool `json:"paused"`
+	// PauseRemaining is how long the consumer will remain paused
+	PauseRemaining time.Duration `json:"pause_remaining,omitempty"`
+	// Replicas is the number of replicas reported in the cluster information including the leader
+	Replicas int `json:"replicas"`
+	// LaggingReplicas are replicas that are not current or lag behind the leader
+	LaggingReplicas []string `json:"lagging_replicas,omitempty"`
+	// OfflineReplicas are replicas that are offline
+	OfflineReplicas []string `json:"offline_replicas,omitempty"`
+}
+
+type consumerHealthOptions struct {
+	ackFloorLag     uint64
+	pending         uint64
+	pendingGrowth   int64
+	redeliveryRatio float64
+	replicaLag      uint64
+	allowPaused     bool
+	previous        *api.ConsumerInfo
+}
+
+// HealthOption configures the thresholds used by ConsumerHealth
+type HealthOption func(o *consumerHealthOptions) error
+
+// HealthAckFloorLag sets the maximum allowed difference between the last delivered stream sequence and the ack floor, 0 disables the check
+func HealthAckFloorLag(n uint64) HealthOption {
+	return func(o *consumerHealthOptions) error {
+		o.ackFloorLag = n
+		return nil
+	}
+}
+
+// HealthPending sets the maximum allowed number of messages not yet delivered, 0 disables the check
+func HealthPending(n uint64) HealthOption {
+	return func(o *consumerHealthOptions) error {
+		o.pending = n
+		return nil
+	}
+}
+
+// HealthPendingGrowth sets the maximum allowed growth in pending messages since the previous information, 0 disables the check
+func HealthPendingGrowth(n int64) HealthOption {
+	return func(o *consumerHealthOptions) error {
+		if n < 0 {
+			return fmt.Errorf("pending growth threshold can not be negative")
+		}
+
+		o.pendingGrowth = n
+		return nil
+	}
+}
+
+// HealthRedeliveryRatio sets the maximum allowed ratio of redelivered to delivered messages, 0 disables the check
+func HealthRedeliveryRatio(r float64) HealthOption {
+	return func(o *consumerHealthOptions) error {
+		if r < 0 || r > 1 {
+			return fmt.Errorf("redelivery ratio must be between 0 and 1")
+		}
+
+		o.redeliveryRatio = r
+		return nil
+	}
+}
+
+// HealthReplicaLag sets how many operations a replica may lag the leader before being considered unhealthy, defaults to 0
+func HealthReplicaLag(n uint64) HealthOption {
+	return func(o *consumerHealthOptions) error {
+		o.replicaLag = n
+		return nil
+	}
+}
+
+// HealthAllowPaused does not consider paused consumers to be unhealthy
+func HealthAllowPaused() HealthOption {
+	return func(o *consumerHealthOptions) error {
+		o.allowPaused = true
+		return nil
+	}
+}
+
+// HealthPreviousInfo supplies earlier information about the same consumer used to calculate growth in pending messages
+func HealthPreviousInfo(info *api.ConsumerInfo) HealthOption {
+	return func(o *consumerHealthOptions) error {
+		o.previous = info
+		return nil
+	}
+}
+
+// ConsumerHealth assesses the health of a consumer based on its information, the thresholds that
+// determine health are set using opts
+func ConsumerHealth(info *api.ConsumerInfo, opts ...HealthOption) (*ConsumerHealthReport, error) {
+	if info == nil {
+		return nil, fmt.Errorf("consumer information is required")
+	}
+
+	hopts := &consumerHealthOptions{}
+	for _, opt := range opts {
+		err := opt(hopts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if hopts.previous != nil && (hopts.previous.Stream != info.Stream || hopts.previous.Name != info.Name) {
+		return nil, fmt.Errorf("previous information is for a different consumer")
+	}
+
+	report := &ConsumerHealthReport{
+		Stream:         info.Stream,
+		Consumer:       info.Name,
+		AckPending:     info.NumAckPending,
+		Pending:        info.NumPending,
+		Paused:         info.Paused,
+		PauseRemaining: info.PauseRemaining,
+	}
+
+	issue := func(format string, a ...any) {
+		report.Issues = append(report.Issues, fmt.Sprintf(format, a...))
+	}
+
+	if info.Delivered.Stream > info.AckFloor.Stream {
+		report.AckFloorLag = info.Delivered.Stream - info.AckFloor.Stream
+	}
+	if hopts.ackFloorLag > 0 && report.AckFloorLag > hopts.ackFloorLag {
+		issue("ack floor lag %d exceeds %d", report.AckFloorLag, hopts.ackFloorLag)
+	}
+
+	if hopts.pending > 0 && report.Pending > hopts.pending {
+		issue("pending messages %d exceeds %d", report.Pending, hopts.pending)
+	}
+
+	if hopts.previous != nil {
+		report.PendingGrowth = int64(info.NumPending) - int64(hopts.previous.NumPending)
+		if hopts.pendingGrowth > 0 && report.PendingGrowth > hopts.pendingGrowth {
+			issue("pending messages grew by %d, exceeding %d", report.PendingGrowth, hopts.pendingGrowth)
+		}
+	}
+
+	if info.Delivered.Consumer > 0 {
+		report.RedeliveryRatio = float64(info.NumRedelivered) / float64(info.Delivered.Consumer)
+	}
+	if hopts.redeliveryRatio > 0 && report.RedeliveryRatio > hopts.redeliveryRatio {
+		issue("redelivery ratio %.2f exceeds %.2f", report.RedeliveryRatio, hopts.redeliveryRatio)
+	}
+
+	if info.Paused && !hopts.allowPaused {
+		issue("consumer is paused for %v", info.PauseRemaining.Round(time.Second))
+	}
+
+	if info.Cluster != nil {
+		report.Replicas = len(info.Cluster.Replicas)
+		if info.Cluster.Leader != "" {
+			report.Replicas++
+		} else {
+			issue("consumer has no leader")
+		}
+
+		for _, peer := range info.Cluster.Replicas {
+			switch {
+			case peer.Offline:
+				report.OfflineReplicas = append(report.OfflineReplicas, peer.Name)
+			case !peer.Current || peer.Lag > hopts.replicaLag:
+				report.LaggingReplicas = append(report.LaggingReplicas, peer.Name)
+			}
+		}
+
+		if len(report.OfflineReplicas) > 0 {
+			issue("%d replicas are offline", len(report.OfflineReplicas))
+		}
+		if len(report.LaggingReplicas) > 0 {
+			issue("%d replicas are lagging", len(report.LaggingReplicas))
+		}
+	}
+
+	report.Healthy = len(report.Issues) == 0
+
+	return report, nil
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
@@ -62,6 +63,37 @@ type Consumer struct {
 
 // NewConsumerFromDefault creates a new consumer based on a template config that gets modified by opts
 func (m *Manager) NewConsumerFromDefault(stream string, dflt api.ConsumerConfig, opts ...ConsumerOption) (consumer *Consumer, err error) {
+	return m.NewConsumerFromDefaultContext(context.Background(), stream, dflt, opts...)
+}
+
+// NewConsumerFromDefaultContext creates a new consumer based on a template config that gets modified by opts, interrupted by ctx
+func (m *Manager) NewConsumerFromDefaultContext(ctx context.Context, stream string, dflt api.ConsumerConfig, opts ...ConsumerOption) (consumer *Consumer, err error) {
+	return m.newConsumerFromDefault(ctx, stream, api.ActionCreateOrUpdate, dflt, opts...)
+}
+
+// CreateConsumerFromDefault creates a new consumer based on a template config that gets modified by opts, unlike
+// NewConsumerFromDefault this will fail if a consumer with the same name already exists with a different configuration
+func (m *Manager) CreateConsumerFromDefault(stream string, dflt api.ConsumerConfig, opts ...ConsumerOption) (consumer *Consumer, err error) {
+	return m.CreateConsumerFromDefaultContext(context.Background(), stream, dflt, opts...)
+}
+
+// CreateConsumerFromDefaultContext creates a new consumer like CreateConsumerFromDefault, interrupted by ctx
+func (m *Manager) CreateConsumerFromDefaultContext(ctx context.Context, stream string, dflt api.ConsumerConfig, opts ...ConsumerOption) (consumer *Consumer, err error) {
+	return m.newConsumerFromDefault(ctx, stream, api.ActionCreate, dflt, opts...)
+}
+
+// UpdateConsumerFromDefault updates an existing consumer based on a template config that gets modified by opts, unlike
+// NewConsumerFromDefault this will fail if the consumer does not already exist
+func (m *Manager) UpdateConsumerFromDefault(stream string, dflt api.ConsumerConfig, opts ...ConsumerOption) (consumer *Consumer, err error) {
+	return m.UpdateConsumerFromDefaultContext(context.Background(), stream, dflt, opts...)
+}
+
+// UpdateConsumerFromDefaultContext updates an existing consumer like UpdateConsumerFromDefault, interrupted by ctx
+func (m *Manager) UpdateConsumerFromDefaultContext(ctx context.Context, stream string, dflt api.ConsumerConfig, opts ...ConsumerOption) (consumer *Consumer, err error) {
+	return m.newConsumerFromDefault(ctx, stream, api.ActionUpdate, dflt, opts...)
+}
+
+func (m *Manager) newConsumerFromDefault(ctx context.Context, stream string, action api.ConsumerAction, dflt api.ConsumerConfig, opts ...ConsumerOption) (consumer *Consumer, err error) {
 	if !IsValidName(stream) {
 		return nil, fmt.Errorf("%q is not a valid stream name", stream)
 	}
@@ -79,10 +111,11 @@ func (m *Manager) NewConsumerFromDefault(stream string, dflt api.ConsumerConfig,
 	req := api.JSApiConsumerCreateRequest{
 		Stream:   stream,
 		Config:   *cfg,
+		Action:   action,
 		Pedantic: m.pedantic,
 	}
 
-	createdInfo, err := m.createConsumer(req)
+	createdInfo, err := m.createConsumer(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +130,7 @@ func (m *Manager) NewConsumerFromDefault(stream string, dflt api.ConsumerConfig,
 	return c, nil
 }
 
-func (m *Manager) createConsumer(req api.JSApiConsumerCreateRequest) (info *api.ConsumerInfo, err error) {
+func (m *Manager) createConsumer(ctx context.Context, req api.JSApiConsumerCreateRequest) (info *api.ConsumerInfo, err error) {
 	var resp api.JSApiConsumerCreateResponse
 
 	if req.Config.Name == "" {
@@ -111,7 +144,7 @@ func (m *Manager) createConsumer(req api.JSApiConsumerCreateRequest) (info *api.
 		subj = fmt.Sprintf(api.JSApiConsumerCreateExT, req.Stream, req.Config.Name, req.Config.FilterSubject)
 	}
 
-	err = m.jsonRequest(subj, req, &resp)
+	err = m.jsonRequestWithContext(ctx, subj, req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +177,7 @@ func (m *Manager) LoadOrNewConsumerFromDefault(stream string, name string, templ
 	}
 
 	c, err := m.LoadConsumer(stream, name)
-	if IsNatsError(err, 10014) {
+	if errors.Is(err, api.ErrConsumerNotFound) {
 		return m.NewConsumerFromDefault(stream, template, opts...)
 	}
 
@@ -153,6 +186,11 @@ func (m *Manager) LoadOrNewConsumerFromDefault(stream string, name string, templ
 
 // LoadConsumer loads a consumer by name
 func (m *Manager) LoadConsumer(stream string, name string) (consumer *Consumer, err error) {
+	return m.LoadConsumerContext(context.Background(), stream, name)
+}
+
+// LoadConsumerContext loads a consumer by name, interrupted by ctx
+func (m *Manager) LoadConsumerContext(ctx context.Context, stream string, name string) (consumer *Consumer, err error) {
 	if !IsValidName(stream) {
 		return nil, fmt.Errorf("%q is not a valid stream name", stream)
 	}
@@ -163,7 +201,7 @@ func (m *Manager) LoadConsumer(stream string, name string) (consumer *Consumer,
 
 	consumer = m.consumerFromCfg(stream, name, &api.ConsumerConfig{})
 
-	err = m.loadConfigForConsumer(consumer)
+	err = m.loadConfigForConsumer(ctx, consumer)
 	if err != nil {
 		return nil, err
 	}
@@ -220,8 +258,8 @@ func generateConsName() string {
 	return string(b[:8])
 }
 
-func (m *Manager) loadConfigForConsumer(consumer *Consumer) (err error) {
-	info, err := m.loadConsumerInfo(consumer.stream, consumer.name)
+func (m *Manager) loadConfigForConsumer(ctx context.Context, consumer *Consumer) (err error) {
+	info, err := m.loadConsumerInfo(ctx, consumer.stream, consumer.name)
 	if err != nil {
 		return err
 	}
@@ -234,9 +272,9 @@ func (m *Manager) loadConfigForConsumer(consumer *Consumer) (err error) {
 	return nil
 }
 
-func (m *Manager) loadConsumerInfo(s string, c string) (info api.ConsumerInfo, err error) {
+func (m *Manager) loadConsumerInfo(ctx context.Context, s string, c string) (info api.ConsumerInfo, err error) {
 	var resp api.JSApiConsumerInfoResponse
-	err = m.jsonRequest(fmt.Sprintf(api.JSApiConsumerInfoT, s, c), nil, &resp)
+	err = m.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiConsumerInfoT, s, c), nil, &resp)
 	if err != nil {
 		return info, err
 	}
@@ -609,6 +647,20 @@ func LinearBackoffPolicy(steps uint, min time.Duration, max time.Duration) Consu
 	}
 }
 
+// ExponentialBackoffPolicy creates a backoff policy starting at base with every following step multiplied by factor
+func ExponentialBackoffPolicy(steps uint, base time.Duration, factor float64) ConsumerOption {
+	return func(o *api.ConsumerConfig) error {
+		p, err := api.ExponentialBackoff(base, factor, steps)
+		if err != nil {
+			return err
+		}
+
+		o.BackOff = p
+
+		return nil
+	}
+}
+
 func ConsumerMetadata(meta map[string]string) ConsumerOption {
 	return func(o *api.ConsumerConfig) error {
 		for k := range meta {
@@ -681,6 +733,11 @@ func OverflowPriorityGroups(groups ...string) ConsumerOption {
 // UpdateConfiguration updates the consumer configuration
 // At present the description, ack wait, max deliver, sample frequency, max ack pending, max waiting and header only settings can be changed
 func (c *Consumer) UpdateConfiguration(opts ...ConsumerOption) error {
+	return c.UpdateConfigurationContext(context.Background(), opts...)
+}
+
+// UpdateConfigurationContext updates the consumer configuration, interrupted by ctx, see UpdateConfiguration()
+func (c *Consumer) UpdateConfigurationContext(ctx context.Context, opts ...ConsumerOption) error {
 	if !c.IsDurable() {
 		return fmt.Errorf("only durable consumers can be updated")
 	}
@@ -690,17 +747,22 @@ func (c *Consumer) UpdateConfiguration(opts ...ConsumerOption) error {
 		return err
 	}
 
-	_, err = c.mgr.NewConsumerFromDefault(c.stream, *ncfg)
+	_, err = c.mgr.NewConsumerFromDefaultContext(ctx, c.stream, *ncfg)
 	if err != nil {
 		return err
 	}
 
-	return c.Reset()
+	return c.ResetContext(ctx)
 }
 
 // Reset reloads the Consumer configuration from the JetStream server
 func (c *Consumer) Reset() error {
-	return c.mgr.loadConfigForConsumer(c)
+	return c.ResetContext(context.Background())
+}
+
+// ResetContext reloads the Consumer configuration from the JetStream server, interrupted by ctx
+func (c *Consumer) ResetContext(ctx context.Context) error {
+	return c.mgr.loadConfigForConsumer(ctx, c)
 }
 
 // NextSubject returns the subject used to retrieve the next message for pull-based Consumers, empty when not a pull-base consumer
@@ -918,7 +980,12 @@ func (c *Consumer) ClusterInfo() (api.ClusterInfo, error) {
 
 // State loads a snapshot of consumer state including delivery counts, retries and more
 func (c *Consumer) State() (api.ConsumerInfo, error) {
-	s, err := c.mgr.loadConsumerInfo(c.stream, c.name)
+	return c.StateContext(context.Background())
+}
+
+// StateContext loads a snapshot of consumer state including delivery counts, retries and more, interrupted by ctx
+func (c *Consumer) StateContext(ctx context.Context) (api.ConsumerInfo, error) {
+	s, err := c.mgr.loadConsumerInfo(ctx, c.stream, c.name)
 	if err != nil {
 		return api.ConsumerInfo{}, err
 	}
@@ -937,8 +1004,13 @@ func (c *Consumer) Configuration() (config api.ConsumerConfig) {
 
 // Delete deletes the Consumer, after this the Consumer object should be disposed
 func (c *Consumer) Delete() (err error) {
+	return c.DeleteContext(context.Background())
+}
+
+// DeleteContext deletes the Consumer, interrupted by ctx, after this the Consumer object should be disposed
+func (c *Consumer) DeleteContext(ctx context.Context) (err error) {
 	var resp api.JSApiConsumerDeleteResponse
-	err = c.mgr.jsonRequest(fmt.Sprintf(api.JSApiConsumerDeleteT, c.StreamName(), c.Name()), nil, &resp)
+	err = c.mgr.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiConsumerDeleteT, c.StreamName(), c.Name()), nil, &resp)
 	if err != nil {
 		return err
 	}
@@ -952,6 +1024,11 @@ func (c *Consumer) Delete() (err error) {
 
 // LeaderStepDown requests the current RAFT group leader in a clustered JetStream to stand down forcing a new election, the election of the next leader can be influenced by placement
 func (c *Consumer) LeaderStepDown(placement ...*api.Placement) error {
+	return c.LeaderStepDownContext(context.Background(), placement...)
+}
+
+// LeaderStepDownContext requests the current RAFT group leader to stand down, interrupted by ctx, see LeaderStepDown()
+func (c *Consumer) LeaderStepDownContext(ctx context.Context, placement ...*api.Placement) error {
 	var p *api.Placement
 	if len(placement) > 1 {
 		return fmt.Errorf("only one placement option allowed")
@@ -960,7 +1037,7 @@ func (c *Consumer) LeaderStepDown(placement ...*api.Placement) error {
 	}
 
 	var resp api.JSApiConsumerLeaderStepDownResponse
-	err := c.mgr.jsonRequest(fmt.Sprintf(api.JSApiConsumerLeaderStepDownT, c.StreamName(), c.Name()), api.JSApiConsumerLeaderStepdownRequest{Placement: p}, &resp)
+	err := c.mgr.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiConsumerLeaderStepDownT, c.StreamName(), c.Name()), api.JSApiConsumerLeaderStepdownRequest{Placement: p}, &resp)
 	if err != nil {
 		return err
 	}
@@ -976,12 +1053,17 @@ func (c *Consumer) LeaderStepDown(placement ...*api.Placement) error {
 //
 // A common reason for failures is when a time is supplied that is in the past from the perspective of the server
 func (c *Consumer) Pause(deadline time.Time) (*api.JSApiConsumerPauseResponse, error) {
+	return c.PauseContext(context.Background(), deadline)
+}
+
+// PauseContext requests a consumer be paused until the deadline, interrupted by ctx, see Pause()
+func (c *Consumer) PauseContext(ctx context.Context, deadline time.Time) (*api.JSApiConsumerPauseResponse, error) {
 	var resp *api.JSApiConsumerPauseResponse
 	req := api.JSApiConsumerPauseRequest{
 		PauseUntil: deadline,
 	}
 
-	err := c.mgr.jsonRequest(fmt.Sprintf(api.JSApiConsumerPauseT, c.StreamName(), c.Name()), &req, &resp)
+	err := c.mgr.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiConsumerPauseT, c.StreamName(), c.Name()), &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -995,9 +1077,14 @@ func (c *Consumer) Pause(deadline time.Time) (*api.JSApiConsumerPauseResponse, e
 
 // Resume requests the server resumes a paused consumer
 func (c *Consumer) Resume() error {
+	return c.ResumeContext(context.Background())
+}
+
+// ResumeContext requests the server resumes a paused consumer, interrupted by ctx
+func (c *Consumer) ResumeContext(ctx context.Context) error {
 	var resp *api.JSApiConsumerPauseResponse
 
-	err := c.mgr.jsonRequest(fmt.Sprintf(api.JSApiConsumerPauseT, c.StreamName(), c.Name()), nil, &resp)
+	err := c.mgr.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiConsumerPauseT, c.StreamName(), c.Name()), nil, &resp)
 	if err != nil {
 		return err
 	}
@@ -1011,6 +1098,11 @@ func (c *Consumer) Resume() error {
 
 // Unpin requests that the server unpins the current client from a grouped consumer
 func (c *Consumer) Unpin(group string) error {
+	return c.UnpinContext(context.Background(), group)
+}
+
+// UnpinContext requests that the server unpins the current client from a grouped consumer, interrupted by ctx
+func (c *Consumer) UnpinContext(ctx context.Context, group string) error {
 	if group == "" {
 		return fmt.Errorf("group is required")
 	}
@@ -1021,7 +1113,7 @@ func (c *Consumer) Unpin(group string) error {
 
 	var resp *api.JSApiConsumerUnpinResponse
 
-	err := c.mgr.jsonRequest(fmt.Sprintf(api.JSApiConsumerUnpinT, c.StreamName(), c.Name()), api.JSApiConsumerUnpinRequest{Group: group}, &resp)
+	err := c.mgr.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiConsumerUnpinT, c.StreamName(), c.Name()), api.JSApiConsumerUnpinRequest{Group: group}, &resp)
 	if err != nil {
 		return err
 	}
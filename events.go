@@ -7,8 +7,8 @@ import (
 	"github.com/nats-io/nats-server/v2/server"
 )
 
-// ParseEvent parses event e and returns event as for example *api.ConsumerAckMetric, all unknown
-// event schemas will be of type *UnknownMessage
+// ParseEvent parses event e and returns event as for example *api.ConsumerAckMetric, events with an
+// unknown schema type will be of type *api.RawEvent and events without a type of type *api.UnknownMessage
 func ParseEvent(e []byte) (schema string, event any, err error) {
 	return api.ParseMessage(e)
 }
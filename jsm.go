@@ -21,7 +21,6 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
-	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -318,22 +317,5 @@ func ParseDuration(d string) (time.Duration, error) {
 
 // FilterServerMetadata copies metadata with the server generated metadata removed
 func FilterServerMetadata(metadata map[string]string) map[string]string {
-	if metadata == nil {
-		return nil
-	}
-
-	nm := map[string]string{}
-	reserved := []string{
-		api.JSMetaCurrentServerVersion,
-		api.JSMetaCurrentServerLevel,
-		api.JsMetaRequiredServerLevel,
-	}
-
-	for k, v := range metadata {
-		if !slices.Contains(reserved, k) {
-			nm[k] = v
-		}
-	}
-
-	return nm
+	return api.FilterServerMetadata(metadata)
 }
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"log"
 	"sort"
 	"strconv"
@@ -39,6 +40,7 @@ type Manager struct {
 	eventPrefix string
 	domain      string
 	pedantic    bool
+	strict      bool
 	apiLEvel    *int
 
 	sync.Mutex
@@ -65,11 +67,37 @@ func New(nc *nats.Conn, opts ...Option) (*Manager, error) {
 	return m, nil
 }
 
+// ForDomain creates a new Manager sharing the connection and settings of this one that addresses the JetStream domain d,
+// an empty domain addresses the local JetStream
+func (m *Manager) ForDomain(d string) *Manager {
+	return &Manager{
+		nc:          m.nc,
+		timeout:     m.timeout,
+		trace:       m.trace,
+		validator:   m.validator,
+		apiPrefix:   m.apiPrefix,
+		eventPrefix: m.eventPrefix,
+		domain:      d,
+		pedantic:    m.pedantic,
+		strict:      m.strict,
+	}
+}
+
+// Domain is the JetStream domain this manager addresses
+func (m *Manager) Domain() string {
+	return m.domain
+}
+
 // IsPedantic checks if the manager is in pedantic mode
 func (m *Manager) IsPedantic() bool {
 	return m.pedantic
 }
 
+// IsStrict checks if the manager rejects API responses holding unknown fields
+func (m *Manager) IsStrict() bool {
+	return m.strict
+}
+
 // IsJetStreamEnabled determines if JetStream is enabled for the current account
 func (m *Manager) IsJetStreamEnabled() bool {
 	info, err := m.JetStreamAccountInfo()
@@ -86,8 +114,13 @@ func (m *Manager) IsJetStreamEnabled() bool {
 
 // JetStreamAccountInfo retrieves information about the current account limits and more
 func (m *Manager) JetStreamAccountInfo() (info *api.JetStreamAccountStats, err error) {
+	return m.JetStreamAccountInfoContext(context.Background())
+}
+
+// JetStreamAccountInfoContext retrieves information about the current account limits and more, interrupted by ctx
+func (m *Manager) JetStreamAccountInfoContext(ctx context.Context) (info *api.JetStreamAccountStats, err error) {
 	var resp api.JSApiAccountInfoResponse
-	err = m.jsonRequest(api.JSApiAccountInfo, nil, &resp)
+	err = m.jsonRequestWithContext(ctx, api.JSApiAccountInfo, nil, &resp)
 	if err != nil {
 		if errors.Is(err, nats.ErrNoResponders) {
 			return nil, nats.ErrJetStreamNotEnabled
@@ -120,6 +153,26 @@ func (m *Manager) MetaApiLevel(refresh bool) (int, error) {
 	return nfo.API.Level, nil
 }
 
+// Features describes the JetStream capabilities of the meta leader based on its API level
+func (m *Manager) Features() (api.Features, error) {
+	lvl, err := m.MetaApiLevel(false)
+	if err != nil {
+		return api.Features{}, err
+	}
+
+	return api.FeaturesForApiLevel(lvl), nil
+}
+
+// Supports determines if the meta leader supports feature, allows callers to avoid making requests that would fail on older servers
+func (m *Manager) Supports(feature api.Feature) (bool, error) {
+	features, err := m.Features()
+	if err != nil {
+		return false, err
+	}
+
+	return features.Supports(feature), nil
+}
+
 // IsStreamMaxBytesRequired determines if the JetStream account requires streams to set a byte limit
 func (m *Manager) IsStreamMaxBytesRequired() (bool, error) {
 	nfo, err := m.JetStreamAccountInfo()
@@ -141,6 +194,21 @@ func (m *Manager) IsStreamMaxBytesRequired() (bool, error) {
 }
 
 func (m *Manager) jsonRequest(subj string, req any, response any) (err error) {
+	return m.jsonRequestWithContext(context.Background(), subj, req, response)
+}
+
+// jsonRequestWithContext performs a JSON API request interrupted by ctx, when ctx has no deadline the manager timeout is used
+func (m *Manager) jsonRequestWithContext(ctx context.Context, subj string, req any, response any) (err error) {
+	if m == nil || m.nc == nil {
+		return fmt.Errorf("nats connection is not set")
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.timeout)
+		defer cancel()
+	}
+
 	var body []byte
 	var hdr nats.Header
 
@@ -164,14 +232,28 @@ func (m *Manager) jsonRequest(subj string, req any, response any) (err error) {
 		}
 	}
 
-	msg, err := m.request(m.apiSubject(subj), body, hdr)
+	msg, err := m.requestWithContext(ctx, m.apiSubject(subj), body, hdr)
 	if err != nil {
 		return err
 	}
 
-	err = json.Unmarshal(msg.Data, response)
+	if m.strict {
+		err = api.UnmarshalStrict(msg.Data, response)
+	} else {
+		err = json.Unmarshal(msg.Data, response)
+	}
 	if err != nil {
-		return err
+		var ufe *api.UnknownFieldsError
+		if !errors.As(err, &ufe) {
+			return err
+		}
+
+		// api errors take precedence as error responses might hold fields unknown to the response type
+		if jsr, ok := response.(jetStreamResponseError); ok && jsr.ToError() != nil {
+			return jsr.ToError()
+		}
+
+		return fmt.Errorf("server response is not a known %T message: %w", response, err)
 	}
 
 	jsr, ok := response.(jetStreamResponseError)
@@ -208,13 +290,18 @@ type StreamNamesFilter struct {
 
 // StreamNames is a sorted list of all known Streams filtered by filter
 func (m *Manager) StreamNames(filter *StreamNamesFilter) (names []string, err error) {
+	return m.StreamNamesContext(context.Background(), filter)
+}
+
+// StreamNamesContext is a sorted list of all known Streams filtered by filter, interrupted by ctx
+func (m *Manager) StreamNamesContext(ctx context.Context, filter *StreamNamesFilter) (names []string, err error) {
 	resp := func() apiIterableResponse { return &api.JSApiStreamNamesResponse{} }
 	req := &api.JSApiStreamNamesRequest{JSApiIterableRequest: api.JSApiIterableRequest{Offset: 0}}
 	if filter != nil {
 		req.Subject = filter.Subject
 	}
 
-	err = m.iterableRequest(api.JSApiStreamNames, req, resp, func(page any) error {
+	err = m.iterableRequestWithContext(ctx, api.JSApiStreamNames, req, resp, func(page any) error {
 		apiresp, ok := page.(*api.JSApiStreamNamesResponse)
 		if !ok {
 			return fmt.Errorf("invalid response type from iterable request")
@@ -260,11 +347,20 @@ func (m *Manager) ReadLastMessageForSubject(stream string, sub string) (msg *api
 }
 
 func (m *Manager) iterableRequest(subj string, req apiIterableRequest, response func() apiIterableResponse, cb func(any) error) (err error) {
+	return m.iterableRequestWithContext(context.Background(), subj, req, response, cb)
+}
+
+func (m *Manager) iterableRequestWithContext(ctx context.Context, subj string, req apiIterableRequest, response func() apiIterableResponse, cb func(any) error) (err error) {
 	offset := 0
 	for {
+		err = ctx.Err()
+		if err != nil {
+			return err
+		}
+
 		req.SetOffset(offset)
 		r := response()
-		err = m.jsonRequest(subj, req, r)
+		err = m.jsonRequestWithContext(ctx, subj, req, r)
 		if err != nil {
 			return err
 		}
@@ -398,8 +494,104 @@ func (m *Manager) EachStream(filter *StreamNamesFilter, cb func(*Stream)) (missi
 	return missing, offline, nil
 }
 
+// StreamsIter iterates over all known Streams fetching pages from the server as needed rather than loading all streams into memory, streams that are missing or offline are not included, use Streams() for those
+func (m *Manager) StreamsIter(filter *StreamNamesFilter) iter.Seq2[*Stream, error] {
+	return m.StreamsIterContext(context.Background(), filter)
+}
+
+// StreamsIterContext iterates over all known Streams like StreamsIter, interrupted by ctx
+func (m *Manager) StreamsIterContext(ctx context.Context, filter *StreamNamesFilter) iter.Seq2[*Stream, error] {
+	return func(yield func(*Stream, error) bool) {
+		req := &api.JSApiStreamListRequest{JSApiIterableRequest: api.JSApiIterableRequest{Offset: 0}}
+		if filter != nil {
+			req.Subject = filter.Subject
+		}
+
+		offset := 0
+		for {
+			req.SetOffset(offset)
+
+			var resp api.JSApiStreamListResponse
+			err := m.jsonRequestWithContext(ctx, api.JSApiStreamList, req, &resp)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			sort.Slice(resp.Streams, func(i int, j int) bool {
+				return resp.Streams[i].Config.Name < resp.Streams[j].Config.Name
+			})
+
+			for _, s := range resp.Streams {
+				if !yield(m.streamFromConfig(&s.Config, s), nil) {
+					return
+				}
+			}
+
+			if resp.LastPage() || resp.ItemsLimit() == 0 {
+				return
+			}
+
+			offset += resp.ItemsLimit()
+		}
+	}
+}
+
+// ConsumersIter iterates over all known Consumers within a Stream fetching pages from the server as needed, consumers that are missing or offline are not included, use Consumers() for those
+func (m *Manager) ConsumersIter(stream string) iter.Seq2[*Consumer, error] {
+	return m.ConsumersIterContext(context.Background(), stream)
+}
+
+// ConsumersIterContext iterates over all known Consumers within a Stream like ConsumersIter, interrupted by ctx
+func (m *Manager) ConsumersIterContext(ctx context.Context, stream string) iter.Seq2[*Consumer, error] {
+	return func(yield func(*Consumer, error) bool) {
+		if !IsValidName(stream) {
+			yield(nil, fmt.Errorf("%q is not a valid stream name", stream))
+			return
+		}
+
+		req := &api.JSApiConsumerListRequest{JSApiIterableRequest: api.JSApiIterableRequest{Offset: 0}}
+
+		offset := 0
+		for {
+			req.SetOffset(offset)
+
+			var resp api.JSApiConsumerListResponse
+			err := m.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiConsumerListT, stream), req, &resp)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			sort.Slice(resp.Consumers, func(i int, j int) bool {
+				return resp.Consumers[i].Name < resp.Consumers[j].Name
+			})
+
+			for _, c := range resp.Consumers {
+				consumer := m.consumerFromCfg(c.Stream, c.Name, &c.Config)
+				consumer.lastInfo = c
+
+				if !yield(consumer, nil) {
+					return
+				}
+			}
+
+			if resp.LastPage() || resp.ItemsLimit() == 0 {
+				return
+			}
+
+			offset += resp.ItemsLimit()
+		}
+	}
+}
+
 // Consumers is a sorted list of all known Consumers within a Stream and a list of any consumer names that were known but no details were found
 func (m *Manager) Consumers(stream string) (consumers []*Consumer, missing []string, offline map[string]string, err error) {
+	return m.ConsumersContext(context.Background(), stream)
+}
+
+// ConsumersContext is a sorted list of all known Consumers within a Stream like Consumers, interrupted by ctx
+func (m *Manager) ConsumersContext(ctx context.Context, stream string) (consumers []*Consumer, missing []string, offline map[string]string, err error) {
 	if !IsValidName(stream) {
 		return nil, nil, nil, fmt.Errorf("%q is not a valid stream name", stream)
 	}
@@ -409,7 +601,7 @@ func (m *Manager) Consumers(stream string) (consumers []*Consumer, missing []str
 		resp  = func() apiIterableResponse { return &api.JSApiConsumerListResponse{} }
 	)
 
-	err = m.iterableRequest(fmt.Sprintf(api.JSApiConsumerListT, stream), &api.JSApiConsumerListRequest{JSApiIterableRequest: api.JSApiIterableRequest{Offset: 0}}, resp, func(page any) error {
+	err = m.iterableRequestWithContext(ctx, fmt.Sprintf(api.JSApiConsumerListT, stream), &api.JSApiConsumerListRequest{JSApiIterableRequest: api.JSApiIterableRequest{Offset: 0}}, resp, func(page any) error {
 		apiresp, ok := page.(*api.JSApiConsumerListResponse)
 		if !ok {
 			return fmt.Errorf("invalid response type from iterable request")
@@ -455,11 +647,16 @@ func (m *Manager) Consumers(stream string) (consumers []*Consumer, missing []str
 
 // ConsumerNames is a sorted list of all known consumers within a stream
 func (m *Manager) ConsumerNames(stream string) (names []string, err error) {
+	return m.ConsumerNamesContext(context.Background(), stream)
+}
+
+// ConsumerNamesContext is a sorted list of all known consumers within a stream, interrupted by ctx
+func (m *Manager) ConsumerNamesContext(ctx context.Context, stream string) (names []string, err error) {
 	if !IsValidName(stream) {
 		return nil, fmt.Errorf("%q is not a valid stream name", stream)
 	}
 
-	err = m.iterableRequest(fmt.Sprintf(api.JSApiConsumerNamesT, stream), &api.JSApiConsumerNamesRequest{JSApiIterableRequest: api.JSApiIterableRequest{Offset: 0}}, func() apiIterableResponse { return &api.JSApiConsumerNamesResponse{} }, func(page any) error {
+	err = m.iterableRequestWithContext(ctx, fmt.Sprintf(api.JSApiConsumerNamesT, stream), &api.JSApiConsumerNamesRequest{JSApiIterableRequest: api.JSApiIterableRequest{Offset: 0}}, func() apiIterableResponse { return &api.JSApiConsumerNamesResponse{} }, func(page any) error {
 		apiresp, ok := page.(*api.JSApiConsumerNamesResponse)
 		if !ok {
 			return fmt.Errorf("invalid response type from iterable request")
@@ -480,6 +677,11 @@ func (m *Manager) ConsumerNames(stream string) (names []string, err error) {
 
 // Streams is a sorted list of all known Streams and a list of any stream names that were known but no details were found, since 2.12 offline streams and reasons will be included also
 func (m *Manager) Streams(filter *StreamNamesFilter) (streams []*Stream, missing []string, offline map[string]string, err error) {
+	return m.StreamsContext(context.Background(), filter)
+}
+
+// StreamsContext is a sorted list of all known Streams like Streams, interrupted by ctx
+func (m *Manager) StreamsContext(ctx context.Context, filter *StreamNamesFilter) (streams []*Stream, missing []string, offline map[string]string, err error) {
 	resp := func() apiIterableResponse { return &api.JSApiStreamListResponse{} }
 
 	req := &api.JSApiStreamListRequest{JSApiIterableRequest: api.JSApiIterableRequest{Offset: 0}}
@@ -487,7 +689,7 @@ func (m *Manager) Streams(filter *StreamNamesFilter) (streams []*Stream, missing
 		req.Subject = filter.Subject
 	}
 
-	err = m.iterableRequest(api.JSApiStreamList, req, resp, func(page any) error {
+	err = m.iterableRequestWithContext(ctx, api.JSApiStreamList, req, resp, func(page any) error {
 		apiresp, ok := page.(*api.JSApiStreamListResponse)
 		if !ok {
 			return fmt.Errorf("invalid response type from iterable request")
@@ -545,12 +747,17 @@ func (m *Manager) MetaLeaderStandDown(placement *api.Placement) error {
 
 // DeleteStream removes a stream without all the drama of loading it etc
 func (m *Manager) DeleteStream(stream string) error {
+	return m.DeleteStreamContext(context.Background(), stream)
+}
+
+// DeleteStreamContext removes a stream without loading it, interrupted by ctx
+func (m *Manager) DeleteStreamContext(ctx context.Context, stream string) error {
 	if stream == "" || strings.ContainsAny(stream, ".>*") {
 		return fmt.Errorf("invalid stream name")
 	}
 
 	var resp api.JSApiStreamDeleteResponse
-	err := m.jsonRequest(fmt.Sprintf(api.JSApiStreamDeleteT, stream), nil, &resp)
+	err := m.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiStreamDeleteT, stream), nil, &resp)
 	if err != nil {
 		return err
 	}
@@ -564,6 +771,11 @@ func (m *Manager) DeleteStream(stream string) error {
 
 // DeleteConsumer removes a consumer without all the drama of loading it etc
 func (m *Manager) DeleteConsumer(stream string, consumer string) error {
+	return m.DeleteConsumerContext(context.Background(), stream, consumer)
+}
+
+// DeleteConsumerContext removes a consumer without loading it, interrupted by ctx
+func (m *Manager) DeleteConsumerContext(ctx context.Context, stream string, consumer string) error {
 	if stream == "" || strings.ContainsAny(stream, ".>*") {
 		return fmt.Errorf("invalid stream name")
 	}
@@ -572,7 +784,7 @@ func (m *Manager) DeleteConsumer(stream string, consumer string) error {
 	}
 
 	var resp api.JSApiConsumerDeleteResponse
-	err := m.jsonRequest(fmt.Sprintf(api.JSApiConsumerDeleteT, stream, consumer), nil, &resp)
+	err := m.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiConsumerDeleteT, stream, consumer), nil, &resp)
 	if err != nil {
 		return err
 	}
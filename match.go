@@ -4,7 +4,11 @@ package jsm
 
 import (
 	"fmt"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/dustin/go-humanize"
 	"github.com/expr-lang/expr"
 	"gopkg.in/yaml.v3"
 )
@@ -12,6 +16,13 @@ import (
 // StreamQueryExpression filters the stream using the expr expression language
 // Using this option with a binary built with the `noexprlang` build tag will
 // always return [ErrNoExprLangBuild].
+//
+// In addition to the config, state and info maps the expression has access to these
+// values: name, subjects, replicas, cluster, leader, storage, bytes, messages, consumers,
+// idle, age, metadata, mirror and sourced.  Numbers may be given with byte units like
+// 1GB or 512MiB and durations using units like 24h, 7d or 1y, for example:
+//
+//	replicas < 3 && bytes > 1GB && idle > 24h
 func StreamQueryExpression(e string) StreamQueryOpt {
 	return func(q *streamQuery) error {
 		q.expression = e
@@ -26,6 +37,11 @@ func (q *streamQuery) matchExpression(streams []*Stream) ([]*Stream, error) {
 
 	var matched []*Stream
 
+	expression, err := expandExpressionUnits(q.expression)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, stream := range streams {
 		cfg := map[string]any{}
 		state := map[string]any{}
@@ -33,20 +49,48 @@ func (q *streamQuery) matchExpression(streams []*Stream) ([]*Stream, error) {
 
 		cfgBytes, _ := yaml.Marshal(stream.Configuration())
 		yaml.Unmarshal(cfgBytes, &cfg)
-		nfo, _ := stream.LatestInformation()
+		nfo, err := stream.LatestInformation()
+		if err != nil {
+			return nil, err
+		}
 		nfoBytes, _ := yaml.Marshal(nfo)
 		yaml.Unmarshal(nfoBytes, &info)
 		stateBytes, _ := yaml.Marshal(nfo.State)
 		yaml.Unmarshal(stateBytes, &state)
 
+		var cluster, leader string
+		if nfo.Cluster != nil {
+			cluster = nfo.Cluster.Name
+			leader = nfo.Cluster.Leader
+		}
+
+		metadata := stream.Metadata()
+		if metadata == nil {
+			metadata = map[string]string{}
+		}
+
 		env := map[string]any{
-			"config": cfg,
-			"state":  state,
-			"info":   info,
-			"Info":   nfo,
+			"config":    cfg,
+			"state":     state,
+			"info":      info,
+			"Info":      nfo,
+			"name":      stream.Name(),
+			"subjects":  stream.Subjects(),
+			"replicas":  stream.Replicas(),
+			"cluster":   cluster,
+			"leader":    leader,
+			"storage":   stream.Storage().String(),
+			"bytes":     nfo.State.Bytes,
+			"messages":  nfo.State.Msgs,
+			"consumers": nfo.State.Consumers,
+			"idle":      time.Since(nfo.State.LastTime),
+			"age":       time.Since(nfo.Created),
+			"metadata":  metadata,
+			"mirror":    stream.IsMirror(),
+			"sourced":   stream.IsSourced(),
 		}
 
-		program, err := expr.Compile(q.expression, expr.Env(env), expr.AsBool())
+		program, err := expr.Compile(expression, expr.Env(env), expr.AsBool())
 		if err != nil {
 			return nil, err
 		}
@@ -125,3 +169,78 @@ func (q *consumerQuery) matchExpression(consumers []*Consumer) ([]*Consumer, err
 
 	return matched, nil
 }
+
+// expandExpressionUnits rewrites numbers with byte or duration units into values the expression language understands,
+// 1GB becomes 1000000000 and 24h becomes duration("24h0m0s"). Quoted strings are left untouched.
+func expandExpressionUnits(e string) (string, error) {
+	var out strings.Builder
+
+	isIdent := func(r rune) bool { return r == '_' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r) }
+
+	runes := []rune(e)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == '"' || r == '\'' || r == '`':
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && r != '`' {
+					j++
+				}
+			}
+			if j >= len(runes) {
+				j = len(runes) - 1
+			}
+			out.WriteString(string(runes[i : j+1]))
+			i = j
+
+		case unicode.IsDigit(r) && (i == 0 || !isIdent(runes[i-1])):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			k := j
+			for k < len(runes) && unicode.IsLetter(runes[k]) {
+				k++
+			}
+
+			num, unit := string(runes[i:j]), string(runes[j:k])
+			if unit == "" || (k < len(runes) && isIdent(runes[k])) {
+				out.WriteString(string(runes[i:k]))
+				i = k - 1
+				continue
+			}
+
+			if isByteUnit(unit) {
+				b, err := humanize.ParseBytes(num + unit)
+				if err != nil {
+					return "", fmt.Errorf("invalid size %s%s: %w", num, unit, err)
+				}
+				fmt.Fprintf(&out, "%d", b)
+			} else {
+				d, err := ParseDuration(num + unit)
+				if err != nil {
+					return "", fmt.Errorf("invalid duration %s%s: %w", num, unit, err)
+				}
+				fmt.Fprintf(&out, "duration(%q)", d.String())
+			}
+
+			i = k - 1
+
+		default:
+			out.WriteRune(r)
+		}
+	}
+
+	return out.String(), nil
+}
+
+func isByteUnit(unit string) bool {
+	switch strings.ToLower(unit) {
+	case "b", "kb", "mb", "gb", "tb", "pb", "kib", "mib", "gib", "tib", "pib":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// DefaultSystemRequestTimeout is the maximum time spent waiting for responses from servers
+	DefaultSystemRequestTimeout = 2 * time.Second
+	// DefaultSystemRequestStall is the time waited for further responses after the last one was received when the
+	// number of servers to expect is not known
+	DefaultSystemRequestStall = 300 * time.Millisecond
+)
+
+// SystemRequestOption configures requests to the server monitoring endpoints in the system account
+type SystemRequestOption func(o *systemRequestOptions) error
+
+type systemRequestOptions struct {
+	filter  server.EventFilterOptions
+	expect  int
+	timeout time.Duration
+	stall   time.Duration
+}
+
+func newSystemRequestOptions(opts ...SystemRequestOption) (*systemRequestOptions, error) {
+	o := &systemRequestOptions{
+		timeout: DefaultSystemRequestTimeout,
+		stall:   DefaultSystemRequestStall,
+	}
+
+	for _, opt := range opts {
+		err := opt(o)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return o, nil
+}
+
+// FilterServerName limits the responses to servers with names containing name, see FilterExactMatch
+func FilterServerName(name string) SystemRequestOption {
+	return func(o *systemRequestOptions) error {
+		o.filter.Name = name
+		return nil
+	}
+}
+
+// FilterCluster limits the responses to servers in clusters with names containing cluster, see FilterExactMatch
+func FilterCluster(cluster string) SystemRequestOption {
+	return func(o *systemRequestOptions) error {
+		o.filter.Cluster = cluster
+		return nil
+	}
+}
+
+// FilterHost limits the responses to servers with host names containing host, see FilterExactMatch
+func FilterHost(host string) SystemRequestOption {
+	return func(o *systemRequestOptions) error {
+		o.filter.Host = host
+		return nil
+	}
+}
+
+// FilterTags limits the responses to servers having all the tags
+func FilterTags(tags ...string) SystemRequestOption {
+	return func(o *systemRequestOptions) error {
+		o.filter.Tags = append(o.filter.Tags, tags...)
+		return nil
+	}
+}
+
+// FilterDomain limits the responses to servers in a JetStream domain
+func FilterDomain(domain string) SystemRequestOption {
+	return func(o *systemRequestOptions) error {
+		o.filter.Domain = domain
+		return nil
+	}
+}
+
+// FilterExactMatch requires the server name, cluster and host filters to match exactly rather than being contained
+func FilterExactMatch() SystemRequestOption {
+	return func(o *systemRequestOptions) error {
+		o.filter.ExactMatch = true
+		return nil
+	}
+}
+
+// ExpectServers stops waiting for responses once responses from servers were received
+func ExpectServers(servers int) SystemRequestOption {
+	return func(o *systemRequestOptions) error {
+		if servers < 0 {
+			return fmt.Errorf("expected servers can not be negative")
+		}
+
+		o.expect = servers
+		return nil
+	}
+}
+
+// SystemRequestTimeout sets the maximum time spent waiting for responses, defaults to DefaultSystemRequestTimeout
+func SystemRequestTimeout(timeout time.Duration) SystemRequestOption {
+	return func(o *systemRequestOptions) error {
+		if timeout <= 0 {
+			return fmt.Errorf("timeout must be greater than 0")
+		}
+
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// SystemRequestStall sets how long to wait for further responses after the last one when the number of servers to
+// expect is not known, defaults to DefaultSystemRequestStall
+func SystemRequestStall(stall time.Duration) SystemRequestOption {
+	return func(o *systemRequestOptions) error {
+		if stall <= 0 {
+			return fmt.Errorf("stall must be greater than 0")
+		}
+
+		o.stall = stall
+		return nil
+	}
+}
+
+// serverResponse is implemented by the typed server monitoring responses
+type serverResponse interface {
+	server.ServerAPIVarzResponse | server.ServerAPIConnzResponse | server.ServerAPISubszResponse |
+		server.ServerAPIJszResponse | server.ServerAPIRoutezResponse | server.ServerAPIGatewayzResponse |
+		server.ServerAPILeafzResponse | server.ServerAPIAccountzResponse | server.ServerAPIHealthzResponse
+}
+
+// ServerVarz requests VARZ from all servers matching the filters, responses are sorted by server name and may hold errors
+func ServerVarz(ctx context.Context, nc *nats.Conn, opts server.VarzOptions, ropts ...SystemRequestOption) ([]*server.ServerAPIVarzResponse, error) {
+	return pingServers[server.ServerAPIVarzResponse](ctx, nc, "VARZ", ropts, func(f server.EventFilterOptions) any {
+		return server.VarzEventOptions{VarzOptions: opts, EventFilterOptions: f}
+	})
+}
+
+// ServerConnz requests CONNZ from all servers matching the filters, responses are sorted by server name and may hold
+// errors. Only the page of connections selected by opts is returned, see ServerConnzAll
+func ServerConnz(ctx context.Context, nc *nats.Conn, opts server.ConnzOptions, ropts ...SystemRequestOption) ([]*server.ServerAPIConnzResponse, error) {
+	return pingServers[server.ServerAPIConnzResponse](ctx, nc, "CONNZ", ropts, func(f server.EventFilterOptions) any {
+		return server.ConnzEventOptions{ConnzOptions: opts, EventFilterOptions: f}
+	})
+}
+
+// ServerConnzAll is like ServerConnz but requests further pages from every server until all its connections were
+// retrieved, the connections of all pages are combined into one response per server
+func ServerConnzAll(ctx context.Context, nc *nats.Conn, opts server.ConnzOptions, ropts ...SystemRequestOption) ([]*server.ServerAPIConnzResponse, error) {
+	responses, err := ServerConnz(ctx, nc, opts, ropts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resp := range responses {
+		if resp.Error != nil || resp.Data == nil || resp.Server == nil {
+			continue
+		}
+
+		for resp.Data.Offset+len(resp.Data.Conns) < resp.Data.Total {
+			popts := opts
+			popts.Offset = resp.Data.Offset + len(resp.Data.Conns)
+
+			page, err := requestServer[server.ServerAPIConnzResponse](ctx, nc, resp.Server.ID, "CONNZ", ropts, server.ConnzEventOptions{ConnzOptions: popts})
+			if err != nil {
+				return nil, err
+			}
+			if page.Error != nil {
+				return nil, fmt.Errorf("connz page request to %s failed: %s", resp.Server.Name, page.Error.Error())
+			}
+			if page.Data == nil || len(page.Data.Conns) == 0 {
+				break
+			}
+
+			resp.Data.Conns = append(resp.Data.Conns, page.Data.Conns...)
+			resp.Data.NumConns = len(resp.Data.Conns)
+			resp.Data.Total = page.Data.Total
+		}
+	}
+
+	return responses, nil
+}
+
+// ServerSubsz requests SUBSZ from all servers matching the filters, responses are sorted by server name and may hold
+// errors. Only the page of subscriptions selected by opts is returned, see ServerSubszAll
+func ServerSubsz(ctx context.Context, nc *nats.Conn, opts server.SubszOptions, ropts ...SystemRequestOption) ([]*server.ServerAPISubszResponse, error) {
+	return pingServers[server.ServerAPISubszResponse](ctx, nc, "SUBSZ", ropts, func(f server.EventFilterOptions) any {
+		return server.SubszEventOptions{SubszOptions: opts, EventFilterOptions: f}
+	})
+}
+
+// ServerSubszAll is like ServerSubsz but requests further pages from every server until all its subscriptions were
+// retrieved, the subscriptions of all pages are combined into one response per server
+func ServerSubszAll(ctx context.Context, nc *nats.Conn, opts server.SubszOptions, ropts ...SystemRequestOption) ([]*server.ServerAPISubszResponse, error) {
+	opts.Subscriptions = true
+
+	responses, err := ServerSubsz(ctx, nc, opts, ropts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resp := range responses {
+		if resp.Error != nil || resp.Data == nil || resp.Server == nil {
+			continue
+		}
+
+		for resp.Data.Offset+len(resp.Data.Subs) < resp.Data.Total {
+			popts := opts
+			popts.Offset = resp.Data.Offset + len(resp.Data.Subs)
+
+			page, err := requestServer[server.ServerAPISubszResponse](ctx, nc, resp.Server.ID, "SUBSZ", ropts, server.SubszEventOptions{SubszOptions: popts})
+			if err != nil {
+				return nil, err
+			}
+			if page.Error != nil {
+				return nil, fmt.Errorf("subsz page request to %s failed: %s", resp.Server.Name, page.Error.Error())
+			}
+			if page.Data == nil || len(page.Data.Subs) == 0 {
+				break
+			}
+
+			resp.Data.Subs = append(resp.Data.Subs, page.Data.Subs...)
+			resp.Data.Total = page.Data.Total
+		}
+	}
+
+	return responses, nil
+}
+
+// ServerJsz requests JSZ from all servers matching the filters, responses are sorted by server name and may hold errors
+func ServerJsz(ctx context.Context, nc *nats.Conn, opts server.JSzOptions, ropts ...SystemRequestOption) ([]*server.ServerAPIJszResponse, error) {
+	return pingServers[server.ServerAPIJszResponse](ctx, nc, "JSZ", ropts, func(f server.EventFilterOptions) any {
+		return server.JszEventOptions{JSzOptions: opts, EventFilterOptions: f}
+	})
+}
+
+// ServerRoutez requests ROUTEZ from all servers matching the filters, responses are sorted by server name and may hold
+// errors
+func ServerRoutez(ctx context.Context, nc *nats.Conn, opts server.RoutezOptions, ropts ...SystemRequestOption) ([]*server.ServerAPIRoutezResponse, error) {
+	return pingServers[server.ServerAPIRoutezResponse](ctx, nc, "ROUTEZ", ropts, func(f server.EventFilterOptions) any {
+		return server.RoutezEventOptions{RoutezOptions: opts, EventFilterOptions: f}
+	})
+}
+
+// ServerGatewayz requests GATEWAYZ from all servers matching the filters, responses are sorted by server name and may
+// hold errors
+func ServerGatewayz(ctx context.Context, nc *nats.Conn, opts server.GatewayzOptions, ropts ...SystemRequestOption) ([]*server.ServerAPIGatewayzResponse, error) {
+	return pingServers[server.ServerAPIGatewayzResponse](ctx, nc, "GATEWAYZ", ropts, func(f server.EventFilterOptions) any {
+		return server.GatewayzEventOptions{GatewayzOptions: opts, EventFilterOptions: f}
+	})
+}
+
+// ServerLeafz requests LEAFZ from all servers matching the filters, responses are sorted by server name and may hold
+// errors
+func ServerLeafz(ctx context.Context, nc *nats.Conn, opts server.LeafzOptions, ropts ...SystemRequestOption) ([]*server.ServerAPILeafzResponse, error) {
+	return pingServers[server.ServerAPILeafzResponse](ctx, nc, "LEAFZ", ropts, func(f server.EventFilterOptions) any {
+		return server.LeafzEventOptions{LeafzOptions: opts, EventFilterOptions: f}
+	})
+}
+
+// ServerAccountz requests ACCOUNTZ from all servers matching the filters, responses are sorted by server name and may
+// hold errors
+func ServerAccountz(ctx context.Context, nc *nats.Conn, opts server.AccountzOptions, ropts ...SystemRequestOption) ([]*server.ServerAPIAccountzResponse, error) {
+	return pingServers[server.ServerAPIAccountzResponse](ctx, nc, "ACCOUNTZ", ropts, func(f server.EventFilterOptions) any {
+		return server.AccountzEventOptions{AccountzOptions: opts, EventFilterOptions: f}
+	})
+}
+
+// ServerHealthz requests HEALTHZ from all servers matching the filters, responses are sorted by server name and may
+// hold errors
+func ServerHealthz(ctx context.Context, nc *nats.Conn, opts server.HealthzOptions, ropts ...SystemRequestOption) ([]*server.ServerAPIHealthzResponse, error) {
+	return pingServers[server.ServerAPIHealthzResponse](ctx, nc, "HEALTHZ", ropts, func(f server.EventFilterOptions) any {
+		return server.HealthzEventOptions{HealthzOptions: opts, EventFilterOptions: f}
+	})
+}
+
+// pingServers sends a request to $SYS.REQ.SERVER.PING.<endpoint> and decodes all responses, req creates the request
+// body using the configured filters
+func pingServers[T serverResponse](ctx context.Context, nc *nats.Conn, endpoint string, ropts []SystemRequestOption, req func(server.EventFilterOptions) any) ([]*T, error) {
+	o, err := newSystemRequestOptions(ropts...)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(req(o.filter))
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := jsm.RequestManyMsgs(ctx, nc, fmt.Sprintf("$SYS.REQ.SERVER.PING.%s", endpoint), body, jsm.RequestManyExpect(o.expect), jsm.RequestManyStall(o.stall), jsm.RequestManyMaxWait(o.timeout))
+	if errors.Is(err, nats.ErrNoResponders) {
+		return nil, fmt.Errorf("server request failed, ensure the account used has system privileges and appropriate permissions")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var res []*T
+	names := map[*T]string{}
+	for _, msg := range msgs {
+		resp := new(T)
+		err = json.Unmarshal(msg.Data, resp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s response: %w", endpoint, err)
+		}
+
+		var si struct {
+			Server *server.ServerInfo `json:"server"`
+		}
+		if json.Unmarshal(msg.Data, &si) == nil && si.Server != nil {
+			names[resp] = si.Server.Name
+		}
+
+		res = append(res, resp)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return names[res[i]] < names[res[j]] })
+
+	return res, nil
+}
+
+// requestServer sends a request to the <endpoint> of a single server identified by id
+func requestServer[T serverResponse](ctx context.Context, nc *nats.Conn, id string, endpoint string, ropts []SystemRequestOption, req any) (*T, error) {
+	o, err := newSystemRequestOptions(ropts...)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	msg, err := nc.RequestWithContext(ctx, fmt.Sprintf("$SYS.REQ.SERVER.%s.%s", id, endpoint), body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(T)
+	err = json.Unmarshal(msg.Data, resp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s response: %w", endpoint, err)
+	}
+
+	return resp, nil
+}
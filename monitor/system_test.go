@@ -0,0 +1,138 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go/monitor"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+func withSystemAccount(t *testing.T, cb func(srv *server.Server, nc *nats.Conn)) {
+	t.Helper()
+
+	sa := server.NewAccount("SYSTEM")
+	ua := server.NewAccount("USERS")
+
+	srv, err := server.NewServer(&server.Options{
+		Port:          -1,
+		ServerName:    "s1",
+		StoreDir:      t.TempDir(),
+		JetStream:     true,
+		Accounts:      []*server.Account{sa, ua},
+		SystemAccount: "SYSTEM",
+		Users: []*server.User{
+			{Account: sa, Username: "SYS", Password: "PASS"},
+			{Account: ua, Username: "USER", Password: "PASS"},
+		},
+	})
+	checkErr(t, err, "could not start server: %v", err)
+
+	go srv.Start()
+	if !srv.ReadyForConnections(10 * time.Second) {
+		t.Errorf("nats server did not start")
+	}
+	defer func() {
+		srv.Shutdown()
+		srv.WaitForShutdown()
+	}()
+
+	nc, err := nats.Connect(srv.ClientURL(), nats.UserInfo("SYS", "PASS"))
+	checkErr(t, err, "could not connect client to server @ %s: %v", srv.ClientURL(), err)
+	defer nc.Close()
+
+	cb(srv, nc)
+}
+
+func TestServerSystemRequests(t *testing.T) {
+	withSystemAccount(t, func(srv *server.Server, nc *nats.Conn) {
+		ctx := context.Background()
+
+		t.Run("varz", func(t *testing.T) {
+			vz, err := monitor.ServerVarz(ctx, nc, server.VarzOptions{}, monitor.ExpectServers(1))
+			checkErr(t, err, "varz failed: %v", err)
+			if len(vz) != 1 || vz[0].Data == nil || vz[0].Data.Name != "s1" {
+				t.Fatalf("invalid varz response: %+v", vz)
+			}
+		})
+
+		t.Run("filters", func(t *testing.T) {
+			vz, err := monitor.ServerVarz(ctx, nc, server.VarzOptions{}, monitor.FilterServerName("s"), monitor.FilterExactMatch(), monitor.SystemRequestTimeout(250*time.Millisecond))
+			checkErr(t, err, "varz failed: %v", err)
+			if len(vz) != 0 {
+				t.Fatalf("expected no responses got %d", len(vz))
+			}
+
+			hz, err := monitor.ServerHealthz(ctx, nc, server.HealthzOptions{}, monitor.FilterServerName("s1"), monitor.FilterExactMatch())
+			checkErr(t, err, "healthz failed: %v", err)
+			if len(hz) != 1 || hz[0].Data == nil || hz[0].Data.Status != "ok" {
+				t.Fatalf("invalid healthz response: %+v", hz)
+			}
+		})
+
+		t.Run("paged connz", func(t *testing.T) {
+			for range 4 {
+				unc, err := nats.Connect(srv.ClientURL(), nats.UserInfo("USER", "PASS"))
+				checkErr(t, err, "connect failed: %v", err)
+				defer unc.Close()
+			}
+
+			cz, err := monitor.ServerConnz(ctx, nc, server.ConnzOptions{Limit: 2})
+			checkErr(t, err, "connz failed: %v", err)
+			if len(cz) != 1 || len(cz[0].Data.Conns) != 2 || cz[0].Data.Total != 5 {
+				t.Fatalf("invalid connz response: %+v", cz[0].Data)
+			}
+
+			cz, err = monitor.ServerConnzAll(ctx, nc, server.ConnzOptions{Limit: 2})
+			checkErr(t, err, "connz failed: %v", err)
+			if len(cz) != 1 || len(cz[0].Data.Conns) != 5 || cz[0].Data.NumConns != 5 {
+				t.Fatalf("invalid connz response: %+v", cz[0].Data)
+			}
+		})
+
+		t.Run("paged subsz", func(t *testing.T) {
+			sz, err := monitor.ServerSubszAll(ctx, nc, server.SubszOptions{Limit: 2})
+			checkErr(t, err, "subsz failed: %v", err)
+			if len(sz) != 1 || sz[0].Data.Total <= 2 || len(sz[0].Data.Subs) != sz[0].Data.Total {
+				t.Fatalf("invalid subsz response: %+v", sz[0].Data)
+			}
+		})
+
+		t.Run("jsz", func(t *testing.T) {
+			jz, err := monitor.ServerJsz(ctx, nc, server.JSzOptions{Accounts: true})
+			checkErr(t, err, "jsz failed: %v", err)
+			if len(jz) != 1 || jz[0].Data == nil {
+				t.Fatalf("invalid jsz response: %+v", jz)
+			}
+		})
+
+		t.Run("options", func(t *testing.T) {
+			_, err := monitor.ServerVarz(ctx, nc, server.VarzOptions{}, monitor.ExpectServers(-1))
+			if err == nil {
+				t.Fatalf("expected an error for negative servers")
+			}
+
+			cctx, cancel := context.WithCancel(ctx)
+			cancel()
+			_, err = monitor.ServerVarz(cctx, nc, server.VarzOptions{})
+			if err != context.Canceled {
+				t.Fatalf("expected context canceled got %v", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats.go"
+)
+
+// MessageTTLNever is the TTL returned by ParseMessageTTL for messages that never expire
+const MessageTTLNever = time.Duration(-1)
+
+// DeleteMarker is a message placed in a stream by the server when the last message for a subject was removed
+type DeleteMarker struct {
+	// Subject is the subject that had its messages removed
+	Subject string `json:"subject"`
+	// Sequence is the stream sequence of the marker, 0 when unknown
+	Sequence uint64 `json:"seq,omitempty"`
+	// Reason is why the messages were removed, one of api.JSMarkerReasonMaxAge, api.JSMarkerReasonPurge or api.JSMarkerReasonRemove
+	Reason string `json:"reason"`
+	// TTL is how long the marker will be kept in the stream
+	TTL time.Duration `json:"ttl"`
+	// Time is when the marker was stored, zero when unknown
+	Time time.Time `json:"time,omitempty"`
+}
+
+// NewMessageWithTTL creates a message that will be removed from the stream after ttl, the stream must allow message TTLs
+func NewMessageWithTTL(subject string, data []byte, ttl time.Duration) (*nats.Msg, error) {
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+
+	err := SetMessageTTL(msg, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// SetMessageTTL sets the Nats-TTL header on msg, ttl is rounded down to whole seconds and must be at least 1 second,
+// use MessageTTLNever for messages that should not expire even when the stream has a MaxAge limit
+func SetMessageTTL(msg *nats.Msg, ttl time.Duration) error {
+	if msg == nil {
+		return fmt.Errorf("no message supplied")
+	}
+
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+
+	switch {
+	case ttl == MessageTTLNever:
+		msg.Header.Set(api.JSMessageTTL, api.JSMessageTTLNever)
+	case ttl < time.Second:
+		return fmt.Errorf("message TTL must be at least 1 second")
+	default:
+		msg.Header.Set(api.JSMessageTTL, strconv.FormatInt(int64(ttl.Seconds()), 10))
+	}
+
+	return nil
+}
+
+// ParseMessageTTL parses a Nats-TTL header value which is either a number of seconds, a Go duration or "never",
+// MessageTTLNever is returned for messages that never expire
+func ParseMessageTTL(ttl string) (time.Duration, error) {
+	if strings.EqualFold(ttl, api.JSMessageTTLNever) {
+		return MessageTTLNever, nil
+	}
+
+	d, err := time.ParseDuration(ttl)
+	if err == nil {
+		if d < time.Second {
+			return 0, fmt.Errorf("message TTL must be at least 1 second")
+		}
+
+		return d.Truncate(time.Second), nil
+	}
+
+	secs, err := strconv.ParseInt(ttl, 10, 64)
+	if err != nil || secs < 0 {
+		return 0, fmt.Errorf("invalid message TTL %q", ttl)
+	}
+
+	return time.Duration(secs) * time.Second, nil
+}
+
+// MessageTTL retrieves the TTL set on msg, ok is false when no TTL is set
+func MessageTTL(msg *nats.Msg) (ttl time.Duration, ok bool, err error) {
+	if msg == nil || msg.Header == nil {
+		return 0, false, nil
+	}
+
+	v := msg.Header.Get(api.JSMessageTTL)
+	if v == "" {
+		return 0, false, nil
+	}
+
+	ttl, err = ParseMessageTTL(v)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return ttl, true, nil
+}
+
+// IsDeleteMarker determines if msg is a subject delete marker placed by the server
+func IsDeleteMarker(msg *nats.Msg) bool {
+	if msg == nil || msg.Header == nil || len(msg.Data) > 0 {
+		return false
+	}
+
+	return msg.Header.Get(api.JSMarkerReason) != ""
+}
+
+// ParseDeleteMarker parses a subject delete marker received from a consumer or a direct get, an error is
+// returned when msg is not a delete marker
+func ParseDeleteMarker(msg *nats.Msg) (*DeleteMarker, error) {
+	if !IsDeleteMarker(msg) {
+		return nil, fmt.Errorf("message is not a delete marker")
+	}
+
+	marker := &DeleteMarker{
+		Subject: msg.Subject,
+		Reason:  msg.Header.Get(api.JSMarkerReason),
+	}
+
+	// direct gets report the original subject in headers while the message subject is the reply inbox
+	if subj := msg.Header.Get("Nats-Subject"); subj != "" {
+		marker.Subject = subj
+	}
+
+	ttl, ok, err := MessageTTL(msg)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		marker.TTL = ttl
+	}
+
+	meta, err := ParseJSMsgMetadata(msg)
+	if err == nil {
+		marker.Sequence = meta.StreamSequence()
+		marker.Time = meta.TimeStamp()
+	}
+
+	return marker, nil
+}
+
+// ParseStoredDeleteMarker parses a subject delete marker retrieved using Stream.ReadMessage() or similar, an error
+// is returned when msg is not a delete marker
+func ParseStoredDeleteMarker(msg *api.StoredMsg) (*DeleteMarker, error) {
+	if msg == nil || len(msg.Header) == 0 {
+		return nil, fmt.Errorf("message is not a delete marker")
+	}
+
+	hdr, err := nats.DecodeHeadersMsg(msg.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	marker, err := ParseDeleteMarker(&nats.Msg{Subject: msg.Subject, Header: hdr, Data: msg.Data})
+	if err != nil {
+		return nil, err
+	}
+
+	marker.Sequence = msg.Sequence
+	marker.Time = msg.Time
+
+	return marker, nil
+}
@@ -70,3 +70,10 @@ func WithPedanticRequests() Option {
 		o.pedantic = true
 	}
 }
+
+// WithStrictDecoding fails API requests when the server response holds fields unknown to this package, helps to detect version mismatches between this package and the server
+func WithStrictDecoding() Option {
+	return func(o *Manager) {
+		o.strict = true
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// OrderedConsumer delivers the messages of a stream in order using an ephemeral push consumer, the
+// consumer is recreated from the last received message whenever a gap is detected or heartbeats stop
+type OrderedConsumer struct {
+	mgr        *Manager
+	stream     string
+	copts      []ConsumerOption
+	heartbeat  time.Duration
+	handler    func(*nats.Msg)
+	errHandler func(error)
+	msgs       chan *nats.Msg
+
+	consumer     *Consumer
+	sub          *nats.Subscription
+	cseq         uint64
+	sseq         uint64
+	lastActivity time.Time
+	resets       int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	sync.Mutex
+}
+
+// OrderedConsumerOption configures an OrderedConsumer
+type OrderedConsumerOption func(o *OrderedConsumer) error
+
+// OrderedConsumerOptions sets the consumer options used when creating the underlying consumer, only
+// options relating to filtering and the initial delivery policy are meaningful
+func OrderedConsumerOptions(opts ...ConsumerOption) OrderedConsumerOption {
+	return func(o *OrderedConsumer) error {
+		o.copts = append(o.copts, opts...)
+		return nil
+	}
+}
+
+// OrderedConsumerHeartbeat sets the idle heartbeat interval, the consumer is recreated when no messages
+// or heartbeats are received for 3 intervals, defaults to 5 seconds
+func OrderedConsumerHeartbeat(hb time.Duration) OrderedConsumerOption {
+	return func(o *OrderedConsumer) error {
+		if hb < 100*time.Millisecond {
+			return fmt.Errorf("heartbeat must be at least 100ms")
+		}
+
+		o.heartbeat = hb
+		return nil
+	}
+}
+
+// OrderedConsumerHandler calls cb for every message in order, when not set messages are retrieved using NextMsg()
+func OrderedConsumerHandler(cb func(*nats.Msg)) OrderedConsumerOption {
+	return func(o *OrderedConsumer) error {
+		o.handler = cb
+		return nil
+	}
+}
+
+// OrderedConsumerErrorHandler calls cb with errors encountered while recreating the underlying consumer
+func OrderedConsumerErrorHandler(cb func(error)) OrderedConsumerOption {
+	return func(o *OrderedConsumer) error {
+		o.errHandler = cb
+		return nil
+	}
+}
+
+// OrderedConsumerBuffer sets how many messages are buffered for NextMsg(), defaults to 1024
+func OrderedConsumerBuffer(n int) OrderedConsumerOption {
+	return func(o *OrderedConsumer) error {
+		if n < 1 {
+			return fmt.Errorf("buffer must be at least 1")
+		}
+
+		o.msgs = make(chan *nats.Msg, n)
+		return nil
+	}
+}
+
+// NewOrderedConsumer creates an ordered consumer on stream and starts delivering messages, call Stop() to remove it
+func (m *Manager) NewOrderedConsumer(stream string, opts ...OrderedConsumerOption) (*OrderedConsumer, error) {
+	if !IsValidName(stream) {
+		return nil, fmt.Errorf("%q is not a valid stream name", stream)
+	}
+
+	o := &OrderedConsumer{
+		mgr:       m,
+		stream:    stream,
+		heartbeat: 5 * time.Second,
+	}
+
+	for _, opt := range opts {
+		err := opt(o)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if o.handler == nil && o.msgs == nil {
+		o.msgs = make(chan *nats.Msg, 1024)
+	}
+
+	o.ctx, o.cancel = context.WithCancel(context.Background())
+
+	o.Lock()
+	err := o.resetLocked()
+	o.Unlock()
+	if err != nil {
+		o.cancel()
+		return nil, err
+	}
+
+	o.wg.Add(1)
+	go o.monitor()
+
+	return o, nil
+}
+
+// NextMsg retrieves the next message in order, only available when no handler was set
+func (o *OrderedConsumer) NextMsg(ctx context.Context) (*nats.Msg, error) {
+	if o.msgs == nil {
+		return nil, fmt.Errorf("messages are delivered to the handler")
+	}
+
+	select {
+	case msg := <-o.msgs:
+		return msg, nil
+	case <-o.ctx.Done():
+		return nil, fmt.Errorf("ordered consumer stopped")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Resets is the number of times the underlying consumer was recreated after it was first created
+func (o *OrderedConsumer) Resets() int {
+	o.Lock()
+	defer o.Unlock()
+
+	return o.resets
+}
+
+// ConsumerName is the name of the current underlying consumer
+func (o *OrderedConsumer) ConsumerName() string {
+	o.Lock()
+	defer o.Unlock()
+
+	if o.consumer == nil {
+		return ""
+	}
+
+	return o.consumer.Name()
+}
+
+// StreamSequence is the stream sequence of the last message delivered
+func (o *OrderedConsumer) StreamSequence() uint64 {
+	o.Lock()
+	defer o.Unlock()
+
+	return o.sseq
+}
+
+// Stop stops delivering messages and removes the underlying consumer
+func (o *OrderedConsumer) Stop() error {
+	o.cancel()
+	o.wg.Wait()
+
+	o.Lock()
+	defer o.Unlock()
+
+	return o.removeLocked()
+}
+
+func (o *OrderedConsumer) monitor() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(o.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			o.Lock()
+			if time.Since(o.lastActivity) > 3*o.heartbeat {
+				o.reset()
+			}
+			o.Unlock()
+
+		case <-o.ctx.Done():
+			return
+		}
+	}
+}
+
+func (o *OrderedConsumer) handleMsg(m *nats.Msg) {
+	o.Lock()
+
+	if m.Sub != o.sub || o.ctx.Err() != nil {
+		o.Unlock()
+		return
+	}
+
+	o.lastActivity = time.Now()
+
+	if len(m.Data) == 0 && m.Header.Get("Status") == "100" {
+		switch {
+		case m.Reply != "":
+			m.Respond(nil)
+		case m.Header.Get("Nats-Last-Consumer") != "":
+			last, err := strconv.ParseUint(m.Header.Get("Nats-Last-Consumer"), 10, 64)
+			if err == nil && last != o.cseq {
+				o.reset()
+			}
+		}
+
+		o.Unlock()
+		return
+	}
+
+	meta, err := ParseJSMsgMetadata(m)
+	if err != nil {
+		o.Unlock()
+		return
+	}
+
+	if meta.ConsumerSequence() != o.cseq+1 {
+		o.reset()
+		o.Unlock()
+		return
+	}
+
+	o.cseq = meta.ConsumerSequence()
+	o.sseq = meta.StreamSequence()
+	o.Unlock()
+
+	if o.handler != nil {
+		o.handler(m)
+		return
+	}
+
+	select {
+	case o.msgs <- m:
+	case <-o.ctx.Done():
+	}
+}
+
+// reset recreates the consumer and reports failures to the error handler, the lock must be held
+func (o *OrderedConsumer) reset() {
+	err := o.resetLocked()
+	if err != nil && o.errHandler != nil {
+		o.errHandler(fmt.Errorf("recreating ordered consumer on %s failed: %w", o.stream, err))
+	}
+}
+
+func (o *OrderedConsumer) resetLocked() error {
+	if !o.lastActivity.IsZero() {
+		o.resets++
+	}
+
+	o.removeLocked()
+
+	// prevents immediate retries by the monitor when recreating fails
+	o.lastActivity = time.Now()
+	o.cseq = 0
+
+	var err error
+	inbox := o.mgr.nc.NewRespInbox()
+	o.sub, err = o.mgr.nc.Subscribe(inbox, o.handleMsg)
+	if err != nil {
+		return err
+	}
+
+	opts := append([]ConsumerOption{}, o.copts...)
+	opts = append(opts,
+		DeliverySubject(inbox),
+		AcknowledgeNone(),
+		PushFlowControl(),
+		IdleHeartbeat(o.heartbeat),
+		InactiveThreshold(5*o.heartbeat),
+		ConsumerOverrideReplicas(1),
+		ConsumerOverrideMemoryStorage(),
+	)
+	if o.sseq > 0 {
+		opts = append(opts, StartAtSequence(o.sseq+1))
+	}
+
+	o.consumer, err = o.mgr.NewConsumer(o.stream, opts...)
+	if err != nil {
+		o.sub.Unsubscribe()
+		o.sub = nil
+		return err
+	}
+
+	return nil
+}
+
+func (o *OrderedConsumer) removeLocked() error {
+	if o.sub != nil {
+		o.sub.Unsubscribe()
+		o.sub = nil
+	}
+
+	if o.consumer == nil {
+		return nil
+	}
+
+	err := o.consumer.Delete()
+	o.consumer = nil
+
+	return err
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsm
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+// PullRequestBuilder builds pull requests for a consumer and validates them against the request limits
+// configured on the consumer before they are sent
+type PullRequestBuilder struct {
+	consumer *Consumer
+	req      api.JSApiConsumerGetNextRequest
+	clamp    bool
+}
+
+// PullRequest creates a builder for pull requests against this consumer, limits are checked against the
+// cached consumer configuration, call Reset() on the consumer to refresh it
+func (c *Consumer) PullRequest() *PullRequestBuilder {
+	return &PullRequestBuilder{
+		consumer: c,
+		req:      api.JSApiConsumerGetNextRequest{Batch: 1},
+	}
+}
+
+// Batch sets the number of messages to request
+func (b *PullRequestBuilder) Batch(n int) *PullRequestBuilder {
+	b.req.Batch = n
+	return b
+}
+
+// MaxBytes sets the maximum size of the messages to deliver for this request
+func (b *PullRequestBuilder) MaxBytes(n int) *PullRequestBuilder {
+	b.req.MaxBytes = n
+	return b
+}
+
+// Expires sets how long the request will remain active on the server
+func (b *PullRequestBuilder) Expires(d time.Duration) *PullRequestBuilder {
+	b.req.Expires = d
+	return b
+}
+
+// NoWait requests that the server respond immediately when no messages are available
+func (b *PullRequestBuilder) NoWait() *PullRequestBuilder {
+	b.req.NoWait = true
+	return b
+}
+
+// Heartbeat sets the interval at which the server will send idle heartbeats while the request is active
+func (b *PullRequestBuilder) Heartbeat(d time.Duration) *PullRequestBuilder {
+	b.req.Heartbeat = d
+	return b
+}
+
+// Group sets the priority group the request belongs to
+func (b *PullRequestBuilder) Group(g string) *PullRequestBuilder {
+	b.req.Group = g
+	return b
+}
+
+// Priority sets the priority of the request for consumers using the prioritized policy
+func (b *PullRequestBuilder) Priority(p int) *PullRequestBuilder {
+	b.req.Priority = p
+	return b
+}
+
+// MinPending only delivers messages when the consumer has at least n messages pending
+func (b *PullRequestBuilder) MinPending(n int64) *PullRequestBuilder {
+	b.req.MinPending = n
+	return b
+}
+
+// MinAckPending only delivers messages when the consumer has at least n messages awaiting acknowledgement
+func (b *PullRequestBuilder) MinAckPending(n int64) *PullRequestBuilder {
+	b.req.MinAckPending = n
+	return b
+}
+
+// Clamp reduces Batch, MaxBytes and Expires to the consumer limits rather than failing
+func (b *PullRequestBuilder) Clamp() *PullRequestBuilder {
+	b.clamp = true
+	return b
+}
+
+// Build validates the request against the consumer limits and returns it
+func (b *PullRequestBuilder) Build() (*api.JSApiConsumerGetNextRequest, error) {
+	c := b.consumer
+	if !c.IsPullMode() {
+		return nil, fmt.Errorf("consumer %s > %s is not a pull consumer", c.StreamName(), c.Name())
+	}
+
+	req := b.req
+
+	if req.Batch < 1 {
+		return nil, fmt.Errorf("batch must be at least 1")
+	}
+	if req.MaxBytes < 0 {
+		return nil, fmt.Errorf("max bytes can not be negative")
+	}
+	if req.Expires < 0 {
+		return nil, fmt.Errorf("expires can not be negative")
+	}
+
+	if limit := c.MaxRequestBatch(); limit > 0 && req.Batch > limit {
+		if !b.clamp {
+			return nil, fmt.Errorf("batch %d exceeds the consumer maximum request batch of %d", req.Batch, limit)
+		}
+		req.Batch = limit
+	}
+
+	if limit := c.MaxRequestMaxBytes(); limit > 0 && req.MaxBytes > limit {
+		if !b.clamp {
+			return nil, fmt.Errorf("max bytes %d exceeds the consumer maximum request max bytes of %d", req.MaxBytes, limit)
+		}
+		req.MaxBytes = limit
+	}
+
+	if limit := c.MaxRequestExpires(); limit > 0 && req.Expires > limit {
+		if !b.clamp {
+			return nil, fmt.Errorf("expires %v exceeds the consumer maximum request expires of %v", req.Expires, limit)
+		}
+		req.Expires = limit
+	}
+
+	if req.Heartbeat > 0 {
+		if req.Expires == 0 {
+			return nil, fmt.Errorf("heartbeat requires expires to be set")
+		}
+		if req.Heartbeat*2 > req.Expires {
+			return nil, fmt.Errorf("heartbeat %v must be less than half of expires %v", req.Heartbeat, req.Expires)
+		}
+	}
+
+	if groups := c.PriorityGroups(); len(groups) > 0 && c.PriorityPolicy() != api.PriorityNone {
+		if req.Group == "" {
+			return nil, fmt.Errorf("a priority group is required")
+		}
+		if !slices.Contains(groups, req.Group) {
+			return nil, fmt.Errorf("priority group %q is not one of %v", req.Group, groups)
+		}
+	}
+
+	if req.Priority < 0 || req.Priority > 9 {
+		return nil, fmt.Errorf("priority must be between 0 and 9")
+	}
+
+	if (req.MinPending > 0 || req.MinAckPending > 0) && c.PriorityPolicy() != api.PriorityOverflow {
+		return nil, fmt.Errorf("min pending and min ack pending require an overflow consumer")
+	}
+
+	return &req, nil
+}
+
+// Send validates the request and sends it, messages will be delivered to inbox
+func (b *PullRequestBuilder) Send(inbox string) error {
+	req, err := b.Build()
+	if err != nil {
+		return err
+	}
+
+	return b.consumer.NextMsgRequest(inbox, req)
+}
@@ -0,0 +1,343 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats.go"
+)
+
+// RedeliveryKind indicates which advisory produced a RedeliveryEntry
+type RedeliveryKind string
+
+const (
+	// RedeliveryMaxDeliver is a message that reached the maximum deliveries of its consumer
+	RedeliveryMaxDeliver RedeliveryKind = "max_deliver"
+	// RedeliveryNak is a message that was negatively acknowledged
+	RedeliveryNak RedeliveryKind = "nak"
+	// RedeliveryTerminated is a message whose delivery was terminated by the client
+	RedeliveryTerminated RedeliveryKind = "terminated"
+)
+
+// RedeliveryEntry is a single message that exceeded the delivery threshold
+type RedeliveryEntry struct {
+	Kind       RedeliveryKind `json:"kind"`
+	Time       time.Time      `json:"timestamp"`
+	Stream     string         `json:"stream"`
+	Consumer   string         `json:"consumer"`
+	StreamSeq  uint64         `json:"stream_seq"`
+	Deliveries uint64         `json:"deliveries"`
+	Reason     string         `json:"reason,omitempty"`
+}
+
+// RedeliveryReport is the list of messages that exceeded the delivery threshold during a reporting interval
+type RedeliveryReport struct {
+	Start   time.Time         `json:"start"`
+	End     time.Time         `json:"end"`
+	Entries []RedeliveryEntry `json:"entries"`
+}
+
+// RedeliveryReporter watches consumer delivery advisories and produces periodic reports of messages
+// that exceed a delivery threshold
+type RedeliveryReporter struct {
+	mgr        *Manager
+	streams    []string
+	threshold  uint64
+	interval   time.Duration
+	handler    func(*RedeliveryReport)
+	deadLetter func(RedeliveryEntry) error
+	errHandler func(error)
+
+	subs     []*nats.Subscription
+	start    time.Time
+	entries  []RedeliveryEntry
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+
+	sync.Mutex
+}
+
+// RedeliveryReporterOption configures a RedeliveryReporter
+type RedeliveryReporterOption func(r *RedeliveryReporter) error
+
+// RedeliveryStreams limits the reporter to advisories for the given streams, by default all streams are watched
+func RedeliveryStreams(streams ...string) RedeliveryReporterOption {
+	return func(r *RedeliveryReporter) error {
+		for _, s := range streams {
+			if !IsValidName(s) {
+				return fmt.Errorf("%q is not a valid stream name", s)
+			}
+		}
+
+		r.streams = append(r.streams, streams...)
+		return nil
+	}
+}
+
+// RedeliveryThreshold sets how many deliveries a NAKed or terminated message needs before it is reported,
+// messages reaching the consumer maximum deliveries are always reported, defaults to 2
+func RedeliveryThreshold(n uint64) RedeliveryReporterOption {
+	return func(r *RedeliveryReporter) error {
+		if n < 1 {
+			return fmt.Errorf("threshold must be at least 1")
+		}
+
+		r.threshold = n
+		return nil
+	}
+}
+
+// RedeliveryInterval sets how often reports are produced, defaults to 1 minute
+func RedeliveryInterval(d time.Duration) RedeliveryReporterOption {
+	return func(r *RedeliveryReporter) error {
+		if d <= 0 {
+			return fmt.Errorf("interval must be positive")
+		}
+
+		r.interval = d
+		return nil
+	}
+}
+
+// RedeliveryReportHandler calls cb with every report that has entries
+func RedeliveryReportHandler(cb func(*RedeliveryReport)) RedeliveryReporterOption {
+	return func(r *RedeliveryReporter) error {
+		r.handler = cb
+		return nil
+	}
+}
+
+// RedeliveryDeadLetter calls cb for every message that reached its maximum deliveries or was terminated
+func RedeliveryDeadLetter(cb func(RedeliveryEntry) error) RedeliveryReporterOption {
+	return func(r *RedeliveryReporter) error {
+		r.deadLetter = cb
+		return nil
+	}
+}
+
+// RedeliveryDeadLetterSubject copies every message that reached its maximum deliveries or was terminated to
+// subject.<stream>.<consumer>, a stream listening on these subjects acts as a dead letter queue
+func RedeliveryDeadLetterSubject(subject string) RedeliveryReporterOption {
+	return func(r *RedeliveryReporter) error {
+		if subject == "" {
+			return fmt.Errorf("subject is required")
+		}
+
+		r.deadLetter = func(e RedeliveryEntry) error {
+			return r.mgr.publishDeadLetter(subject, e)
+		}
+
+		return nil
+	}
+}
+
+// RedeliveryErrorHandler calls cb with errors encountered while handling advisories
+func RedeliveryErrorHandler(cb func(error)) RedeliveryReporterOption {
+	return func(r *RedeliveryReporter) error {
+		r.errHandler = cb
+		return nil
+	}
+}
+
+// RedeliveryReporter starts watching delivery advisories, call Stop() to end watching
+func (m *Manager) RedeliveryReporter(opts ...RedeliveryReporterOption) (*RedeliveryReporter, error) {
+	r := &RedeliveryReporter{
+		mgr:       m,
+		threshold: 2,
+		interval:  time.Minute,
+		stopCh:    make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		err := opt(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	streams := r.streams
+	if len(streams) == 0 {
+		streams = []string{"*"}
+	}
+
+	r.start = time.Now()
+
+	for _, prefix := range []string{api.JSAdvisoryConsumerMaxDeliveryExceedPre, api.JSAdvisoryConsumerMsgNakPre, api.JSAdvisoryConsumerMsgTerminatedPre} {
+		for _, stream := range streams {
+			sub, err := m.nc.Subscribe(EventSubject(prefix+"."+stream+".*", m.eventPrefix), r.handleMsg)
+			if err != nil {
+				r.unsubscribe()
+				return nil, err
+			}
+
+			r.subs = append(r.subs, sub)
+		}
+	}
+
+	r.wg.Add(1)
+	go r.publisher()
+
+	return r, nil
+}
+
+func (r *RedeliveryReporter) handleMsg(m *nats.Msg) {
+	var entry RedeliveryEntry
+	err := json.Unmarshal(m.Data, &entry)
+	if err != nil {
+		r.error(fmt.Errorf("invalid advisory received on %s: %w", m.Subject, err))
+		return
+	}
+
+	var deadLetter bool
+
+	switch {
+	case strings.Contains(m.Subject, ".CONSUMER.MAX_DELIVERIES."):
+		entry.Kind = RedeliveryMaxDeliver
+		deadLetter = true
+	case strings.Contains(m.Subject, ".CONSUMER.MSG_TERMINATED."):
+		entry.Kind = RedeliveryTerminated
+		deadLetter = true
+	case strings.Contains(m.Subject, ".CONSUMER.MSG_NAKED."):
+		entry.Kind = RedeliveryNak
+	default:
+		return
+	}
+
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+
+	if entry.Kind == RedeliveryMaxDeliver || entry.Deliveries >= r.threshold {
+		r.Lock()
+		r.entries = append(r.entries, entry)
+		r.Unlock()
+	}
+
+	if deadLetter {
+		r.sendDeadLetter(entry)
+	}
+}
+
+func (r *RedeliveryReporter) sendDeadLetter(entry RedeliveryEntry) {
+	if r.deadLetter == nil {
+		return
+	}
+
+	err := r.deadLetter(entry)
+	if err != nil {
+		r.error(fmt.Errorf("dead letter handling for %s > %s sequence %d failed: %w", entry.Stream, entry.Consumer, entry.StreamSeq, err))
+	}
+}
+
+func (r *RedeliveryReporter) error(err error) {
+	if r.errHandler != nil {
+		r.errHandler(err)
+	}
+}
+
+func (r *RedeliveryReporter) publisher() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.publish()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+func (r *RedeliveryReporter) publish() {
+	report := r.Report()
+	if len(report.Entries) > 0 && r.handler != nil {
+		r.handler(report)
+	}
+}
+
+// Report returns the entries gathered since the previous report and starts a new reporting interval
+func (r *RedeliveryReporter) Report() *RedeliveryReport {
+	r.Lock()
+	defer r.Unlock()
+
+	now := time.Now()
+	report := &RedeliveryReport{
+		Start:   r.start,
+		End:     now,
+		Entries: r.entries,
+	}
+
+	r.start = now
+	r.entries = nil
+
+	return report
+}
+
+func (r *RedeliveryReporter) unsubscribe() {
+	for _, sub := range r.subs {
+		sub.Unsubscribe()
+	}
+}
+
+// Stop stops watching advisories and delivers a final report for any remaining entries, it is safe to call more than once
+func (r *RedeliveryReporter) Stop() {
+	r.stopOnce.Do(func() {
+		r.unsubscribe()
+		close(r.stopCh)
+		r.wg.Wait()
+		r.publish()
+	})
+}
+
+func (m *Manager) publishDeadLetter(subject string, e RedeliveryEntry) error {
+	if e.Kind == RedeliveryNak {
+		return nil
+	}
+
+	stream := m.streamFromConfig(&api.StreamConfig{Name: e.Stream}, nil)
+	stored, err := stream.ReadMessage(e.StreamSeq)
+	if err != nil {
+		return err
+	}
+
+	hdr := nats.Header{}
+	hdr.Set("Nats-DLQ-Kind", string(e.Kind))
+	hdr.Set("Nats-DLQ-Stream", e.Stream)
+	hdr.Set("Nats-DLQ-Consumer", e.Consumer)
+	hdr.Set("Nats-DLQ-Subject", stored.Subject)
+	hdr.Set("Nats-DLQ-Sequence", strconv.FormatUint(e.StreamSeq, 10))
+	hdr.Set("Nats-DLQ-Deliveries", strconv.FormatUint(e.Deliveries, 10))
+	if e.Reason != "" {
+		hdr.Set("Nats-DLQ-Reason", e.Reason)
+	}
+
+	res, err := m.request(fmt.Sprintf("%s.%s.%s", subject, e.Stream, e.Consumer), stored.Data, hdr)
+	if err != nil {
+		return err
+	}
+
+	_, err = ParsePubAck(res)
+	return err
+}
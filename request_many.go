@@ -0,0 +1,228 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// DefaultRequestManyMaxWait is the default maximum time RequestMany waits for responses
+	DefaultRequestManyMaxWait = 2 * time.Second
+	// DefaultRequestManyStall is the default time RequestMany waits for further responses after the last one
+	DefaultRequestManyStall = 300 * time.Millisecond
+)
+
+type requestManyOptions struct {
+	expect      int
+	maxMessages int
+	stall       time.Duration
+	maxWait     time.Duration
+	header      nats.Header
+}
+
+// RequestManyOption configures RequestMany
+type RequestManyOption func(o *requestManyOptions) error
+
+// RequestManyExpect stops waiting once n responses were received, when the number of responders is not known use 0
+// and rely on the stall timer instead
+func RequestManyExpect(n int) RequestManyOption {
+	return func(o *requestManyOptions) error {
+		if n < 0 {
+			return fmt.Errorf("expected responses can not be negative")
+		}
+
+		o.expect = n
+		return nil
+	}
+}
+
+// RequestManyMaxMessages stops waiting once n responses were received while still applying the stall timer, 0 means
+// no limit
+func RequestManyMaxMessages(n int) RequestManyOption {
+	return func(o *requestManyOptions) error {
+		if n < 0 {
+			return fmt.Errorf("maximum messages can not be negative")
+		}
+
+		o.maxMessages = n
+		return nil
+	}
+}
+
+// RequestManyStall sets how long to wait for further responses after the last one was received, only used when the
+// number of expected responses is not known. Defaults to DefaultRequestManyStall
+func RequestManyStall(d time.Duration) RequestManyOption {
+	return func(o *requestManyOptions) error {
+		if d <= 0 {
+			return fmt.Errorf("stall must be greater than 0")
+		}
+
+		o.stall = d
+		return nil
+	}
+}
+
+// RequestManyMaxWait sets the maximum time to wait for responses, defaults to DefaultRequestManyMaxWait
+func RequestManyMaxWait(d time.Duration) RequestManyOption {
+	return func(o *requestManyOptions) error {
+		if d <= 0 {
+			return fmt.Errorf("maximum wait must be greater than 0")
+		}
+
+		o.maxWait = d
+		return nil
+	}
+}
+
+// RequestManyHeader adds a header to the request
+func RequestManyHeader(key string, value string) RequestManyOption {
+	return func(o *requestManyOptions) error {
+		if o.header == nil {
+			o.header = nats.Header{}
+		}
+
+		o.header.Add(key, value)
+		return nil
+	}
+}
+
+// RequestMany publishes data to subj and calls cb for every response received, cb is never called concurrently. It
+// waits until the expected number of responses arrived, no further responses arrived within the stall period, the
+// maximum wait time passed or ctx is done. Returning an error from cb stops collection and the error is returned.
+//
+// This is typically used to gather responses from all servers via the $SYS account but works for any subject. When
+// nothing responds nats.ErrNoResponders is returned
+func RequestMany(ctx context.Context, nc *nats.Conn, subj string, data []byte, cb func(*nats.Msg) error, opts ...RequestManyOption) error {
+	o := &requestManyOptions{
+		stall:   DefaultRequestManyStall,
+		maxWait: DefaultRequestManyMaxWait,
+	}
+
+	for _, opt := range opts {
+		err := opt(o)
+		if err != nil {
+			return err
+		}
+	}
+
+	limit := o.expect
+	if limit == 0 || (o.maxMessages > 0 && o.maxMessages < limit) {
+		limit = o.maxMessages
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.maxWait)
+	defer cancel()
+
+	var (
+		mu    sync.Mutex
+		ctr   int
+		done  bool
+		stall *time.Timer
+		errs  = make(chan error, 1)
+	)
+
+	if o.expect == 0 {
+		// the first response can take up to the maximum wait time to arrive
+		stall = time.AfterFunc(o.maxWait, cancel)
+		defer stall.Stop()
+	}
+
+	sub, err := nc.Subscribe(nc.NewRespInbox(), func(m *nats.Msg) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if done {
+			return
+		}
+
+		if m.Header.Get("Status") == "503" {
+			done = true
+			errs <- nats.ErrNoResponders
+			return
+		}
+
+		if stall != nil {
+			stall.Reset(o.stall)
+		}
+
+		err := cb(m)
+		if err != nil {
+			done = true
+			errs <- err
+			return
+		}
+
+		ctr++
+		if limit > 0 && ctr == limit {
+			done = true
+			cancel()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	if limit > 0 {
+		sub.AutoUnsubscribe(limit)
+	}
+
+	msg := nats.NewMsg(subj)
+	msg.Reply = sub.Subject
+	msg.Data = data
+	for k, v := range o.header {
+		msg.Header[k] = v
+	}
+
+	err = nc.PublishMsg(msg)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case err = <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	sub.Unsubscribe()
+
+	// wait for a callback that might be in progress to finish
+	mu.Lock()
+	done = true
+	mu.Unlock()
+
+	return nil
+}
+
+// RequestManyMsgs is like RequestMany but returns all responses received
+func RequestManyMsgs(ctx context.Context, nc *nats.Conn, subj string, data []byte, opts ...RequestManyOption) ([]*nats.Msg, error) {
+	var res []*nats.Msg
+
+	err := RequestMany(ctx, nc, subj, data, func(m *nats.Msg) error {
+		res = append(res, m)
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
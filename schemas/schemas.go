@@ -2,6 +2,9 @@ package schemas
 
 import (
 	"embed"
+	"encoding/json"
+	"fmt"
+	"sync"
 )
 
 //go:embed jetstream
@@ -10,6 +13,40 @@ import (
 
 var schemas embed.FS
 
+var (
+	updated = map[string][]byte{}
+	mu      sync.RWMutex
+)
+
+// Load reads a schema, schemas replaced using Update takes precedence over the embedded ones
 func Load(schema string) ([]byte, error) {
+	mu.RLock()
+	data, ok := updated[schema]
+	mu.RUnlock()
+
+	if ok {
+		return data, nil
+	}
+
 	return schemas.ReadFile(schema)
 }
+
+// Update replaces a schema with data, typically a newer version fetched from the schema registry
+func Update(schema string, data []byte) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("invalid JSON schema %s", schema)
+	}
+
+	mu.Lock()
+	updated[schema] = append([]byte{}, data...)
+	mu.Unlock()
+
+	return nil
+}
+
+// Reset discards all schemas set using Update restoring the embedded ones
+func Reset() {
+	mu.Lock()
+	updated = map[string][]byte{}
+	mu.Unlock()
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/klauspost/compress/s2"
+)
+
+const (
+	s2ChunkCompressed   = 0x00
+	s2ChunkUncompressed = 0x01
+	s2ChunkPadding      = 0xfe
+	s2ChunkIdentifier   = 0xff
+)
+
+var (
+	s2CRCTable       = crc32.MakeTable(crc32.Castagnoli)
+	s2MagicBody      = []byte("S2sTwO")
+	s2MagicBodySnapy = []byte("sNaPpY")
+)
+
+// s2VerifyingReader passes through an s2 compressed stream one frame at a time, verifying the checksum
+// of every data frame before it is returned to the caller
+type s2VerifyingReader struct {
+	r      io.Reader
+	pend   []byte
+	frames int
+}
+
+func newS2VerifyingReader(r io.Reader) *s2VerifyingReader {
+	return &s2VerifyingReader{r: r}
+}
+
+func (v *s2VerifyingReader) Read(p []byte) (int, error) {
+	if len(v.pend) == 0 {
+		err := v.nextFrame()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, v.pend)
+	v.pend = v.pend[n:]
+
+	return n, nil
+}
+
+func (v *s2VerifyingReader) nextFrame() error {
+	hdr := make([]byte, 4)
+	_, err := io.ReadFull(v.r, hdr)
+	if err == io.EOF {
+		if v.frames == 0 {
+			return fmt.Errorf("snapshot data is empty")
+		}
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("snapshot frame %d header is truncated: %w", v.frames+1, err)
+	}
+
+	v.frames++
+	kind := hdr[0]
+	size := int(hdr[1]) | int(hdr[2])<<8 | int(hdr[3])<<16
+
+	body := make([]byte, size)
+	_, err = io.ReadFull(v.r, body)
+	if err != nil {
+		return fmt.Errorf("snapshot frame %d is truncated: %w", v.frames, err)
+	}
+
+	if v.frames == 1 && kind != s2ChunkIdentifier {
+		return fmt.Errorf("snapshot data is not s2 compressed")
+	}
+
+	switch {
+	case kind == s2ChunkIdentifier:
+		if !bytes.Equal(body, s2MagicBody) && !bytes.Equal(body, s2MagicBodySnapy) {
+			return fmt.Errorf("snapshot frame %d has an invalid stream identifier", v.frames)
+		}
+
+	case kind == s2ChunkCompressed, kind == s2ChunkUncompressed:
+		if size < 4 {
+			return fmt.Errorf("snapshot frame %d is too short", v.frames)
+		}
+
+		data := body[4:]
+		if kind == s2ChunkCompressed {
+			data, err = s2.Decode(nil, data)
+			if err != nil {
+				return fmt.Errorf("snapshot frame %d could not be decoded: %w", v.frames, err)
+			}
+		}
+
+		expected := uint32(body[0]) | uint32(body[1])<<8 | uint32(body[2])<<16 | uint32(body[3])<<24
+		if s2Checksum(data) != expected {
+			return fmt.Errorf("snapshot frame %d checksum mismatch", v.frames)
+		}
+
+	case kind == s2ChunkPadding, kind >= 0x80:
+		// padding and skippable frames carry no data
+
+	default:
+		return errors.New("snapshot data contains a reserved unskippable frame")
+	}
+
+	v.pend = append(hdr, body...)
+
+	return nil
+}
+
+func s2Checksum(b []byte) uint32 {
+	c := crc32.Update(0, s2CRCTable, b)
+	return ((c >> 15) | (c << 17)) + 0xa282ead8
+}
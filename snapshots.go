@@ -48,6 +48,7 @@ type snapshotOptions struct {
 	jsck          bool
 	chunkSz       int
 	progress      bool
+	verify        bool
 	restoreConfig *api.StreamConfig
 }
 
@@ -103,6 +104,20 @@ func RestoreConfiguration(cfg api.StreamConfig) SnapshotOption {
 	}
 }
 
+// RestoreVerifyChecksums verifies the checksum of every compressed block of the snapshot data before it is sent to the server
+func RestoreVerifyChecksums() SnapshotOption {
+	return func(o *snapshotOptions) {
+		o.verify = true
+	}
+}
+
+// RestoreDataSize sets the size of the snapshot data, used to estimate progress when restoring from a reader
+func RestoreDataSize(sz int64) SnapshotOption {
+	return func(o *snapshotOptions) {
+		o.dataFileSize = sz
+	}
+}
+
 // SnapshotChunkSize sets the size of messages holding data the server will send, good values are 64KB and 128KB
 func SnapshotChunkSize(sz int) SnapshotOption {
 	return func(o *snapshotOptions) {
@@ -138,6 +153,8 @@ type SnapshotProgress interface {
 	HealthCheck() bool
 	// Finished will be true after all data have been written
 	Finished() bool
+	// ETA is the estimated time remaining based on the uncompressed bytes received, 0 when unknown or finished
+	ETA() time.Duration
 }
 
 type RestoreProgress interface {
@@ -155,6 +172,8 @@ type RestoreProgress interface {
 	BytesSent() uint64
 	// BytesPerSecond is the number of bytes received in the last second, 0 during the first second
 	BytesPerSecond() uint64
+	// ETA is the estimated time remaining based on the chunks sent, 0 when unknown or finished
+	ETA() time.Duration
 }
 
 type snapshotProgress struct {
@@ -278,6 +297,28 @@ func (sp *snapshotProgress) BytesSent() uint64 {
 	return sp.bytesSent
 }
 
+func (sp *snapshotProgress) ETA() time.Duration {
+	sp.Lock()
+	defer sp.Unlock()
+
+	if sp.finished || !sp.endTime.IsZero() {
+		return 0
+	}
+
+	var done, total float64
+	if sp.sending {
+		done, total = float64(sp.chunksSent), float64(sp.chunksToSend)
+	} else {
+		done, total = float64(sp.uncompressedBytesReceived), float64(sp.bytesExpected)
+	}
+
+	if done == 0 || done >= total {
+		return 0
+	}
+
+	return time.Duration(float64(time.Since(sp.startTime)) * (total - done) / done)
+}
+
 func (sp *snapshotProgress) notify() {
 	if sp.scb != nil {
 		sp.scb(sp)
@@ -522,10 +563,49 @@ func (s *Stream) SnapshotToBuffer(ctx context.Context, dataBuffer, metadataBuffe
 	return s.createSnapshot(ctx, dataBuffer, metadataBuffer, sopts)
 }
 
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// SnapshotToWriter creates a compressed s2 backup streaming the data to data and the metadata to meta as
+// chunks arrive, the writers are not closed. Progress is tracked when SnapshotNotify() is used.
+//
+// The JetStream snapshot API does not support resuming a snapshot, an interrupted snapshot has to be started again
+func (s *Stream) SnapshotToWriter(ctx context.Context, data io.Writer, meta io.Writer, opts ...SnapshotOption) (SnapshotProgress, error) {
+	sopts := &snapshotOptions{
+		chunkSz: 128 * 1024,
+	}
+
+	for _, opt := range opts {
+		opt(sopts)
+	}
+
+	if sopts.scb != nil {
+		sopts.progress = true
+	}
+
+	if sopts.chunkSz <= 0 {
+		return nil, fmt.Errorf("chunk size must be positive")
+	}
+
+	return s.createSnapshot(ctx, nopWriteCloser{data}, nopWriteCloser{meta}, sopts)
+}
+
 func (m *Manager) restoreSnapshot(ctx context.Context, stream string, dataReader, metadataReader io.ReadCloser, sopts *snapshotOptions) (RestoreProgress, *api.StreamState, error) {
 	defer dataReader.Close()
 	defer metadataReader.Close()
 
+	if sopts.chunkSz <= 0 {
+		return nil, nil, fmt.Errorf("chunk size must be positive")
+	}
+
+	// chunks are sent as single messages so can not exceed the max payload of the connection
+	if mp := m.nc.MaxPayload(); mp > 0 && int64(sopts.chunkSz) > mp {
+		sopts.chunkSz = int(mp)
+	}
+
 	req := api.JSApiStreamRestoreRequest{}
 	mj, err := io.ReadAll(metadataReader)
 	if err != nil {
@@ -533,9 +613,15 @@ func (m *Manager) restoreSnapshot(ctx context.Context, stream string, dataReader
 	}
 	err = json.Unmarshal(mj, &req)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, fmt.Errorf("invalid snapshot metadata: %w", err)
+	}
+
+	if req.Config.Name == "" {
+		return nil, nil, fmt.Errorf("snapshot metadata does not contain a stream configuration")
 	}
 
+	snapshotConfig := req.Config
+
 	// allow full config override
 	if sopts.restoreConfig != nil {
 		req.Config = *sopts.restoreConfig
@@ -554,6 +640,29 @@ func (m *Manager) restoreSnapshot(ctx context.Context, stream string, dataReader
 		return nil, nil, ErrMemoryStreamNotSupported
 	}
 
+	// the data in the snapshot has to remain compatible with the configuration it is restored into, other
+	// settings like replicas or placement may be changed during restore
+	var incompatible []string
+	for _, change := range api.DiffStreamConfig(snapshotConfig, req.Config).Recreate() {
+		switch change.Field {
+		case "storage", "retention":
+			incompatible = append(incompatible, fmt.Sprintf("%s: %s", change.Field, change.Reason))
+		}
+	}
+	if len(incompatible) > 0 {
+		return nil, nil, fmt.Errorf("restore configuration is not compatible with the snapshot: %s", strings.Join(incompatible, ", "))
+	}
+
+	valid, errs := req.Config.Validate(m.validator)
+	if !valid {
+		return nil, nil, fmt.Errorf("restore configuration validation failed: %s", strings.Join(errs, ", "))
+	}
+
+	var data io.Reader = dataReader
+	if sopts.verify {
+		data = newS2VerifyingReader(dataReader)
+	}
+
 	var resp api.JSApiStreamRestoreResponse
 	err = m.jsonRequest(fmt.Sprintf(api.JSApiStreamRestoreT, req.Config.Name), req, &resp)
 	if err != nil {
@@ -590,7 +699,7 @@ func (m *Manager) restoreSnapshot(ctx context.Context, stream string, dataReader
 	}
 
 	nc := m.nc
-	var chunk [64 * 1024]byte
+	chunk := make([]byte, sopts.chunkSz)
 	var cresp *nats.Msg
 
 	for {
@@ -598,7 +707,10 @@ func (m *Manager) restoreSnapshot(ctx context.Context, stream string, dataReader
 			return nil, nil, ctx.Err()
 		}
 
-		n, err := dataReader.Read(chunk[:])
+		n, err := io.ReadFull(data, chunk)
+		if err == io.ErrUnexpectedEOF {
+			err = nil
+		}
 		if err == io.EOF {
 			break
 		}
@@ -706,3 +818,22 @@ func (m *Manager) RestoreSnapshotFromBuffer(ctx context.Context, stream string,
 	_, ss, err := m.restoreSnapshot(ctx, stream, dataReader, metadataReader, sopts)
 	return ss, err
 }
+
+// RestoreSnapshotFromReader restores a stream from a s2 compressed backup read from data with metadata read from
+// meta, the readers are not closed. The metadata is validated against the restore configuration before any data is
+// sent and progress is tracked when RestoreNotify() is used, use RestoreDataSize() to improve progress estimates.
+func (m *Manager) RestoreSnapshotFromReader(ctx context.Context, stream string, data io.Reader, meta io.Reader, opts ...SnapshotOption) (RestoreProgress, *api.StreamState, error) {
+	sopts := &snapshotOptions{
+		chunkSz: 64 * 1024,
+	}
+
+	for _, opt := range opts {
+		opt(sopts)
+	}
+
+	if sopts.rcb != nil {
+		sopts.progress = true
+	}
+
+	return m.restoreSnapshot(ctx, stream, io.NopCloser(data), io.NopCloser(meta), sopts)
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/nats-io/jsm.go/api"
+)
+
+// CompressionEstimate is an estimate of the on-disk size of a stream when compressed with S2
+type CompressionEstimate struct {
+	// Compressed indicates the stream is already configured for compression
+	Compressed bool `json:"compressed"`
+	// Bytes is the size of the messages in the stream as reported by the server
+	Bytes uint64 `json:"bytes"`
+	// EstimatedBytes is the estimated size of the messages once compressed
+	EstimatedBytes uint64 `json:"estimated_bytes"`
+	// EstimatedSavings is the estimated number of bytes saved by compression
+	EstimatedSavings uint64 `json:"estimated_savings"`
+	// Ratio is the compressed size of the sample divided by its uncompressed size
+	Ratio float64 `json:"ratio"`
+	// SampledBytes is the size of the sample the ratio was calculated from
+	SampledBytes int `json:"sampled_bytes"`
+}
+
+// EstimateCompressionSavings estimates the on-disk savings S2 compression would give the stream described by info
+// based on how well sample compresses, sample should be representative message content from the stream
+func EstimateCompressionSavings(info *api.StreamInfo, sample []byte) (*CompressionEstimate, error) {
+	if info == nil {
+		return nil, fmt.Errorf("stream info is required")
+	}
+
+	if info.Config.Storage != api.FileStorage {
+		return nil, fmt.Errorf("compression is only supported for file storage")
+	}
+
+	if len(sample) == 0 {
+		return nil, fmt.Errorf("sample is required")
+	}
+
+	est := &CompressionEstimate{
+		Compressed:   info.Config.Compression != api.NoCompression,
+		Bytes:        info.State.Bytes,
+		SampledBytes: len(sample),
+	}
+
+	est.Ratio = min(float64(len(s2.Encode(nil, sample)))/float64(len(sample)), 1)
+	est.EstimatedBytes = uint64(float64(est.Bytes) * est.Ratio)
+	est.EstimatedSavings = est.Bytes - est.EstimatedBytes
+
+	return est, nil
+}
+
+// EstimateCompressionSavings reads up to samples messages spread evenly across the stream and estimates the
+// on-disk savings S2 compression would give, see EstimateCompressionSavings()
+func (s *Stream) EstimateCompressionSavings(samples int) (*CompressionEstimate, error) {
+	if samples < 1 {
+		return nil, fmt.Errorf("at least 1 sample is required")
+	}
+
+	info, err := s.Information()
+	if err != nil {
+		return nil, err
+	}
+
+	if info.State.Msgs == 0 {
+		return nil, fmt.Errorf("stream %s has no messages to sample", s.Name())
+	}
+
+	first := info.State.FirstSeq
+	span := info.State.LastSeq - first + 1
+	step := max(span/uint64(samples), 1)
+
+	var sample []byte
+	for seq := first; seq <= info.State.LastSeq && samples > 0; seq += step {
+		msg, err := s.ReadMessage(seq)
+		switch {
+		case errors.Is(err, api.ErrNoMessageFound):
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		sample = append(sample, msg.Subject...)
+		sample = append(sample, msg.Header...)
+		sample = append(sample, msg.Data...)
+		samples--
+	}
+
+	return EstimateCompressionSavings(info, sample)
+}
@@ -0,0 +1,294 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsm
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+// StreamSourceLag describes how far a mirror or source is behind its origin stream
+type StreamSourceLag struct {
+	// Name is the name of the origin stream
+	Name string `json:"name"`
+	// External is the API prefix of the origin stream when it is in another account or domain
+	External string `json:"external,omitempty"`
+	// Lag is the number of messages the origin stream is ahead
+	Lag uint64 `json:"lag"`
+	// LastSeen is how long ago the origin stream was last heard from, -1 when it was never heard from
+	LastSeen time.Duration `json:"last_seen"`
+	// Active indicates the origin stream was heard from and no error is reported
+	Active bool `json:"active"`
+	// Error is the error reported by the server for this source
+	Error error `json:"-"`
+}
+
+// NewStreamSourceLag creates lag information from the source information reported by the server
+func NewStreamSourceLag(info *api.StreamSourceInfo) StreamSourceLag {
+	lag := StreamSourceLag{
+		Name:     info.Name,
+		Lag:      info.Lag,
+		LastSeen: info.Active,
+		Active:   info.Active >= 0 && info.Error == nil,
+	}
+
+	if info.External != nil {
+		lag.External = info.External.ApiPrefix
+	}
+
+	if info.Error != nil {
+		lag.Error = info.Error
+	}
+
+	return lag
+}
+
+// MirrorLag retrieves current information about the stream and reports how far the mirror is behind its origin
+func (s *Stream) MirrorLag() (*StreamSourceLag, error) {
+	if !s.IsMirror() {
+		return nil, fmt.Errorf("stream %s is not a mirror", s.Name())
+	}
+
+	info, err := s.Information()
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Mirror == nil {
+		return nil, fmt.Errorf("no mirror information reported for stream %s", s.Name())
+	}
+
+	lag := NewStreamSourceLag(info.Mirror)
+
+	return &lag, nil
+}
+
+// SourceLags retrieves current information about the stream and reports how far every source is behind its origin
+func (s *Stream) SourceLags() ([]StreamSourceLag, error) {
+	if !s.IsSourced() {
+		return nil, fmt.Errorf("stream %s is not sourcing any streams", s.Name())
+	}
+
+	info, err := s.Information()
+	if err != nil {
+		return nil, err
+	}
+
+	lags := make([]StreamSourceLag, 0, len(info.Sources))
+	for _, source := range info.Sources {
+		if source == nil {
+			continue
+		}
+
+		lags = append(lags, NewStreamSourceLag(source))
+	}
+
+	return lags, nil
+}
+
+// StreamLagEvent is produced by a StreamLagWatcher when a mirror or source starts or stops exceeding the thresholds
+type StreamLagEvent struct {
+	// Stream is the stream being watched
+	Stream string `json:"stream"`
+	// Mirror indicates the event is about the mirror rather than a source
+	Mirror bool `json:"mirror"`
+	// Source is the lag information for the mirror or source
+	Source StreamSourceLag `json:"source"`
+	// Exceeded is true when the thresholds are exceeded and false when the source recovered
+	Exceeded bool `json:"exceeded"`
+	// Reasons lists the thresholds that are exceeded
+	Reasons []string `json:"reasons,omitempty"`
+	// Time is when the event was produced
+	Time time.Time `json:"time"`
+}
+
+// StreamLagWatcher periodically polls a stream and reports when its mirror or sources exceed lag thresholds
+type StreamLagWatcher struct {
+	stream      *Stream
+	interval    time.Duration
+	maxLag      uint64
+	maxLastSeen time.Duration
+	handler     func(StreamLagEvent)
+	errHandler  func(error)
+	exceeded    map[string]bool
+	stopCh      chan struct{}
+	stopOnce    sync.Once
+	wg          sync.WaitGroup
+}
+
+// StreamLagWatcherOption configures a StreamLagWatcher
+type StreamLagWatcherOption func(w *StreamLagWatcher) error
+
+// LagWatchInterval sets how often the stream is polled, defaults to 10 seconds
+func LagWatchInterval(d time.Duration) StreamLagWatcherOption {
+	return func(w *StreamLagWatcher) error {
+		if d <= 0 {
+			return fmt.Errorf("interval must be positive")
+		}
+
+		w.interval = d
+		return nil
+	}
+}
+
+// LagWatchMaxLag sets the number of messages a source may be behind before being reported, 0 disables the check
+func LagWatchMaxLag(n uint64) StreamLagWatcherOption {
+	return func(w *StreamLagWatcher) error {
+		w.maxLag = n
+		return nil
+	}
+}
+
+// LagWatchMaxLastSeen sets how long a source may go without being heard from before being reported, 0 disables the check
+func LagWatchMaxLastSeen(d time.Duration) StreamLagWatcherOption {
+	return func(w *StreamLagWatcher) error {
+		if d < 0 {
+			return fmt.Errorf("last seen threshold can not be negative")
+		}
+
+		w.maxLastSeen = d
+		return nil
+	}
+}
+
+// LagWatchHandler calls cb for every event
+func LagWatchHandler(cb func(StreamLagEvent)) StreamLagWatcherOption {
+	return func(w *StreamLagWatcher) error {
+		w.handler = cb
+		return nil
+	}
+}
+
+// LagWatchErrorHandler calls cb with errors encountered while polling the stream
+func LagWatchErrorHandler(cb func(error)) StreamLagWatcherOption {
+	return func(w *StreamLagWatcher) error {
+		w.errHandler = cb
+		return nil
+	}
+}
+
+// WatchLag starts polling the stream for mirror and source lag, call Stop() on the watcher to end polling
+func (s *Stream) WatchLag(opts ...StreamLagWatcherOption) (*StreamLagWatcher, error) {
+	if !s.IsMirror() && !s.IsSourced() {
+		return nil, fmt.Errorf("stream %s is not a mirror and does not source any streams", s.Name())
+	}
+
+	w := &StreamLagWatcher{
+		stream:   s,
+		interval: 10 * time.Second,
+		exceeded: map[string]bool{},
+		stopCh:   make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		err := opt(w)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if w.handler == nil {
+		return nil, fmt.Errorf("a handler is required")
+	}
+
+	w.wg.Add(1)
+	go w.watch()
+
+	return w, nil
+}
+
+// Stop stops polling the stream, it is safe to call more than once
+func (w *StreamLagWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+		w.wg.Wait()
+	})
+}
+
+func (w *StreamLagWatcher) watch() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.poll()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.poll()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+func (w *StreamLagWatcher) poll() {
+	info, err := w.stream.Information()
+	if err != nil {
+		if w.errHandler != nil {
+			w.errHandler(err)
+		}
+		return
+	}
+
+	if info.Mirror != nil {
+		w.check(NewStreamSourceLag(info.Mirror), true)
+	}
+
+	for _, source := range info.Sources {
+		if source != nil {
+			w.check(NewStreamSourceLag(source), false)
+		}
+	}
+}
+
+func (w *StreamLagWatcher) check(lag StreamSourceLag, mirror bool) {
+	var reasons []string
+
+	if lag.Error != nil {
+		reasons = append(reasons, fmt.Sprintf("error: %v", lag.Error))
+	}
+	if w.maxLag > 0 && lag.Lag > w.maxLag {
+		reasons = append(reasons, fmt.Sprintf("lag %d exceeds %d", lag.Lag, w.maxLag))
+	}
+	if w.maxLastSeen > 0 {
+		switch {
+		case lag.LastSeen < 0:
+			reasons = append(reasons, "never seen")
+		case lag.LastSeen > w.maxLastSeen:
+			reasons = append(reasons, fmt.Sprintf("last seen %v ago exceeds %v", lag.LastSeen.Round(time.Millisecond), w.maxLastSeen))
+		}
+	}
+
+	key := lag.External + ">" + lag.Name
+	exceeded := len(reasons) > 0
+	if exceeded == w.exceeded[key] {
+		return
+	}
+
+	w.exceeded[key] = exceeded
+
+	w.handler(StreamLagEvent{
+		Stream:   w.stream.Name(),
+		Mirror:   mirror,
+		Source:   lag,
+		Exceeded: exceeded,
+		Reasons:  reasons,
+		Time:     time.Now().UTC(),
+	})
+}
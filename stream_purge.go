@@ -0,0 +1,225 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+// StreamPurge builds a purge request for a stream combining subject filters, keep limits and sequence or time
+// limits, the request can be previewed using DryRun() before messages are removed using Purge()
+type StreamPurge struct {
+	stream  *Stream
+	subject string
+	keep    *uint64
+	seq     *uint64
+	before  *time.Time
+}
+
+// PurgePreview reports the effect a purge would have
+type PurgePreview struct {
+	// Request is the purge request that would be sent to the server
+	Request api.JSApiStreamPurgeRequest `json:"request"`
+	// Messages is the number of messages that would be removed
+	Messages uint64 `json:"messages"`
+	// Exact indicates Messages is an exact count rather than an upper bound, limiting by sequence or time
+	// in combination with a subject filter can only be estimated
+	Exact bool `json:"exact"`
+}
+
+// NewPurge starts building a purge request for the stream
+func (s *Stream) NewPurge() *StreamPurge {
+	return &StreamPurge{stream: s}
+}
+
+// Subject limits the purge to messages matching subject, which may include wildcards
+func (p *StreamPurge) Subject(subject string) *StreamPurge {
+	p.subject = subject
+	return p
+}
+
+// Keep retains the last n messages matching the purge
+func (p *StreamPurge) Keep(n uint64) *StreamPurge {
+	p.keep = &n
+	return p
+}
+
+// UpToSequence removes messages with sequences lower than seq
+func (p *StreamPurge) UpToSequence(seq uint64) *StreamPurge {
+	p.seq = &seq
+	return p
+}
+
+// UpToTime removes messages received before t
+func (p *StreamPurge) UpToTime(t time.Time) *StreamPurge {
+	p.before = &t
+	return p
+}
+
+func (p *StreamPurge) validate() error {
+	if !p.stream.PurgeAllowed() {
+		return fmt.Errorf("stream %s does not allow purging", p.stream.Name())
+	}
+
+	if p.keep != nil && *p.keep == 0 {
+		return fmt.Errorf("keep must be at least 1")
+	}
+
+	if p.seq != nil && *p.seq == 0 {
+		return fmt.Errorf("sequence must be at least 1")
+	}
+
+	if p.seq != nil && p.before != nil {
+		return fmt.Errorf("sequence and time limits are mutually exclusive")
+	}
+
+	if p.keep != nil && (p.seq != nil || p.before != nil) {
+		return fmt.Errorf("keep and sequence or time limits are mutually exclusive")
+	}
+
+	return nil
+}
+
+// Request validates the purge and creates the request that would be sent to the server, time limits are
+// resolved to a sequence by looking up the first message received at or after the time
+func (p *StreamPurge) Request() (*api.JSApiStreamPurgeRequest, error) {
+	err := p.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	req := &api.JSApiStreamPurgeRequest{Subject: p.subject}
+
+	if p.keep != nil {
+		req.Keep = *p.keep
+	}
+
+	if p.seq != nil {
+		req.Sequence = *p.seq
+	}
+
+	if p.before != nil {
+		req.Sequence, err = p.sequenceForTime(*p.before)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return req, nil
+}
+
+func (p *StreamPurge) sequenceForTime(t time.Time) (uint64, error) {
+	filter := p.subject
+	if filter == "" {
+		filter = ">"
+	}
+
+	ut := t.UTC()
+	var resp api.JSApiMsgGetResponse
+	err := p.stream.mgr.jsonRequest(fmt.Sprintf(api.JSApiMsgGetT, p.stream.Name()), api.JSApiMsgGetRequest{NextFor: filter, StartTime: &ut}, &resp)
+	if err == nil {
+		return resp.Message.Sequence, nil
+	}
+
+	if !errors.Is(err, api.ErrNoMessageFound) {
+		return 0, err
+	}
+
+	// no messages after t so everything is older
+	state, err := p.stream.State()
+	if err != nil {
+		return 0, err
+	}
+
+	return state.LastSeq + 1, nil
+}
+
+// DryRun reports how many messages the purge would remove without removing any
+func (p *StreamPurge) DryRun() (*PurgePreview, error) {
+	req, err := p.Request()
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &PurgePreview{Request: *req, Exact: true}
+
+	var ireq api.JSApiStreamInfoRequest
+	if req.Subject != "" {
+		ireq.SubjectsFilter = req.Subject
+	}
+
+	nfo, err := p.stream.Information(ireq)
+	if err != nil {
+		return nil, err
+	}
+
+	state := nfo.State
+
+	matched := state.Msgs
+	if req.Subject != "" {
+		matched = 0
+		for _, n := range state.Subjects {
+			matched += n
+		}
+	}
+
+	switch {
+	case req.Keep > 0:
+		if matched > req.Keep {
+			preview.Messages = matched - req.Keep
+		}
+
+	case req.Sequence > 0:
+		if req.Sequence <= state.FirstSeq {
+			break
+		}
+
+		below := min(req.Sequence, state.LastSeq+1) - state.FirstSeq
+		if req.Subject == "" && state.NumDeleted == 0 {
+			preview.Messages = below
+		} else {
+			preview.Messages = min(matched, below)
+			preview.Exact = req.Sequence > state.LastSeq
+		}
+
+	default:
+		preview.Messages = matched
+	}
+
+	return preview, nil
+}
+
+// Purge removes the messages and returns how many were removed
+func (p *StreamPurge) Purge() (uint64, error) {
+	req, err := p.Request()
+	if err != nil {
+		return 0, err
+	}
+
+	var resp api.JSApiStreamPurgeResponse
+	err = p.stream.mgr.jsonRequest(fmt.Sprintf(api.JSApiStreamPurgeT, p.stream.Name()), req, &resp)
+	if err != nil {
+		return 0, err
+	}
+
+	if !resp.Success {
+		return 0, fmt.Errorf("unknown failure")
+	}
+
+	return resp.Purged, nil
+}
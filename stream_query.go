@@ -16,10 +16,10 @@ package jsm
 import (
 	"regexp"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/subject"
 )
 
 type streamMatcher func([]*Stream) ([]*Stream, error)
@@ -495,77 +495,7 @@ func (q *streamQuery) matchServer(streams []*Stream) ([]*Stream, error) {
 	return matched, nil
 }
 
-const (
-	btsep = '.'
-	fwc   = '>'
-	pwc   = '*'
-)
-
-// SubjectIsSubsetMatch tests if a subject matches a standard nats wildcard
-func SubjectIsSubsetMatch(subject, test string) bool {
-	tsa := [32]string{}
-	tts := tokenizeSubjectIntoSlice(tsa[:0], subject)
-	return isSubsetMatch(tts, test)
-}
-
-// This will test a subject as an array of tokens against a test subject
-// Calls into the function isSubsetMatchTokenized
-func isSubsetMatch(tokens []string, test string) bool {
-	tsa := [32]string{}
-	tts := tokenizeSubjectIntoSlice(tsa[:0], test)
-	return isSubsetMatchTokenized(tokens, tts)
-}
-
-// use similar to append. meaning, the updated slice will be returned
-func tokenizeSubjectIntoSlice(tts []string, subject string) []string {
-	start := 0
-	for i := 0; i < len(subject); i++ {
-		if subject[i] == btsep {
-			tts = append(tts, subject[start:i])
-			start = i + 1
-		}
-	}
-	tts = append(tts, subject[start:])
-	return tts
-}
-
-// This will test a subject as an array of tokens against a test subject (also encoded as array of tokens)
-// and determine if the tokens are matched. Both test subject and tokens
-// may contain wildcards. So foo.* is a subset match of [">", "*.*", "foo.*"],
-// but not of foo.bar, etc.
-func isSubsetMatchTokenized(tokens, test []string) bool {
-	// Walk the target tokens
-	for i, t2 := range test {
-		if i >= len(tokens) {
-			return false
-		}
-		l := len(t2)
-		if l == 0 {
-			return false
-		}
-		if t2[0] == fwc && l == 1 {
-			return true
-		}
-		t1 := tokens[i]
-
-		l = len(t1)
-		if l == 0 || t1[0] == fwc && l == 1 {
-			return false
-		}
-
-		if t1[0] == pwc && len(t1) == 1 {
-			m := t2[0] == pwc && len(t2) == 1
-			if !m {
-				return false
-			}
-			if i >= len(test) {
-				return true
-			}
-			continue
-		}
-		if t2[0] != pwc && strings.Compare(t1, t2) != 0 {
-			return false
-		}
-	}
-	return len(tokens) == len(test)
+// SubjectIsSubsetMatch tests if a subject matches a standard nats wildcard, see subject.IsSubsetMatch()
+func SubjectIsSubsetMatch(subj, test string) bool {
+	return subject.IsSubsetMatch(subj, test)
 }
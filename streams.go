@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"math"
 	"strconv"
 	"strings"
@@ -73,6 +74,11 @@ type Stream struct {
 
 // NewStreamFromDefault creates a new stream based on a supplied template and options
 func (m *Manager) NewStreamFromDefault(name string, dflt api.StreamConfig, opts ...StreamOption) (stream *Stream, err error) {
+	return m.NewStreamFromDefaultContext(context.Background(), name, dflt, opts...)
+}
+
+// NewStreamFromDefaultContext creates a new stream based on a supplied template and options, interrupted by ctx
+func (m *Manager) NewStreamFromDefaultContext(ctx context.Context, name string, dflt api.StreamConfig, opts ...StreamOption) (stream *Stream, err error) {
 	if !IsValidName(name) {
 		return nil, fmt.Errorf("%q is not a valid stream name", name)
 	}
@@ -89,6 +95,16 @@ func (m *Manager) NewStreamFromDefault(name string, dflt api.StreamConfig, opts
 		return nil, fmt.Errorf("configuration validation failed: %s", strings.Join(errs, ", "))
 	}
 
+	err = cfg.ValidateRePublish()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.ValidateCompression()
+	if err != nil {
+		return nil, err
+	}
+
 	var resp api.JSApiStreamCreateResponse
 
 	req := api.JSApiStreamCreateRequest{
@@ -96,7 +112,7 @@ func (m *Manager) NewStreamFromDefault(name string, dflt api.StreamConfig, opts
 		StreamConfig: *cfg,
 	}
 
-	err = m.jsonRequest(fmt.Sprintf(api.JSApiStreamCreateT, name), &req, &resp)
+	err = m.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiStreamCreateT, name), &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +182,7 @@ func (m *Manager) LoadOrNewStreamFromDefault(name string, dflt api.StreamConfig,
 		o(&dflt)
 	}
 	s, err := m.LoadStream(name)
-	if IsNatsError(err, 10059) {
+	if errors.Is(err, api.ErrStreamNotFound) {
 		return m.NewStreamFromDefault(name, dflt)
 	}
 
@@ -185,6 +201,11 @@ func (m *Manager) LoadOrNewStream(name string, opts ...StreamOption) (stream *St
 
 // LoadStream loads a stream by name
 func (m *Manager) LoadStream(name string) (stream *Stream, err error) {
+	return m.LoadStreamContext(context.Background(), name)
+}
+
+// LoadStreamContext loads a stream by name, interrupted by ctx
+func (m *Manager) LoadStreamContext(ctx context.Context, name string) (stream *Stream, err error) {
 	if !IsValidName(name) {
 		return nil, fmt.Errorf("%q is not a valid stream name", name)
 	}
@@ -194,7 +215,7 @@ func (m *Manager) LoadStream(name string) (stream *Stream, err error) {
 		cfg: &api.StreamConfig{Name: name},
 	}
 
-	err = m.loadConfigForStream(stream)
+	err = m.loadConfigForStream(ctx, stream)
 	if err != nil {
 		return nil, err
 	}
@@ -202,6 +223,37 @@ func (m *Manager) LoadStream(name string) (stream *Stream, err error) {
 	return stream, nil
 }
 
+// CloneStream creates a new stream dst using the configuration of stream src as a template, opts can be used to
+// adjust the configuration of the new stream.  Subjects of the source stream are copied, since overlapping subjects
+// are not allowed these would typically be changed or removed using Subjects().
+//
+// To copy the data from the original stream into the clone add it as a source:
+//
+//	mgr.CloneStream("ORDERS", "ORDERS_NEW", jsm.Subjects(), jsm.AppendSource(&api.StreamSource{Name: "ORDERS"}))
+func (m *Manager) CloneStream(src string, dst string, opts ...StreamOption) (stream *Stream, err error) {
+	if !IsValidName(src) {
+		return nil, fmt.Errorf("%q is not a valid stream name", src)
+	}
+	if !IsValidName(dst) {
+		return nil, fmt.Errorf("%q is not a valid stream name", dst)
+	}
+	if src == dst {
+		return nil, fmt.Errorf("source and destination streams can not be the same")
+	}
+
+	source, err := m.LoadStream(src)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := source.Configuration()
+	cfg.Name = dst
+	cfg.Sealed = false
+	cfg.Metadata = FilterServerMetadata(cfg.Metadata)
+
+	return m.NewStreamFromDefault(dst, cfg, opts...)
+}
+
 // NewStreamConfiguration generates a new configuration based on template modified by opts
 func (m *Manager) NewStreamConfiguration(template api.StreamConfig, opts ...StreamOption) (*api.StreamConfig, error) {
 	return NewStreamConfiguration(template, opts...)
@@ -221,8 +273,8 @@ func NewStreamConfiguration(template api.StreamConfig, opts ...StreamOption) (*a
 	return cfg, nil
 }
 
-func (m *Manager) loadConfigForStream(stream *Stream) (err error) {
-	info, err := m.loadStreamInfo(stream.cfg.Name, nil)
+func (m *Manager) loadConfigForStream(ctx context.Context, stream *Stream) (err error) {
+	info, err := m.loadStreamInfo(ctx, stream.cfg.Name, nil)
 	if err != nil {
 		return err
 	}
@@ -235,9 +287,9 @@ func (m *Manager) loadConfigForStream(stream *Stream) (err error) {
 	return nil
 }
 
-func (m *Manager) loadStreamInfo(stream string, req *api.JSApiStreamInfoRequest) (info *api.StreamInfo, err error) {
+func (m *Manager) loadStreamInfo(ctx context.Context, stream string, req *api.JSApiStreamInfoRequest) (info *api.StreamInfo, err error) {
 	var resp api.JSApiStreamInfoResponse
-	err = m.jsonRequest(fmt.Sprintf(api.JSApiStreamInfoT, stream), req, &resp)
+	err = m.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiStreamInfoT, stream), req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -540,6 +592,30 @@ func Republish(m *api.RePublish) StreamOption {
 	}
 }
 
+// RepublishSubjects republishes messages stored on subjects matching src to dest after they are stored
+func RepublishSubjects(src string, dest string) StreamOption {
+	return republish(src, dest, false)
+}
+
+// RepublishHeadersOnly republishes only the headers of messages stored on subjects matching src to dest
+func RepublishHeadersOnly(src string, dest string) StreamOption {
+	return republish(src, dest, true)
+}
+
+func republish(src string, dest string, headersOnly bool) StreamOption {
+	return func(o *api.StreamConfig) error {
+		r := &api.RePublish{Source: src, Destination: dest, HeadersOnly: headersOnly}
+
+		err := r.Validate(nil)
+		if err != nil {
+			return err
+		}
+
+		o.RePublish = r
+		return nil
+	}
+}
+
 func StreamMetadata(meta map[string]string) StreamOption {
 	return func(o *api.StreamConfig) error {
 		for k := range meta {
@@ -553,8 +629,15 @@ func StreamMetadata(meta map[string]string) StreamOption {
 	}
 }
 
+// Compression sets the algorithm used to compress stored messages, compression is only supported for file storage
 func Compression(alg api.Compression) StreamOption {
 	return func(o *api.StreamConfig) error {
+		switch alg {
+		case api.NoCompression, api.S2Compression:
+		default:
+			return fmt.Errorf("unknown compression algorithm %d", alg)
+		}
+
 		o.Compression = alg
 		return nil
 	}
@@ -574,9 +657,23 @@ func AllowMsgTTL() StreamOption {
 	}
 }
 
+// SubjectDeleteMarkerTTL places delete markers with the given TTL when the last message for a subject is removed,
+// delete markers require per-message TTLs and rollups so both are enabled. A TTL of 0 disables delete markers
 func SubjectDeleteMarkerTTL(d time.Duration) StreamOption {
 	return func(o *api.StreamConfig) error {
+		if d == 0 {
+			o.SubjectDeleteMarkerTTL = 0
+			return nil
+		}
+
+		if d < time.Second {
+			return fmt.Errorf("subject delete marker TTL must be at least 1 second")
+		}
+
 		o.SubjectDeleteMarkerTTL = d
+		o.AllowMsgTTL = true
+		o.RollupAllowed = true
+		o.DenyPurge = false
 
 		return nil
 	}
@@ -614,28 +711,47 @@ func (s *Stream) PageContents(opts ...PagerOption) (*StreamPager, error) {
 
 // UpdateConfiguration updates the stream using cfg modified by opts, reloads configuration from the server post update
 func (s *Stream) UpdateConfiguration(cfg api.StreamConfig, opts ...StreamOption) error {
+	return s.UpdateConfigurationContext(context.Background(), cfg, opts...)
+}
+
+// UpdateConfigurationContext updates the stream using cfg modified by opts, reloads configuration from the server post update, interrupted by ctx
+func (s *Stream) UpdateConfigurationContext(ctx context.Context, cfg api.StreamConfig, opts ...StreamOption) error {
 	ncfg, err := NewStreamConfiguration(cfg, opts...)
 	if err != nil {
 		return err
 	}
 
+	// existing streams may hold settings the server accepts, only validate compression when it is being changed
+	current := s.Configuration()
+	if ncfg.Compression != current.Compression || ncfg.Storage != current.Storage {
+		err = ncfg.ValidateCompression()
+		if err != nil {
+			return err
+		}
+	}
+
 	req := api.JSApiStreamUpdateRequest{
 		Pedantic:     s.mgr.pedantic,
 		StreamConfig: *ncfg,
 	}
 
 	var resp api.JSApiStreamUpdateResponse
-	err = s.mgr.jsonRequest(fmt.Sprintf(api.JSApiStreamUpdateT, s.Name()), &req, &resp)
+	err = s.mgr.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiStreamUpdateT, s.Name()), &req, &resp)
 	if err != nil {
 		return err
 	}
 
-	return s.Reset()
+	return s.ResetContext(ctx)
 }
 
 // Reset reloads the Stream configuration from the JetStream server
 func (s *Stream) Reset() error {
-	return s.mgr.loadConfigForStream(s)
+	return s.ResetContext(context.Background())
+}
+
+// ResetContext reloads the Stream configuration from the JetStream server, interrupted by ctx
+func (s *Stream) ResetContext(ctx context.Context) error {
+	return s.mgr.loadConfigForStream(ctx, s)
 }
 
 // LoadConsumer loads a named consumer related to this Stream
@@ -682,6 +798,11 @@ func (s *Stream) EachConsumer(cb func(consumer *Consumer)) (missing []string, of
 	return missing, offline, nil
 }
 
+// ConsumersIter iterates over all known consumers for this stream, fetching pages from the server as needed
+func (s *Stream) ConsumersIter() iter.Seq2[*Consumer, error] {
+	return s.mgr.ConsumersIter(s.Name())
+}
+
 // LatestInformation returns the most recently fetched stream information
 func (s *Stream) LatestInformation() (info *api.StreamInfo, err error) {
 	nfo := s.lastInfoLocked()
@@ -702,6 +823,11 @@ func (s *Stream) lastInfoLocked() *api.StreamInfo {
 
 // Information loads the current stream information
 func (s *Stream) Information(req ...api.JSApiStreamInfoRequest) (info *api.StreamInfo, err error) {
+	return s.InformationContext(context.Background(), req...)
+}
+
+// InformationContext loads the current stream information, interrupted by ctx
+func (s *Stream) InformationContext(ctx context.Context, req ...api.JSApiStreamInfoRequest) (info *api.StreamInfo, err error) {
 	if len(req) > 1 {
 		return nil, fmt.Errorf("only one request info is accepted")
 	}
@@ -711,7 +837,7 @@ func (s *Stream) Information(req ...api.JSApiStreamInfoRequest) (info *api.Strea
 		ireq = req[0]
 	}
 
-	info, err = s.mgr.loadStreamInfo(s.Name(), &ireq)
+	info, err = s.mgr.loadStreamInfo(ctx, s.Name(), &ireq)
 	if err != nil {
 		return nil, err
 	}
@@ -744,7 +870,12 @@ func (s *Stream) ClusterInfo() (api.ClusterInfo, error) {
 
 // State retrieves the Stream State
 func (s *Stream) State(req ...api.JSApiStreamInfoRequest) (stats api.StreamState, err error) {
-	info, err := s.Information(req...)
+	return s.StateContext(context.Background(), req...)
+}
+
+// StateContext retrieves the Stream State, interrupted by ctx
+func (s *Stream) StateContext(ctx context.Context, req ...api.JSApiStreamInfoRequest) (stats api.StreamState, err error) {
+	info, err := s.InformationContext(ctx, req...)
 	if err != nil {
 		return api.StreamState{}, err
 	}
@@ -754,8 +885,13 @@ func (s *Stream) State(req ...api.JSApiStreamInfoRequest) (stats api.StreamState
 
 // Delete deletes the Stream, after this the Stream object should be disposed
 func (s *Stream) Delete() error {
+	return s.DeleteContext(context.Background())
+}
+
+// DeleteContext deletes the Stream, interrupted by ctx, after this the Stream object should be disposed
+func (s *Stream) DeleteContext(ctx context.Context) error {
 	var resp api.JSApiStreamDeleteResponse
-	err := s.mgr.jsonRequest(fmt.Sprintf(api.JSApiStreamDeleteT, s.Name()), nil, &resp)
+	err := s.mgr.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiStreamDeleteT, s.Name()), nil, &resp)
 	if err != nil {
 		return err
 	}
@@ -770,13 +906,23 @@ func (s *Stream) Delete() error {
 // Seal updates a stream so that messages can not be added or removed using the API and limits will not be processed - messages will never age out.
 // A sealed stream can not be unsealed.
 func (s *Stream) Seal() error {
+	return s.SealContext(context.Background())
+}
+
+// SealContext seals the stream, interrupted by ctx, see Seal()
+func (s *Stream) SealContext(ctx context.Context) error {
 	cfg := s.Configuration()
 	cfg.Sealed = true
-	return s.UpdateConfiguration(cfg)
+	return s.UpdateConfigurationContext(ctx, cfg)
 }
 
 // Purge deletes messages from the Stream, an optional JSApiStreamPurgeRequest can be supplied to limit the purge to a subset of messages
 func (s *Stream) Purge(opts ...*api.JSApiStreamPurgeRequest) error {
+	return s.PurgeContext(context.Background(), opts...)
+}
+
+// PurgeContext deletes messages from the Stream, interrupted by ctx, see Purge()
+func (s *Stream) PurgeContext(ctx context.Context, opts ...*api.JSApiStreamPurgeRequest) error {
 	if len(opts) > 1 {
 		return fmt.Errorf("only one purge option allowed")
 	}
@@ -787,7 +933,7 @@ func (s *Stream) Purge(opts ...*api.JSApiStreamPurgeRequest) error {
 	}
 
 	var resp api.JSApiStreamPurgeResponse
-	err := s.mgr.jsonRequest(fmt.Sprintf(api.JSApiStreamPurgeT, s.Name()), req, &resp)
+	err := s.mgr.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiStreamPurgeT, s.Name()), req, &resp)
 	if err != nil {
 		return err
 	}
@@ -806,8 +952,13 @@ func (s *Stream) ReadLastMessageForSubject(subj string) (*api.StoredMsg, error)
 
 // ReadMessage loads a message from the stream by its sequence number
 func (s *Stream) ReadMessage(seq uint64) (msg *api.StoredMsg, err error) {
+	return s.ReadMessageContext(context.Background(), seq)
+}
+
+// ReadMessageContext loads a message from the stream by its sequence number, interrupted by ctx
+func (s *Stream) ReadMessageContext(ctx context.Context, seq uint64) (msg *api.StoredMsg, err error) {
 	var resp api.JSApiMsgGetResponse
-	err = s.mgr.jsonRequest(fmt.Sprintf(api.JSApiMsgGetT, s.Name()), api.JSApiMsgGetRequest{Seq: seq}, &resp)
+	err = s.mgr.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiMsgGetT, s.Name()), api.JSApiMsgGetRequest{Seq: seq}, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -831,12 +982,17 @@ func (s *Stream) DeleteMessage(seq uint64) (err error) {
 
 // DeleteMessageRequest deletes a specific message from the Stream with a full request
 func (s *Stream) DeleteMessageRequest(req api.JSApiMsgDeleteRequest) (err error) {
+	return s.DeleteMessageRequestContext(context.Background(), req)
+}
+
+// DeleteMessageRequestContext deletes a specific message from the Stream with a full request, interrupted by ctx
+func (s *Stream) DeleteMessageRequestContext(ctx context.Context, req api.JSApiMsgDeleteRequest) (err error) {
 	if req.Seq == 0 {
 		return fmt.Errorf("sequence number is required")
 	}
 
 	var resp api.JSApiMsgDeleteResponse
-	err = s.mgr.jsonRequest(fmt.Sprintf(api.JSApiMsgDeleteT, s.Name()), req, &resp)
+	err = s.mgr.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiMsgDeleteT, s.Name()), req, &resp)
 	if err != nil {
 		return err
 	}
@@ -861,8 +1017,13 @@ func (s *Stream) MetricSubject() string {
 
 // RemoveRAFTPeer removes a peer from the group indicating it will not return
 func (s *Stream) RemoveRAFTPeer(peer string) error {
+	return s.RemoveRAFTPeerContext(context.Background(), peer)
+}
+
+// RemoveRAFTPeerContext removes a peer from the group indicating it will not return, interrupted by ctx
+func (s *Stream) RemoveRAFTPeerContext(ctx context.Context, peer string) error {
 	var resp api.JSApiStreamRemovePeerResponse
-	err := s.mgr.jsonRequest(fmt.Sprintf(api.JSApiStreamRemovePeerT, s.Name()), api.JSApiStreamRemovePeerRequest{Peer: peer}, &resp)
+	err := s.mgr.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiStreamRemovePeerT, s.Name()), api.JSApiStreamRemovePeerRequest{Peer: peer}, &resp)
 	if err != nil {
 		return err
 	}
@@ -876,6 +1037,11 @@ func (s *Stream) RemoveRAFTPeer(peer string) error {
 
 // LeaderStepDown requests the current RAFT group leader in a clustered JetStream to stand down forcing a new election, the election of the next leader can be influenced by placement
 func (s *Stream) LeaderStepDown(placement ...*api.Placement) error {
+	return s.LeaderStepDownContext(context.Background(), placement...)
+}
+
+// LeaderStepDownContext requests the current RAFT group leader to stand down, interrupted by ctx, see LeaderStepDown()
+func (s *Stream) LeaderStepDownContext(ctx context.Context, placement ...*api.Placement) error {
 	var p *api.Placement
 	if len(placement) > 1 {
 		return fmt.Errorf("only one placement option allowed")
@@ -884,7 +1050,7 @@ func (s *Stream) LeaderStepDown(placement ...*api.Placement) error {
 	}
 
 	var resp api.JSApiStreamLeaderStepDownResponse
-	err := s.mgr.jsonRequest(fmt.Sprintf(api.JSApiStreamLeaderStepDownT, s.Name()), api.JSApiStreamLeaderStepDownRequest{Placement: p}, &resp)
+	err := s.mgr.jsonRequestWithContext(ctx, fmt.Sprintf(api.JSApiStreamLeaderStepDownT, s.Name()), api.JSApiStreamLeaderStepDownRequest{Placement: p}, &resp)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,179 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package subject provides helpers to validate, compare and manipulate NATS subjects and subject filters
+package subject
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// Separator separates the tokens of a subject
+	Separator = "."
+	// SingleWildcard matches any single token
+	SingleWildcard = "*"
+	// FullWildcard matches one or more trailing tokens
+	FullWildcard = ">"
+)
+
+// IsValid determines if subj is a valid subject or filter, tokens may not be empty or hold whitespace and the full
+// wildcard is only allowed as the last token
+func IsValid(subj string) bool {
+	if subj == "" {
+		return false
+	}
+
+	tokens := Tokens(subj)
+	for i, t := range tokens {
+		if t == "" || strings.ContainsAny(t, " \t\r\n\f") {
+			return false
+		}
+
+		if t == FullWildcard && i != len(tokens)-1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsValidLiteral determines if subj is a valid subject without wildcards, as required when publishing
+func IsValidLiteral(subj string) bool {
+	return IsValid(subj) && !HasWildcard(subj)
+}
+
+// HasWildcard determines if any token of subj is a wildcard
+func HasWildcard(subj string) bool {
+	for _, t := range Tokens(subj) {
+		if t == SingleWildcard || t == FullWildcard {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches determines if the literal subject subj matches filter, always false when subj holds wildcards
+func Matches(subj string, filter string) bool {
+	return IsValidLiteral(subj) && IsSubsetMatch(subj, filter)
+}
+
+// IsSubsetMatch determines if every subject matched by subj, that may hold wildcards, is also matched by filter.
+// For example foo.* is a subset of foo.> and *.* but not of foo.bar
+func IsSubsetMatch(subj string, filter string) bool {
+	st := Tokens(subj)
+	ft := Tokens(filter)
+
+	for i, f := range ft {
+		if i >= len(st) {
+			return false
+		}
+
+		if f == FullWildcard {
+			return true
+		}
+
+		s := st[i]
+		switch {
+		case s == FullWildcard:
+			return false
+		case f == SingleWildcard:
+			continue
+		case s != f:
+			return false
+		}
+	}
+
+	return len(st) == len(ft)
+}
+
+// Overlaps determines if any subject can be matched by both filters a and b
+func Overlaps(a string, b string) bool {
+	at := Tokens(a)
+	bt := Tokens(b)
+
+	for i := 0; i < len(at) && i < len(bt); i++ {
+		x, y := at[i], bt[i]
+
+		switch {
+		case x == FullWildcard || y == FullWildcard:
+			return true
+		case x == SingleWildcard || y == SingleWildcard || x == y:
+			continue
+		default:
+			return false
+		}
+	}
+
+	return len(at) == len(bt)
+}
+
+// Tokens splits subj into its tokens
+func Tokens(subj string) []string {
+	return strings.Split(subj, Separator)
+}
+
+// Join creates a subject from tokens
+func Join(tokens ...string) string {
+	return strings.Join(tokens, Separator)
+}
+
+// NumTokens is the number of tokens in subj
+func NumTokens(subj string) int {
+	return strings.Count(subj, Separator) + 1
+}
+
+// Token retrieves the token at index, starting from 0, false when subj has too few tokens
+func Token(subj string, index int) (string, bool) {
+	tokens := Tokens(subj)
+	if index < 0 || index >= len(tokens) {
+		return "", false
+	}
+
+	return tokens[index], true
+}
+
+// ReplaceToken replaces the token at index, starting from 0, with token
+func ReplaceToken(subj string, index int, token string) (string, error) {
+	tokens := Tokens(subj)
+	if index < 0 || index >= len(tokens) {
+		return "", fmt.Errorf("subject %q has no token %d", subj, index)
+	}
+	if token == "" || strings.Contains(token, Separator) {
+		return "", fmt.Errorf("invalid token %q", token)
+	}
+
+	tokens[index] = token
+
+	return Join(tokens...), nil
+}
+
+// TrimPrefix removes the leading tokens of subj that match prefix, false when subj does not start with all the tokens of prefix
+func TrimPrefix(subj string, prefix string) (string, bool) {
+	st := Tokens(subj)
+	pt := Tokens(prefix)
+
+	if len(pt) >= len(st) {
+		return "", false
+	}
+
+	for i, p := range pt {
+		if p != st[i] {
+			return "", false
+		}
+	}
+
+	return Join(st[len(pt):]...), true
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subject
+
+import (
+	"testing"
+)
+
+func TestIsValid(t *testing.T) {
+	for subj, valid := range map[string]bool{
+		"foo":         true,
+		"foo.bar":     true,
+		"foo.*.baz":   true,
+		"foo.>":       true,
+		">":           true,
+		"foo*.bar":    true,
+		"":            false,
+		"foo..bar":    false,
+		".foo":        false,
+		"foo.":        false,
+		"foo.>.bar":   false,
+		"foo bar":     false,
+		"foo.\tbar":   false,
+		"$JS.API.>":   true,
+		"_INBOX.abc1": true,
+	} {
+		if IsValid(subj) != valid {
+			t.Fatalf("expected IsValid(%q) to be %v", subj, valid)
+		}
+	}
+
+	if IsValidLiteral("foo.*") || IsValidLiteral("foo.>") || !IsValidLiteral("foo.bar") || IsValidLiteral("foo..bar") {
+		t.Fatalf("invalid literal detection")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	for _, c := range []struct {
+		subj   string
+		filter string
+		match  bool
+	}{
+		{"foo.bar", "foo.bar", true},
+		{"foo.bar", "foo.*", true},
+		{"foo.bar", "foo.>", true},
+		{"foo.bar.baz", "foo.>", true},
+		{"foo.bar", ">", true},
+		{"foo", "foo.>", false},
+		{"foo.bar.baz", "foo.*", false},
+		{"foo.bar", "foo.baz", false},
+		{"foo.*", "foo.*", false},
+	} {
+		if Matches(c.subj, c.filter) != c.match {
+			t.Fatalf("expected Matches(%q, %q) to be %v", c.subj, c.filter, c.match)
+		}
+	}
+}
+
+func TestIsSubsetMatch(t *testing.T) {
+	for _, c := range []struct {
+		subj   string
+		filter string
+		match  bool
+	}{
+		{"foo.*", "foo.>", true},
+		{"foo.*", "*.*", true},
+		{"foo.*", "foo.*", true},
+		{"foo.>", "foo.>", true},
+		{"foo.>", ">", true},
+		{"foo.*", "foo.bar", false},
+		{"foo.>", "foo.*", false},
+		{"*.bar", "foo.bar", false},
+		{"foo", "foo.>", false},
+	} {
+		if IsSubsetMatch(c.subj, c.filter) != c.match {
+			t.Fatalf("expected IsSubsetMatch(%q, %q) to be %v", c.subj, c.filter, c.match)
+		}
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	for _, c := range []struct {
+		a       string
+		b       string
+		overlap bool
+	}{
+		{"foo.bar", "foo.bar", true},
+		{"foo.*", "foo.bar", true},
+		{"foo.*", "*.bar", true},
+		{"foo.>", "foo.bar.baz", true},
+		{">", "foo", true},
+		{"foo.*.baz", "foo.>", true},
+		{"foo.bar", "foo.baz", false},
+		{"foo.>", "foo", false},
+		{"foo.*", "foo.bar.baz", false},
+		{"foo.*.baz", "foo.*.bar", false},
+	} {
+		if Overlaps(c.a, c.b) != c.overlap || Overlaps(c.b, c.a) != c.overlap {
+			t.Fatalf("expected Overlaps(%q, %q) to be %v", c.a, c.b, c.overlap)
+		}
+	}
+}
+
+func TestTokens(t *testing.T) {
+	if NumTokens("foo.bar.baz") != 3 || NumTokens("foo") != 1 {
+		t.Fatalf("invalid token count")
+	}
+
+	tok, ok := Token("foo.bar.baz", 1)
+	if !ok || tok != "bar" {
+		t.Fatalf("expected bar got %q", tok)
+	}
+	_, ok = Token("foo.bar.baz", 3)
+	if ok {
+		t.Fatalf("expected no token")
+	}
+
+	subj, err := ReplaceToken("foo.bar.baz", 1, "*")
+	if err != nil || subj != "foo.*.baz" {
+		t.Fatalf("expected foo.*.baz got %q: %v", subj, err)
+	}
+	_, err = ReplaceToken("foo.bar", 2, "x")
+	if err == nil {
+		t.Fatalf("expected an error for an invalid index")
+	}
+	_, err = ReplaceToken("foo.bar", 1, "x.y")
+	if err == nil {
+		t.Fatalf("expected an error for an invalid token")
+	}
+
+	if Join("foo", "bar") != "foo.bar" {
+		t.Fatalf("invalid join")
+	}
+
+	rest, ok := TrimPrefix("$JS.API.STREAM.INFO.ORDERS", "$JS.API")
+	if !ok || rest != "STREAM.INFO.ORDERS" {
+		t.Fatalf("expected STREAM.INFO.ORDERS got %q", rest)
+	}
+	_, ok = TrimPrefix("$JS.API", "$JS.API")
+	if ok {
+		t.Fatalf("expected no remainder")
+	}
+	_, ok = TrimPrefix("$JS.APIX.STREAM", "$JS.API")
+	if ok {
+		t.Fatalf("expected prefix to match whole tokens")
+	}
+}
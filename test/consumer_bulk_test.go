@@ -0,0 +1,80 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go"
+)
+
+func TestBulkConsumerOperations(t *testing.T) {
+	srv, nc, stream, mgr := setupConsumerTest(t)
+	defer srv.Shutdown()
+	defer nc.Flush()
+
+	for _, name := range []string{"A1", "A2", "B1"} {
+		_, err := stream.NewConsumer(jsm.DurableName(name))
+		checkErr(t, err, "create failed")
+	}
+
+	_, err := mgr.PauseAllConsumers(stream.Name(), time.Now().Add(time.Hour), jsm.BulkConcurrency(0))
+	if err == nil {
+		t.Fatalf("expected invalid concurrency error")
+	}
+
+	res, err := mgr.PauseAllConsumers(stream.Name(), time.Now().Add(time.Hour), jsm.BulkConcurrency(2))
+	checkErr(t, err, "pause failed")
+	checkErr(t, res.Err(), "pause failed")
+	if len(res) != 3 || res[0].Consumer != "A1" || res[2].Consumer != "B1" {
+		t.Fatalf("unexpected results: %+v", res)
+	}
+
+	for _, name := range []string{"A1", "A2", "B1"} {
+		c, err := stream.LoadConsumer(name)
+		checkErr(t, err, "load failed")
+		state, err := c.LatestState()
+		checkErr(t, err, "state failed")
+		if !state.Paused {
+			t.Fatalf("expected %s to be paused", name)
+		}
+	}
+
+	res, err = mgr.ResumeAllConsumers(stream.Name())
+	checkErr(t, err, "resume failed")
+	checkErr(t, res.Err(), "resume failed")
+
+	res, err = mgr.PauseAllConsumers(stream.Name(), time.Now().Add(-time.Hour))
+	checkErr(t, err, "pause failed")
+	if len(res.Failed()) != 3 || res.Err() == nil {
+		t.Fatalf("expected all pauses in the past to fail: %+v", res)
+	}
+
+	res, err = mgr.DeleteConsumersMatching(stream.Name(), func(c *jsm.Consumer) bool {
+		return strings.HasPrefix(c.Name(), "A")
+	})
+	checkErr(t, err, "delete failed")
+	checkErr(t, res.Err(), "delete failed")
+	if len(res) != 2 {
+		t.Fatalf("expected 2 deletes got %+v", res)
+	}
+
+	names, err := stream.ConsumerNames()
+	checkErr(t, err, "names failed")
+	if len(names) != 1 || names[0] != "B1" {
+		t.Fatalf("expected [B1] got %v", names)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+)
+
+func TestConsumerHealth(t *testing.T) {
+	_, err := jsm.ConsumerHealth(nil)
+	if err == nil {
+		t.Fatalf("expected error for nil info")
+	}
+
+	info := &api.ConsumerInfo{
+		Stream:         "ORDERS",
+		Name:           "C1",
+		Delivered:      api.SequenceInfo{Consumer: 100, Stream: 200},
+		AckFloor:       api.SequenceInfo{Consumer: 50, Stream: 150},
+		NumRedelivered: 10,
+		NumPending:     500,
+		Cluster: &api.ClusterInfo{
+			Leader: "s1",
+			Replicas: []*api.PeerInfo{
+				{Name: "s2", Current: true},
+				{Name: "s3", Current: true, Lag: 5},
+			},
+		},
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		report, err := jsm.ConsumerHealth(info, jsm.HealthReplicaLag(10))
+		checkErr(t, err, "health failed")
+		if !report.Healthy {
+			t.Fatalf("expected healthy report: %v", report.Issues)
+		}
+		if report.AckFloorLag != 50 || report.RedeliveryRatio != 0.1 || report.Replicas != 3 {
+			t.Fatalf("invalid report: %+v", report)
+		}
+	})
+
+	t.Run("thresholds", func(t *testing.T) {
+		prev := *info
+		prev.NumPending = 100
+
+		report, err := jsm.ConsumerHealth(info,
+			jsm.HealthAckFloorLag(10),
+			jsm.HealthPending(100),
+			jsm.HealthPendingGrowth(100),
+			jsm.HealthRedeliveryRatio(0.05),
+			jsm.HealthPreviousInfo(&prev))
+		checkErr(t, err, "health failed")
+
+		if report.Healthy {
+			t.Fatalf("expected unhealthy report")
+		}
+		if len(report.Issues) != 5 {
+			t.Fatalf("expected 5 issues got %v", report.Issues)
+		}
+		if report.PendingGrowth != 400 {
+			t.Fatalf("expected 400 growth got %d", report.PendingGrowth)
+		}
+		if len(report.LaggingReplicas) != 1 || report.LaggingReplicas[0] != "s3" {
+			t.Fatalf("expected s3 to be lagging got %v", report.LaggingReplicas)
+		}
+	})
+
+	t.Run("paused", func(t *testing.T) {
+		paused := *info
+		paused.Cluster = nil
+		paused.Paused = true
+		paused.PauseRemaining = time.Minute
+
+		report, err := jsm.ConsumerHealth(&paused)
+		checkErr(t, err, "health failed")
+		if report.Healthy || len(report.Issues) != 1 {
+			t.Fatalf("expected paused issue got %v", report.Issues)
+		}
+
+		report, err = jsm.ConsumerHealth(&paused, jsm.HealthAllowPaused())
+		checkErr(t, err, "health failed")
+		if !report.Healthy {
+			t.Fatalf("expected healthy report got %v", report.Issues)
+		}
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := jsm.ConsumerHealth(info, jsm.HealthRedeliveryRatio(2))
+		if err == nil {
+			t.Fatalf("expected invalid ratio error")
+		}
+
+		other := *info
+		other.Name = "OTHER"
+		_, err = jsm.ConsumerHealth(info, jsm.HealthPreviousInfo(&other))
+		if err == nil {
+			t.Fatalf("expected mismatched previous error")
+		}
+	})
+}
@@ -14,8 +14,11 @@
 package test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,6 +26,7 @@ import (
 	"github.com/nats-io/nats-server/v2/server"
 
 	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/api/jetstream/metric"
 
 	"github.com/nats-io/nats.go"
 
@@ -195,6 +199,32 @@ func TestNewConsumerFromDefaultDurable(t *testing.T) {
 	}
 }
 
+func TestCreateAndUpdateConsumerFromDefault(t *testing.T) {
+	srv, nc, stream, mgr := setupConsumerTest(t)
+	defer srv.Shutdown()
+	defer nc.Flush()
+
+	_, err := mgr.UpdateConsumerFromDefault(stream.Name(), jsm.DefaultConsumer, jsm.DurableName("NEW"))
+	if !errors.Is(err, api.ErrConsumerDoesNotExist) {
+		t.Fatalf("expected consumer does not exist error, got %v", err)
+	}
+
+	_, err = mgr.CreateConsumerFromDefault(stream.Name(), jsm.DefaultConsumer, jsm.DurableName("NEW"))
+	checkErr(t, err, "create failed")
+
+	_, err = mgr.CreateConsumerFromDefault(stream.Name(), jsm.DefaultConsumer, jsm.DurableName("NEW"), jsm.ConsumerDescription("changed"))
+	if !errors.Is(err, api.ErrConsumerAlreadyExists) {
+		t.Fatalf("expected consumer already exists error, got %v", err)
+	}
+
+	consumer, err := mgr.UpdateConsumerFromDefault(stream.Name(), jsm.DefaultConsumer, jsm.DurableName("NEW"), jsm.ConsumerDescription("changed"))
+	checkErr(t, err, "update failed")
+
+	if consumer.Description() != "changed" {
+		t.Fatalf("expected updated description got %q", consumer.Description())
+	}
+}
+
 func TestNewConsumerFromDefaultEphemeral(t *testing.T) {
 	srv, nc, stream, mgr := setupConsumerTest(t)
 	defer srv.Shutdown()
@@ -401,6 +431,203 @@ func TestConsumer_SampleSubject(t *testing.T) {
 	}
 }
 
+func TestManager_RedeliveryReporter(t *testing.T) {
+	srv, nc, _, mgr := setupConsumerTest(t)
+	defer srv.Shutdown()
+	defer nc.Flush()
+
+	dlq, err := mgr.NewStreamFromDefault("DLQ", jsm.DefaultStream, jsm.MemoryStorage(), jsm.Subjects("DLQ.>"))
+	checkErr(t, err, "create failed")
+
+	consumer, err := mgr.NewConsumerFromDefault("ORDERS", jsm.DefaultConsumer, jsm.DurableName("PULL"), jsm.MaxDeliveryAttempts(2))
+	checkErr(t, err, "create failed")
+
+	reports := make(chan *jsm.RedeliveryReport, 10)
+	reporter, err := mgr.RedeliveryReporter(
+		jsm.RedeliveryStreams("ORDERS"),
+		jsm.RedeliveryInterval(100*time.Millisecond),
+		jsm.RedeliveryReportHandler(func(r *jsm.RedeliveryReport) { reports <- r }),
+		jsm.RedeliveryDeadLetterSubject("DLQ"),
+		jsm.RedeliveryErrorHandler(func(err error) { t.Errorf("reporter failed: %v", err) }))
+	checkErr(t, err, "reporter failed")
+	defer reporter.Stop()
+
+	for i := 0; i < 2; i++ {
+		msg, err := consumer.NextMsg()
+		checkErr(t, err, "next failed")
+		checkErr(t, msg.Respond([]byte("-NAK")), "nak failed")
+	}
+
+	_, err = consumer.NextMsg()
+	if err == nil {
+		t.Fatalf("expected no further deliveries")
+	}
+
+	var entries []jsm.RedeliveryEntry
+	timeout := time.After(5 * time.Second)
+	for len(entries) < 2 {
+		select {
+		case r := <-reports:
+			entries = append(entries, r.Entries...)
+		case <-timeout:
+			t.Fatalf("did not receive reports, got %+v", entries)
+		}
+	}
+
+	kinds := map[jsm.RedeliveryKind]bool{}
+	for _, e := range entries {
+		if e.Stream != "ORDERS" || e.Consumer != "PULL" || e.StreamSeq != 1 || e.Deliveries != 2 {
+			t.Fatalf("invalid entry: %+v", e)
+		}
+		kinds[e.Kind] = true
+	}
+	if !kinds[jsm.RedeliveryNak] || !kinds[jsm.RedeliveryMaxDeliver] {
+		t.Fatalf("expected nak and max deliver entries: %+v", entries)
+	}
+
+	msg, err := dlq.ReadMessage(1)
+	checkErr(t, err, "dead letter read failed")
+	if msg.Subject != "DLQ.ORDERS.PULL" || string(msg.Data) != "order 1" {
+		t.Fatalf("invalid dead letter: %+v", msg)
+	}
+}
+
+func TestRedeliveryReporter_Advisories(t *testing.T) {
+	srv, nc, _, mgr := setupConsumerTest(t)
+	defer srv.Shutdown()
+	defer nc.Flush()
+
+	reporter, err := mgr.RedeliveryReporter(jsm.RedeliveryStreams("ORDERS"), jsm.RedeliveryInterval(time.Hour))
+	checkErr(t, err, "reporter failed")
+
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	adv := fmt.Sprintf(`{"type":"io.nats.jetstream.advisory.v1.max_deliver","id":"x","timestamp":%q,"stream":"ORDERS","consumer":"PULL","stream_seq":1,"deliveries":2}`, ts.Format(time.RFC3339Nano))
+	err = nc.Publish(api.JSAdvisoryConsumerMaxDeliveryExceedPre+".ORDERS.PULL", []byte(adv))
+	checkErr(t, err, "publish failed")
+	checkErr(t, nc.Flush(), "flush failed")
+
+	var report *jsm.RedeliveryReport
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		report = reporter.Report()
+		if len(report.Entries) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if len(report.Entries) != 1 {
+		t.Fatalf("expected 1 entry got %+v", report.Entries)
+	}
+	if !report.Entries[0].Time.Equal(ts) {
+		t.Fatalf("expected the advisory timestamp %v got %v", ts, report.Entries[0].Time)
+	}
+
+	reporter.Stop()
+	reporter.Stop()
+}
+
+func TestManager_NewOrderedConsumer(t *testing.T) {
+	srv, nc, _, mgr := setupConsumerTest(t)
+	defer srv.Shutdown()
+	defer nc.Flush()
+
+	for i := 2; i <= 5; i++ {
+		_, err := nc.Request("ORDERS.new", []byte(fmt.Sprintf("order %d", i)), time.Second)
+		checkErr(t, err, "publish failed")
+	}
+
+	oc, err := mgr.NewOrderedConsumer("ORDERS", jsm.OrderedConsumerHeartbeat(200*time.Millisecond))
+	checkErr(t, err, "create failed")
+	defer oc.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	next := func(expect int) {
+		t.Helper()
+		msg, err := oc.NextMsg(ctx)
+		checkErr(t, err, "next failed")
+		if string(msg.Data) != fmt.Sprintf("order %d", expect) {
+			t.Fatalf("expected order %d got %q", expect, msg.Data)
+		}
+	}
+
+	for i := 1; i <= 5; i++ {
+		next(i)
+	}
+	if oc.StreamSequence() != 5 {
+		t.Fatalf("expected stream sequence 5 got %d", oc.StreamSequence())
+	}
+
+	err = mgr.DeleteConsumer("ORDERS", oc.ConsumerName())
+	checkErr(t, err, "delete failed")
+
+	for i := 6; i <= 8; i++ {
+		_, err := nc.Request("ORDERS.new", []byte(fmt.Sprintf("order %d", i)), time.Second)
+		checkErr(t, err, "publish failed")
+	}
+
+	for i := 6; i <= 8; i++ {
+		next(i)
+	}
+
+	if oc.Resets() == 0 {
+		t.Fatalf("expected the consumer to be recreated")
+	}
+}
+
+func TestConsumer_AckSampler(t *testing.T) {
+	srv, nc, _, mgr := setupConsumerTest(t)
+	defer srv.Shutdown()
+	defer nc.Flush()
+
+	unsampled, err := mgr.NewConsumerFromDefault("ORDERS", jsm.DefaultConsumer, jsm.DurableName("UNSAMPLED"))
+	checkErr(t, err, "create failed")
+	_, err = unsampled.AckSampler()
+	if err == nil {
+		t.Fatalf("expected error for unsampled consumer")
+	}
+
+	consumer, err := mgr.NewConsumerFromDefault("ORDERS", jsm.SampledDefaultConsumer, jsm.DurableName("NEW"))
+	checkErr(t, err, "create failed")
+
+	samples := make(chan *metric.ConsumerAckMetricV1, 10)
+	sampler, err := consumer.AckSampler(jsm.AckSamplerChannel(samples), jsm.AckSamplerWindow(time.Hour))
+	checkErr(t, err, "sampler failed")
+	defer sampler.Stop()
+
+	for i := 0; i < 3; i++ {
+		_, err = nc.Request("ORDERS.new", []byte("order"), time.Second)
+		checkErr(t, err, "publish failed")
+	}
+
+	for i := 0; i < 4; i++ {
+		msg, err := consumer.NextMsg()
+		checkErr(t, err, "next failed")
+		checkErr(t, msg.AckSync(), "ack failed")
+	}
+
+	for i := 0; i < 4; i++ {
+		select {
+		case sample := <-samples:
+			if sample.Consumer != "NEW" || sample.Stream != "ORDERS" {
+				t.Fatalf("invalid sample: %+v", sample)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("did not receive sample %d", i)
+		}
+	}
+
+	stats := sampler.Stats()
+	if stats.Samples != 4 || stats.Redelivered != 0 || stats.Window != time.Hour {
+		t.Fatalf("invalid stats: %+v", stats)
+	}
+	if stats.P50 > stats.P95 || stats.P95 > stats.Max {
+		t.Fatalf("invalid percentiles: %+v", stats)
+	}
+}
+
 func TestConsumer_DeliveredState(t *testing.T) {
 	srv, nc, _, mgr := setupConsumerTest(t)
 	defer srv.Shutdown()
@@ -622,6 +849,55 @@ func TestConsumer_IsPullMode(t *testing.T) {
 	}
 }
 
+func TestConsumer_PullRequest(t *testing.T) {
+	srv, nc, _, mgr := setupConsumerTest(t)
+	defer srv.Shutdown()
+	defer nc.Flush()
+
+	push, err := mgr.NewConsumerFromDefault("ORDERS", jsm.DefaultConsumer, jsm.DurableName("PUSH"), jsm.DeliverySubject("out"))
+	checkErr(t, err, "create failed")
+	_, err = push.PullRequest().Build()
+	if err == nil {
+		t.Fatalf("expected error for push consumer")
+	}
+
+	pull, err := mgr.NewConsumerFromDefault("ORDERS", jsm.DefaultConsumer, jsm.DurableName("PULL"), jsm.MaxRequestBatch(10), jsm.MaxRequestExpires(time.Second), jsm.MaxRequestMaxBytes(1024))
+	checkErr(t, err, "create failed")
+
+	_, err = pull.PullRequest().Batch(20).Build()
+	if err == nil || !strings.Contains(err.Error(), "maximum request batch") {
+		t.Fatalf("expected batch error, got %v", err)
+	}
+	_, err = pull.PullRequest().Expires(time.Minute).Build()
+	if err == nil || !strings.Contains(err.Error(), "maximum request expires") {
+		t.Fatalf("expected expires error, got %v", err)
+	}
+	_, err = pull.PullRequest().MaxBytes(2048).Build()
+	if err == nil || !strings.Contains(err.Error(), "maximum request max bytes") {
+		t.Fatalf("expected max bytes error, got %v", err)
+	}
+	_, err = pull.PullRequest().Expires(time.Second).Heartbeat(time.Second).Build()
+	if err == nil || !strings.Contains(err.Error(), "heartbeat") {
+		t.Fatalf("expected heartbeat error, got %v", err)
+	}
+
+	req, err := pull.PullRequest().Batch(20).Expires(time.Minute).MaxBytes(2048).Clamp().Build()
+	checkErr(t, err, "build failed")
+	if req.Batch != 10 || req.Expires != time.Second || req.MaxBytes != 1024 {
+		t.Fatalf("request was not clamped: %+v", req)
+	}
+
+	sub, err := nc.SubscribeSync(nc.NewRespInbox())
+	checkErr(t, err, "subscribe failed")
+	err = pull.PullRequest().Batch(1).Expires(time.Second).Send(sub.Subject)
+	checkErr(t, err, "send failed")
+	msg, err := sub.NextMsg(time.Second)
+	checkErr(t, err, "next failed")
+	if string(msg.Data) != "order 1" {
+		t.Fatalf("expected order 1 got %q", msg.Data)
+	}
+}
+
 func TestConsumer_IsPushMode(t *testing.T) {
 	srv, nc, _, mgr := setupConsumerTest(t)
 	defer srv.Shutdown()
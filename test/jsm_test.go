@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/nats.go"
@@ -181,3 +182,27 @@ func TestFilterServerMetadata(t *testing.T) {
 		t.Fatalf("all server data was not removed: %v", newMeta)
 	}
 }
+
+func TestLinearBackoffPeriods(t *testing.T) {
+	p, err := jsm.LinearBackoffPeriods(5, 6*time.Second, time.Second)
+	checkErr(t, err, "backoff failed")
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second, 5 * time.Second}
+	if !reflect.DeepEqual(p, expected) {
+		t.Fatalf("expected %v got %v", expected, p)
+	}
+
+	// only the increments are rounded to the millisecond, min is used as is
+	p, err = jsm.LinearBackoffPeriods(2, 1500*time.Microsecond, 3*time.Second)
+	checkErr(t, err, "backoff failed")
+
+	expected = []time.Duration{1500 * time.Microsecond, 1500500 * time.Microsecond}
+	if !reflect.DeepEqual(p, expected) {
+		t.Fatalf("expected %v got %v", expected, p)
+	}
+
+	_, err = jsm.LinearBackoffPeriods(0, time.Second, time.Minute)
+	if err == nil {
+		t.Fatalf("expected an error for 0 steps")
+	}
+}
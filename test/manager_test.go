@@ -15,6 +15,7 @@ package test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -24,6 +25,7 @@ import (
 
 	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/api/jetstream/advisory"
 	natsd "github.com/nats-io/nats-server/v2/server"
 	"github.com/nats-io/nats.go"
 )
@@ -502,3 +504,284 @@ func TestEachStream(t *testing.T) {
 		t.Fatalf("incorrect streams or order, expected [ORDERS] got %v", seen)
 	}
 }
+
+func TestStreamsIter(t *testing.T) {
+	srv, nc, mgr := startJSServer(t)
+	defer srv.Shutdown()
+	defer nc.Close()
+
+	orders, err := mgr.NewStreamFromDefault("ORDERS", jsm.DefaultStream, jsm.Subjects("ORDERS.*"), jsm.MemoryStorage())
+	checkErr(t, err, "create failed")
+
+	_, err = mgr.NewStreamFromDefault("ARCHIVE", orders.Configuration(), jsm.Subjects("OTHER"))
+	checkErr(t, err, "create failed")
+
+	var seen []string
+	for s, err := range mgr.StreamsIter(nil) {
+		checkErr(t, err, "iteration failed")
+		seen = append(seen, s.Name())
+	}
+
+	if len(seen) != 2 || seen[0] != "ARCHIVE" || seen[1] != "ORDERS" {
+		t.Fatalf("incorrect streams or order, expected [ARCHIVE, ORDERS] got %v", seen)
+	}
+
+	seen = []string{}
+	for s, err := range mgr.StreamsIter(&jsm.StreamNamesFilter{Subject: "ORDERS.*"}) {
+		checkErr(t, err, "iteration failed")
+		seen = append(seen, s.Name())
+	}
+	if len(seen) != 1 || seen[0] != "ORDERS" {
+		t.Fatalf("incorrect streams, expected [ORDERS] got %v", seen)
+	}
+
+	_, err = orders.NewConsumer(jsm.DurableName("C1"))
+	checkErr(t, err, "create failed")
+	_, err = orders.NewConsumer(jsm.DurableName("C2"))
+	checkErr(t, err, "create failed")
+
+	seen = []string{}
+	for c, err := range orders.ConsumersIter() {
+		checkErr(t, err, "iteration failed")
+		seen = append(seen, c.Name())
+		break
+	}
+	if len(seen) != 1 || seen[0] != "C1" {
+		t.Fatalf("expected early break after [C1] got %v", seen)
+	}
+
+	for _, err := range mgr.ConsumersIter("X.Y") {
+		if err == nil {
+			t.Fatalf("expected an error for invalid stream name")
+		}
+	}
+}
+
+func TestManagerContext(t *testing.T) {
+	srv, nc, mgr := startJSServer(t)
+	defer srv.Shutdown()
+	defer nc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := mgr.NewStreamFromDefaultContext(ctx, "ORDERS", jsm.DefaultStream, jsm.Subjects("ORDERS.*"), jsm.MemoryStorage())
+	checkErr(t, err, "create failed")
+
+	_, err = mgr.NewConsumerFromDefaultContext(ctx, stream.Name(), jsm.DefaultConsumer, jsm.DurableName("C1"))
+	checkErr(t, err, "create failed")
+
+	_, err = mgr.LoadStreamContext(ctx, "ORDERS")
+	checkErr(t, err, "load failed")
+
+	_, err = mgr.LoadConsumerContext(ctx, "ORDERS", "C1")
+	checkErr(t, err, "load failed")
+
+	names, err := mgr.StreamNamesContext(ctx, nil)
+	checkErr(t, err, "names failed")
+	if len(names) != 1 || names[0] != "ORDERS" {
+		t.Fatalf("expected [ORDERS] got %v", names)
+	}
+
+	_, err = nc.Request("ORDERS.new", []byte("order 1"), time.Second)
+	checkErr(t, err, "publish failed")
+
+	msg, err := stream.ReadMessageContext(ctx, 1)
+	checkErr(t, err, "read failed")
+	if string(msg.Data) != "order 1" {
+		t.Fatalf("unexpected message %q", msg.Data)
+	}
+
+	checkErr(t, stream.UpdateConfigurationContext(ctx, stream.Configuration(), jsm.StreamDescription("updated")), "update failed")
+	if stream.Description() != "updated" {
+		t.Fatalf("expected the configuration to be reloaded")
+	}
+
+	consumer, err := stream.LoadConsumer("C1")
+	checkErr(t, err, "load failed")
+	checkErr(t, consumer.UpdateConfigurationContext(ctx, jsm.ConsumerDescription("updated")), "update failed")
+	if consumer.Description() != "updated" {
+		t.Fatalf("expected the configuration to be reloaded")
+	}
+
+	_, err = consumer.PauseContext(ctx, time.Now().Add(time.Hour))
+	checkErr(t, err, "pause failed")
+	checkErr(t, consumer.ResumeContext(ctx), "resume failed")
+
+	cctx, ccancel := context.WithCancel(context.Background())
+	ccancel()
+
+	for _, err := range []error{
+		stream.ResetContext(cctx),
+		stream.PurgeContext(cctx),
+		stream.DeleteContext(cctx),
+		consumer.ResetContext(cctx),
+		consumer.DeleteContext(cctx),
+	} {
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context canceled error got %v", err)
+		}
+	}
+
+	_, err = stream.ReadMessageContext(cctx, 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled error got %v", err)
+	}
+
+	state, err := stream.StateContext(ctx)
+	checkErr(t, err, "state failed")
+	if state.Msgs != 1 {
+		t.Fatalf("expected the cancelled purge not to remove messages")
+	}
+
+	checkErr(t, consumer.DeleteContext(ctx), "delete failed")
+	checkErr(t, stream.DeleteContext(ctx), "delete failed")
+
+	_, err = mgr.LoadStreamContext(cctx, "ORDERS")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled error got %v", err)
+	}
+
+	_, _, _, err = mgr.StreamsContext(cctx, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled error got %v", err)
+	}
+
+	_, err = mgr.ConsumerNamesContext(cctx, "ORDERS")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled error got %v", err)
+	}
+}
+
+func TestManager_ForDomain(t *testing.T) {
+	d, err := os.MkdirTemp("", "jstest")
+	checkErr(t, err, "temp dir could not be made")
+	defer os.RemoveAll(d)
+
+	srv, err := natsd.NewServer(&natsd.Options{
+		JetStream:       true,
+		JetStreamDomain: "HUB",
+		StoreDir:        d,
+		Port:            -1,
+		Host:            "localhost",
+		LogFile:         "/dev/null",
+	})
+	checkErr(t, err, "server start failed")
+	go srv.Start()
+	if !srv.ReadyForConnections(10 * time.Second) {
+		t.Fatalf("nats server did not start")
+	}
+	defer srv.Shutdown()
+
+	nc, err := nats.Connect(srv.ClientURL(), nats.UseOldRequestStyle())
+	checkErr(t, err, "client start failed")
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc, jsm.WithTimeout(time.Second))
+	checkErr(t, err, "manager creation failed")
+
+	hub := mgr.ForDomain("HUB")
+	if hub.Domain() != "HUB" {
+		t.Fatalf("expected HUB domain got %q", hub.Domain())
+	}
+	if mgr.Domain() != "" {
+		t.Fatalf("expected parent domain to be unchanged got %q", mgr.Domain())
+	}
+
+	stream, err := hub.NewStream("ORDERS", jsm.Subjects("ORDERS.*"), jsm.MemoryStorage())
+	checkErr(t, err, "create failed")
+
+	_, err = stream.NewConsumer(jsm.DurableName("C1"))
+	checkErr(t, err, "consumer create failed")
+
+	names, err := hub.StreamNames(nil)
+	checkErr(t, err, "names failed")
+	if len(names) != 1 || names[0] != "ORDERS" {
+		t.Fatalf("expected [ORDERS] got %v", names)
+	}
+
+	_, err = mgr.ForDomain("OTHER").StreamNames(nil)
+	if err == nil {
+		t.Fatalf("expected an error for unknown domain")
+	}
+}
+
+func TestManager_APIAuditFeed(t *testing.T) {
+	srv, nc, mgr := startJSServer(t)
+	defer srv.Shutdown()
+	defer nc.Close()
+
+	_, err := mgr.APIAuditFeed()
+	if err == nil {
+		t.Fatalf("expected an error without a handler or channel")
+	}
+
+	audits := make(chan *advisory.JetStreamAPIAuditV1, 10)
+	feed, err := mgr.APIAuditFeed(jsm.APIAuditChannel(audits))
+	checkErr(t, err, "feed failed")
+	defer feed.Stop()
+
+	_, err = mgr.NewStream("ORDERS", jsm.Subjects("ORDERS.*"), jsm.MemoryStorage())
+	checkErr(t, err, "create failed")
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case audit := <-audits:
+			if audit.Subject != "$JS.API.STREAM.CREATE.ORDERS" {
+				continue
+			}
+			if audit.Request == "" || audit.Response == "" {
+				t.Fatalf("invalid audit: %+v", audit)
+			}
+			return
+		case <-timeout:
+			t.Fatalf("did not receive the stream create audit")
+		}
+	}
+}
+
+func TestManager_StrictDecoding(t *testing.T) {
+	srv, nc, _ := startJSServer(t)
+	defer srv.Shutdown()
+	defer nc.Close()
+
+	mgr, err := jsm.New(nc, jsm.WithTimeout(time.Second), jsm.WithStrictDecoding())
+	checkErr(t, err, "manager failed")
+
+	if !mgr.IsStrict() || !mgr.ForDomain("").IsStrict() {
+		t.Fatalf("expected strict decoding to be enabled")
+	}
+
+	_, err = mgr.JetStreamAccountInfo()
+	checkErr(t, err, "info failed")
+
+	_, err = mgr.NewStream("ORDERS", jsm.Subjects("ORDERS.*"), jsm.MemoryStorage())
+	checkErr(t, err, "create failed")
+
+	_, err = mgr.LoadStream("MISSING")
+	if !jsm.IsNatsError(err, 10059) {
+		t.Fatalf("expected a stream not found error got %v", err)
+	}
+}
+
+func TestManager_Supports(t *testing.T) {
+	srv, nc, mgr := startJSServer(t)
+	defer srv.Shutdown()
+	defer nc.Close()
+
+	lvl, err := mgr.MetaApiLevel(true)
+	checkErr(t, err, "level failed")
+
+	features, err := mgr.Features()
+	checkErr(t, err, "features failed")
+	if features.ApiLevel != lvl {
+		t.Fatalf("expected level %d got %d", lvl, features.ApiLevel)
+	}
+
+	ok, err := mgr.Supports(api.FeatureConsumerPause)
+	checkErr(t, err, "supports failed")
+	if !ok {
+		t.Fatalf("expected consumer pause to be supported")
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/nats.go"
+)
+
+func TestRequestMany(t *testing.T) {
+	srv, nc, _ := startJSServer(t)
+	defer srv.Shutdown()
+	defer nc.Close()
+
+	ctx := context.Background()
+
+	for i := range 3 {
+		_, err := nc.Subscribe("svc", func(m *nats.Msg) {
+			if i == 2 {
+				time.Sleep(100 * time.Millisecond)
+			}
+			res := nats.NewMsg(m.Reply)
+			res.Data = []byte(m.Header.Get("X-Test"))
+			m.RespondMsg(res)
+		})
+		checkErr(t, err, "subscribe failed")
+	}
+	checkErr(t, nc.Flush(), "flush failed")
+
+	t.Run("expect", func(t *testing.T) {
+		start := time.Now()
+		msgs, err := jsm.RequestManyMsgs(ctx, nc, "svc", nil, jsm.RequestManyExpect(3), jsm.RequestManyHeader("X-Test", "hello"))
+		checkErr(t, err, "request failed")
+		if len(msgs) != 3 {
+			t.Fatalf("expected 3 responses got %d", len(msgs))
+		}
+		if string(msgs[0].Data) != "hello" {
+			t.Fatalf("header was not sent: %q", msgs[0].Data)
+		}
+		if time.Since(start) > time.Second {
+			t.Fatalf("did not stop after the expected responses")
+		}
+	})
+
+	t.Run("stall", func(t *testing.T) {
+		msgs, err := jsm.RequestManyMsgs(ctx, nc, "svc", nil, jsm.RequestManyStall(20*time.Millisecond))
+		checkErr(t, err, "request failed")
+		if len(msgs) != 2 {
+			t.Fatalf("expected the slow response to be missed got %d responses", len(msgs))
+		}
+	})
+
+	t.Run("max messages", func(t *testing.T) {
+		msgs, err := jsm.RequestManyMsgs(ctx, nc, "svc", nil, jsm.RequestManyMaxMessages(1))
+		checkErr(t, err, "request failed")
+		if len(msgs) != 1 {
+			t.Fatalf("expected 1 response got %d", len(msgs))
+		}
+	})
+
+	t.Run("max wait", func(t *testing.T) {
+		start := time.Now()
+		msgs, err := jsm.RequestManyMsgs(ctx, nc, "svc", nil, jsm.RequestManyExpect(4), jsm.RequestManyMaxWait(750*time.Millisecond))
+		checkErr(t, err, "request failed")
+		if len(msgs) != 3 {
+			t.Fatalf("expected 3 responses got %d", len(msgs))
+		}
+		if time.Since(start) < 750*time.Millisecond {
+			t.Fatalf("did not wait for the maximum time")
+		}
+	})
+
+	t.Run("callback error", func(t *testing.T) {
+		cbErr := errors.New("cb failed")
+		err := jsm.RequestMany(ctx, nc, "svc", nil, func(*nats.Msg) error { return cbErr })
+		if !errors.Is(err, cbErr) {
+			t.Fatalf("expected callback error got %v", err)
+		}
+	})
+
+	t.Run("no responders", func(t *testing.T) {
+		_, err := jsm.RequestManyMsgs(ctx, nc, "missing", nil)
+		if !errors.Is(err, nats.ErrNoResponders) {
+			t.Fatalf("expected no responders got %v", err)
+		}
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := jsm.RequestManyMsgs(ctx, nc, "svc", nil, jsm.RequestManyExpect(-1))
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
@@ -14,15 +14,21 @@
 package test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"math/rand"
 	"os"
 	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
 )
 
 func TestStream_Snapshot(t *testing.T) {
@@ -113,6 +119,121 @@ func TestStream_Snapshot(t *testing.T) {
 	}
 }
 
+func TestStream_SnapshotToWriter(t *testing.T) {
+	srv, nc, mgr := startJSServer(t)
+	defer os.RemoveAll(srv.JetStreamConfig().StoreDir)
+	defer srv.Shutdown()
+
+	stream, err := mgr.NewStream("q1", jsm.FileStorage(), jsm.Subjects("test"))
+	checkErr(t, err, "create failed")
+
+	for i := 0; i < 500; i++ {
+		_, err = nc.Request("test", []byte(RandomString(1024)), time.Second)
+		checkErr(t, err, "publish failed")
+	}
+
+	var data, meta bytes.Buffer
+	var notified atomic.Int32
+	progress, err := stream.SnapshotToWriter(context.Background(), &data, &meta, jsm.SnapshotChunkSize(16*1024), jsm.SnapshotNotify(func(jsm.SnapshotProgress) {
+		notified.Add(1)
+	}))
+	checkErr(t, err, "snapshot failed")
+
+	if !progress.Finished() || progress.ETA() != 0 || progress.ChunkSize() != 16*1024 {
+		t.Fatalf("invalid progress: finished: %v eta: %v chunk size: %d", progress.Finished(), progress.ETA(), progress.ChunkSize())
+	}
+	if notified.Load() == 0 || progress.ChunksReceived() == 0 || uint64(data.Len()) != progress.BytesReceived() {
+		t.Fatalf("invalid progress: notified: %d chunks: %d bytes: %d received: %d", notified.Load(), progress.ChunksReceived(), data.Len(), progress.BytesReceived())
+	}
+	if !strings.Contains(meta.String(), `"name": "q1"`) {
+		t.Fatalf("invalid metadata: %s", meta.String())
+	}
+
+	checkErr(t, stream.Delete(), "delete failed")
+
+	_, err = mgr.RestoreSnapshotFromBuffer(context.Background(), "q1", io.NopCloser(&data), io.NopCloser(&meta), jsm.SnapshotChunkSize(0))
+	if err == nil || !strings.Contains(err.Error(), "chunk size must be positive") {
+		t.Fatalf("expected chunk size error, got %v", err)
+	}
+
+	// larger than the max payload, will be capped
+	_, err = mgr.RestoreSnapshotFromBuffer(context.Background(), "q1", io.NopCloser(&data), io.NopCloser(&meta), jsm.SnapshotChunkSize(int(nc.MaxPayload())*2))
+	checkErr(t, err, "restore failed")
+
+	stream, err = mgr.LoadStream("q1")
+	checkErr(t, err, "load failed")
+	nfo, err := stream.State()
+	checkErr(t, err, "state failed")
+	if nfo.Msgs != 500 {
+		t.Fatalf("expected 500 messages got %d", nfo.Msgs)
+	}
+}
+
+func TestManager_RestoreSnapshotFromReader(t *testing.T) {
+	srv, nc, mgr := startJSServer(t)
+	defer os.RemoveAll(srv.JetStreamConfig().StoreDir)
+	defer srv.Shutdown()
+
+	stream, err := mgr.NewStream("q1", jsm.FileStorage(), jsm.Subjects("test"))
+	checkErr(t, err, "create failed")
+
+	for i := 0; i < 100; i++ {
+		_, err = nc.Request("test", []byte(RandomString(1024)), time.Second)
+		checkErr(t, err, "publish failed")
+	}
+
+	var data, meta bytes.Buffer
+	_, err = stream.SnapshotToWriter(context.Background(), &data, &meta)
+	checkErr(t, err, "snapshot failed")
+	cfg := stream.Configuration()
+	checkErr(t, stream.Delete(), "delete failed")
+
+	_, _, err = mgr.RestoreSnapshotFromReader(context.Background(), "q1", bytes.NewReader(data.Bytes()), strings.NewReader("{}"))
+	if err == nil || !strings.Contains(err.Error(), "does not contain a stream configuration") {
+		t.Fatalf("expected metadata error, got %v", err)
+	}
+
+	wq := cfg
+	wq.Retention = api.WorkQueuePolicy
+	_, _, err = mgr.RestoreSnapshotFromReader(context.Background(), "q1", bytes.NewReader(data.Bytes()), bytes.NewReader(meta.Bytes()), jsm.RestoreConfiguration(wq))
+	if err == nil || !strings.Contains(err.Error(), "not compatible") {
+		t.Fatalf("expected compatibility error, got %v", err)
+	}
+
+	// settings that do not affect the snapshot data may be changed
+	mc := cfg
+	mc.MaxConsumers = 10
+
+	var sent atomic.Uint32
+	progress, state, err := mgr.RestoreSnapshotFromReader(context.Background(), "q1", bytes.NewReader(data.Bytes()), bytes.NewReader(meta.Bytes()),
+		jsm.RestoreConfiguration(mc),
+		jsm.RestoreVerifyChecksums(),
+		jsm.RestoreDataSize(int64(data.Len())),
+		jsm.SnapshotChunkSize(4*1024),
+		jsm.RestoreNotify(func(p jsm.RestoreProgress) { sent.Store(p.ChunksSent()) }))
+	checkErr(t, err, "restore failed")
+	if state.Msgs != 100 {
+		t.Fatalf("expected 100 messages got %d", state.Msgs)
+	}
+	if sent.Load() == 0 || int(progress.ChunksSent()) != progress.ChunksToSend() {
+		t.Fatalf("invalid progress: sent %d of %d", progress.ChunksSent(), progress.ChunksToSend())
+	}
+
+	stream, err = mgr.LoadStream("q1")
+	checkErr(t, err, "load failed")
+	if stream.MaxConsumers() != 10 {
+		t.Fatalf("restore configuration was not applied: %d", stream.MaxConsumers())
+	}
+	checkErr(t, stream.Delete(), "delete failed")
+
+	corrupt := bytes.Clone(data.Bytes())
+	corrupt[len(corrupt)-10] ^= 0xff
+	_, _, err = mgr.RestoreSnapshotFromReader(context.Background(), "q1", bytes.NewReader(corrupt), bytes.NewReader(meta.Bytes()), jsm.RestoreVerifyChecksums())
+	if err == nil || !strings.Contains(err.Error(), "snapshot frame") {
+		t.Fatalf("expected verification error, got %v", err)
+	}
+}
+
 func RandomString(n int) string {
 	var letterRunes = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
 
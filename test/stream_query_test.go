@@ -53,6 +53,18 @@ func TestStreamQueryExpression(t *testing.T) {
 		checkStreamQueryMatched(t, mgr, 1, jsm.StreamQueryExpression("state.messages == 0"))
 		checkStreamQueryMatched(t, mgr, 1, jsm.StreamQueryExpression("state.messages == 1"))
 		checkStreamQueryMatched(t, mgr, 1, jsm.StreamQueryExpression("Info.State.Msgs == 1"))
+
+		checkStreamQueryMatched(t, mgr, 1, jsm.StreamQueryExpression("name == 'q1' && replicas < 3"))
+		checkStreamQueryMatched(t, mgr, 1, jsm.StreamQueryExpression("messages > 0 && bytes < 1MB"))
+		checkStreamQueryMatched(t, mgr, 0, jsm.StreamQueryExpression("bytes > 1GB"))
+		checkStreamQueryMatched(t, mgr, 2, jsm.StreamQueryExpression("age < 1h && cluster != ''"))
+		checkStreamQueryMatched(t, mgr, 1, jsm.StreamQueryExpression("idle > 7d"))
+		checkStreamQueryMatched(t, mgr, 1, jsm.StreamQueryExpression("idle < 1h && name != 'in.q1 24h'"))
+
+		_, err = mgr.QueryStreams(jsm.StreamQueryExpression("bytes > 1XB"))
+		if err == nil {
+			t.Fatalf("expected an error for invalid units")
+		}
 	})
 }
 
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -166,6 +167,53 @@ func TestLoadStream(t *testing.T) {
 	}
 }
 
+func TestCloneStream(t *testing.T) {
+	srv, nc, mgr := startJSServer(t)
+	defer srv.Shutdown()
+	defer nc.Flush()
+
+	orders, err := mgr.NewStream("ORDERS", jsm.Subjects("ORDERS.*"), jsm.FileStorage(), jsm.MaxMessages(100))
+	checkErr(t, err, "create failed")
+
+	for i := 0; i < 10; i++ {
+		_, err = nc.Request("ORDERS.new", []byte("hello"), time.Second)
+		checkErr(t, err, "publish failed")
+	}
+
+	_, err = mgr.CloneStream("ORDERS", "ORDERS")
+	if err == nil {
+		t.Fatalf("expected error cloning into the same stream")
+	}
+
+	clone, err := mgr.CloneStream("ORDERS", "ORDERS_NEW", jsm.Subjects(), jsm.AppendSource(&api.StreamSource{Name: orders.Name()}))
+	checkErr(t, err, "clone failed")
+
+	if clone.Name() != "ORDERS_NEW" {
+		t.Fatalf("expected ORDERS_NEW got %s", clone.Name())
+	}
+	if clone.MaxMsgs() != 100 {
+		t.Fatalf("expected max messages to be copied got %d", clone.MaxMsgs())
+	}
+	if len(clone.Subjects()) != 0 {
+		t.Fatalf("expected no subjects got %v", clone.Subjects())
+	}
+	if !clone.IsSourced() {
+		t.Fatalf("expected clone to be sourced")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		state, err := clone.State()
+		checkErr(t, err, "state failed")
+		if state.Msgs == 10 {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	t.Fatalf("clone did not receive messages from source stream")
+}
+
 func TestLoadFromStreamDetailBytes(t *testing.T) {
 	srv, nc, mgr := startJSServer(t)
 	defer srv.Shutdown()
@@ -551,6 +599,76 @@ func TestStream_Purge(t *testing.T) {
 	checkCnt(t, 0)
 }
 
+func TestStream_NewPurge(t *testing.T) {
+	srv, nc, mgr := startJSServer(t)
+	defer srv.Shutdown()
+	defer nc.Flush()
+
+	stream, err := mgr.NewStream("q1", jsm.FileStorage(), jsm.Subjects("test.>"))
+	checkErr(t, err, "create failed")
+
+	for i := 0; i < 100; i++ {
+		_, err := nc.Request(fmt.Sprintf("test.%d", i%2), []byte(fmt.Sprintf("message %d", i)), time.Second)
+		checkErr(t, err, "publish failed")
+	}
+
+	_, err = stream.NewPurge().Keep(1).UpToSequence(10).Request()
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected keep and sequence error, got %v", err)
+	}
+	_, err = stream.NewPurge().UpToTime(time.Now()).UpToSequence(10).Request()
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected time and sequence error, got %v", err)
+	}
+
+	checkPreview := func(p *jsm.StreamPurge, count uint64, exact bool) {
+		t.Helper()
+		preview, err := p.DryRun()
+		checkErr(t, err, "dry run failed")
+		if preview.Messages != count || preview.Exact != exact {
+			t.Fatalf("expected %d exact %v got %d exact %v", count, exact, preview.Messages, preview.Exact)
+		}
+	}
+
+	checkPreview(stream.NewPurge(), 100, true)
+	checkPreview(stream.NewPurge().Subject("test.1"), 50, true)
+	checkPreview(stream.NewPurge().Subject("test.1").Keep(10), 40, true)
+	checkPreview(stream.NewPurge().UpToSequence(21), 20, true)
+	checkPreview(stream.NewPurge().Subject("test.1").UpToSequence(21), 20, false)
+	checkPreview(stream.NewPurge().UpToTime(time.Now().Add(time.Hour)), 100, true)
+
+	nfo, err := stream.State()
+	checkErr(t, err, "state failed")
+	if nfo.Msgs != 100 {
+		t.Fatalf("dry run removed messages")
+	}
+
+	purged, err := stream.NewPurge().Subject("test.1").Keep(10).Purge()
+	checkErr(t, err, "purge failed")
+	if purged != 40 {
+		t.Fatalf("expected 40 purged got %d", purged)
+	}
+
+	purged, err = stream.NewPurge().UpToSequence(21).Purge()
+	checkErr(t, err, "purge failed")
+	if purged != 10 {
+		t.Fatalf("expected 10 purged got %d", purged)
+	}
+
+	purged, err = stream.NewPurge().UpToTime(time.Now().Add(time.Hour)).Purge()
+	checkErr(t, err, "purge failed")
+	if purged != 50 {
+		t.Fatalf("expected 50 purged got %d", purged)
+	}
+
+	denied, err := mgr.NewStream("q2", jsm.FileStorage(), jsm.Subjects("denied.>"), jsm.DenyPurge())
+	checkErr(t, err, "create failed")
+	_, err = denied.NewPurge().Purge()
+	if err == nil {
+		t.Fatalf("expected deny purge error")
+	}
+}
+
 func TestStream_ReadLastMessageForSubject(t *testing.T) {
 	srv, nc, mgr := startJSServer(t)
 	defer srv.Shutdown()
@@ -1008,6 +1126,90 @@ func TestStream_IsSourced(t *testing.T) {
 	}
 }
 
+func TestStream_MirrorAndSourceLag(t *testing.T) {
+	srv, nc, mgr := startJSServer(t)
+	defer srv.Shutdown()
+	defer nc.Flush()
+
+	q1, err := mgr.NewStream("q1", jsm.Subjects("in.q1"), jsm.MemoryStorage())
+	checkErr(t, err, "create failed")
+	_, err = q1.MirrorLag()
+	if err == nil {
+		t.Fatalf("expected error for non mirror")
+	}
+
+	for i := 0; i < 5; i++ {
+		_, err = nc.Request("in.q1", []byte("hello"), time.Second)
+		checkErr(t, err, "publish failed")
+	}
+
+	mirror, err := mgr.NewStream("q2", jsm.MemoryStorage(), jsm.Mirror(&api.StreamSource{Name: "q1"}))
+	checkErr(t, err, "create failed")
+	sourced, err := mgr.NewStream("q3", jsm.MemoryStorage(), jsm.Sources(&api.StreamSource{Name: "q1"}))
+	checkErr(t, err, "create failed")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		lag, err := mirror.MirrorLag()
+		checkErr(t, err, "mirror lag failed")
+		lags, err := sourced.SourceLags()
+		checkErr(t, err, "source lags failed")
+		if len(lags) != 1 || lags[0].Name != "q1" || lag.Name != "q1" {
+			t.Fatalf("invalid lag information: %+v %+v", lag, lags)
+		}
+
+		if lag.Active && lag.Lag == 0 && lags[0].Active && lags[0].Lag == 0 {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("mirror and source did not become current: %+v %+v", lag, lags)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestStream_WatchLag(t *testing.T) {
+	srv, nc, mgr := startJSServer(t)
+	defer srv.Shutdown()
+	defer nc.Flush()
+
+	mirror, err := mgr.NewStream("q1", jsm.MemoryStorage(), jsm.Mirror(&api.StreamSource{Name: "MISSING"}))
+	checkErr(t, err, "create failed")
+
+	events := make(chan jsm.StreamLagEvent, 10)
+	watcher, err := mirror.WatchLag(
+		jsm.LagWatchInterval(50*time.Millisecond),
+		jsm.LagWatchMaxLastSeen(time.Second),
+		jsm.LagWatchHandler(func(e jsm.StreamLagEvent) { events <- e }))
+	checkErr(t, err, "watch failed")
+	defer watcher.Stop()
+
+	select {
+	case e := <-events:
+		if !e.Exceeded || !e.Mirror || e.Stream != "q1" || e.Source.Name != "MISSING" || len(e.Reasons) == 0 {
+			t.Fatalf("invalid event: %+v", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("did not receive lag event")
+	}
+
+	_, err = mgr.NewStream("MISSING", jsm.Subjects("missing"), jsm.MemoryStorage())
+	checkErr(t, err, "create failed")
+
+	select {
+	case e := <-events:
+		if e.Exceeded || e.Source.Name != "MISSING" {
+			t.Fatalf("invalid recovery event: %+v", e)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("did not receive recovery event")
+	}
+
+	// stopping again in the deferred Stop() must not panic
+	watcher.Stop()
+}
+
 func TestStreamDescription(t *testing.T) {
 	srv, nc, mgr := startJSServer(t)
 	defer srv.Shutdown()
@@ -1196,6 +1398,58 @@ func TestStream_Compression(t *testing.T) {
 	if !s.IsCompressed() {
 		t.Fatalf("s2 compression was not reported correctly")
 	}
+
+	_, err = mgr.NewStream("m1", jsm.Subjects("mem.*"), jsm.MemoryStorage(), jsm.Compression(api.S2Compression))
+	if err == nil || !strings.Contains(err.Error(), "only supported for file storage") {
+		t.Fatalf("expected memory compression to fail: %v", err)
+	}
+
+	_, err = mgr.NewStream("f2", jsm.Subjects("f2.*"), jsm.Compression(api.Compression(10)))
+	if err == nil {
+		t.Fatalf("expected unknown compression to fail")
+	}
+
+	_, err = s.EstimateCompressionSavings(10)
+	if err == nil {
+		t.Fatalf("expected empty stream estimate to fail")
+	}
+
+	for i := 0; i < 100; i++ {
+		_, err = nc.Request("test.1", bytes.Repeat([]byte("compressible "), 50), time.Second)
+		checkErr(t, err, "publish failed")
+	}
+
+	est, err := s.EstimateCompressionSavings(10)
+	checkErr(t, err, "estimate failed")
+	if !est.Compressed || est.Ratio >= 0.5 || est.EstimatedSavings == 0 || est.EstimatedBytes+est.EstimatedSavings != est.Bytes {
+		t.Fatalf("invalid estimate: %#v", est)
+	}
+
+	mem, err := mgr.NewStream("m1", jsm.Subjects("mem.*"), jsm.MemoryStorage())
+	checkErr(t, err, "create failed")
+	err = mem.UpdateConfiguration(mem.Configuration(), jsm.Compression(api.S2Compression))
+	if err == nil {
+		t.Fatalf("expected memory compression update to fail")
+	}
+
+	// the server accepts compressed memory streams, updates unrelated to compression should not be rejected
+	cfg := api.StreamConfig{Name: "m2", Subjects: []string{"m2.*"}, Storage: api.MemoryStorage, Compression: api.S2Compression, Retention: api.LimitsPolicy, Discard: api.DiscardOld, Replicas: 1, MaxMsgs: -1, MaxBytes: -1, MaxAge: 0, MaxMsgSize: -1, MaxConsumers: -1, MaxMsgsPer: -1}
+	req, err := json.Marshal(cfg)
+	checkErr(t, err, "marshal failed")
+	_, err = nc.Request(fmt.Sprintf(api.JSApiStreamCreateT, "m2"), req, time.Second)
+	checkErr(t, err, "create failed")
+
+	m2, err := mgr.LoadStream("m2")
+	checkErr(t, err, "load failed")
+	if m2.Configuration().Compression != api.S2Compression {
+		t.Skipf("server did not create a compressed memory stream")
+	}
+
+	err = m2.UpdateConfiguration(m2.Configuration(), jsm.StreamDescription("updated"))
+	checkErr(t, err, "update failed")
+	if m2.Description() != "updated" {
+		t.Fatalf("expected the description to be updated")
+	}
 }
 
 func TestStream_DetectGaps(t *testing.T) {
@@ -1340,6 +1594,33 @@ func TestStreamRepublish(t *testing.T) {
 	}
 }
 
+func TestStreamRepublishValidation(t *testing.T) {
+	srv, nc, mgr := startJSServer(t)
+	defer srv.Shutdown()
+	defer nc.Flush()
+
+	_, err := mgr.NewStream("LOOP", jsm.Subjects("test.>"), jsm.RepublishSubjects("test.*", "test.repub.$1"))
+	if err == nil || !strings.Contains(err.Error(), "form a loop") {
+		t.Fatalf("expected loop error, got %v", err)
+	}
+
+	_, err = mgr.NewStream("INVALID", jsm.Subjects("test.>"), jsm.RepublishSubjects("test.*", "repub.$2"))
+	if err == nil {
+		t.Fatalf("expected transform error")
+	}
+
+	s, err := mgr.NewStream("HDRS", jsm.Subjects("test.>"), jsm.RepublishHeadersOnly("test.>", "repub.>"))
+	checkErr(t, err, "create failed")
+	rp := s.Republish()
+	if rp == nil || !rp.HeadersOnly || rp.Source != "test.>" || rp.Destination != "repub.>" {
+		t.Fatalf("invalid republish configuration: %+v", rp)
+	}
+
+	// sourced messages keep their subjects so the source need not match the stream subjects
+	_, err = mgr.NewStream("SOURCED", jsm.Subjects("local.>"), jsm.Sources(&api.StreamSource{Name: "HDRS"}), jsm.RepublishSubjects("test.>", "sourced.>"))
+	checkErr(t, err, "create failed")
+}
+
 func TestStreamPedantic(t *testing.T) {
 	srv, nc, mgr := startJSServer(t)
 	defer srv.Shutdown()
@@ -1479,3 +1760,90 @@ func TestAsyncPersistenceStream(t *testing.T) {
 		t.Fatalf("Expected default persist mode to be set")
 	}
 }
+
+func TestStream_MessageTTL(t *testing.T) {
+	srv, nc, mgr := startJSServer(t)
+	defer srv.Shutdown()
+	defer nc.Flush()
+
+	_, err := mgr.NewStream("TTL", jsm.Subjects("ttl.>"), jsm.MemoryStorage(), jsm.SubjectDeleteMarkerTTL(500*time.Millisecond))
+	if err == nil || !strings.Contains(err.Error(), "at least 1 second") {
+		t.Fatalf("expected short marker ttl to fail: %v", err)
+	}
+
+	// with one message per subject the server does not raise message TTLs to the marker TTL
+	s, err := mgr.NewStream("TTL", jsm.Subjects("ttl.>"), jsm.MemoryStorage(), jsm.MaxMessagesPerSubject(1), jsm.SubjectDeleteMarkerTTL(time.Minute))
+	checkErr(t, err, "create failed")
+	if !s.AllowMsgTTL() || s.SubjectDeleteMarkerTTL() != time.Minute {
+		t.Fatalf("expected ttl settings to be applied: %#v", s.Configuration())
+	}
+
+	cfg, err := jsm.NewStreamConfiguration(s.Configuration(), jsm.SubjectDeleteMarkerTTL(0))
+	checkErr(t, err, "disabling delete markers failed")
+	if cfg.SubjectDeleteMarkerTTL != 0 {
+		t.Fatalf("expected delete markers to be disabled: %v", cfg.SubjectDeleteMarkerTTL)
+	}
+
+	_, err = jsm.NewMessageWithTTL("ttl.x", nil, 100*time.Millisecond)
+	if err == nil {
+		t.Fatalf("expected sub second ttl to fail")
+	}
+
+	msg, err := jsm.NewMessageWithTTL("ttl.short", []byte("short"), time.Second)
+	checkErr(t, err, "msg failed")
+	if msg.Header.Get(api.JSMessageTTL) != "1" {
+		t.Fatalf("invalid ttl header: %q", msg.Header.Get(api.JSMessageTTL))
+	}
+	res, err := nc.RequestMsg(msg, time.Second)
+	checkErr(t, err, "publish failed")
+	_, err = jsm.ParsePubAck(res)
+	checkErr(t, err, "publish failed")
+
+	msg = nats.NewMsg("ttl.long")
+	checkErr(t, jsm.SetMessageTTL(msg, jsm.MessageTTLNever), "set ttl failed")
+	res, err = nc.RequestMsg(msg, time.Second)
+	checkErr(t, err, "publish failed")
+	_, err = jsm.ParsePubAck(res)
+	checkErr(t, err, "publish failed")
+
+	ttl, ok, err := jsm.MessageTTL(msg)
+	checkErr(t, err, "ttl failed")
+	if !ok || ttl != jsm.MessageTTLNever {
+		t.Fatalf("expected never ttl: %v %v", ok, ttl)
+	}
+
+	for _, v := range []string{"1s", "60", "never", "NEVER"} {
+		_, err = jsm.ParseMessageTTL(v)
+		checkErr(t, err, "parse failed")
+	}
+	for _, v := range []string{"100ms", "-1", "x"} {
+		_, err = jsm.ParseMessageTTL(v)
+		if err == nil {
+			t.Fatalf("expected %q to fail", v)
+		}
+	}
+
+	var marker *api.StoredMsg
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		marker, err = s.ReadLastMessageForSubject("ttl.short")
+		checkErr(t, err, "read failed")
+		if marker.Sequence != 1 {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	dm, err := jsm.ParseStoredDeleteMarker(marker)
+	checkErr(t, err, "parse marker failed")
+	if dm.Subject != "ttl.short" || dm.Reason != api.JSMarkerReasonMaxAge || dm.TTL != time.Minute || dm.Sequence != 3 {
+		t.Fatalf("invalid marker: %#v", dm)
+	}
+
+	long, err := s.ReadLastMessageForSubject("ttl.long")
+	checkErr(t, err, "read failed")
+	_, err = jsm.ParseStoredDeleteMarker(long)
+	if err == nil {
+		t.Fatalf("expected regular message to not be a marker")
+	}
+}